@@ -13,6 +13,7 @@ import (
 
 	"github.com/ory/kratos/driver"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
 	"github.com/ory/x/cmdx"
 	"github.com/ory/x/flagx"
 )
@@ -77,6 +78,53 @@ func (h *MigrateHandler) MigrateSQL(cmd *cobra.Command, args []string) {
 	fmt.Println("Successfully applied SQL migrations!")
 }
 
+func (h *MigrateHandler) MigratePhoneNumbers(cmd *cobra.Command, _ []string) {
+	d := driver.New(cmd.Context(), cmd.ErrOrStderr(), configx.WithFlags(cmd.Flags()))
+
+	if len(d.Config(cmd.Context()).IdentityPhoneDefaultRegion()) == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Configuration key identity.phone.default_region is not set, only phone numbers in international format will be normalized.")
+	}
+
+	if !flagx.MustGetBool(cmd, "yes") {
+		fmt.Println("All identities will be validated again and their phone number identifiers, verifiable and recovery addresses will be stored in E.164 format.")
+		fmt.Println("")
+		fmt.Println("To skip the next question use flag --yes (at your own risk).")
+		if !askForConfirmation("Do you wish to continue?") {
+			fmt.Println("Migration aborted.")
+			return
+		}
+	}
+
+	var migrated, failed int
+	for page := 1; ; page++ {
+		is, err := d.PrivilegedIdentityPool().ListIdentities(cmd.Context(), page, 500)
+		cmdx.Must(err, "An error occurred while listing identities: %s", err)
+
+		for k := range is {
+			i, err := d.PrivilegedIdentityPool().GetIdentityConfidential(cmd.Context(), is[k].ID)
+			if err == nil {
+				err = d.IdentityManager().Update(cmd.Context(), i, identity.ManagerAllowWriteProtectedTraits)
+			}
+
+			if err != nil {
+				failed++
+				fmt.Fprintf(cmd.ErrOrStderr(), "Unable to migrate identity %s: %s\n", is[k].ID, err)
+				continue
+			}
+			migrated++
+		}
+
+		if len(is) < 500 {
+			break
+		}
+	}
+
+	fmt.Printf("Migrated %d identities, %d failed.\n", migrated, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
 func askForConfirmation(s string) bool {
 	reader := bufio.NewReader(os.Stdin)
 
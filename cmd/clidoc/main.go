@@ -44,6 +44,7 @@ func init() {
 		"NewInfoNodeLabelSave":                       text.NewInfoNodeLabelSave(),
 		"NewInfoNodeLabelSubmit":                     text.NewInfoNodeLabelSubmit(),
 		"NewInfoNodeLabelID":                         text.NewInfoNodeLabelID(),
+		"NewInfoNodeLabelCode":                       text.NewInfoNodeLabelCode(),
 		"NewErrorValidationSettingsFlowExpired":      text.NewErrorValidationSettingsFlowExpired(time.Second),
 		"NewInfoSelfServiceSettingsTOTPQRCode":       text.NewInfoSelfServiceSettingsTOTPQRCode(),
 		"NewInfoSelfServiceSettingsTOTPSecret":       text.NewInfoSelfServiceSettingsTOTPSecret("{secret}"),
@@ -69,6 +70,9 @@ func init() {
 		"NewErrorValidationVerificationFlowExpired":               text.NewErrorValidationVerificationFlowExpired(-time.Second),
 		"NewInfoSelfServiceVerificationSuccessful":                text.NewInfoSelfServiceVerificationSuccessful(),
 		"NewVerificationEmailSent":                                text.NewVerificationEmailSent(),
+		"NewVerificationCodeSent":                                 text.NewVerificationCodeSent(),
+		"NewVerificationPhoneSent":                                text.NewVerificationPhoneSent(),
+		"NewVerificationPhoneCodeSent":                            text.NewVerificationPhoneCodeSent(),
 		"NewErrorValidationVerificationTokenInvalidOrAlreadyUsed": text.NewErrorValidationVerificationTokenInvalidOrAlreadyUsed(),
 		"NewErrorValidationVerificationRetrySuccess":              text.NewErrorValidationVerificationRetrySuccess(),
 		"NewErrorValidationVerificationStateFailure":              text.NewErrorValidationVerificationStateFailure(),
@@ -83,11 +87,15 @@ func init() {
 		"NewErrorValidationTOTPVerifierWrong":                     text.NewErrorValidationTOTPVerifierWrong(),
 		"NewErrorValidationLookupAlreadyUsed":                     text.NewErrorValidationLookupAlreadyUsed(),
 		"NewErrorValidationLookupInvalid":                         text.NewErrorValidationLookupInvalid(),
+		"NewErrorValidationCodeInvalid":                           text.NewErrorValidationCodeInvalid(),
+		"NewErrorValidationCodeAttemptsExceeded":                  text.NewErrorValidationCodeAttemptsExceeded(5),
 		"NewErrorValidationIdentifierMissing":                     text.NewErrorValidationIdentifierMissing(),
 		"NewErrorValidationAddressNotVerified":                    text.NewErrorValidationAddressNotVerified(),
 		"NewErrorValidationNoTOTPDevice":                          text.NewErrorValidationNoTOTPDevice(),
 		"NewErrorValidationNoLookup":                              text.NewErrorValidationNoLookup(),
 		"NewErrorValidationNoWebAuthnDevice":                      text.NewErrorValidationNoWebAuthnDevice(),
+		"NewErrorValidationRateLimitExceeded":                     text.NewErrorValidationRateLimitExceeded(time.Minute),
+		"NewErrorValidationIdentifierLocked":                      text.NewErrorValidationIdentifierLocked(time.Minute),
 		"NewInfoLoginReAuth":                                      text.NewInfoLoginReAuth(),
 		"NewInfoLoginMFA":                                         text.NewInfoLoginMFA(),
 		"NewInfoLoginTOTPLabel":                                   text.NewInfoLoginTOTPLabel(),
@@ -104,13 +112,20 @@ func init() {
 		"NewErrorValidationRecoveryNoStrategyFound":               text.NewErrorValidationRecoveryNoStrategyFound(),
 		"NewErrorValidationVerificationNoStrategyFound":           text.NewErrorValidationVerificationNoStrategyFound(),
 		"NewInfoSelfServiceLoginWebAuthn":                         text.NewInfoSelfServiceLoginWebAuthn(),
+		"NewInfoSelfServiceLoginMagicLink":                        text.NewInfoSelfServiceLoginMagicLink(),
+		"NewInfoSelfServiceLoginMagicLinkSent":                    text.NewInfoSelfServiceLoginMagicLinkSent(),
+		"NewErrorValidationLoginMagicLinkInvalidOrAlreadyUsed":    text.NewErrorValidationLoginMagicLinkInvalidOrAlreadyUsed(),
 		"NewInfoRegistration":                                     text.NewInfoRegistration(),
 		"NewInfoRegistrationWith":                                 text.NewInfoRegistrationWith("{provider}"),
 		"NewInfoRegistrationContinue":                             text.NewInfoRegistrationContinue(),
+		"NewInfoSelfServiceRegistrationRegisterWebAuthn":          text.NewInfoSelfServiceRegistrationRegisterWebAuthn(),
 		"NewErrorValidationRegistrationFlowExpired":               text.NewErrorValidationRegistrationFlowExpired(time.Second),
 		"NewErrorValidationRecoveryFlowExpired":                   text.NewErrorValidationRecoveryFlowExpired(time.Second),
 		"NewRecoverySuccessful":                                   text.NewRecoverySuccessful(inAMinute),
 		"NewRecoveryEmailSent":                                    text.NewRecoveryEmailSent(),
+		"NewRecoveryCodeSent":                                     text.NewRecoveryCodeSent(),
+		"NewRecoveryPhoneSent":                                    text.NewRecoveryPhoneSent(),
+		"NewRecoveryPhoneCodeSent":                                text.NewRecoveryPhoneCodeSent(),
 		"NewErrorValidationRecoveryTokenInvalidOrAlreadyUsed":     text.NewErrorValidationRecoveryTokenInvalidOrAlreadyUsed(),
 		"NewErrorValidationRecoveryRetrySuccess":                  text.NewErrorValidationRecoveryRetrySuccess(),
 		"NewErrorValidationRecoveryStateFailure":                  text.NewErrorValidationRecoveryStateFailure(),
@@ -0,0 +1,72 @@
+package courier
+
+import (
+	"strconv"
+
+	kratos "github.com/ory/kratos-client-go"
+
+	"github.com/ory/x/cmdx"
+)
+
+type (
+	outputMessage           kratos.Message
+	outputMessageCollection struct {
+		messages []kratos.Message
+	}
+)
+
+func (_ *outputMessage) Header() []string {
+	return []string{"ID", "STATUS", "TYPE", "RECIPIENT", "TEMPLATE TYPE", "SUBJECT", "SEND COUNT", "LAST ERROR", "CREATED AT"}
+}
+
+func (m *outputMessage) Columns() []string {
+	return messageColumns((*kratos.Message)(m))
+}
+
+func (m *outputMessage) Interface() interface{} {
+	return m
+}
+
+func (_ *outputMessageCollection) Header() []string {
+	return []string{"ID", "STATUS", "TYPE", "RECIPIENT", "TEMPLATE TYPE", "SUBJECT", "SEND COUNT", "LAST ERROR", "CREATED AT"}
+}
+
+func (c *outputMessageCollection) Table() [][]string {
+	rows := make([][]string, len(c.messages))
+	for i := range c.messages {
+		rows[i] = messageColumns(&c.messages[i])
+	}
+	return rows
+}
+
+func (c *outputMessageCollection) Interface() interface{} {
+	return c.messages
+}
+
+func messageColumns(m *kratos.Message) []string {
+	data := [9]string{
+		m.Id,
+		string(m.Status),
+		string(m.Type),
+		m.Recipient,
+		m.TemplateType,
+		m.Subject,
+		strconv.FormatInt(m.SendCount, 10),
+		m.GetLastError(),
+		m.CreatedAt.String(),
+	}
+
+	if len(m.Subject) == 0 {
+		data[5] = cmdx.None
+	}
+
+	if len(data[7]) == 0 {
+		data[7] = cmdx.None
+	}
+
+	return data[:]
+}
+
+func (c *outputMessageCollection) Len() int {
+	return len(c.messages)
+}
@@ -0,0 +1,40 @@
+package courier_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	cmdcourier "github.com/ory/kratos/cmd/courier"
+	"github.com/ory/kratos/x"
+)
+
+func TestGetCmd(t *testing.T) {
+	c := cmdcourier.NewGetCmd()
+	reg := setup(t, c)
+
+	t.Run("case=gets a single message", func(t *testing.T) {
+		ms, _ := makeMessages(t, reg, 1)
+
+		stdOut := execNoErr(t, c, ms[0].ID.String())
+		assert.Equal(t, ms[0].ID.String(), gjson.Get(stdOut, "id").String(), stdOut)
+		assert.Equal(t, "queued", gjson.Get(stdOut, "status").String(), stdOut)
+		assert.Equal(t, ms[0].Body, gjson.Get(stdOut, "body").String(), stdOut)
+	})
+
+	t.Run("case=gets three messages", func(t *testing.T) {
+		ms, ids := makeMessages(t, reg, 3)
+
+		stdOut := execNoErr(t, c, ids...)
+		for i := range ms {
+			assert.Equal(t, ids[i], gjson.Get(stdOut, "#.id").Array()[i].String(), stdOut)
+		}
+	})
+
+	t.Run("case=fails with unknown ID", func(t *testing.T) {
+		stdErr := execErr(t, c, x.NewUUID().String())
+
+		assert.Contains(t, stdErr, "404 Not Found", stdErr)
+	})
+}
@@ -0,0 +1,89 @@
+package courier
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ory/x/cmdx"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/cmd/cliclient"
+)
+
+const (
+	FlagStatus       = "status"
+	FlagRecipient    = "recipient"
+	FlagTemplateType = "template-type"
+)
+
+func NewListCmd() *cobra.Command {
+	var (
+		status, recipient, templateType string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list [<page> <per-page>]",
+		Short: "List messages",
+		Long:  "List messages in the courier queue (paginated), optionally filtered by status, recipient, or template type.",
+		Example: `To list all messages the courier gave up on, run:
+
+	$ kratos courier messages list --status abandoned`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			// zero or exactly two args
+			if len(args) != 0 && len(args) != 2 {
+				return fmt.Errorf("expected zero or two args, got %d: %+v", len(args), args)
+			}
+			return nil
+		},
+		Aliases: []string{"ls"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := cliclient.NewClient(cmd)
+			req := c.V0alpha2Api.AdminListCourierMessages(cmd.Context())
+
+			if len(args) == 2 {
+				page, err := strconv.ParseInt(args[0], 0, 64)
+				if err != nil {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not parse page argument\"%s\": %s", args[0], err)
+					return cmdx.FailSilently(cmd)
+				}
+				req = req.Page(page)
+
+				perPage, err := strconv.ParseInt(args[1], 0, 64)
+				if err != nil {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not parse per-page argument\"%s\": %s", args[1], err)
+					return cmdx.FailSilently(cmd)
+				}
+				req = req.PerPage(perPage)
+			}
+
+			if len(status) > 0 {
+				req = req.Status(status)
+			}
+			if len(recipient) > 0 {
+				req = req.Recipient(recipient)
+			}
+			if len(templateType) > 0 {
+				req = req.TemplateType(templateType)
+			}
+
+			messages, _, err := req.Execute()
+			if err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not get the messages: %+v\n", err)
+				return cmdx.FailSilently(cmd)
+			}
+
+			cmdx.PrintTable(cmd, &outputMessageCollection{
+				messages: messages,
+			})
+
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&status, FlagStatus, "", `Only list messages with this status, one of "queued", "sent", "processing", or "abandoned"`)
+	flags.StringVar(&recipient, FlagRecipient, "", "Only list messages sent to this recipient")
+	flags.StringVar(&templateType, FlagTemplateType, "", `Only list messages created from this template type, e.g. "recovery_valid"`)
+	return cmd
+}
@@ -0,0 +1,65 @@
+package courier_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/cmdx"
+
+	cmdcourier "github.com/ory/kratos/cmd/courier"
+	"github.com/ory/kratos/courier"
+)
+
+func TestListCmd(t *testing.T) {
+	c := cmdcourier.NewListCmd()
+	reg := setup(t, c)
+	require.NoError(t, c.Flags().Set(cmdx.FlagQuiet, "true"))
+
+	ms, ids := makeMessages(t, reg, 6)
+	require.NoError(t, reg.Persister().SetMessageStatus(context.Background(), ms[0].ID, courier.MessageStatusAbandoned))
+
+	t.Run("case=lists all messages with default pagination", func(t *testing.T) {
+		stdOut := execNoErr(t, c)
+
+		for _, id := range ids {
+			assert.Contains(t, stdOut, id)
+		}
+	})
+
+	t.Run("case=lists all messages with pagination", func(t *testing.T) {
+		stdoutP1 := execNoErr(t, c, "1", "3")
+		stdoutP2 := execNoErr(t, c, "2", "3")
+
+		for _, id := range ids {
+			// exactly one of page 1 and 2 should contain the id
+			assert.True(t, strings.Contains(stdoutP1, id) != strings.Contains(stdoutP2, id), "%s \n %s", stdoutP1, stdoutP2)
+		}
+	})
+
+	t.Run("case=filters messages", func(t *testing.T) {
+		require.NoError(t, c.Flags().Set(cmdcourier.FlagStatus, "abandoned"))
+		t.Cleanup(func() {
+			require.NoError(t, c.Flags().Set(cmdcourier.FlagStatus, ""))
+		})
+
+		stdOut := execNoErr(t, c)
+		assert.Contains(t, stdOut, ids[0])
+		for _, id := range ids[1:] {
+			assert.NotContains(t, stdOut, id)
+		}
+	})
+
+	t.Run("case=fails on invalid status", func(t *testing.T) {
+		require.NoError(t, c.Flags().Set(cmdcourier.FlagStatus, "unknown"))
+		t.Cleanup(func() {
+			require.NoError(t, c.Flags().Set(cmdcourier.FlagStatus, ""))
+		})
+
+		stdErr := execErr(t, c)
+		assert.Contains(t, stdErr, "400 Bad Request", stdErr)
+	})
+}
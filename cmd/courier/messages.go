@@ -0,0 +1,21 @@
+package courier
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ory/x/cmdx"
+
+	"github.com/ory/kratos/cmd/cliclient"
+)
+
+// NewMessagesCmd represents the courier messages command
+func NewMessagesCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "messages",
+		Short: "Tools to inspect and retry messages in the courier queue",
+	}
+
+	cliclient.RegisterClientFlags(c.PersistentFlags())
+	cmdx.RegisterFormatFlags(c.PersistentFlags())
+	return c
+}
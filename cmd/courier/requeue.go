@@ -0,0 +1,52 @@
+package courier
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/x/cmdx"
+)
+
+func NewRequeueCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "requeue <id-0 [id-1 ...]>",
+		Short: "Re-queue abandoned messages by ID",
+		Long:  "This command puts one or more abandoned messages back into the courier queue so that delivery is attempted again. Only messages with status \"abandoned\" can be re-queued.",
+		Example: `To re-queue all abandoned messages, run:
+
+	$ kratos courier messages requeue $(kratos courier messages list --status abandoned --format json | jq -r '.[].id')`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := cliclient.NewClient(cmd)
+
+			var (
+				requeued = make([]string, 0, len(args))
+				errs     []error
+			)
+
+			for _, a := range args {
+				_, _, err := c.V0alpha2Api.AdminRequeueCourierMessage(cmd.Context(), a).Execute()
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				requeued = append(requeued, a)
+			}
+
+			for _, r := range requeued {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), r)
+			}
+
+			for _, err := range errs {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%+v\n", err)
+			}
+
+			if len(errs) != 0 {
+				return cmdx.FailSilently(cmd)
+			}
+			return nil
+		},
+	}
+}
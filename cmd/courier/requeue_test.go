@@ -0,0 +1,40 @@
+package courier_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmdcourier "github.com/ory/kratos/cmd/courier"
+	"github.com/ory/kratos/courier"
+)
+
+func TestRequeueCmd(t *testing.T) {
+	c := cmdcourier.NewRequeueCmd()
+	reg := setup(t, c)
+
+	t.Run("case=requeues abandoned messages", func(t *testing.T) {
+		ms, ids := makeMessages(t, reg, 2)
+		for _, m := range ms {
+			require.NoError(t, reg.Persister().SetMessageStatus(context.Background(), m.ID, courier.MessageStatusAbandoned))
+		}
+
+		stdOut := execNoErr(t, c, ids...)
+		assert.Equal(t, ids[0]+"\n"+ids[1]+"\n", stdOut)
+
+		for _, m := range ms {
+			actual, err := reg.Persister().FetchMessage(context.Background(), m.ID)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusQueued, actual.Status)
+		}
+	})
+
+	t.Run("case=fails on messages which are not abandoned", func(t *testing.T) {
+		_, ids := makeMessages(t, reg, 1)
+
+		stdErr := execErr(t, c, ids...)
+		assert.Contains(t, stdErr, "400 Bad Request", stdErr)
+	})
+}
@@ -20,4 +20,10 @@ func RegisterCommandRecursive(parent *cobra.Command) {
 	c := NewCourierCmd()
 	parent.AddCommand(c)
 	c.AddCommand(NewWatchCmd())
+
+	m := NewMessagesCmd()
+	c.AddCommand(m)
+	m.AddCommand(NewListCmd())
+	m.AddCommand(NewGetCmd())
+	m.AddCommand(NewRequeueCmd())
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/ory/x/reqlog"
 
 	"github.com/ory/kratos/cmd/courier"
+	"github.com/ory/kratos/cmd/lifecycle"
+	"github.com/ory/kratos/cmd/webhooks"
 	"github.com/ory/kratos/driver/config"
 
 	"github.com/rs/cors"
@@ -273,6 +275,14 @@ func bgTasks(d driver.Registry, wg *sync.WaitGroup, cmd *cobra.Command, args []s
 	if d.Config(ctx).IsBackgroundCourierEnabled() {
 		go courier.Watch(ctx, d)
 	}
+
+	if d.Config(ctx).IsBackgroundIdentityLifecycleEnabled() {
+		go lifecycle.Watch(ctx, d)
+	}
+
+	if d.Config(ctx).IsBackgroundWebHookDeliveryEnabled() {
+		go webhooks.Watch(ctx, d)
+	}
 }
 
 func ServeAll(d driver.Registry, opts ...Option) func(cmd *cobra.Command, args []string) {
@@ -1,6 +1,7 @@
 package identities
 
 import (
+	"fmt"
 	"strings"
 
 	kratos "github.com/ory/kratos-client-go"
@@ -13,6 +14,13 @@ type (
 	outputIdentityCollection struct {
 		identities []kratos.Identity
 	}
+	outputImportResult struct {
+		Source string                      `json:"source"`
+		Result kratos.IdentityImportResult `json:"result"`
+	}
+	outputImportResultCollection struct {
+		results []outputImportResult
+	}
 )
 
 func (_ *outputIdentity) Header() []string {
@@ -91,3 +99,54 @@ func (c *outputIdentityCollection) Interface() interface{} {
 func (c *outputIdentityCollection) Len() int {
 	return len(c.identities)
 }
+
+func (c *outputImportResultCollection) add(source string, result kratos.IdentityImportResult) {
+	c.results = append(c.results, outputImportResult{Source: source, Result: result})
+}
+
+func (c *outputImportResultCollection) failed() bool {
+	for _, r := range c.results {
+		if r.Result.Error != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (_ *outputImportResultCollection) Header() []string {
+	return []string{"SOURCE", "LINE", "IDENTITY ID", "ERROR"}
+}
+
+func (c *outputImportResultCollection) Table() [][]string {
+	rows := make([][]string, len(c.results))
+	for i, r := range c.results {
+		data := [4]string{
+			r.Source,
+			fmt.Sprintf("%d", r.Result.Line),
+			cmdx.None,
+			cmdx.None,
+		}
+
+		if r.Result.IdentityId != nil {
+			data[2] = *r.Result.IdentityId
+		}
+
+		if e := r.Result.Error; e != nil {
+			data[3] = e.Message
+			if e.Reason != nil {
+				data[3] += ": " + *e.Reason
+			}
+		}
+
+		rows[i] = data[:]
+	}
+	return rows
+}
+
+func (c *outputImportResultCollection) Interface() interface{} {
+	return c.results
+}
+
+func (c *outputImportResultCollection) Len() int {
+	return len(c.results)
+}
@@ -0,0 +1,53 @@
+package identities
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/x/cmdx"
+)
+
+func NewEraseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "erase <id-0 [id-1 ...]>",
+		Short: "Erase identities and all data belonging to them by ID",
+		Long: `This command erases one or more identities by ID. In addition to deleting the identities, it deletes their sessions,
+self-service flows and tokens, and the courier messages which were sent to their verifiable and recovery addresses.
+
+Use this command to erase an identity's personal data, for example when the user requests it. This action can not be undone.`,
+		Example: `$ kratos identities erase 5da1a3f3-bd69-4ed8-ad57-0c1ec5d1e5d2`,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := cliclient.NewClient(cmd)
+
+			var (
+				erased = make([]string, 0, len(args))
+				errs   []error
+			)
+
+			for _, a := range args {
+				_, err := c.V0alpha2Api.AdminEraseIdentity(cmd.Context(), a).Execute()
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				erased = append(erased, a)
+			}
+
+			for _, e := range erased {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), e)
+			}
+
+			for _, err := range errs {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%+v\n", err)
+			}
+
+			if len(errs) != 0 {
+				return cmdx.FailSilently(cmd)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package identities_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/cmd/identities"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/sqlcon"
+)
+
+func TestEraseCmd(t *testing.T) {
+	c := identities.NewEraseCmd()
+	reg := setup(t, c)
+
+	t.Run("case=erases successfully", func(t *testing.T) {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		require.NoError(t, reg.Persister().CreateIdentity(context.Background(), i))
+
+		s, err := session.NewActiveSession(i, reg.Config(context.Background()), time.Now(), identity.CredentialsTypePassword)
+		require.NoError(t, err)
+		require.NoError(t, reg.SessionPersister().UpsertSession(context.Background(), s))
+
+		stdOut := execNoErr(t, c, i.ID.String())
+		assert.Equal(t, i.ID.String()+"\n", stdOut)
+
+		_, err = reg.Persister().GetIdentity(context.Background(), i.ID)
+		assert.True(t, errors.Is(err, sqlcon.ErrNoRows))
+
+		_, err = reg.SessionPersister().GetSession(context.Background(), s.ID)
+		assert.True(t, errors.Is(err, sqlcon.ErrNoRows))
+	})
+
+	t.Run("case=erases three identities", func(t *testing.T) {
+		is, ids := makeIdentities(t, reg, 3)
+
+		stdOut := execNoErr(t, c, ids...)
+		assert.Equal(t, strings.Join(ids, "\n")+"\n", stdOut)
+
+		for _, i := range is {
+			_, err := reg.Persister().GetIdentity(context.Background(), i.ID)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("case=fails with unknown ID", func(t *testing.T) {
+		stdErr := execErr(t, c, x.NewUUID().String())
+
+		assert.Contains(t, stdErr, "404 Not Found", stdErr)
+	})
+}
@@ -0,0 +1,40 @@
+package identities
+
+import (
+	"fmt"
+
+	"github.com/ory/x/cmdx"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/cmd/cliclient"
+)
+
+// NewExportCmd represents the export command
+func NewExportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export",
+		Short: "Export all identities to STD_OUT",
+		Long: `Export all identities as newline-delimited JSON to STD_OUT.
+
+Each line contains one identity in the format used to import identities, including password and OpenID Connect
+credentials and the state of verifiable and recovery addresses. The output can be imported again using
+"... identities import --ndjson", for example to restore a backup or to copy identities to another environment.
+Other credentials, such as TOTP, WebAuthn and lookup secrets, are not exported.`,
+		Example: `$ kratos identities export > identities.ndjson
+$ kratos identities import --ndjson identities.ndjson --endpoint http://other-kratos:4434`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := cliclient.NewClient(cmd)
+
+			exported, _, err := c.V0alpha2Api.AdminExportIdentities(cmd.Context()).Execute()
+			if err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not export the identities: %+v\n", err)
+				return cmdx.FailSilently(cmd)
+			}
+
+			_, _ = fmt.Fprint(cmd.OutOrStdout(), exported)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package identities_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/cmd/identities"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x"
+)
+
+func TestExportCmd(t *testing.T) {
+	c := identities.NewExportCmd()
+	reg := setup(t, c)
+
+	t.Run("case=exports all identities as newline-delimited JSON", func(t *testing.T) {
+		var keys []string
+		for j := 0; j < 3; j++ {
+			i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			key := x.NewUUID().String()
+			i.Traits = identity.Traits(`{"testKey":"` + key + `"}`)
+			require.NoError(t, reg.Persister().CreateIdentity(context.Background(), i))
+			keys = append(keys, key)
+		}
+
+		stdOut := execNoErr(t, c)
+
+		var exported []string
+		for _, line := range strings.Split(strings.TrimSpace(stdOut), "\n") {
+			record := gjson.Parse(line)
+			assert.Equal(t, config.DefaultIdentityTraitsSchemaID, record.Get("schema_id").String(), "%s", line)
+			assert.False(t, record.Get("id").Exists(), "%s", line)
+			exported = append(exported, record.Get("traits.testKey").String())
+		}
+		assert.Subset(t, exported, keys)
+	})
+
+	t.Run("case=fails on unexpected arguments", func(t *testing.T) {
+		_, _, err := exec(c, nil, "foo")
+		require.Error(t, err)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ory/kratos/selfservice/strategy/oidc"
 
 	"github.com/ory/x/assertx"
+	"github.com/ory/x/sqlxx"
 
 	"github.com/ory/kratos/x"
 
@@ -26,11 +27,13 @@ func TestGetCmd(t *testing.T) {
 
 	t.Run("case=gets a single identity", func(t *testing.T) {
 		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.MetadataPublic = sqlxx.NullJSONRawMessage(`{"public":"data"}`)
+		i.MetadataAdmin = sqlxx.NullJSONRawMessage(`{"admin":"data"}`)
 		require.NoError(t, reg.Persister().CreateIdentity(context.Background(), i))
 
 		stdOut := execNoErr(t, c, i.ID.String())
 
-		ij, err := json.Marshal(i)
+		ij, err := json.Marshal(identity.WithAdminMetadataInJSON(*i))
 		require.NoError(t, err)
 
 		assertx.EqualAsJSONExcept(t, json.RawMessage(ij), json.RawMessage(stdOut), []string{"created_at", "updated_at"})
@@ -88,6 +91,7 @@ func TestGetCmd(t *testing.T) {
 			}
 		}
 		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.MetadataPublic = sqlxx.NullJSONRawMessage(`{"public":"data"}`)
 		i.SetCredentials(identity.CredentialsTypeOIDC, applyCredentials("uniqueIdentifier", "accessBar", "refreshBar", "idBar", true))
 		// duplicate identity with decrypted tokens
 		di := i.CopyWithoutCredentials()
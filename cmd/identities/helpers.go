@@ -22,27 +22,43 @@ func parseIdentities(raw []byte) (rawIdentities []string) {
 	return
 }
 
-func readIdentities(cmd *cobra.Command, args []string) (map[string]string, error) {
-	rawIdentities := make(map[string]string)
+type source struct {
+	name, content string
+}
+
+// readSources reads the given files or STD_IN if no files are given.
+func readSources(cmd *cobra.Command, args []string) ([]source, error) {
 	if len(args) == 0 {
 		fc, err := ioutil.ReadAll(cmd.InOrStdin())
 		if err != nil {
 			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "STD_IN: Could not read: %s\n", err)
 			return nil, cmdx.FailSilently(cmd)
 		}
-		for i, id := range parseIdentities(fc) {
-			rawIdentities[fmt.Sprintf("STD_IN[%d]", i)] = id
-		}
-		return rawIdentities, nil
+		return []source{{name: "STD_IN", content: string(fc)}}, nil
 	}
+
+	sources := make([]source, 0, len(args))
 	for _, fn := range args {
 		fc, err := ioutil.ReadFile(fn)
 		if err != nil {
 			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s: Could not open identity file: %s\n", fn, err)
 			return nil, cmdx.FailSilently(cmd)
 		}
-		for i, id := range parseIdentities(fc) {
-			rawIdentities[fmt.Sprintf("%s[%d]", fn, i)] = id
+		sources = append(sources, source{name: fn, content: string(fc)})
+	}
+	return sources, nil
+}
+
+func readIdentities(cmd *cobra.Command, args []string) (map[string]string, error) {
+	sources, err := readSources(cmd, args)
+	if err != nil {
+		return nil, err
+	}
+
+	rawIdentities := make(map[string]string)
+	for _, src := range sources {
+		for i, id := range parseIdentities([]byte(src.content)) {
+			rawIdentities[fmt.Sprintf("%s[%d]", src.name, i)] = id
 		}
 	}
 	return rawIdentities, nil
@@ -3,10 +3,12 @@ package identities_test
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"testing"
 
 	"github.com/ory/x/cmdx"
+	"github.com/ory/x/sqlxx"
 
 	"github.com/pkg/errors"
 
@@ -65,6 +67,7 @@ func execErr(t *testing.T, cmd *cobra.Command, args ...string) string {
 func makeIdentities(t *testing.T, reg driver.Registry, n int) (is []*identity.Identity, ids []string) {
 	for j := 0; j < n; j++ {
 		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.MetadataPublic = sqlxx.NullJSONRawMessage(fmt.Sprintf(`{"index":%d}`, j))
 		require.NoError(t, reg.Persister().CreateIdentity(context.Background(), i))
 		is = append(is, i)
 		ids = append(ids, i.ID.String())
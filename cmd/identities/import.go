@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	kratos "github.com/ory/kratos-client-go"
 
@@ -15,9 +16,15 @@ import (
 	"github.com/ory/kratos/cmd/cliclient"
 )
 
+const (
+	FlagNDJSON = "ndjson"
+)
+
 // NewImportCmd represents the import command
 func NewImportCmd() *cobra.Command {
-	return &cobra.Command{
+	var ndjson bool
+
+	cmd := &cobra.Command{
 		Use:   "import <file.json [file-2.json [file-3.json] ...]>",
 		Short: "Import identities from files or STD_IN",
 		Example: `$ cat > ./file.json <<EOF
@@ -25,21 +32,50 @@ func NewImportCmd() *cobra.Command {
     "schema_id": "default",
     "traits": {
         "email": "foo@example.com"
-    }
+    },
+    "credentials": {
+        "password": {
+            "config": {
+                "hashed_password": "$2a$10$ZsCsoVQ3xfBG/K2z2XpBf.tm90GZmtOqtqWcB5.pYd5Eq8y7RlDyq"
+            }
+        }
+    },
+    "verifiable_addresses": [
+        {
+            "value": "foo@example.com",
+            "via": "email",
+            "verified": true
+        }
+    ]
 }
 EOF
 
 $ kratos identities import file.json
 # Alternatively:
-$ cat file.json | kratos identities import`,
+$ cat file.json | kratos identities import
+
+# Import many identities, for example a backup created by "... identities export":
+$ kratos identities import --ndjson identities.ndjson`,
 		Long: `Import identities from files or STD_IN.
 
 Files can contain only a single or an array of identities. The validity of files can be tested beforehand using "... identities validate".
 
-WARNING: Importing credentials is not yet supported.`,
+Password credentials can be imported in plain text ("password") or as a hash ("hashed_password"). Supported hash formats
+are bcrypt, argon2id and pbkdf2 as well as the legacy formats md5, sha1, sha256, sha512, scrypt, Firebase scrypt and PHPass.
+Legacy hashes are replaced by the configured hasher when the user signs in the next time.
+OpenID Connect credentials are imported as a list of providers and subjects. The state of verifiable and recovery addresses
+which are part of the identity's traits can be imported as well.
+
+Use "--ndjson" to import files which contain one identity per line, such as the output of "... identities export".
+These files are sent to the batch import endpoint, which imports the identities in transactional chunks. Records
+which fail to import are reported and do not abort the import.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c := cliclient.NewClient(cmd)
 
+			if ndjson {
+				return importNDJSON(cmd, c, args)
+			}
+
 			imported := make([]kratos.Identity, 0, len(args))
 			failed := make(map[string]error)
 
@@ -84,4 +120,44 @@ WARNING: Importing credentials is not yet supported.`,
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&ndjson, FlagNDJSON, false, "Read newline-delimited JSON and import it using the batch import endpoint.")
+	return cmd
+}
+
+func importNDJSON(cmd *cobra.Command, c *kratos.APIClient, args []string) error {
+	sources, err := readSources(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	var (
+		results = &outputImportResultCollection{}
+		failed  = make(map[string]error)
+	)
+	for _, src := range sources {
+		raw, _, err := c.V0alpha2Api.AdminImportIdentities(cmd.Context()).Body(src.content).Execute()
+		if err != nil {
+			failed[src.name] = err
+			continue
+		}
+
+		for _, line := range strings.Split(strings.TrimSpace(raw), "\n") {
+			var result kratos.IdentityImportResult
+			if err := json.Unmarshal([]byte(line), &result); err != nil {
+				failed[src.name] = err
+				break
+			}
+			results.add(src.name, result)
+		}
+	}
+
+	cmdx.PrintTable(cmd, results)
+	cmdx.PrintErrors(cmd, failed)
+
+	if len(failed) != 0 || results.failed() {
+		return cmdx.FailSilently(cmd)
+	}
+
+	return nil
 }
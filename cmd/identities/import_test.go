@@ -17,6 +17,7 @@ import (
 
 	kratos "github.com/ory/kratos-client-go"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
 	"github.com/ory/x/cmdx"
 )
 
@@ -122,6 +123,30 @@ func TestImportCmd(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("case=imports an identity with credentials", func(t *testing.T) {
+		subject := uuid.Must(uuid.NewV4()).String()
+		i := kratos.AdminCreateIdentityBody{
+			SchemaId: config.DefaultIdentityTraitsSchemaID,
+			Traits:   map[string]interface{}{},
+			Credentials: &kratos.AdminIdentityImportCredentials{
+				Oidc: &kratos.AdminIdentityImportCredentialsOidc{
+					Config: &kratos.AdminIdentityImportCredentialsOidcConfig{
+						Providers: []kratos.AdminIdentityImportCredentialsOidcProvider{{Provider: "google", Subject: subject}},
+					},
+				},
+			},
+		}
+		ij, err := json.Marshal(i)
+		require.NoError(t, err)
+
+		stdOut, stdErr, err := exec(c, bytes.NewBuffer(ij))
+		require.NoError(t, err, "%s %s", stdOut, stdErr)
+
+		actual, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(context.Background(), identity.CredentialsTypeOIDC, "google:"+subject)
+		require.NoError(t, err)
+		assert.Equal(t, gjson.Get(stdOut, "id").String(), actual.ID.String())
+	})
+
 	t.Run("case=fails to import invalid identity", func(t *testing.T) {
 		// validation is further tested with the validate command
 		stdOut, stdErr, err := exec(c, bytes.NewBufferString("{}"))
@@ -129,4 +154,33 @@ func TestImportCmd(t *testing.T) {
 		assert.Contains(t, stdErr, "STD_IN[0]: not valid")
 		assert.Len(t, stdOut, 0)
 	})
+
+	t.Run("case=imports newline-delimited JSON and reports failed records", func(t *testing.T) {
+		require.NoError(t, c.Flags().Set(identities.FlagNDJSON, "true"))
+		t.Cleanup(func() {
+			require.NoError(t, c.Flags().Set(identities.FlagNDJSON, "false"))
+		})
+
+		key := uuid.Must(uuid.NewV4()).String()
+		stdOut, stdErr, err := exec(c, bytes.NewBufferString(
+			`{"schema_id":"default","traits":{"testKey":"`+key+`"}}`+"\n"+
+				`{"schema_id":"default","traits":{"unknown":"trait"}}`+"\n"+
+				`{"schema_id":"default","traits":{}}`+"\n"))
+		assert.True(t, errors.Is(err, cmdx.ErrNoPrintButFail), "%s %s", stdOut, stdErr)
+
+		results := gjson.Parse(stdOut).Array()
+		require.Len(t, results, 3, "%s", stdOut)
+		for k, r := range results {
+			assert.Equal(t, "STD_IN", r.Get("source").String())
+			assert.EqualValues(t, k+1, r.Get("result.line").Int())
+		}
+		assert.EqualValues(t, 400, results[1].Get("result.error.code").Int(), "%s", stdOut)
+
+		for _, k := range []int{0, 2} {
+			id, err := uuid.FromString(results[k].Get("result.identity_id").String())
+			require.NoError(t, err, "%s", stdOut)
+			_, err = reg.Persister().GetIdentity(context.Background(), id)
+			assert.NoError(t, err)
+		}
+	})
 }
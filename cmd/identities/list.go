@@ -3,19 +3,50 @@ package identities
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
 
 	"github.com/spf13/cobra"
 
 	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	FlagCredentialsIdentifier = "credentials-identifier"
+	FlagVerifiableAddress     = "verifiable-address"
+	FlagRecoveryAddress       = "recovery-address"
+	FlagState                 = "state"
+	FlagSchemaID              = "schema-id"
+	FlagCreatedAfter          = "created-after"
+	FlagCreatedBefore         = "created-before"
+	FlagTrait                 = "trait"
+	FlagPageToken             = "page-token"
 )
 
 func NewListCmd() *cobra.Command {
-	return &cobra.Command{
+	var (
+		credentialsIdentifier, verifiableAddress, recoveryAddress string
+		state, schemaID, createdAfter, createdBefore, pageToken   string
+		traits                                                    []string
+	)
+
+	cmd := &cobra.Command{
 		Use:   "list [<page> <per-page>]",
 		Short: "List identities",
-		Long:  "List identities (paginated)",
+		Long: `List identities (paginated).
+
+The identities can be filtered by credentials identifier, verifiable and recovery address, state, schema ID,
+creation time and traits. All filters which are set must match.
+
+Use "--page-token" to page through large amounts of identities. The token of the next page is printed to STD_ERR
+unless "--quiet" is set.`,
+		Example: `$ kratos identities list --credentials-identifier foo@example.com
+$ kratos identities list --state inactive --created-after 2022-01-01T00:00:00Z
+$ kratos identities list --trait name.first=Jane --trait name.last=Doe
+$ kratos identities list 0 100 --page-token 6c2e3c35-7a1a-4bd7-8c5a-bb3e5b0b8d8b`,
 		Args: func(cmd *cobra.Command, args []string) error {
 			// zero or exactly two args
 			if len(args) != 0 && len(args) != 2 {
@@ -44,7 +75,46 @@ func NewListCmd() *cobra.Command {
 				req = req.PerPage(perPage)
 			}
 
-			identities, _, err := req.Execute()
+			for _, filter := range []struct {
+				value string
+				set   func(string)
+			}{
+				{value: credentialsIdentifier, set: func(v string) { req = req.CredentialsIdentifier(v) }},
+				{value: verifiableAddress, set: func(v string) { req = req.VerifiableAddress(v) }},
+				{value: recoveryAddress, set: func(v string) { req = req.RecoveryAddress(v) }},
+				{value: state, set: func(v string) { req = req.State(v) }},
+				{value: schemaID, set: func(v string) { req = req.SchemaId(v) }},
+				{value: pageToken, set: func(v string) { req = req.PageToken(v) }},
+			} {
+				if filter.value != "" {
+					filter.set(filter.value)
+				}
+			}
+
+			for _, filter := range []struct {
+				flag, value string
+				set         func(time.Time)
+			}{
+				{flag: FlagCreatedAfter, value: createdAfter, set: func(t time.Time) { req = req.CreatedAfter(t) }},
+				{flag: FlagCreatedBefore, value: createdBefore, set: func(t time.Time) { req = req.CreatedBefore(t) }},
+			} {
+				if filter.value == "" {
+					continue
+				}
+
+				t, err := time.Parse(time.RFC3339, filter.value)
+				if err != nil {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not parse --%s \"%s\" as RFC 3339 date-time: %s\n", filter.flag, filter.value, err)
+					return cmdx.FailSilently(cmd)
+				}
+				filter.set(t)
+			}
+
+			if len(traits) > 0 {
+				req = req.Trait(traits)
+			}
+
+			identities, res, err := req.Execute()
 			if err != nil {
 				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not get the identities: %+v\n", err)
 				return cmdx.FailSilently(cmd)
@@ -54,7 +124,23 @@ func NewListCmd() *cobra.Command {
 				identities: identities,
 			})
 
+			if next := res.Header.Get(x.HeaderNextPageToken); next != "" && !flagx.MustGetBool(cmd, cmdx.FlagQuiet) {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Next page token: %s\n", next)
+			}
+
 			return nil
 		},
 	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&credentialsIdentifier, FlagCredentialsIdentifier, "", "Only list identities having a credential with this identifier, e.g. the email address used to sign in.")
+	flags.StringVar(&verifiableAddress, FlagVerifiableAddress, "", "Only list identities having this verifiable address.")
+	flags.StringVar(&recoveryAddress, FlagRecoveryAddress, "", "Only list identities having this recovery address.")
+	flags.StringVar(&state, FlagState, "", `Only list identities in this state. One of "active" and "inactive".`)
+	flags.StringVar(&schemaID, FlagSchemaID, "", "Only list identities using this identity schema.")
+	flags.StringVar(&createdAfter, FlagCreatedAfter, "", "Only list identities created at or after this RFC 3339 date-time.")
+	flags.StringVar(&createdBefore, FlagCreatedBefore, "", "Only list identities created before this RFC 3339 date-time.")
+	flags.StringArrayVar(&traits, FlagTrait, []string{}, `Only list identities whose trait matches, in the format "<path>=<value>" (e.g. "name.first=Jane"). Can be repeated.`)
+	flags.StringVar(&pageToken, FlagPageToken, "", "Use keyset pagination and list the identities after the identity with this ID. Ignores the page argument.")
+	return cmd
 }
@@ -2,9 +2,13 @@ package identities_test
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 
+	"github.com/gofrs/uuid"
+	"github.com/spf13/pflag"
+
 	"github.com/ory/kratos/cmd/identities"
 
 	"github.com/ory/x/cmdx"
@@ -49,4 +53,36 @@ func TestListCmd(t *testing.T) {
 			assert.True(t, strings.Contains(stdoutP1, id) != strings.Contains(stdoutP2, id), "%s \n %s", stdoutP1, stdoutP2)
 		}
 	})
+
+	t.Run("case=lists identities matching the filters", func(t *testing.T) {
+		is, ids := makeIdentities(t, reg, 3)
+		defer deleteIdentities(t, is)
+
+		key := uuid.Must(uuid.NewV4()).String()
+		is[1].Traits = identity.Traits(`{"testKey":"` + key + `"}`)
+		require.NoError(t, reg.Persister().UpdateIdentity(context.Background(), is[1]))
+
+		require.NoError(t, c.Flags().Set(identities.FlagTrait, "testKey="+key))
+		require.NoError(t, c.Flags().Set(identities.FlagState, "active"))
+		t.Cleanup(func() {
+			require.NoError(t, c.Flags().Set(identities.FlagState, ""))
+			c.Flags().Lookup(identities.FlagTrait).Value.(pflag.SliceValue).Replace(nil)
+		})
+
+		stdOut := execNoErr(t, c)
+		assert.Contains(t, stdOut, ids[1])
+		assert.NotContains(t, stdOut, ids[0])
+		assert.NotContains(t, stdOut, ids[2])
+	})
+
+	t.Run("case=fails on invalid filters", func(t *testing.T) {
+		require.NoError(t, c.Flags().Set(identities.FlagCreatedAfter, "yesterday"))
+		t.Cleanup(func() {
+			require.NoError(t, c.Flags().Set(identities.FlagCreatedAfter, ""))
+		})
+
+		_, stdErr, err := exec(c, nil)
+		assert.True(t, errors.Is(err, cmdx.ErrNoPrintButFail))
+		assert.Contains(t, stdErr, "RFC 3339")
+	})
 }
@@ -1,21 +1,78 @@
 package identities
 
 import (
+	"encoding/json"
 	"fmt"
-	"os"
+
+	kratos "github.com/ory/kratos-client-go"
+	"github.com/ory/x/cmdx"
 
 	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/cmd/cliclient"
+)
+
+const (
+	FlagIfMatch = "if-match"
 )
 
+// NewPatchCmd represents the patch command
 func NewPatchCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "patch <file.json [file-2.json [file-3.json] ...]>",
-		Short: "Patch identities by ID (not yet implemented)",
-		Args:  cobra.MinimumNArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			// TODO
-			fmt.Println("not yet implemented")
-			os.Exit(1)
+	var ifMatch string
+
+	cmd := &cobra.Command{
+		Use:   "patch <id> [file.json]",
+		Short: "Patch an identity by ID using JSON Patch",
+		Long: `Patch an identity by ID using a JSON Patch (RFC 6902) read from a file or STD_IN.
+
+The patch can change the identity's "/schema_id", "/state", "/traits", "/metadata_public" and "/metadata_admin".
+The patched identity is validated against its identity schema. Credentials can not be patched.
+
+Use "--if-match" with the entity tag returned when getting the identity, or add a "test" operation to the patch,
+to make sure the patch is only applied if the identity was not changed in the meantime.`,
+		Example: `$ cat > ./patch.json <<EOF
+[
+    {"op": "test", "path": "/traits/email", "value": "foo@example.com"},
+    {"op": "replace", "path": "/traits/email", "value": "bar@example.com"},
+    {"op": "replace", "path": "/state", "value": "inactive"}
+]
+EOF
+
+$ kratos identities patch 5da1a3f3-bd69-4ed8-ad57-0c1ec5d1e5d2 patch.json
+
+# Alternatively:
+$ cat patch.json | kratos identities patch 5da1a3f3-bd69-4ed8-ad57-0c1ec5d1e5d2`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := cliclient.NewClient(cmd)
+
+			sources, err := readSources(cmd, args[1:])
+			if err != nil {
+				return err
+			}
+
+			var patch []kratos.JsonPatch
+			if err := json.Unmarshal([]byte(sources[0].content), &patch); err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s: Could not parse JSON Patch: %s\n", sources[0].name, err)
+				return cmdx.FailSilently(cmd)
+			}
+
+			req := c.V0alpha2Api.AdminPatchIdentity(cmd.Context(), args[0]).JsonPatch(patch)
+			if ifMatch != "" {
+				req = req.IfMatch(ifMatch)
+			}
+
+			identity, _, err := req.Execute()
+			if err != nil {
+				cmdx.PrintErrors(cmd, map[string]error{args[0]: err})
+				return cmdx.FailSilently(cmd)
+			}
+
+			cmdx.PrintRow(cmd, (*outputIdentity)(identity))
+			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&ifMatch, FlagIfMatch, "", "Only apply the patch if the identity's entity tag (ETag) matches this value.")
+	return cmd
 }
@@ -0,0 +1,91 @@
+package identities_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/cmd/identities"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/cmdx"
+)
+
+func TestPatchCmd(t *testing.T) {
+	c := identities.NewPatchCmd()
+	reg := setup(t, c)
+
+	create := func(t *testing.T) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"testKey":"original"}`)
+		require.NoError(t, reg.Persister().CreateIdentity(context.Background(), i))
+		return i
+	}
+
+	t.Run("case=patches an identity from file", func(t *testing.T) {
+		i := create(t)
+
+		f, err := ioutil.TempFile("", "")
+		require.NoError(t, err)
+		_, err = f.WriteString(`[{"op": "replace", "path": "/traits/testKey", "value": "patched"}]`)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		stdOut := execNoErr(t, c, i.ID.String(), f.Name())
+		assert.Equal(t, "patched", gjson.Get(stdOut, "traits.testKey").String(), stdOut)
+
+		actual, err := reg.Persister().GetIdentity(context.Background(), i.ID)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"testKey":"patched"}`, string(actual.Traits))
+	})
+
+	t.Run("case=patches an identity from STD_IN", func(t *testing.T) {
+		i := create(t)
+
+		stdOut, stdErr, err := exec(c, bytes.NewBufferString(`[{"op": "replace", "path": "/state", "value": "inactive"}]`), i.ID.String())
+		require.NoError(t, err, "%s %s", stdOut, stdErr)
+		assert.Equal(t, string(identity.StateInactive), gjson.Get(stdOut, "state").String(), stdOut)
+	})
+
+	t.Run("case=fails if the entity tag does not match", func(t *testing.T) {
+		i := create(t)
+
+		require.NoError(t, c.Flags().Set(identities.FlagIfMatch, `"0"`))
+		t.Cleanup(func() {
+			require.NoError(t, c.Flags().Set(identities.FlagIfMatch, ""))
+		})
+
+		stdOut, stdErr, err := exec(c, bytes.NewBufferString(`[{"op": "replace", "path": "/traits/testKey", "value": "patched"}]`), i.ID.String())
+		assert.True(t, errors.Is(err, cmdx.ErrNoPrintButFail))
+		assert.Contains(t, stdErr, "412 Precondition Failed", stdErr)
+		assert.Len(t, stdOut, 0)
+	})
+
+	t.Run("case=fails with invalid patch", func(t *testing.T) {
+		i := create(t)
+
+		stdOut, stdErr, err := exec(c, bytes.NewBufferString(`[{"op": "replace", "path": "/traits/unknown", "value": "patched"}]`), i.ID.String())
+		assert.True(t, errors.Is(err, cmdx.ErrNoPrintButFail))
+		assert.Contains(t, stdErr, "400 Bad Request", stdErr)
+		assert.Len(t, stdOut, 0)
+
+		stdOut, stdErr, err = exec(c, bytes.NewBufferString(`{"op": "replace"}`), i.ID.String())
+		assert.True(t, errors.Is(err, cmdx.ErrNoPrintButFail))
+		assert.Contains(t, stdErr, "Could not parse JSON Patch", stdErr)
+		assert.Len(t, stdOut, 0)
+	})
+
+	t.Run("case=fails with unknown ID", func(t *testing.T) {
+		stdOut, stdErr, err := exec(c, bytes.NewBufferString(`[{"op": "replace", "path": "/state", "value": "inactive"}]`), x.NewUUID().String())
+		assert.True(t, errors.Is(err, cmdx.ErrNoPrintButFail))
+		assert.Contains(t, stdErr, "404 Not Found", stdErr)
+		assert.Len(t, stdOut, 0)
+	})
+}
@@ -25,9 +25,11 @@ func RegisterCommandRecursive(parent *cobra.Command) {
 	parent.AddCommand(c)
 
 	c.AddCommand(NewImportCmd())
+	c.AddCommand(NewExportCmd())
 	c.AddCommand(NewValidateCmd())
 	c.AddCommand(NewListCmd())
 	c.AddCommand(NewGetCmd())
 	c.AddCommand(NewDeleteCmd())
+	c.AddCommand(NewEraseCmd())
 	c.AddCommand(NewPatchCmd())
 }
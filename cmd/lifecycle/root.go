@@ -0,0 +1,23 @@
+package lifecycle
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ory/x/configx"
+)
+
+// NewLifecycleCmd creates a new lifecycle command
+func NewLifecycleCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "lifecycle",
+		Short: "Commands related to the Ory Kratos identity lifecycle",
+	}
+	configx.RegisterFlags(c.PersistentFlags())
+	return c
+}
+
+func RegisterCommandRecursive(parent *cobra.Command) {
+	c := NewLifecycleCmd()
+	parent.AddCommand(c)
+	c.AddCommand(NewWatchCmd())
+}
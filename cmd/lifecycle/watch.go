@@ -0,0 +1,44 @@
+package lifecycle
+
+import (
+	cx "context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/graceful"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/x/configx"
+)
+
+func NewWatchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch",
+		Short: "Starts the Ory Kratos identity lifecycle worker",
+		Long: `Starts the Ory Kratos identity lifecycle worker.
+
+The worker deactivates identities once their "inactive_at" time has come, and erases identities together with their
+sessions, self-service flows, tokens, and courier messages once their "delete_at" time has come. Deactivated identities
+are scheduled to be erased after the deletion grace period configured in "identity.lifecycle.deletion_grace_period".
+It also deletes rate limit counters whose window ended.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			r := driver.New(cmd.Context(), cmd.ErrOrStderr(), configx.WithFlags(cmd.Flags()))
+			Watch(cmd.Context(), r)
+		},
+	}
+}
+
+func Watch(ctx cx.Context, r driver.Registry) {
+	ctx, cancel := cx.WithCancel(ctx)
+
+	r.Logger().Println("Identity lifecycle worker started.")
+	if err := graceful.Graceful(func() error {
+		return r.IdentityLifecycle().Work(ctx)
+	}, func(_ cx.Context) error {
+		cancel()
+		return nil
+	}); err != nil {
+		r.Logger().WithError(err).Fatalf("Failed to run identity lifecycle worker.")
+	}
+
+	r.Logger().Println("Identity lifecycle worker was shutdown gracefully.")
+}
@@ -0,0 +1,37 @@
+package migrate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/x/configx"
+)
+
+func NewMigratePhoneNumbersCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "phone-numbers",
+		Short: "Normalize stored phone numbers to the E.164 format",
+		Long: `Phone numbers used as password identifiers, verifiable addresses and recovery addresses are stored in
+the E.164 format (e.g. +491701234567). Identities created with earlier versions of Ory Kratos may still contain
+phone numbers in the format they were entered in.
+
+This command validates every identity again using the configured identity schemas and
+the default region set in identity.phone.default_region, and stores the normalized values.
+Verification status of existing addresses is kept. Identities whose phone numbers conflict
+with another identity after normalization are reported and left untouched.
+
+	kratos migrate phone-numbers -c path/to/config.yml
+
+### WARNING ###
+
+Before running this command on an existing database, create a back up!
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cliclient.NewMigrateHandler().MigratePhoneNumbers(cmd, args)
+		},
+	}
+
+	configx.RegisterFlags(c.PersistentFlags())
+	c.Flags().BoolP("yes", "y", false, "If set all confirmation requests are accepted without user interaction.")
+	return c
+}
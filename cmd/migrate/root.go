@@ -15,4 +15,5 @@ func RegisterCommandRecursive(parent *cobra.Command) {
 	c := NewMigrateCmd()
 	parent.AddCommand(c)
 	c.AddCommand(NewMigrateSQLCmd())
+	c.AddCommand(NewMigratePhoneNumbersCmd())
 }
@@ -14,8 +14,10 @@ import (
 
 	"github.com/ory/kratos/cmd/identities"
 	"github.com/ory/kratos/cmd/jsonnet"
+	"github.com/ory/kratos/cmd/lifecycle"
 	"github.com/ory/kratos/cmd/migrate"
 	"github.com/ory/kratos/cmd/serve"
+	"github.com/ory/kratos/cmd/webhooks"
 	"github.com/ory/x/cmdx"
 
 	"github.com/spf13/cobra"
@@ -34,6 +36,8 @@ func NewRootCmd() (cmd *cobra.Command) {
 	remote.RegisterCommandRecursive(cmd)
 	hashers.RegisterCommandRecursive(cmd)
 	courier.RegisterCommandRecursive(cmd)
+	lifecycle.RegisterCommandRecursive(cmd)
+	webhooks.RegisterCommandRecursive(cmd)
 
 	cmd.AddCommand(cmdx.Version(&config.Version, &config.Commit, &config.Date))
 
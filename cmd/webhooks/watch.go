@@ -0,0 +1,43 @@
+package webhooks
+
+import (
+	cx "context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/graceful"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/x/configx"
+)
+
+func NewWatchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch",
+		Short: "Starts the Ory Kratos web hook worker",
+		Long: `Starts the Ory Kratos web hook worker.
+
+The worker delivers the requests of web hooks which have "async" enabled. Failed deliveries are retried with
+exponential backoff until the maximum number of attempts configured in "webhooks.delivery.max_attempts" is reached.
+Requests are signed with a key derived from the default secret.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			r := driver.New(cmd.Context(), cmd.ErrOrStderr(), configx.WithFlags(cmd.Flags()))
+			Watch(cmd.Context(), r)
+		},
+	}
+}
+
+func Watch(ctx cx.Context, r driver.Registry) {
+	ctx, cancel := cx.WithCancel(ctx)
+
+	r.Logger().Println("Web hook worker started.")
+	if err := graceful.Graceful(func() error {
+		return r.WebHookDispatcher().Work(ctx)
+	}, func(_ cx.Context) error {
+		cancel()
+		return nil
+	}); err != nil {
+		r.Logger().WithError(err).Fatalf("Failed to run web hook worker.")
+	}
+
+	r.Logger().Println("Web hook worker was shutdown gracefully.")
+}
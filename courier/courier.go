@@ -2,21 +2,15 @@ package courier
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
-	"fmt"
 	"net/url"
-	"strconv"
 	"time"
 
 	"github.com/cenkalti/backoff"
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 
-	"github.com/ory/herodot"
-
-	gomail "github.com/ory/mail/v3"
-
+	"github.com/ory/kratos/request"
 	"github.com/ory/kratos/x"
 )
 
@@ -28,107 +22,106 @@ type (
 		CourierSMTPHeaders() map[string]string
 		CourierTemplatesRoot() string
 	}
-	SMTPDependencies interface {
+	SMSConfig interface {
+		CourierSMSEnabled() bool
+		CourierSMSFrom() string
+		CourierSMSRequestConfig() json.RawMessage
+		CourierTemplatesRoot() string
+	}
+	HTTPConfig interface {
+		CourierEmailRequestConfig() json.RawMessage
+	}
+	MaildirConfig interface {
+		CourierMaildirPath() string
+	}
+	Config interface {
+		SMTPConfig
+		SMSConfig
+		HTTPConfig
+		MaildirConfig
+		CourierEmailStrategy() string
+		CourierMessageRetries() int
+	}
+	Dependencies interface {
 		PersistenceProvider
 		x.LoggingProvider
+		x.HTTPClientProvider
+		request.AuthCacheProvider
 		ConfigProvider
 	}
 	TemplateTyper            func(t EmailTemplate) (TemplateType, error)
 	EmailTemplateFromMessage func(c SMTPConfig, msg Message) (EmailTemplate, error)
 	Courier                  struct {
-		Dialer                      *gomail.Dialer
-		d                           SMTPDependencies
+		EmailSender                 EmailSender
+		d                           Dependencies
 		GetTemplateType             TemplateTyper
 		NewEmailTemplateFromMessage EmailTemplateFromMessage
+		GetSMSTemplateType          SMSTemplateTyper
+		NewSMSTemplateFromMessage   SMSTemplateFromMessage
 	}
 	Provider interface {
 		Courier(ctx context.Context) *Courier
 	}
 	ConfigProvider interface {
-		CourierConfig(ctx context.Context) SMTPConfig
+		CourierConfig(ctx context.Context) Config
 	}
 )
 
-func NewSMTP(ctx context.Context, d SMTPDependencies) *Courier {
-	uri := d.CourierConfig(ctx).CourierSMTPURL()
-
-	password, _ := uri.User.Password()
-	port, _ := strconv.ParseInt(uri.Port(), 10, 0)
-
-	dialer := &gomail.Dialer{
-		Host:     uri.Hostname(),
-		Port:     int(port),
-		Username: uri.User.Username(),
-		Password: password,
-
-		Timeout:      time.Second * 10,
-		RetryFailure: true,
-	}
-
-	sslSkipVerify, _ := strconv.ParseBool(uri.Query().Get("skip_ssl_verify"))
-
-	// SMTP schemes
-	// smtp: smtp clear text (with uri parameter) or with StartTLS (enforced by default)
-	// smtps: smtp with implicit TLS (recommended way in 2021 to avoid StartTLS downgrade attacks
-	//    and defaulting to fully-encrypted protocols https://datatracker.ietf.org/doc/html/rfc8314)
-	switch uri.Scheme {
-	case "smtp":
-		// Enforcing StartTLS by default for security best practices (config review, etc.)
-		skipStartTLS, _ := strconv.ParseBool(uri.Query().Get("disable_starttls"))
-		if !skipStartTLS {
-			// #nosec G402 This is ok (and required!) because it is configurable and disabled by default.
-			dialer.TLSConfig = &tls.Config{InsecureSkipVerify: sslSkipVerify, ServerName: uri.Hostname()}
-			// Enforcing StartTLS
-			dialer.StartTLSPolicy = gomail.MandatoryStartTLS
-		}
-	case "smtps":
-		// #nosec G402 This is ok (and required!) because it is configurable and disabled by default.
-		dialer.TLSConfig = &tls.Config{InsecureSkipVerify: sslSkipVerify, ServerName: uri.Hostname()}
-		dialer.SSL = true
-	}
-
+func NewCourier(ctx context.Context, d Dependencies) *Courier {
 	return &Courier{
 		d:                           d,
-		Dialer:                      dialer,
+		EmailSender:                 NewEmailSender(ctx, d),
 		GetTemplateType:             GetTemplateType,
 		NewEmailTemplateFromMessage: NewEmailTemplateFromMessage,
+		GetSMSTemplateType:          GetSMSTemplateType,
+		NewSMSTemplateFromMessage:   NewSMSTemplateFromMessage,
 	}
 }
 
 func (m *Courier) QueueEmail(ctx context.Context, t EmailTemplate) (uuid.UUID, error) {
-	recipient, err := t.EmailRecipient()
+	templateType, err := m.GetTemplateType(t)
 	if err != nil {
 		return uuid.Nil, err
 	}
 
-	subject, err := t.EmailSubject()
+	templateData, err := json.Marshal(t)
 	if err != nil {
 		return uuid.Nil, err
 	}
 
-	bodyPlaintext, err := t.EmailBodyPlaintext()
+	message := &Message{
+		Status:       MessageStatusQueued,
+		Type:         MessageTypeEmail,
+		TemplateType: templateType,
+		TemplateData: templateData,
+		Locale:       t.EmailLocale(),
+	}
+
+	// The template is re-created from the message so that templates stored in the database are used.
+	c, err := m.templateConfig(ctx)
 	if err != nil {
 		return uuid.Nil, err
 	}
 
-	templateType, err := m.GetTemplateType(t)
+	t, err = m.NewEmailTemplateFromMessage(c, *message)
 	if err != nil {
 		return uuid.Nil, err
 	}
 
-	templateData, err := json.Marshal(t)
-	if err != nil {
+	if message.Recipient, err = t.EmailRecipient(); err != nil {
 		return uuid.Nil, err
 	}
 
-	message := &Message{
-		Status:       MessageStatusQueued,
-		Type:         MessageTypeEmail,
-		Recipient:    recipient,
-		Body:         bodyPlaintext,
-		Subject:      subject,
-		TemplateType: templateType,
-		TemplateData: templateData,
+	if message.Subject, err = t.EmailSubject(); err != nil {
+		return uuid.Nil, err
+	}
+
+	if message.Body, err = t.EmailBodyPlaintext(); err != nil {
+		return uuid.Nil, err
+	}
+
+	if message.HTMLBody, err = t.EmailBody(); err != nil {
+		return uuid.Nil, err
 	}
 
 	if err := m.d.CourierPersister().AddMessage(ctx, message); err != nil {
@@ -137,6 +130,16 @@ func (m *Courier) QueueEmail(ctx context.Context, t EmailTemplate) (uuid.UUID, e
 	return message.ID, nil
 }
 
+// templateConfig returns the configuration email templates are rendered with. Templates stored in the
+// database take precedence over the configured and built-in templates.
+func (m *Courier) templateConfig(ctx context.Context) (SMTPConfig, error) {
+	stored, err := m.d.CourierPersister().ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newStoredTemplatesConfig(m.d.CourierConfig(ctx), stored), nil
+}
+
 func (m *Courier) Work(ctx context.Context) error {
 	errChan := make(chan error)
 	defer close(errChan)
@@ -169,26 +172,34 @@ func (m *Courier) watchMessages(ctx context.Context, errChan chan error) {
 func (m *Courier) DispatchMessage(ctx context.Context, msg Message) error {
 	switch msg.Type {
 	case MessageTypeEmail:
-		from := m.d.CourierConfig(ctx).CourierSMTPFrom()
-		fromName := m.d.CourierConfig(ctx).CourierSMTPFromName()
-		gm := gomail.NewMessage()
-		if fromName == "" {
-			gm.SetHeader("From", from)
-		} else {
-			gm.SetAddressHeader("From", from, fromName)
-		}
+		return m.dispatchEmail(ctx, msg)
+	case MessageTypeSMS:
+		return m.dispatchSMS(ctx, msg)
+	}
+	return errors.Errorf("received unexpected message type: %d", msg.Type)
+}
 
-		gm.SetHeader("To", msg.Recipient)
-		gm.SetHeader("Subject", msg.Subject)
+func (m *Courier) dispatchEmail(ctx context.Context, msg Message) error {
+	c := m.d.CourierConfig(ctx)
+	email := &Email{
+		From:      c.CourierSMTPFrom(),
+		FromName:  c.CourierSMTPFromName(),
+		To:        msg.Recipient,
+		Subject:   msg.Subject,
+		Headers:   c.CourierSMTPHeaders(),
+		Body:      msg.Body,
+		HTMLBody:  msg.HTMLBody,
+		MessageID: msg.ID,
+	}
 
-		headers := m.d.CourierConfig(ctx).CourierSMTPHeaders()
-		for k, v := range headers {
-			gm.SetHeader(k, v)
+	// Messages queued before the HTML body was rendered at queue time are rendered now.
+	if len(email.HTMLBody) == 0 {
+		tc, err := m.templateConfig(ctx)
+		if err != nil {
+			return err
 		}
 
-		gm.SetBody("text/plain", msg.Body)
-
-		tmpl, err := m.NewEmailTemplateFromMessage(m.d.CourierConfig(ctx), msg)
+		tmpl, err := m.NewEmailTemplateFromMessage(tc, msg)
 		if err != nil {
 			m.d.Logger().
 				WithError(err).
@@ -202,44 +213,34 @@ func (m *Courier) DispatchMessage(ctx context.Context, msg Message) error {
 					WithField("message_id", msg.ID).
 					Error(`Unable to get email body from template.`)
 			} else {
-				gm.AddAlternative("text/html", htmlBody)
+				email.HTMLBody = htmlBody
 			}
 		}
+	}
 
-		if err := m.Dialer.DialAndSend(ctx, gm); err != nil {
-			m.d.Logger().
-				WithError(err).
-				WithField("smtp_server", fmt.Sprintf("%s:%d", m.Dialer.Host, m.Dialer.Port)).
-				WithField("smtp_ssl_enabled", m.Dialer.SSL).
-				// WithField("email_to", msg.Recipient).
-				WithField("message_from", from).
-				Error("Unable to send email using SMTP connection.")
-			return errors.WithStack(err)
-		}
-
-		if err := m.d.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusSent); err != nil {
-			m.d.Logger().
-				WithError(err).
-				WithField("message_id", msg.ID).
-				Error(`Unable to set the message status to "sent".`)
-			return err
-		}
+	if err := m.EmailSender.SendEmail(ctx, email); err != nil {
+		return err
+	}
 
+	if err := m.d.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusSent); err != nil {
 		m.d.Logger().
+			WithError(err).
 			WithField("message_id", msg.ID).
-			WithField("message_type", msg.Type).
-			WithField("message_template_type", msg.TemplateType).
-			WithField("message_subject", msg.Subject).
-			Debug("Courier sent out message.")
-		return nil
+			Error(`Unable to set the message status to "sent".`)
+		return err
 	}
-	return errors.Errorf("received unexpected message type: %d", msg.Type)
+
+	m.d.Logger().
+		WithField("message_id", msg.ID).
+		WithField("message_type", msg.Type).
+		WithField("message_template_type", msg.TemplateType).
+		WithField("message_subject", msg.Subject).
+		Debug("Courier sent out message.")
+	return nil
 }
 
 func (m *Courier) DispatchQueue(ctx context.Context) error {
-	if len(m.Dialer.Host) == 0 {
-		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Courier tried to deliver an email but courier.smtp_url is not set!"))
-	}
+	maxRetries := m.d.CourierConfig(ctx).CourierMessageRetries()
 
 	messages, err := m.d.CourierPersister().NextMessages(ctx, 10)
 	if err != nil {
@@ -252,7 +253,29 @@ func (m *Courier) DispatchQueue(ctx context.Context) error {
 	for k := range messages {
 		var msg = messages[k]
 		if err := m.DispatchMessage(ctx, msg); err != nil {
-			for _, replace := range messages[k:] {
+			status := MessageStatusQueued
+			if msg.SendCount+1 >= maxRetries {
+				status = MessageStatusAbandoned
+			}
+
+			if err := m.d.CourierPersister().RecordMessageFailure(ctx, msg.ID, status, err.Error()); err != nil {
+				m.d.Logger().
+					WithError(err).
+					WithField("message_id", msg.ID).
+					Error(`Unable to record the failed delivery attempt of the message.`)
+			}
+
+			if status == MessageStatusAbandoned {
+				// The message is dead-lettered, which is why we continue with the remaining messages.
+				m.d.Logger().
+					WithError(err).
+					WithField("message_id", msg.ID).
+					WithField("message_send_count", msg.SendCount+1).
+					Warn("Courier gave up on delivering the message and marked it as abandoned.")
+				continue
+			}
+
+			for _, replace := range messages[k+1:] {
 				if err := m.d.CourierPersister().SetMessageStatus(ctx, replace.ID, MessageStatusQueued); err != nil {
 					m.d.Logger().
 						WithError(err).
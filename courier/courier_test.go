@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -37,11 +39,11 @@ func TestMain(m *testing.M) {
 func TestNewSMTP(t *testing.T) {
 	ctx := context.Background()
 
-	setupConfig := func(stringURL string) *courier.Courier {
+	setupConfig := func(stringURL string) *courier.SMTPSender {
 		conf, reg := internal.NewFastRegistryWithMocks(t)
 		conf.MustSet(config.ViperKeyCourierSMTPURL, stringURL)
 		t.Logf("SMTP URL: %s", conf.CourierSMTPURL().String())
-		return courier.NewSMTP(ctx, reg)
+		return courier.NewSMTPSender(ctx, reg)
 	}
 
 	if testing.Short() {
@@ -162,3 +164,101 @@ func TestSMTP(t *testing.T) {
 	assert.Contains(t, string(body), `"test-stub-header1":["foo"]`)
 	assert.Contains(t, string(body), `"test-stub-header2":["bar"]`)
 }
+
+func TestDispatchQueueAbandonsMessages(t *testing.T) {
+	ctx := context.Background()
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	// Sending SMS fails if the SMS channel is disabled.
+	conf.MustSet(config.ViperKeyCourierSMSEnabled, false)
+	conf.MustSet(config.ViperKeyCourierSMTPURL, "http://foo.url")
+	conf.MustSet(config.ViperKeyCourierMessageRetries, 2)
+
+	c := reg.Courier(ctx)
+
+	var ids []uuid.UUID
+	for _, to := range []string{"+12065550101", "+12065550102"} {
+		id, err := c.QueueSMS(ctx, templates.NewSMSTestStub(conf, &templates.SMSTestStubModel{To: to, Body: "test-sms-body"}))
+		require.NoError(t, err)
+		ids = append(ids, id)
+		time.Sleep(time.Second) // wait a bit so that the timestamp ordering works in MySQL.
+	}
+
+	require.Error(t, c.DispatchQueue(ctx))
+
+	first, err := reg.CourierPersister().FetchMessage(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, courier.MessageStatusQueued, first.Status)
+	assert.Equal(t, 1, first.SendCount)
+	assert.NotEmpty(t, first.LastError)
+
+	second, err := reg.CourierPersister().FetchMessage(ctx, ids[1])
+	require.NoError(t, err)
+	assert.Equal(t, courier.MessageStatusQueued, second.Status)
+	assert.Equal(t, 0, second.SendCount)
+
+	// The first message is abandoned and the courier moves on to the second message.
+	require.Error(t, c.DispatchQueue(ctx))
+
+	first, err = reg.CourierPersister().FetchMessage(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, courier.MessageStatusAbandoned, first.Status)
+	assert.Equal(t, 2, first.SendCount)
+
+	second, err = reg.CourierPersister().FetchMessage(ctx, ids[1])
+	require.NoError(t, err)
+	assert.Equal(t, courier.MessageStatusQueued, second.Status)
+	assert.Equal(t, 1, second.SendCount)
+
+	// Abandoning a message does not fail the dispatch.
+	require.NoError(t, c.DispatchQueue(ctx))
+
+	second, err = reg.CourierPersister().FetchMessage(ctx, ids[1])
+	require.NoError(t, err)
+	assert.Equal(t, courier.MessageStatusAbandoned, second.Status)
+
+	// Abandoned messages are no longer dispatched.
+	_, err = reg.CourierPersister().NextMessages(ctx, 10)
+	require.ErrorIs(t, err, courier.ErrQueueEmpty)
+}
+
+func TestQueueEmailLocalized(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "test_stub"), 0700))
+	for name, content := range map[string]string{
+		"email.subject.de.gotmpl":        "Betreff {{ .Subject }}",
+		"email.body.de.gotmpl":           "<p>Inhalt {{ .Body }}</p>",
+		"email.body.plaintext.de.gotmpl": "Inhalt {{ .Body }}",
+		"email.subject.gotmpl":           "subject {{ .Subject }}",
+		"email.body.gotmpl":              "<p>body {{ .Body }}</p>",
+		"email.body.plaintext.gotmpl":    "body {{ .Body }}",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "test_stub", name), []byte(content), 0600))
+	}
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyCourierTemplatesPath, dir)
+
+	c := reg.Courier(ctx)
+	id, err := c.QueueEmail(ctx, templates.NewTestStub(conf, &templates.TestStubModel{
+		To:      "test-recipient@example.org",
+		Subject: "test-subject",
+		Body:    "test-body",
+		Locale:  "de_DE",
+	}))
+	require.NoError(t, err)
+
+	m, err := reg.CourierPersister().FetchMessage(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "de_DE", m.Locale)
+	assert.Equal(t, "Betreff test-subject", m.Subject)
+	assert.Equal(t, "Inhalt test-body", m.Body)
+
+	tpl, err := courier.NewEmailTemplateFromMessage(conf, *m)
+	require.NoError(t, err)
+	html, err := tpl.EmailBody()
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Inhalt test-body</p>", html)
+}
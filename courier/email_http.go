@@ -0,0 +1,79 @@
+package courier
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/request"
+)
+
+type sendEmailRequestBody struct {
+	From     string            `json:"from"`
+	FromName string            `json:"from_name"`
+	To       string            `json:"to"`
+	Subject  string            `json:"subject"`
+	Headers  map[string]string `json:"headers"`
+	Body     string            `json:"body"`
+	HTMLBody string            `json:"html_body"`
+}
+
+// HTTPSender delivers emails by calling the HTTP API of a (transactional) mail service.
+type HTTPSender struct {
+	d      Dependencies
+	config HTTPConfig
+}
+
+var _ EmailSender = new(HTTPSender)
+
+func NewHTTPSender(ctx context.Context, d Dependencies) *HTTPSender {
+	return &HTTPSender{d: d, config: d.CourierConfig(ctx)}
+}
+
+func (s *HTTPSender) SendEmail(ctx context.Context, email *Email) error {
+	requestConfig := s.config.CourierEmailRequestConfig()
+	if len(requestConfig) == 0 {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Courier tried to deliver an email but courier.http.request_config is not set!"))
+	}
+
+	builder, err := request.NewBuilder(requestConfig, s.d.Logger(), s.d.RequestAuthCache(ctx))
+	if err != nil {
+		return err
+	}
+
+	req, err := builder.BuildRequest(&sendEmailRequestBody{
+		From:     email.From,
+		FromName: email.FromName,
+		To:       email.To,
+		Subject:  email.Subject,
+		Headers:  email.Headers,
+		Body:     email.Body,
+		HTMLBody: email.HTMLBody,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := builder.Client(s.d.HTTPClient(ctx)).Do(req.WithContext(ctx))
+	if err != nil {
+		s.d.Logger().
+			WithError(err).
+			WithField("message_id", email.MessageID).
+			Error("Unable to send email using the configured HTTP mail API.")
+		return errors.WithStack(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		s.d.Logger().
+			WithField("message_id", email.MessageID).
+			WithField("status_code", res.StatusCode).
+			Error("The HTTP mail API rejected the email.")
+		return errors.Errorf("mail API responded with unexpected status code: %d", res.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package courier
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/x"
+)
+
+// MaildirSender writes emails to a local Maildir instead of delivering them. It is intended
+// for development and testing.
+type MaildirSender struct {
+	d    Dependencies
+	path string
+}
+
+var _ EmailSender = new(MaildirSender)
+
+func NewMaildirSender(ctx context.Context, d Dependencies) *MaildirSender {
+	return &MaildirSender{d: d, path: d.CourierConfig(ctx).CourierMaildirPath()}
+}
+
+func (s *MaildirSender) SendEmail(_ context.Context, email *Email) error {
+	if len(s.path) == 0 {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Courier tried to deliver an email but courier.maildir.path is not set!"))
+	}
+
+	for _, dir := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(s.path, dir), 0700); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	// Messages are written to "tmp" first and then moved to "new" so that readers never see partial messages.
+	name := fmt.Sprintf("%d.%s.kratos", time.Now().UnixNano(), x.NewUUID())
+	tmp := filepath.Join(s.path, "tmp", name)
+
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if _, err := email.gomailMessage().WriteTo(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return errors.WithStack(err)
+	}
+
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return errors.WithStack(err)
+	}
+
+	if err := os.Rename(tmp, filepath.Join(s.path, "new", name)); err != nil {
+		return errors.WithStack(err)
+	}
+
+	s.d.Logger().
+		WithField("message_id", email.MessageID).
+		WithField("maildir", s.path).
+		Debug("Courier wrote message to maildir.")
+	return nil
+}
@@ -0,0 +1,70 @@
+package courier
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	gomail "github.com/ory/mail/v3"
+)
+
+const (
+	EmailStrategySMTP    = "smtp"
+	EmailStrategyHTTP    = "http"
+	EmailStrategyMaildir = "maildir"
+)
+
+type (
+	// Email is a rendered email which is ready to be delivered.
+	Email struct {
+		From     string
+		FromName string
+		To       string
+		Subject  string
+		Headers  map[string]string
+		Body     string
+		HTMLBody string
+
+		// MessageID is the ID of the courier message this email was rendered from.
+		MessageID uuid.UUID
+	}
+
+	// EmailSender delivers emails, for example using SMTP or an HTTP mail API.
+	EmailSender interface {
+		SendEmail(ctx context.Context, email *Email) error
+	}
+)
+
+// NewEmailSender returns the EmailSender configured in `courier.delivery_strategy`.
+func NewEmailSender(ctx context.Context, d Dependencies) EmailSender {
+	switch d.CourierConfig(ctx).CourierEmailStrategy() {
+	case EmailStrategyHTTP:
+		return NewHTTPSender(ctx, d)
+	case EmailStrategyMaildir:
+		return NewMaildirSender(ctx, d)
+	}
+	return NewSMTPSender(ctx, d)
+}
+
+func (e *Email) gomailMessage() *gomail.Message {
+	gm := gomail.NewMessage()
+	if e.FromName == "" {
+		gm.SetHeader("From", e.From)
+	} else {
+		gm.SetAddressHeader("From", e.From, e.FromName)
+	}
+
+	gm.SetHeader("To", e.To)
+	gm.SetHeader("Subject", e.Subject)
+
+	for k, v := range e.Headers {
+		gm.SetHeader(k, v)
+	}
+
+	gm.SetBody("text/plain", e.Body)
+	if len(e.HTMLBody) > 0 {
+		gm.AddAlternative("text/html", e.HTMLBody)
+	}
+
+	return gm
+}
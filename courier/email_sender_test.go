@@ -0,0 +1,209 @@
+package courier_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/courier"
+	templates "github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+)
+
+func TestNewEmailSender(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		strategy string
+		expected courier.EmailSender
+	}{
+		{strategy: "", expected: new(courier.SMTPSender)},
+		{strategy: "smtp", expected: new(courier.SMTPSender)},
+		{strategy: "http", expected: new(courier.HTTPSender)},
+		{strategy: "maildir", expected: new(courier.MaildirSender)},
+	} {
+		t.Run("strategy="+tc.strategy, func(t *testing.T) {
+			conf, reg := internal.NewFastRegistryWithMocks(t)
+			if tc.strategy != "" {
+				conf.MustSet(config.ViperKeyCourierEmailStrategy, tc.strategy)
+			}
+
+			assert.IsType(t, tc.expected, reg.Courier(ctx).EmailSender)
+		})
+	}
+}
+
+func TestHTTPSender(t *testing.T) {
+	ctx := context.Background()
+
+	actual := make(chan []byte, 1)
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		actual <- body
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyCourierEmailStrategy, "http")
+	conf.MustSet(config.ViperKeyCourierSMTPFrom, "test-stub@ory.sh")
+	conf.MustSet(config.ViperKeyCourierSMTPFromName, "Bob")
+	conf.MustSet(config.ViperKeyCourierEmailRequestConfig, json.RawMessage(fmt.Sprintf(`{
+		"url": "%s",
+		"method": "POST",
+		"body": "file://./stub/request.config.mailapi.jsonnet",
+		"auth": {
+			"type": "api_key",
+			"config": {
+				"in": "header",
+				"name": "Authorization",
+				"value": "Bearer secret"
+			}
+		}
+	}`, srv.URL)))
+
+	c := reg.Courier(ctx)
+
+	t.Run("case=sends email", func(t *testing.T) {
+		id, err := c.QueueEmail(ctx, templates.NewTestStub(conf, &templates.TestStubModel{
+			To:      "test-recipient-1@example.org",
+			Subject: "test-subject-1",
+			Body:    "test-body-1",
+		}))
+		require.NoError(t, err)
+
+		require.NoError(t, c.DispatchQueue(ctx))
+
+		body := <-actual
+		assert.Equal(t, "test-stub@ory.sh", gjson.GetBytes(body, "from.email").String(), "%s", body)
+		assert.Equal(t, "Bob", gjson.GetBytes(body, "from.name").String(), "%s", body)
+		assert.Equal(t, "test-recipient-1@example.org", gjson.GetBytes(body, "to").String(), "%s", body)
+		assert.Equal(t, "stub email subject test-subject-1", gjson.GetBytes(body, "subject").String(), "%s", body)
+		assert.Contains(t, gjson.GetBytes(body, "text").String(), "test-body-1", "%s", body)
+		assert.Contains(t, gjson.GetBytes(body, "html").String(), "test-body-1", "%s", body)
+
+		m, err := reg.CourierPersister().FetchMessage(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, courier.MessageStatusSent, m.Status)
+	})
+
+	t.Run("case=fails if the mail API rejects the email", func(t *testing.T) {
+		status = http.StatusBadRequest
+		t.Cleanup(func() {
+			status = http.StatusOK
+		})
+
+		id, err := c.QueueEmail(ctx, templates.NewTestStub(conf, &templates.TestStubModel{
+			To:      "test-recipient-2@example.org",
+			Subject: "test-subject-2",
+			Body:    "test-body-2",
+		}))
+		require.NoError(t, err)
+
+		require.Error(t, c.DispatchQueue(ctx))
+		<-actual
+
+		m, err := reg.CourierPersister().FetchMessage(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, courier.MessageStatusQueued, m.Status)
+		assert.Contains(t, string(m.LastError), "400")
+	})
+}
+
+func TestMaildirSender(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "maildir")
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyCourierEmailStrategy, "maildir")
+	conf.MustSet(config.ViperKeyCourierMaildirPath, dir)
+	conf.MustSet(config.ViperKeyCourierSMTPFrom, "test-stub@ory.sh")
+
+	c := reg.Courier(ctx)
+
+	for k := 1; k <= 2; k++ {
+		_, err := c.QueueEmail(ctx, templates.NewTestStub(conf, &templates.TestStubModel{
+			To:      fmt.Sprintf("test-recipient-%d@example.org", k),
+			Subject: fmt.Sprintf("test-subject-%d", k),
+			Body:    fmt.Sprintf("test-body-%d", k),
+		}))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, c.DispatchQueue(ctx))
+
+	tmp, err := os.ReadDir(filepath.Join(dir, "tmp"))
+	require.NoError(t, err)
+	assert.Len(t, tmp, 0)
+
+	files, err := os.ReadDir(filepath.Join(dir, "new"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	var mails string
+	for _, f := range files {
+		raw, err := os.ReadFile(filepath.Join(dir, "new", f.Name()))
+		require.NoError(t, err)
+		mails += string(raw)
+	}
+
+	for k := 1; k <= 2; k++ {
+		assert.Contains(t, mails, fmt.Sprintf("To: test-recipient-%d@example.org", k))
+		assert.Contains(t, mails, fmt.Sprintf("Subject: stub email subject test-subject-%d", k))
+		assert.Contains(t, mails, fmt.Sprintf("test-body-%d", k))
+	}
+	assert.Contains(t, mails, "From: test-stub@ory.sh")
+	assert.Contains(t, mails, "Content-Type: text/html")
+}
+
+func TestDispatchSendsPartsRenderedAtQueueTime(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "maildir")
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyCourierEmailStrategy, "maildir")
+	conf.MustSet(config.ViperKeyCourierMaildirPath, dir)
+
+	stored := courier.StoredTemplate{
+		TemplateType:  courier.TypeRecoveryValid,
+		Subject:       "subject-at-queue-time",
+		BodyPlaintext: "plaintext-at-queue-time",
+		BodyHTML:      "<p>html-at-queue-time</p>",
+	}
+	require.NoError(t, reg.CourierPersister().CreateTemplate(ctx, &stored))
+
+	c := reg.Courier(ctx)
+	_, err := c.QueueEmail(ctx, templates.NewRecoveryValid(conf, &templates.RecoveryValidModel{To: "test-recipient@example.org", RecoveryURL: "https://www.ory.sh/"}))
+	require.NoError(t, err)
+
+	stored.Subject, stored.BodyPlaintext, stored.BodyHTML = "subject-at-dispatch-time", "plaintext-at-dispatch-time", "<p>html-at-dispatch-time</p>"
+	require.NoError(t, reg.CourierPersister().UpdateTemplate(ctx, &stored))
+
+	require.NoError(t, c.DispatchQueue(ctx))
+
+	files, err := os.ReadDir(filepath.Join(dir, "new"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	raw, err := os.ReadFile(filepath.Join(dir, "new", files[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "subject-at-queue-time")
+	assert.Contains(t, string(raw), "plaintext-at-queue-time")
+	assert.Contains(t, string(raw), "html-at-queue-time")
+	assert.NotContains(t, string(raw), "at-dispatch-time")
+}
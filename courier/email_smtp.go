@@ -0,0 +1,83 @@
+package courier
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+
+	gomail "github.com/ory/mail/v3"
+)
+
+// SMTPSender delivers emails using an SMTP server.
+type SMTPSender struct {
+	Dialer *gomail.Dialer
+	d      Dependencies
+}
+
+var _ EmailSender = new(SMTPSender)
+
+func NewSMTPSender(ctx context.Context, d Dependencies) *SMTPSender {
+	uri := d.CourierConfig(ctx).CourierSMTPURL()
+
+	password, _ := uri.User.Password()
+	port, _ := strconv.ParseInt(uri.Port(), 10, 0)
+
+	dialer := &gomail.Dialer{
+		Host:     uri.Hostname(),
+		Port:     int(port),
+		Username: uri.User.Username(),
+		Password: password,
+
+		Timeout:      time.Second * 10,
+		RetryFailure: true,
+	}
+
+	sslSkipVerify, _ := strconv.ParseBool(uri.Query().Get("skip_ssl_verify"))
+
+	// SMTP schemes
+	// smtp: smtp clear text (with uri parameter) or with StartTLS (enforced by default)
+	// smtps: smtp with implicit TLS (recommended way in 2021 to avoid StartTLS downgrade attacks
+	//    and defaulting to fully-encrypted protocols https://datatracker.ietf.org/doc/html/rfc8314)
+	switch uri.Scheme {
+	case "smtp":
+		// Enforcing StartTLS by default for security best practices (config review, etc.)
+		skipStartTLS, _ := strconv.ParseBool(uri.Query().Get("disable_starttls"))
+		if !skipStartTLS {
+			// #nosec G402 This is ok (and required!) because it is configurable and disabled by default.
+			dialer.TLSConfig = &tls.Config{InsecureSkipVerify: sslSkipVerify, ServerName: uri.Hostname()}
+			// Enforcing StartTLS
+			dialer.StartTLSPolicy = gomail.MandatoryStartTLS
+		}
+	case "smtps":
+		// #nosec G402 This is ok (and required!) because it is configurable and disabled by default.
+		dialer.TLSConfig = &tls.Config{InsecureSkipVerify: sslSkipVerify, ServerName: uri.Hostname()}
+		dialer.SSL = true
+	}
+
+	return &SMTPSender{Dialer: dialer, d: d}
+}
+
+func (s *SMTPSender) SendEmail(ctx context.Context, email *Email) error {
+	if len(s.Dialer.Host) == 0 {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Courier tried to deliver an email but courier.smtp_url is not set!"))
+	}
+
+	if err := s.Dialer.DialAndSend(ctx, email.gomailMessage()); err != nil {
+		s.d.Logger().
+			WithError(err).
+			WithField("smtp_server", fmt.Sprintf("%s:%d", s.Dialer.Host, s.Dialer.Port)).
+			WithField("smtp_ssl_enabled", s.Dialer.SSL).
+			// WithField("email_to", msg.Recipient).
+			WithField("message_from", email.From).
+			Error("Unable to send email using SMTP connection.")
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package courier
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	RouteCollection = "/courier/messages"
+	RouteItem       = RouteCollection + "/:id"
+	RouteRequeue    = RouteItem + "/requeue"
+)
+
+type (
+	handlerDependencies interface {
+		PersistenceProvider
+		x.WriterProvider
+		x.CSRFProvider
+		config.Provider
+	}
+	HandlerProvider interface {
+		CourierHandler() *Handler
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
+	h.r.CSRFHandler().IgnoreGlobs(RouteCollection, RouteCollection+"/*", RouteCollection+"/*/requeue")
+	public.GET(RouteCollection, x.RedirectToAdminRoute(h.r))
+	public.GET(RouteItem, x.RedirectToAdminRoute(h.r))
+	public.POST(RouteRequeue, x.RedirectToAdminRoute(h.r))
+
+	h.r.CSRFHandler().IgnoreGlobs(RouteTemplateCollection, RouteTemplateCollection+"/*")
+	public.GET(RouteTemplateCollection, x.RedirectToAdminRoute(h.r))
+	public.POST(RouteTemplateCollection, x.RedirectToAdminRoute(h.r))
+	public.GET(RouteTemplateItem, x.RedirectToAdminRoute(h.r))
+	public.PUT(RouteTemplateItem, x.RedirectToAdminRoute(h.r))
+	public.DELETE(RouteTemplateItem, x.RedirectToAdminRoute(h.r))
+	public.POST(RouteTemplatePreview, x.RedirectToAdminRoute(h.r))
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
+	admin.GET(RouteCollection, h.list)
+	admin.GET(RouteItem, h.get)
+	admin.POST(RouteRequeue, h.requeue)
+
+	admin.GET(RouteTemplateCollection, h.listTemplates)
+	admin.POST(RouteTemplateCollection, h.createTemplate)
+	admin.GET(RouteTemplateItem, h.getTemplate)
+	admin.PUT(RouteTemplateItem, h.updateTemplate)
+	admin.DELETE(RouteTemplateItem, h.deleteTemplate)
+	admin.POST(RouteTemplatePreview, h.previewTemplate)
+}
+
+// A list of messages.
+// swagger:model courierMessageList
+// nolint:deadcode,unused
+type courierMessageList []Message
+
+// swagger:parameters adminListCourierMessages
+// nolint:deadcode,unused
+type adminListCourierMessages struct {
+	x.PaginationParams
+
+	// Status filters messages by their status
+	//
+	// One of `queued`, `sent`, `processing`, or `abandoned`.
+	//
+	// required: false
+	// in: query
+	Status string `json:"status"`
+
+	// Recipient filters messages by their recipient
+	//
+	// required: false
+	// in: query
+	Recipient string `json:"recipient"`
+
+	// TemplateType filters messages by the template they were created from, e.g. `recovery_valid`
+	//
+	// required: false
+	// in: query
+	TemplateType string `json:"template_type"`
+}
+
+// swagger:route GET /courier/messages v0alpha2 adminListCourierMessages
+//
+// List Messages
+//
+// Lists all messages stored in the courier queue, newest first. The list can be filtered by status,
+// recipient, and template type. The message bodies are not included because they contain live links and codes.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: courierMessageList
+//       400: jsonError
+//       500: jsonError
+func (h *Handler) list(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+	filter := ListMessagesFilter{
+		Recipient:    query.Get("recipient"),
+		TemplateType: TemplateType(query.Get("template_type")),
+	}
+
+	if status := query.Get("status"); len(status) > 0 {
+		ms, err := ToMessageStatus(status)
+		if err != nil {
+			h.r.Writer().WriteError(w, r, err)
+			return
+		}
+		filter.Status = ms
+	}
+
+	page, itemsPerPage := x.ParsePagination(r)
+	ms, total, err := h.r.CourierPersister().ListMessages(r.Context(), filter, page, itemsPerPage)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	for k := range ms {
+		ms[k].Body = ""
+	}
+
+	x.PaginationHeader(w, urlx.AppendPaths(h.r.Config(r.Context()).SelfAdminURL(), RouteCollection), total, page, itemsPerPage)
+	h.r.Writer().Write(w, r, ms)
+}
+
+// swagger:parameters adminGetCourierMessage
+// nolint:deadcode,unused
+type adminGetCourierMessage struct {
+	// ID is the ID of the message
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// IncludeBody includes the rendered message body in the response
+	//
+	// The body contains live links and codes, so it is only returned if explicitly requested.
+	//
+	// required: false
+	// in: query
+	IncludeBody bool `json:"include_body"`
+}
+
+// swagger:parameters adminRequeueCourierMessage
+// nolint:deadcode,unused
+type adminRequeueCourierMessage struct {
+	// ID is the ID of the message
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /courier/messages/{id} v0alpha2 adminGetCourierMessage
+//
+// Get a Message
+//
+// The rendered message body is only included if `include_body` is set to `true`.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: message
+//       404: jsonError
+//       500: jsonError
+func (h *Handler) get(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var includeBody bool
+	if raw := r.URL.Query().Get("include_body"); len(raw) > 0 {
+		var err error
+		if includeBody, err = strconv.ParseBool(raw); err != nil {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Invalid value `%s` for parameter `include_body`.", raw)))
+			return
+		}
+	}
+
+	m, err := h.r.CourierPersister().FetchMessage(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if !includeBody {
+		m.Body = ""
+	}
+
+	h.r.Writer().Write(w, r, m)
+}
+
+// swagger:route POST /courier/messages/{id}/requeue v0alpha2 adminRequeueCourierMessage
+//
+// Re-Queue an Abandoned Message
+//
+// Puts a message which the courier gave up on back into the queue so that delivery is attempted again.
+// The message's send count and last error are reset. Only messages with status `abandoned` can be re-queued.
+// The message body is not included in the response.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: message
+//       400: jsonError
+//       404: jsonError
+//       500: jsonError
+func (h *Handler) requeue(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	m, err := h.r.CourierPersister().FetchMessage(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if m.Status != MessageStatusAbandoned {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Only abandoned messages can be re-queued but message has status \"%s\".", m.Status)))
+		return
+	}
+
+	if err := h.r.CourierPersister().RequeueMessage(r.Context(), m.ID); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	m, err = h.r.CourierPersister().FetchMessage(r.Context(), m.ID)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	m.Body = ""
+	h.r.Writer().Write(w, r, m)
+}
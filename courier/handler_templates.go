@@ -0,0 +1,386 @@
+package courier
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/jsonx"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	RouteTemplateCollection = "/courier/templates"
+	RouteTemplateItem       = RouteTemplateCollection + "/:id"
+	RouteTemplatePreview    = RouteTemplateCollection + "/preview"
+)
+
+// A list of templates.
+// swagger:model courierTemplateList
+// nolint:deadcode,unused
+type courierTemplateList []StoredTemplate
+
+// swagger:model adminCourierTemplateBody
+type AdminCourierTemplateBody struct {
+	// TemplateType is the type of email the template is used for, e.g. `recovery_valid`.
+	//
+	// required: true
+	TemplateType TemplateType `json:"template_type"`
+
+	// Locale is the locale the template is used for, e.g. `de` or `de_DE`. Leave empty to use the
+	// template for all locales.
+	Locale string `json:"locale"`
+
+	// Subject is the Go template of the email's subject.
+	Subject string `json:"subject"`
+
+	// BodyPlaintext is the Go template of the email's plaintext body.
+	BodyPlaintext string `json:"body_plaintext"`
+
+	// BodyHTML is the Go template of the email's HTML body.
+	BodyHTML string `json:"body_html"`
+}
+
+// A rendered template preview.
+//
+// swagger:model courierTemplatePreview
+type TemplatePreview struct {
+	// required: true
+	Subject string `json:"subject"`
+
+	// required: true
+	BodyPlaintext string `json:"body_plaintext"`
+
+	// required: true
+	BodyHTML string `json:"body_html"`
+}
+
+// decodeTemplate decodes and validates the template in the request body. Unless allowEmpty is set, at least one
+// part of the template must be set.
+func (h *Handler) decodeTemplate(r *http.Request, t *StoredTemplate, allowEmpty bool) error {
+	var body AdminCourierTemplateBody
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&body); err != nil {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to decode the request body: %s", err).WithWrap(err))
+	}
+
+	t.TemplateType = body.TemplateType
+	t.Locale = body.Locale
+	t.Subject = body.Subject
+	t.BodyPlaintext = body.BodyPlaintext
+	t.BodyHTML = body.BodyHTML
+
+	if !allowEmpty && len(t.Subject)+len(t.BodyPlaintext)+len(t.BodyHTML) == 0 {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("At least one of subject, body_plaintext, or body_html must be set."))
+	}
+
+	return t.Validate()
+}
+
+// swagger:route GET /courier/templates v0alpha2 adminListCourierTemplates
+//
+// List Templates
+//
+// Lists all email templates stored in the database.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: courierTemplateList
+//       500: jsonError
+func (h *Handler) listTemplates(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ts, err := h.r.CourierPersister().ListTemplates(r.Context())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, ts)
+}
+
+// swagger:parameters adminGetCourierTemplate adminDeleteCourierTemplate
+// nolint:deadcode,unused
+type adminGetCourierTemplate struct {
+	// ID is the ID of the template
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /courier/templates/{id} v0alpha2 adminGetCourierTemplate
+//
+// Get a Template
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: courierTemplate
+//       404: jsonError
+//       500: jsonError
+func (h *Handler) getTemplate(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	t, err := h.r.CourierPersister().GetTemplate(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, t)
+}
+
+// swagger:parameters adminCreateCourierTemplate adminPreviewCourierTemplate
+// nolint:deadcode,unused
+type adminCreateCourierTemplate struct {
+	// in: body
+	Body AdminCourierTemplateBody
+}
+
+// swagger:route POST /courier/templates v0alpha2 adminCreateCourierTemplate
+//
+// Create a Template
+//
+// Stores an email template in the database. Stored templates take precedence over the templates found in
+// `courier.template_override_path` and the built-in templates. Parts of the template which are left empty
+// fall back to these templates. Only one template can be stored per template type and locale.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       201: courierTemplate
+//       400: jsonError
+//       409: jsonError
+//       500: jsonError
+func (h *Handler) createTemplate(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var t StoredTemplate
+	if err := h.decodeTemplate(r, &t, false); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.CourierPersister().CreateTemplate(r.Context(), &t); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().WriteCreated(w, r,
+		urlx.AppendPaths(h.r.Config(r.Context()).SelfAdminURL(), RouteTemplateCollection, t.ID.String()).String(),
+		&t,
+	)
+}
+
+// swagger:parameters adminUpdateCourierTemplate
+// nolint:deadcode,unused
+type adminUpdateCourierTemplate struct {
+	// ID is the ID of the template
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// in: body
+	Body AdminCourierTemplateBody
+}
+
+// swagger:route PUT /courier/templates/{id} v0alpha2 adminUpdateCourierTemplate
+//
+// Update a Template
+//
+// Replaces a stored email template. The full template payload is expected.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: courierTemplate
+//       400: jsonError
+//       404: jsonError
+//       409: jsonError
+//       500: jsonError
+func (h *Handler) updateTemplate(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	t, err := h.r.CourierPersister().GetTemplate(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.decodeTemplate(r, t, false); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.CourierPersister().UpdateTemplate(r.Context(), t); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, t)
+}
+
+// swagger:route DELETE /courier/templates/{id} v0alpha2 adminDeleteCourierTemplate
+//
+// Delete a Template
+//
+// Deletes a stored email template. Emails of this type and locale are rendered using the templates found in
+// `courier.template_override_path` or the built-in templates again.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       204: emptyResponse
+//       404: jsonError
+//       500: jsonError
+func (h *Handler) deleteTemplate(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if err := h.r.CourierPersister().DeleteTemplate(r.Context(), x.ParseUUID(ps.ByName("id"))); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// swagger:route POST /courier/templates/preview v0alpha2 adminPreviewCourierTemplate
+//
+// Preview a Template
+//
+// Renders the given template against sample data without storing it. The template replaces the stored
+// template of the same type and locale, if any. Parts of the template which are left empty are rendered
+// using the templates found in `courier.template_override_path` or the built-in templates.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: courierTemplatePreview
+//       400: jsonError
+//       500: jsonError
+func (h *Handler) previewTemplate(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var draft StoredTemplate
+	if err := h.decodeTemplate(r, &draft, true); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	stored, err := h.r.CourierPersister().ListTemplates(r.Context())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	templates := []StoredTemplate{draft}
+	for _, t := range stored {
+		if t.TemplateType != draft.TemplateType || t.Locale != draft.Locale {
+			templates = append(templates, t)
+		}
+	}
+
+	t, err := newSampleEmailTemplate(newStoredTemplatesConfig(h.r.Config(r.Context()), templates), draft.TemplateType, draft.Locale)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	var preview TemplatePreview
+	for _, part := range []struct {
+		name   string
+		render func() (string, error)
+		target *string
+	}{
+		{name: "subject", render: t.EmailSubject, target: &preview.Subject},
+		{name: "body_plaintext", render: t.EmailBodyPlaintext, target: &preview.BodyPlaintext},
+		{name: "body_html", render: t.EmailBody, target: &preview.BodyHTML},
+	} {
+		out, err := part.render()
+		if err != nil {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to render the %s: %s", part.name, err).WithWrap(err)))
+			return
+		}
+		*part.target = out
+	}
+
+	h.r.Writer().Write(w, r, &preview)
+}
+
+// newSampleEmailTemplate returns an email template of the given type which is filled with sample data.
+func newSampleEmailTemplate(c SMTPConfig, tt TemplateType, locale string) (EmailTemplate, error) {
+	const to = "jane.doe@example.org"
+	identity := map[string]interface{}{
+		"id":        "9f425a8d-7efc-4768-8f23-7647a74fdf13",
+		"schema_id": "default",
+		"state":     "active",
+		"traits":    map[string]interface{}{"email": to},
+	}
+
+	switch tt {
+	case TypeRecoveryInvalid:
+		return template.NewRecoveryInvalid(c, &template.RecoveryInvalidModel{To: to, Locale: locale}), nil
+	case TypeRecoveryValid:
+		return template.NewRecoveryValid(c, &template.RecoveryValidModel{To: to, Locale: locale, Identity: identity,
+			RecoveryURL: "https://www.example.org/self-service/recovery?flow=3a3b5ad5-5c0b-4b5b-a3fe-42d3d6d79d02&token=sample-token"}), nil
+	case TypeRecoveryCodeValid:
+		return template.NewRecoveryCodeValid(c, &template.RecoveryCodeValidModel{To: to, Locale: locale, Identity: identity,
+			RecoveryCode: "123456"}), nil
+	case TypeVerificationInvalid:
+		return template.NewVerificationInvalid(c, &template.VerificationInvalidModel{To: to, Locale: locale}), nil
+	case TypeVerificationValid:
+		return template.NewVerificationValid(c, &template.VerificationValidModel{To: to, Locale: locale, Identity: identity,
+			VerificationURL: "https://www.example.org/self-service/verification?flow=3a3b5ad5-5c0b-4b5b-a3fe-42d3d6d79d02&token=sample-token"}), nil
+	case TypeVerificationCodeValid:
+		return template.NewVerificationCodeValid(c, &template.VerificationCodeValidModel{To: to, Locale: locale, Identity: identity,
+			VerificationCode: "123456"}), nil
+	case TypeLoginInvalid:
+		return template.NewLoginInvalid(c, &template.LoginInvalidModel{To: to, Locale: locale}), nil
+	case TypeLoginValid:
+		return template.NewLoginValid(c, &template.LoginValidModel{To: to, Locale: locale, Identity: identity,
+			LoginURL: "https://www.example.org/self-service/login?flow=3a3b5ad5-5c0b-4b5b-a3fe-42d3d6d79d02&token=sample-token", LoginCode: "123456"}), nil
+	case TypeTestStub:
+		return template.NewTestStub(c, &template.TestStubModel{To: to, Locale: locale, Subject: "sample subject", Body: "sample body"}), nil
+	}
+	return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Template type \"%s\" is not supported.", tt))
+}
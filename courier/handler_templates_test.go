@@ -0,0 +1,187 @@
+package courier_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/courier"
+	templates "github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/x"
+)
+
+func TestTemplateHandler(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	_, adminTS := testhelpers.NewKratosServerWithCSRF(t, reg)
+	conf.MustSet(config.ViperKeyAdminBaseURL, adminTS.URL)
+
+	ctx := context.Background()
+
+	var do = func(t *testing.T, method, href string, payload interface{}, expectCode int) gjson.Result {
+		var body bytes.Buffer
+		if payload != nil {
+			require.NoError(t, json.NewEncoder(&body).Encode(payload))
+		}
+
+		req, err := http.NewRequest(method, adminTS.URL+href, &body)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		raw, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.EqualValues(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	var queue = func(t *testing.T, locale string) *courier.Message {
+		id, err := reg.Courier(ctx).QueueEmail(ctx, templates.NewTestStub(conf, &templates.TestStubModel{
+			To:      "test-recipient@example.org",
+			Subject: "test-subject",
+			Body:    "test-body",
+			Locale:  locale,
+		}))
+		require.NoError(t, err)
+
+		m, err := reg.CourierPersister().FetchMessage(ctx, id)
+		require.NoError(t, err)
+		return m
+	}
+
+	var defaultID, germanID string
+	t.Run("case=should create templates", func(t *testing.T) {
+		res := do(t, "POST", courier.RouteTemplateCollection, &courier.AdminCourierTemplateBody{
+			TemplateType:  courier.TypeTestStub,
+			Subject:       "stored subject {{ .Subject }}",
+			BodyPlaintext: "stored body {{ .Body }}",
+		}, http.StatusCreated)
+		defaultID = res.Get("id").String()
+		assert.Equal(t, "stub", res.Get("template_type").String(), res.Raw)
+		assert.Equal(t, "", res.Get("locale").String(), res.Raw)
+		assert.Equal(t, "stored subject {{ .Subject }}", res.Get("subject").String(), res.Raw)
+		assert.Equal(t, "", res.Get("body_html").String(), res.Raw)
+
+		res = do(t, "POST", courier.RouteTemplateCollection, &courier.AdminCourierTemplateBody{
+			TemplateType: courier.TypeTestStub,
+			Locale:       "de-DE",
+			Subject:      "gespeicherter Betreff {{ .Subject }}",
+		}, http.StatusCreated)
+		germanID = res.Get("id").String()
+		assert.Equal(t, "de_DE", res.Get("locale").String(), "the locale is normalized: %s", res.Raw)
+	})
+
+	t.Run("case=should reject invalid templates", func(t *testing.T) {
+		for _, tc := range []struct {
+			name   string
+			body   courier.AdminCourierTemplateBody
+			code   int
+			reason string
+		}{
+			{name: "duplicate", code: http.StatusConflict, body: courier.AdminCourierTemplateBody{TemplateType: courier.TypeTestStub, Subject: "duplicate"}},
+			{name: "unknown type", code: http.StatusBadRequest, reason: "not supported", body: courier.AdminCourierTemplateBody{TemplateType: courier.TypeOTP, Subject: "foo"}},
+			{name: "invalid locale", code: http.StatusBadRequest, reason: "Locale", body: courier.AdminCourierTemplateBody{TemplateType: courier.TypeTestStub, Locale: "../../etc", Subject: "foo"}},
+			{name: "empty", code: http.StatusBadRequest, reason: "must be set", body: courier.AdminCourierTemplateBody{TemplateType: courier.TypeLoginValid}},
+			{name: "invalid syntax", code: http.StatusBadRequest, reason: "body_html", body: courier.AdminCourierTemplateBody{TemplateType: courier.TypeLoginValid, BodyHTML: "{{ .LoginURL "}},
+		} {
+			t.Run("case="+tc.name, func(t *testing.T) {
+				res := do(t, "POST", courier.RouteTemplateCollection, &tc.body, tc.code)
+				assert.Contains(t, res.Get("error.reason").String(), tc.reason, res.Raw)
+			})
+		}
+	})
+
+	t.Run("case=should list and get templates", func(t *testing.T) {
+		res := do(t, "GET", courier.RouteTemplateCollection, nil, http.StatusOK)
+		require.Len(t, res.Array(), 2, res.Raw)
+		assert.Equal(t, defaultID, res.Get("0.id").String(), res.Raw)
+		assert.Equal(t, germanID, res.Get("1.id").String(), res.Raw)
+
+		res = do(t, "GET", courier.RouteTemplateCollection+"/"+germanID, nil, http.StatusOK)
+		assert.Equal(t, "gespeicherter Betreff {{ .Subject }}", res.Get("subject").String(), res.Raw)
+
+		_ = do(t, "GET", courier.RouteTemplateCollection+"/"+x.NewUUID().String(), nil, http.StatusNotFound)
+	})
+
+	t.Run("case=should render emails with stored templates", func(t *testing.T) {
+		m := queue(t, "")
+		assert.Equal(t, "stored subject test-subject", m.Subject)
+		assert.Equal(t, "stored body test-body", m.Body)
+
+		m = queue(t, "de_DE")
+		assert.Equal(t, "gespeicherter Betreff test-subject", m.Subject)
+		assert.Equal(t, "stored body test-body", m.Body, "falls back to the stored default locale")
+
+		tpl, err := courier.NewEmailTemplateFromMessage(conf, *m)
+		require.NoError(t, err)
+		html, err := tpl.EmailBody()
+		require.NoError(t, err)
+		assert.Contains(t, html, "stub email body test-body", "falls back to the built-in template")
+	})
+
+	t.Run("case=should update templates", func(t *testing.T) {
+		res := do(t, "PUT", courier.RouteTemplateCollection+"/"+defaultID, &courier.AdminCourierTemplateBody{
+			TemplateType: courier.TypeTestStub,
+			Subject:      "updated subject {{ .Subject }}",
+		}, http.StatusOK)
+		assert.Equal(t, defaultID, res.Get("id").String(), res.Raw)
+		assert.Equal(t, "", res.Get("body_plaintext").String(), res.Raw)
+
+		m := queue(t, "")
+		assert.Equal(t, "updated subject test-subject", m.Subject, "updated templates are not cached")
+		assert.Equal(t, "stub email body test-body", m.Body)
+
+		_ = do(t, "PUT", courier.RouteTemplateCollection+"/"+defaultID, &courier.AdminCourierTemplateBody{TemplateType: courier.TypeTestStub}, http.StatusBadRequest)
+		_ = do(t, "PUT", courier.RouteTemplateCollection+"/"+germanID, &courier.AdminCourierTemplateBody{TemplateType: courier.TypeTestStub, Subject: "conflict"}, http.StatusConflict)
+		_ = do(t, "PUT", courier.RouteTemplateCollection+"/"+x.NewUUID().String(), &courier.AdminCourierTemplateBody{TemplateType: courier.TypeTestStub, Subject: "foo"}, http.StatusNotFound)
+	})
+
+	t.Run("case=should preview templates", func(t *testing.T) {
+		res := do(t, "POST", courier.RouteTemplatePreview, &courier.AdminCourierTemplateBody{
+			TemplateType: courier.TypeRecoveryValid,
+			Locale:       "de",
+			Subject:      "Konto von {{ .To }} wiederherstellen",
+			BodyHTML:     `<a href="{{ .RecoveryURL }}">{{ .Identity.traits.email }}</a>`,
+		}, http.StatusOK)
+		assert.Equal(t, "Konto von jane.doe@example.org wiederherstellen", res.Get("subject").String(), res.Raw)
+		assert.Contains(t, res.Get("body_html").String(), `<a href="https://www.example.org/self-service/recovery?flow=`, res.Raw)
+		assert.Contains(t, res.Get("body_html").String(), `>jane.doe@example.org</a>`, res.Raw)
+		assert.Contains(t, res.Get("body_plaintext").String(), "https://www.example.org/self-service/recovery?flow=", "uses the built-in template: %s", res.Raw)
+
+		res = do(t, "POST", courier.RouteTemplatePreview, &courier.AdminCourierTemplateBody{TemplateType: courier.TypeTestStub}, http.StatusOK)
+		assert.Equal(t, "stub email subject sample subject", res.Get("subject").String(), "the draft replaces the stored template: %s", res.Raw)
+
+		res = do(t, "POST", courier.RouteTemplatePreview, &courier.AdminCourierTemplateBody{TemplateType: courier.TypeTestStub, Subject: "{{ .Unknown.Field }}"}, http.StatusBadRequest)
+		assert.Contains(t, res.Get("error.reason").String(), "subject", res.Raw)
+
+		t.Setenv("KRATOS_TEST_TEMPLATE_SECRET", "secret")
+		res = do(t, "POST", courier.RouteTemplatePreview, &courier.AdminCourierTemplateBody{TemplateType: courier.TypeTestStub, Subject: `{{ env "KRATOS_TEST_TEMPLATE_SECRET" }}`}, http.StatusBadRequest)
+		assert.NotContains(t, res.Raw, "secret")
+		_ = do(t, "POST", courier.RouteTemplatePreview, &courier.AdminCourierTemplateBody{TemplateType: courier.TypeTestStub, BodyHTML: `{{ expandenv "$KRATOS_TEST_TEMPLATE_SECRET" }}`}, http.StatusBadRequest)
+		_ = do(t, "POST", courier.RouteTemplateCollection, &courier.AdminCourierTemplateBody{TemplateType: courier.TypeTestStub, Locale: "fr", Subject: `{{ env "KRATOS_TEST_TEMPLATE_SECRET" }}`}, http.StatusBadRequest)
+
+		// Previews are not stored.
+		assert.Len(t, do(t, "GET", courier.RouteTemplateCollection, nil, http.StatusOK).Array(), 2)
+	})
+
+	t.Run("case=should delete templates", func(t *testing.T) {
+		_ = do(t, "DELETE", courier.RouteTemplateCollection+"/"+defaultID, nil, http.StatusNoContent)
+		_ = do(t, "DELETE", courier.RouteTemplateCollection+"/"+defaultID, nil, http.StatusNotFound)
+		_ = do(t, "GET", courier.RouteTemplateCollection+"/"+defaultID, nil, http.StatusNotFound)
+
+		m := queue(t, "")
+		assert.Equal(t, "stub email subject test-subject", m.Subject)
+	})
+}
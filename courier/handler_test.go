@@ -0,0 +1,142 @@
+package courier_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/x"
+)
+
+func TestHandler(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	_, adminTS := testhelpers.NewKratosServerWithCSRF(t, reg)
+	conf.MustSet(config.ViperKeyAdminBaseURL, adminTS.URL)
+
+	ctx := context.Background()
+
+	var do = func(t *testing.T, base *httptest.Server, method, href string, expectCode int) gjson.Result {
+		req, err := http.NewRequest(method, base.URL+href, nil)
+		require.NoError(t, err)
+		res, err := base.Client().Do(req)
+		require.NoError(t, err)
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+
+		require.EqualValues(t, expectCode, res.StatusCode, "%s", body)
+		return gjson.ParseBytes(body)
+	}
+
+	messages := []courier.Message{
+		{Type: courier.MessageTypeEmail, Recipient: "foo@ory.sh", Subject: "foo", Body: "foo body", TemplateType: courier.TypeRecoveryValid},
+		{Type: courier.MessageTypeEmail, Recipient: "bar@ory.sh", Subject: "bar", Body: "bar body", TemplateType: courier.TypeVerificationValid},
+		{Type: courier.MessageTypeSMS, Recipient: "+12065550100", Body: "baz body", TemplateType: courier.TypeOTP},
+	}
+	for k := range messages {
+		require.NoError(t, reg.CourierPersister().AddMessage(ctx, &messages[k]))
+	}
+	require.NoError(t, reg.CourierPersister().RecordMessageFailure(ctx, messages[1].ID, courier.MessageStatusQueued, "first error"))
+	require.NoError(t, reg.CourierPersister().RecordMessageFailure(ctx, messages[1].ID, courier.MessageStatusAbandoned, "second error"))
+
+	t.Run("case=should list all messages", func(t *testing.T) {
+		res := do(t, adminTS, "GET", courier.RouteCollection, http.StatusOK)
+		assert.Len(t, res.Array(), len(messages))
+		for _, m := range messages {
+			assert.Contains(t, res.Raw, m.ID.String())
+			assert.NotContains(t, res.Raw, m.Body)
+		}
+		assert.False(t, res.Get("0.body").Exists(), res.Raw)
+	})
+
+	t.Run("case=should filter messages", func(t *testing.T) {
+		res := do(t, adminTS, "GET", courier.RouteCollection+"?status=abandoned", http.StatusOK)
+		require.Len(t, res.Array(), 1)
+		assert.Equal(t, messages[1].ID.String(), res.Get("0.id").String())
+		assert.Equal(t, "abandoned", res.Get("0.status").String())
+
+		res = do(t, adminTS, "GET", courier.RouteCollection+"?recipient=foo@ory.sh", http.StatusOK)
+		require.Len(t, res.Array(), 1)
+		assert.Equal(t, messages[0].ID.String(), res.Get("0.id").String())
+
+		res = do(t, adminTS, "GET", courier.RouteCollection+"?template_type=otp", http.StatusOK)
+		require.Len(t, res.Array(), 1)
+		assert.Equal(t, messages[2].ID.String(), res.Get("0.id").String())
+		assert.Equal(t, "sms", res.Get("0.type").String())
+	})
+
+	t.Run("case=should fail on unknown status", func(t *testing.T) {
+		res := do(t, adminTS, "GET", courier.RouteCollection+"?status=foo", http.StatusBadRequest)
+		assert.Contains(t, res.Get("error.reason").String(), "not supported", res.Raw)
+	})
+
+	t.Run("case=should paginate messages", func(t *testing.T) {
+		req, err := http.NewRequest("GET", adminTS.URL+courier.RouteCollection+"?per_page=2&page=0", nil)
+		require.NoError(t, err)
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, "3", res.Header.Get("X-Total-Count"))
+		assert.Contains(t, res.Header.Get("Link"), "page=1")
+	})
+
+	t.Run("case=should get a message", func(t *testing.T) {
+		res := do(t, adminTS, "GET", courier.RouteCollection+"/"+messages[0].ID.String(), http.StatusOK)
+		assert.Equal(t, messages[0].ID.String(), res.Get("id").String(), res.Raw)
+		assert.Equal(t, "queued", res.Get("status").String(), res.Raw)
+		assert.Equal(t, "email", res.Get("type").String(), res.Raw)
+		assert.Equal(t, "foo@ory.sh", res.Get("recipient").String(), res.Raw)
+		assert.False(t, res.Get("body").Exists(), res.Raw)
+		assert.Equal(t, "recovery_valid", res.Get("template_type").String(), res.Raw)
+		assert.EqualValues(t, 0, res.Get("send_count").Int(), res.Raw)
+		assert.False(t, res.Get("last_error").Exists(), res.Raw)
+		assert.False(t, res.Get("nid").Exists(), res.Raw)
+	})
+
+	t.Run("case=should only include the body if requested", func(t *testing.T) {
+		res := do(t, adminTS, "GET", courier.RouteCollection+"/"+messages[0].ID.String()+"?include_body=true", http.StatusOK)
+		assert.Equal(t, "foo body", res.Get("body").String(), res.Raw)
+
+		res = do(t, adminTS, "GET", courier.RouteCollection+"/"+messages[0].ID.String()+"?include_body=false", http.StatusOK)
+		assert.False(t, res.Get("body").Exists(), res.Raw)
+
+		res = do(t, adminTS, "GET", courier.RouteCollection+"/"+messages[0].ID.String()+"?include_body=foo", http.StatusBadRequest)
+		assert.Contains(t, res.Get("error.reason").String(), "include_body", res.Raw)
+	})
+
+	t.Run("case=should return 404 for unknown message", func(t *testing.T) {
+		_ = do(t, adminTS, "GET", courier.RouteCollection+"/"+x.NewUUID().String(), http.StatusNotFound)
+		_ = do(t, adminTS, "POST", courier.RouteCollection+"/"+x.NewUUID().String()+"/requeue", http.StatusNotFound)
+	})
+
+	t.Run("case=should only requeue abandoned messages", func(t *testing.T) {
+		res := do(t, adminTS, "POST", courier.RouteCollection+"/"+messages[0].ID.String()+"/requeue", http.StatusBadRequest)
+		assert.Contains(t, res.Get("error.reason").String(), "queued", res.Raw)
+
+		res = do(t, adminTS, "GET", courier.RouteCollection+"/"+messages[1].ID.String(), http.StatusOK)
+		assert.EqualValues(t, 2, res.Get("send_count").Int(), res.Raw)
+		assert.Equal(t, "second error", res.Get("last_error").String(), res.Raw)
+
+		res = do(t, adminTS, "POST", courier.RouteCollection+"/"+messages[1].ID.String()+"/requeue", http.StatusOK)
+		assert.Equal(t, "queued", res.Get("status").String(), res.Raw)
+		assert.EqualValues(t, 0, res.Get("send_count").Int(), res.Raw)
+		assert.False(t, res.Get("last_error").Exists(), res.Raw)
+		assert.False(t, res.Get("body").Exists(), res.Raw)
+
+		actual, err := reg.CourierPersister().FetchMessage(ctx, messages[1].ID)
+		require.NoError(t, err)
+		assert.Equal(t, courier.MessageStatusQueued, actual.Status)
+		assert.Equal(t, 0, actual.SendCount)
+		assert.Empty(t, actual.LastError)
+	})
+}
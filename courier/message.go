@@ -2,43 +2,161 @@ package courier
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"time"
 
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/sqlxx"
+
 	"github.com/ory/kratos/corp"
 
 	"github.com/gofrs/uuid"
 )
 
+// A Message's Status
+//
+// swagger:model courierMessageStatus
+// enum: queued,sent,processing,abandoned
 type MessageStatus int
 
 const (
 	MessageStatusQueued MessageStatus = iota + 1
 	MessageStatusSent
 	MessageStatusProcessing
+	MessageStatusAbandoned
 )
 
+var messageStatusNames = map[MessageStatus]string{
+	MessageStatusQueued:     "queued",
+	MessageStatusSent:       "sent",
+	MessageStatusProcessing: "processing",
+	MessageStatusAbandoned:  "abandoned",
+}
+
+// ToMessageStatus parses the string representation of a message status.
+func ToMessageStatus(str string) (MessageStatus, error) {
+	for s, name := range messageStatusNames {
+		if name == str {
+			return s, nil
+		}
+	}
+	return 0, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Message status \"%s\" is not supported.", str))
+}
+
+func (ms MessageStatus) String() string {
+	if name, ok := messageStatusNames[ms]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", int(ms))
+}
+
+func (ms MessageStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ms.String())
+}
+
+func (ms *MessageStatus) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return errors.WithStack(err)
+	}
+
+	s, err := ToMessageStatus(str)
+	if err != nil {
+		return err
+	}
+
+	*ms = s
+	return nil
+}
+
+// A Message's Type
+//
+// swagger:model courierMessageType
+// enum: email,sms
 type MessageType int
 
 const (
 	MessageTypeEmail MessageType = iota + 1
+	MessageTypeSMS
 )
 
-// swagger:ignore
+func (mt MessageType) String() string {
+	switch mt {
+	case MessageTypeEmail:
+		return "email"
+	case MessageTypeSMS:
+		return "sms"
+	}
+	return fmt.Sprintf("unknown(%d)", int(mt))
+}
+
+func (mt MessageType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mt.String())
+}
+
+func (mt *MessageType) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return errors.WithStack(err)
+	}
+
+	switch str {
+	case "email":
+		*mt = MessageTypeEmail
+	case "sms":
+		*mt = MessageTypeSMS
+	default:
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("Message type \"%s\" is not supported.", str))
+	}
+	return nil
+}
+
+// swagger:model message
 type Message struct {
-	ID           uuid.UUID     `json:"-" faker:"-" db:"id"`
-	NID          uuid.UUID     `json:"-"  faker:"-" db:"nid"`
-	Status       MessageStatus `json:"-" db:"status"`
-	Type         MessageType   `json:"-" db:"type"`
-	Recipient    string        `json:"-" db:"recipient"`
-	Body         string        `json:"-" db:"body"`
-	Subject      string        `json:"-" db:"subject"`
-	TemplateType TemplateType  `json:"-" db:"template_type"`
-	TemplateData []byte        `json:"-" db:"template_data"`
+	// required: true
+	ID  uuid.UUID `json:"id" faker:"-" db:"id"`
+	NID uuid.UUID `json:"-"  faker:"-" db:"nid"`
+	// required: true
+	Status MessageStatus `json:"status" db:"status"`
+	// required: true
+	Type MessageType `json:"type" db:"type"`
+	// required: true
+	Recipient string `json:"recipient" db:"recipient"`
+	// Body contains the rendered message and therefore live links and codes. The admin API only returns
+	// it for a single message and only if explicitly requested.
+	Body string `json:"body,omitempty" db:"body"`
+	// HTMLBody is the HTML body of an email. It is rendered when the message is queued, together with the
+	// subject and the plaintext body.
+	HTMLBody string `json:"-" faker:"-" db:"html_body"`
+	// required: true
+	Subject string `json:"subject" db:"subject"`
+	// required: true
+	TemplateType TemplateType `json:"template_type" db:"template_type"`
+	TemplateData []byte       `json:"-" db:"template_data"`
+
+	// Locale is the locale the message was rendered in, e.g. `de_DE`. It is empty if the default
+	// templates were used.
+	Locale string `json:"locale,omitempty" faker:"-" db:"locale"`
+
+	// SendCount is the number of failed attempts to deliver this message.
+	//
+	// required: true
+	SendCount int `json:"send_count" faker:"-" db:"send_count"`
+
+	// LastError is the error of the last failed attempt to deliver this message.
+	LastError sqlxx.NullString `json:"last_error,omitempty" faker:"-" db:"last_error"`
 
 	// CreatedAt is a helper struct field for gobuffalo.pop.
-	CreatedAt time.Time `json:"-" faker:"-" db:"created_at"`
+	//
+	// required: true
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
 	// UpdatedAt is a helper struct field for gobuffalo.pop.
-	UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+	//
+	// required: true
+	UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
 }
 
 func (m Message) TableName(ctx context.Context) string {
@@ -17,7 +17,34 @@ type (
 
 		SetMessageStatus(context.Context, uuid.UUID, MessageStatus) error
 
+		// RecordMessageFailure increments the message's send count, stores the delivery error, and sets the new status.
+		RecordMessageFailure(ctx context.Context, id uuid.UUID, status MessageStatus, lastError string) error
+
+		// RequeueMessage puts the message back into the queue and resets its send count and last error.
+		RequeueMessage(context.Context, uuid.UUID) error
+
 		LatestQueuedMessage(ctx context.Context) (*Message, error)
+
+		ListMessages(ctx context.Context, filter ListMessagesFilter, page, itemsPerPage int) ([]Message, int64, error)
+
+		FetchMessage(context.Context, uuid.UUID) (*Message, error)
+
+		// ListTemplates returns all email templates stored in the database.
+		ListTemplates(context.Context) ([]StoredTemplate, error)
+
+		GetTemplate(context.Context, uuid.UUID) (*StoredTemplate, error)
+
+		CreateTemplate(context.Context, *StoredTemplate) error
+
+		UpdateTemplate(context.Context, *StoredTemplate) error
+
+		DeleteTemplate(context.Context, uuid.UUID) error
+	}
+	// ListMessagesFilter restricts the messages returned by ListMessages. Zero values are ignored.
+	ListMessagesFilter struct {
+		Status       MessageStatus
+		Recipient    string
+		TemplateType TemplateType
 	}
 	PersistenceProvider interface {
 		CourierPersister() Persister
@@ -0,0 +1,111 @@
+package courier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/request"
+)
+
+type sendSMSRequestBody struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Body string `json:"body"`
+}
+
+func (m *Courier) QueueSMS(ctx context.Context, t SMSTemplate) (uuid.UUID, error) {
+	recipient, err := t.PhoneNumber()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	body, err := t.SMSBody()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	templateType, err := m.GetSMSTemplateType(t)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	templateData, err := json.Marshal(t)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	message := &Message{
+		Status:       MessageStatusQueued,
+		Type:         MessageTypeSMS,
+		Recipient:    recipient,
+		Body:         body,
+		TemplateType: templateType,
+		TemplateData: templateData,
+	}
+
+	if err := m.d.CourierPersister().AddMessage(ctx, message); err != nil {
+		return uuid.Nil, err
+	}
+
+	return message.ID, nil
+}
+
+func (m *Courier) dispatchSMS(ctx context.Context, msg Message) error {
+	c := m.d.CourierConfig(ctx)
+	if !c.CourierSMSEnabled() {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Courier tried to deliver an sms but courier.sms.enabled is set to false!"))
+	}
+
+	builder, err := request.NewBuilder(c.CourierSMSRequestConfig(), m.d.Logger(), m.d.RequestAuthCache(ctx))
+	if err != nil {
+		return err
+	}
+
+	req, err := builder.BuildRequest(&sendSMSRequestBody{
+		To:   msg.Recipient,
+		From: c.CourierSMSFrom(),
+		Body: msg.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := builder.Client(m.d.HTTPClient(ctx)).Do(req.WithContext(ctx))
+	if err != nil {
+		m.d.Logger().
+			WithError(err).
+			WithField("message_id", msg.ID).
+			Error("Unable to send sms using the configured HTTP gateway.")
+		return errors.WithStack(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		m.d.Logger().
+			WithField("message_id", msg.ID).
+			WithField("status_code", res.StatusCode).
+			Error("The HTTP gateway rejected the sms.")
+		return errors.Errorf("sms gateway responded with unexpected status code: %d", res.StatusCode)
+	}
+
+	if err := m.d.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusSent); err != nil {
+		m.d.Logger().
+			WithError(err).
+			WithField("message_id", msg.ID).
+			Error(`Unable to set the message status to "sent".`)
+		return err
+	}
+
+	m.d.Logger().
+		WithField("message_id", msg.ID).
+		WithField("message_type", msg.Type).
+		WithField("message_template_type", msg.TemplateType).
+		Debug("Courier sent out message.")
+	return nil
+}
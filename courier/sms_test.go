@@ -0,0 +1,106 @@
+package courier_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier"
+	templates "github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+)
+
+func TestQueueSMS(t *testing.T) {
+	ctx := context.Background()
+
+	expectedSender := "Kratos Test"
+	expectedSMS := []*templates.SMSTestStubModel{
+		{
+			To:   "+12065550101",
+			Body: "test-sms-body-1",
+		},
+		{
+			To:   "+12065550102",
+			Body: "test-sms-body-2",
+		},
+	}
+
+	actual := make(chan map[string]string, len(expectedSMS))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var payload map[string]string
+		require.NoError(t, json.Unmarshal(body, &payload))
+		actual <- payload
+	}))
+	t.Cleanup(srv.Close)
+
+	requestConfig := fmt.Sprintf(`{
+		"url": "%s",
+		"method": "POST",
+		"body": "file://./stub/request.config.twilio.jsonnet",
+		"auth": {
+			"type": "api_key",
+			"config": {
+				"in": "header",
+				"name": "X-Api-Key",
+				"value": "secret"
+			}
+		}
+	}`, srv.URL)
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyCourierSMSRequestConfig, json.RawMessage(requestConfig))
+	conf.MustSet(config.ViperKeyCourierSMSFrom, expectedSender)
+	conf.MustSet(config.ViperKeyCourierSMSEnabled, true)
+	conf.MustSet(config.ViperKeyCourierSMTPURL, "http://foo.url")
+
+	c := reg.Courier(ctx)
+
+	for _, message := range expectedSMS {
+		id, err := c.QueueSMS(ctx, templates.NewSMSTestStub(conf, message))
+		require.NoError(t, err)
+		require.NotEqual(t, uuid.Nil, id)
+	}
+
+	require.NoError(t, c.DispatchQueue(ctx))
+
+	for _, message := range expectedSMS {
+		payload := <-actual
+		assert.Equal(t, expectedSender, payload["From"])
+		assert.Equal(t, message.To, payload["To"])
+		assert.Equal(t, message.Body, payload["Body"])
+	}
+
+	_, err := reg.CourierPersister().NextMessages(ctx, 10)
+	require.ErrorIs(t, err, courier.ErrQueueEmpty)
+}
+
+func TestDisabledSMS(t *testing.T) {
+	ctx := context.Background()
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyCourierSMSEnabled, false)
+	conf.MustSet(config.ViperKeyCourierSMTPURL, "http://foo.url")
+
+	c := reg.Courier(ctx)
+	_, err := c.QueueSMS(ctx, templates.NewSMSTestStub(conf, &templates.SMSTestStubModel{
+		To:   "+12065550101",
+		Body: "test-sms-body",
+	}))
+	require.NoError(t, err)
+
+	require.Error(t, c.DispatchQueue(ctx))
+}
@@ -0,0 +1,157 @@
+package courier
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/courier/template"
+)
+
+// StoredTemplate is an email template which is stored in the database. It takes precedence over the templates
+// found in `courier.template_override_path` and the built-in templates.
+//
+// swagger:model courierTemplate
+type StoredTemplate struct {
+	// required: true
+	ID  uuid.UUID `json:"id" faker:"-" db:"id"`
+	NID uuid.UUID `json:"-"  faker:"-" db:"nid"`
+
+	// TemplateType is the type of email this template is used for, e.g. `recovery_valid`.
+	//
+	// required: true
+	TemplateType TemplateType `json:"template_type" faker:"-" db:"template_type"`
+
+	// Locale is the locale this template is used for, e.g. `de` or `de_DE`. The template is used for all
+	// locales if empty.
+	Locale string `json:"locale" faker:"-" db:"locale"`
+
+	// Subject is the Go template of the email's subject. The default subject is used if empty.
+	Subject string `json:"subject" db:"subject"`
+
+	// BodyPlaintext is the Go template of the email's plaintext body. The default plaintext body is used if empty.
+	BodyPlaintext string `json:"body_plaintext" db:"body_plaintext"`
+
+	// BodyHTML is the Go template of the email's HTML body. The default HTML body is used if empty.
+	BodyHTML string `json:"body_html" db:"body_html"`
+
+	// CreatedAt is a helper struct field for gobuffalo.pop.
+	//
+	// required: true
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	//
+	// required: true
+	UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+}
+
+// templateDirs maps the email template types to the directory their templates are located in.
+var templateDirs = map[TemplateType]string{
+	TypeRecoveryInvalid:       "recovery/invalid",
+	TypeRecoveryValid:         "recovery/valid",
+	TypeRecoveryCodeValid:     "recovery_code/valid",
+	TypeVerificationInvalid:   "verification/invalid",
+	TypeVerificationValid:     "verification/valid",
+	TypeVerificationCodeValid: "verification_code/valid",
+	TypeLoginInvalid:          "login/invalid",
+	TypeLoginValid:            "login/valid",
+	TypeTestStub:              "test_stub",
+}
+
+var localePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(_[a-zA-Z0-9]{2,8})?$`)
+
+func (t StoredTemplate) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "courier_templates")
+}
+
+func (t *StoredTemplate) GetID() uuid.UUID {
+	return t.ID
+}
+
+func (t *StoredTemplate) GetNID() uuid.UUID {
+	return t.NID
+}
+
+// Validate normalizes the template's locale and checks that its type is known and that its Go templates can be parsed.
+func (t *StoredTemplate) Validate() error {
+	if _, ok := templateDirs[t.TemplateType]; !ok {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("Template type \"%s\" is not supported.", t.TemplateType))
+	}
+
+	t.Locale = strings.ReplaceAll(strings.TrimSpace(t.Locale), "-", "_")
+	if len(t.Locale) > 0 && !localePattern.MatchString(t.Locale) {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("Locale \"%s\" is invalid. Use a language code such as \"de\" or \"de_DE\".", t.Locale))
+	}
+
+	for _, part := range []struct {
+		name, text string
+		html       bool
+	}{
+		{name: "subject", text: t.Subject},
+		{name: "body_plaintext", text: t.BodyPlaintext},
+		{name: "body_html", text: t.BodyHTML, html: true},
+	} {
+		if err := template.Validate(part.name, part.text, part.html); err != nil {
+			return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The %s is not a valid Go template: %s", part.name, err).WithWrap(err))
+		}
+	}
+
+	return nil
+}
+
+// files returns the template files this template overrides, keyed by their path.
+func (t *StoredTemplate) files() map[string]string {
+	suffix := ".gotmpl"
+	if len(t.Locale) > 0 {
+		suffix = "." + t.Locale + suffix
+	}
+
+	dir := templateDirs[t.TemplateType]
+	files := make(map[string]string, 3)
+	for name, content := range map[string]string{
+		"email.subject":        t.Subject,
+		"email.body.plaintext": t.BodyPlaintext,
+		"email.body":           t.BodyHTML,
+	} {
+		if len(content) > 0 {
+			files[dir+"/"+name+suffix] = content
+		}
+	}
+	return files
+}
+
+// NewTemplatesFS returns a file system which serves the stored templates on top of base.
+func NewTemplatesFS(base fs.FS, stored []StoredTemplate) fs.FS {
+	files := make(map[string]string)
+	for k := range stored {
+		for name, content := range stored[k].files() {
+			files[name] = content
+		}
+	}
+	return template.NewOverlayFS(base, files)
+}
+
+// storedTemplatesConfig loads email templates from the database before falling back to the configured templates.
+type storedTemplatesConfig struct {
+	SMTPConfig
+	fs fs.FS
+}
+
+var _ template.FileSystemProvider = new(storedTemplatesConfig)
+
+func (c *storedTemplatesConfig) CourierTemplatesFS() fs.FS {
+	return c.fs
+}
+
+func newStoredTemplatesConfig(c SMTPConfig, stored []StoredTemplate) *storedTemplatesConfig {
+	return &storedTemplatesConfig{SMTPConfig: c, fs: NewTemplatesFS(os.DirFS(c.CourierTemplatesRoot()), stored)}
+}
@@ -3,10 +3,13 @@ package template
 import (
 	"bytes"
 	"embed"
+	"fmt"
 	htemplate "html/template"
 	"io"
 	"io/fs"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
@@ -19,12 +22,48 @@ var templates embed.FS
 
 var cache, _ = lru.New(16)
 
+// txtFuncMap and htmlFuncMap return the sprig functions without those which read from the process
+// environment, as templates may be supplied by administrators through the API and must not leak secrets.
+func txtFuncMap() template.FuncMap {
+	return hermetic(sprig.TxtFuncMap())
+}
+
+func htmlFuncMap() htemplate.FuncMap {
+	return hermetic(sprig.HtmlFuncMap())
+}
+
+func hermetic(funcs map[string]interface{}) map[string]interface{} {
+	delete(funcs, "env")
+	delete(funcs, "expandenv")
+	return funcs
+}
+
 type Template interface {
 	Execute(wr io.Writer, data interface{}) error
 }
 
-func loadBuiltInTemplate(filesytem fs.FS, name string, html bool) (Template, error) {
-	if t, found := cache.Get(name); found {
+// cacheKey identifies the template with the given name in the given file system. Templates with the same name
+// in different template directories must not share a cache entry.
+func cacheKey(filesystem fs.FS, name string) string {
+	if o, ok := filesystem.(*OverlayFS); ok {
+		// templates not served from memory are loaded from the base file system
+		filesystem = o.base
+	}
+
+	switch v := reflect.ValueOf(filesystem); v.Kind() {
+	case reflect.String:
+		// os.DirFS is identified by its root directory
+		return fmt.Sprintf("%T(%s):%s", filesystem, v.String(), name)
+	case reflect.Ptr, reflect.Map:
+		return fmt.Sprintf("%T(%p):%s", filesystem, filesystem, name)
+	default:
+		return fmt.Sprintf("%T(%v):%s", filesystem, filesystem, name)
+	}
+}
+
+func loadBuiltInTemplate(filesytem fs.FS, name string, html, cacheable bool) (Template, error) {
+	key := cacheKey(filesytem, name)
+	if t, found := cache.Get(key); found && cacheable {
 		return t.(Template), nil
 	}
 
@@ -48,32 +87,88 @@ func loadBuiltInTemplate(filesytem fs.FS, name string, html bool) (Template, err
 
 	var tpl Template
 	if html {
-		t, err := htemplate.New(name).Funcs(sprig.HtmlFuncMap()).Parse(b.String())
+		t, err := htemplate.New(name).Funcs(htmlFuncMap()).Parse(b.String())
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
 		tpl = t
 	} else {
-		t, err := template.New(name).Funcs(sprig.TxtFuncMap()).Parse(b.String())
+		t, err := template.New(name).Funcs(txtFuncMap()).Parse(b.String())
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
 		tpl = t
 	}
 
-	_ = cache.Add(name, tpl)
+	if cacheable {
+		_ = cache.Add(key, tpl)
+	}
 	return tpl, nil
 }
 
+// localizedNames returns the names of the templates which are tried for the given locale, most specific first.
+// For `recovery/valid/email.body.gotmpl` and locale `de_DE` these are `recovery/valid/email.body.de_DE.gotmpl`,
+// `recovery/valid/email.body.de.gotmpl`, and `recovery/valid/email.body.gotmpl`.
+func localizedNames(name, locale string) []string {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "-", "_")
+	if locale == "" {
+		return []string{name}
+	}
+
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	names := []string{base + "." + locale + ext}
+	if lang := strings.SplitN(locale, "_", 2)[0]; lang != locale {
+		names = append(names, base+"."+lang+ext)
+	}
+	return append(names, name)
+}
+
+func templateExists(filesystem fs.FS, name string) bool {
+	if _, err := fs.Stat(filesystem, name); err == nil {
+		return true
+	}
+	_, err := fs.Stat(templates, filepath.Join("courier/builtin/templates", name))
+	return err == nil
+}
+
+// Validate parses the template text to check it for syntax errors.
+func Validate(name, text string, html bool) error {
+	var err error
+	if html {
+		_, err = htemplate.New(name).Funcs(htmlFuncMap()).Parse(text)
+	} else {
+		_, err = template.New(name).Funcs(txtFuncMap()).Parse(text)
+	}
+	return errors.WithStack(err)
+}
+
+func loadLocalizedTemplate(filesystem fs.FS, name, pattern, locale string, html bool) (Template, error) {
+	names := localizedNames(name, locale)
+	for _, localized := range names[:len(names)-1] {
+		if templateExists(filesystem, localized) {
+			return loadTemplate(filesystem, localized, pattern, html)
+		}
+	}
+	return loadTemplate(filesystem, name, pattern, html)
+}
+
 func loadTemplate(filesystem fs.FS, name, pattern string, html bool) (Template, error) {
-	if t, found := cache.Get(name); found {
+	cacheable := true
+	if o, ok := filesystem.(*OverlayFS); ok && o.overrides(name) {
+		cacheable = false
+	}
+
+	key := cacheKey(filesystem, name)
+	if t, found := cache.Get(key); found && cacheable {
 		return t.(Template), nil
 	}
 
 	matches, _ := fs.Glob(filesystem, name)
 	// make sure the file exists in the fs, otherwise fallback to built in templates
 	if matches == nil {
-		return loadBuiltInTemplate(filesystem, name, html)
+		return loadBuiltInTemplate(filesystem, name, html, cacheable)
 	}
 
 	glob := name
@@ -89,25 +184,29 @@ func loadTemplate(filesystem fs.FS, name, pattern string, html bool) (Template,
 
 	var tpl Template
 	if html {
-		t, err := htemplate.New(filepath.Base(name)).Funcs(sprig.HtmlFuncMap()).ParseFS(filesystem, glob)
+		t, err := htemplate.New(filepath.Base(name)).Funcs(htmlFuncMap()).ParseFS(filesystem, glob)
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
 		tpl = t
 	} else {
-		t, err := template.New(filepath.Base(name)).Funcs(sprig.TxtFuncMap()).ParseFS(filesystem, glob)
+		t, err := template.New(filepath.Base(name)).Funcs(txtFuncMap()).ParseFS(filesystem, glob)
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
 		tpl = t
 	}
 
-	_ = cache.Add(name, tpl)
+	if cacheable {
+		_ = cache.Add(key, tpl)
+	}
 	return tpl, nil
 }
 
-func LoadTextTemplate(filesystem fs.FS, name, pattern string, model interface{}) (string, error) {
-	t, err := loadTemplate(filesystem, name, pattern, false)
+// LoadTextTemplate renders the text template with the given name. If a locale such as `de_DE` is given, the
+// templates `<name>.de_DE.gotmpl` and `<name>.de.gotmpl` are preferred over the default template.
+func LoadTextTemplate(filesystem fs.FS, name, pattern, locale string, model interface{}) (string, error) {
+	t, err := loadLocalizedTemplate(filesystem, name, pattern, locale, false)
 
 	if err != nil {
 		return "", err
@@ -120,8 +219,10 @@ func LoadTextTemplate(filesystem fs.FS, name, pattern string, model interface{})
 	return b.String(), nil
 }
 
-func LoadHTMLTemplate(filesystem fs.FS, name, pattern string, model interface{}) (string, error) {
-	t, err := loadTemplate(filesystem, name, pattern, true)
+// LoadHTMLTemplate renders the HTML template with the given name and falls back to less specific locales
+// just like LoadTextTemplate.
+func LoadHTMLTemplate(filesystem fs.FS, name, pattern, locale string, model interface{}) (string, error) {
+	t, err := loadLocalizedTemplate(filesystem, name, pattern, locale, true)
 
 	if err != nil {
 		return "", err
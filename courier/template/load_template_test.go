@@ -14,13 +14,13 @@ import (
 
 func TestLoadTextTemplate(t *testing.T) {
 	var executeTextTemplate = func(t *testing.T, dir, name, pattern string, model map[string]interface{}) string {
-		tp, err := LoadTextTemplate(os.DirFS(dir), name, pattern, model)
+		tp, err := LoadTextTemplate(os.DirFS(dir), name, pattern, "", model)
 		require.NoError(t, err)
 		return tp
 	}
 
 	var executeHTMLTemplate = func(t *testing.T, dir, name, pattern string, model map[string]interface{}) string {
-		tp, err := LoadHTMLTemplate(os.DirFS(dir), name, pattern, model)
+		tp, err := LoadHTMLTemplate(os.DirFS(dir), name, pattern, "", model)
 		require.NoError(t, err)
 		return tp
 	}
@@ -50,6 +50,71 @@ func TestLoadTextTemplate(t *testing.T) {
 		assert.Contains(t, actual, "lang=en_US")
 	})
 
+	t.Run("method=localized", func(t *testing.T) {
+		dir := t.TempDir()
+		for name, content := range map[string]string{
+			"email.subject.gotmpl":       "default subject",
+			"email.subject.de.gotmpl":    "deutscher Betreff",
+			"email.subject.de_AT.gotmpl": "österreichischer Betreff",
+			"email.body.gotmpl":          "<p>default body</p>",
+			"email.body.de.gotmpl":       "<p>deutscher Inhalt</p>",
+		} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+		}
+
+		for _, tc := range []struct {
+			locale, subject, body string
+		}{
+			{locale: "", subject: "default subject", body: "default body"},
+			{locale: "fr_FR", subject: "default subject", body: "default body"},
+			{locale: "de", subject: "deutscher Betreff", body: "deutscher Inhalt"},
+			{locale: "de_DE", subject: "deutscher Betreff", body: "deutscher Inhalt"},
+			{locale: "de-CH", subject: "deutscher Betreff", body: "deutscher Inhalt"},
+			{locale: "de_AT", subject: "österreichischer Betreff", body: "deutscher Inhalt"},
+		} {
+			t.Run("locale="+tc.locale, func(t *testing.T) {
+				cache, _ = lru.New(16) // prevent cache hit
+
+				actual, err := LoadTextTemplate(os.DirFS(dir), "email.subject.gotmpl", "", tc.locale, nil)
+				require.NoError(t, err)
+				assert.Equal(t, tc.subject, actual)
+
+				actual, err = LoadHTMLTemplate(os.DirFS(dir), "email.body.gotmpl", "email.body*", tc.locale, nil)
+				require.NoError(t, err)
+				assert.Contains(t, actual, tc.body)
+			})
+		}
+
+		t.Run("case=fallback to bundled", func(t *testing.T) {
+			cache, _ = lru.New(16) // prevent cache hit
+			actual, err := LoadTextTemplate(os.DirFS("some/inexistent/dir"), "test_stub/email.body.gotmpl", "", "de_DE", nil)
+			require.NoError(t, err)
+			assert.Contains(t, actual, "stub email")
+		})
+	})
+
+	t.Run("method=without environment functions", func(t *testing.T) {
+		t.Setenv("KRATOS_TEST_TEMPLATE_SECRET", "secret")
+		for _, text := range []string{`{{ env "KRATOS_TEST_TEMPLATE_SECRET" }}`, `{{ expandenv "$KRATOS_TEST_TEMPLATE_SECRET" }}`} {
+			for _, html := range []bool{false, true} {
+				err := Validate("email.body.gotmpl", text, html)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "not defined")
+			}
+
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "email.body.gotmpl"), []byte(text), 0600))
+
+			cache, _ = lru.New(16) // prevent cache hit
+			_, err := LoadTextTemplate(os.DirFS(dir), "email.body.gotmpl", "", "", nil)
+			require.Error(t, err)
+
+			cache, _ = lru.New(16) // prevent cache hit
+			_, err = LoadHTMLTemplate(os.DirFS(dir), "email.body.gotmpl", "", "", nil)
+			require.Error(t, err)
+		}
+	})
+
 	t.Run("method=cache works", func(t *testing.T) {
 		dir := os.TempDir()
 		name := x.NewUUID().String() + ".body.gotmpl"
@@ -61,4 +126,17 @@ func TestLoadTextTemplate(t *testing.T) {
 		require.NoError(t, os.RemoveAll(fp))
 		assert.Contains(t, executeTextTemplate(t, dir, name, "", nil), "cached stub body")
 	})
+
+	t.Run("method=cache is keyed by template directory", func(t *testing.T) {
+		first, second := t.TempDir(), t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(first, "email.body.gotmpl"), []byte("first body"), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(second, "email.body.gotmpl"), []byte("second body"), 0600))
+
+		assert.Equal(t, "first body", executeTextTemplate(t, first, "email.body.gotmpl", "", nil))
+		assert.Equal(t, "second body", executeTextTemplate(t, second, "email.body.gotmpl", "", nil))
+
+		actual, err := LoadTextTemplate(NewOverlayFS(os.DirFS(first), map[string]string{"other/email.body.gotmpl": "other body"}), "email.body.gotmpl", "", "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "first body", actual)
+	})
 }
@@ -0,0 +1,44 @@
+package template
+
+import (
+	"encoding/json"
+)
+
+type (
+	LoginInvalid struct {
+		c TemplateConfig
+		m *LoginInvalidModel
+	}
+	LoginInvalidModel struct {
+		To     string
+		Locale string
+	}
+)
+
+func NewLoginInvalid(c TemplateConfig, m *LoginInvalidModel) *LoginInvalid {
+	return &LoginInvalid{c: c, m: m}
+}
+
+func (t *LoginInvalid) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *LoginInvalid) EmailLocale() string {
+	return t.m.Locale
+}
+
+func (t *LoginInvalid) EmailSubject() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "login/invalid/email.subject.gotmpl", "login/invalid/email.subject*", t.m.Locale, t.m)
+}
+
+func (t *LoginInvalid) EmailBody() (string, error) {
+	return LoadHTMLTemplate(templatesFS(t.c), "login/invalid/email.body.gotmpl", "login/invalid/email.body*", t.m.Locale, t.m)
+}
+
+func (t *LoginInvalid) EmailBodyPlaintext() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "login/invalid/email.body.plaintext.gotmpl", "login/invalid/email.body.plaintext*", t.m.Locale, t.m)
+}
+
+func (t *LoginInvalid) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
@@ -0,0 +1,47 @@
+package template
+
+import (
+	"encoding/json"
+)
+
+type (
+	LoginValid struct {
+		c TemplateConfig
+		m *LoginValidModel
+	}
+	LoginValidModel struct {
+		To        string
+		LoginURL  string
+		LoginCode string
+		Identity  map[string]interface{}
+		Locale    string
+	}
+)
+
+func NewLoginValid(c TemplateConfig, m *LoginValidModel) *LoginValid {
+	return &LoginValid{c: c, m: m}
+}
+
+func (t *LoginValid) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *LoginValid) EmailLocale() string {
+	return t.m.Locale
+}
+
+func (t *LoginValid) EmailSubject() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "login/valid/email.subject.gotmpl", "login/valid/email.subject*", t.m.Locale, t.m)
+}
+
+func (t *LoginValid) EmailBody() (string, error) {
+	return LoadHTMLTemplate(templatesFS(t.c), "login/valid/email.body.gotmpl", "login/valid/email.body*", t.m.Locale, t.m)
+}
+
+func (t *LoginValid) EmailBodyPlaintext() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "login/valid/email.body.plaintext.gotmpl", "login/valid/email.body.plaintext*", t.m.Locale, t.m)
+}
+
+func (t *LoginValid) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
@@ -0,0 +1,33 @@
+package template
+
+import (
+	"encoding/json"
+)
+
+type (
+	OTPMessage struct {
+		c TemplateConfig
+		m *OTPMessageModel
+	}
+	OTPMessageModel struct {
+		To       string
+		Code     string
+		Identity map[string]interface{}
+	}
+)
+
+func NewOTPMessage(c TemplateConfig, m *OTPMessageModel) *OTPMessage {
+	return &OTPMessage{c: c, m: m}
+}
+
+func (t *OTPMessage) PhoneNumber() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *OTPMessage) SMSBody() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "otp/sms.body.gotmpl", "otp/sms.body*", "", t.m)
+}
+
+func (t *OTPMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
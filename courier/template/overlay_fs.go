@@ -0,0 +1,92 @@
+package template
+
+import (
+	"bytes"
+	"io/fs"
+	"path"
+	"sort"
+	"time"
+)
+
+// OverlayFS serves in-memory templates, such as templates stored in the database, and falls back to
+// the base file system for all other files.
+type OverlayFS struct {
+	base  fs.FS
+	files map[string][]byte
+}
+
+var (
+	_ fs.ReadFileFS = new(OverlayFS)
+	_ fs.GlobFS     = new(OverlayFS)
+)
+
+// NewOverlayFS returns a file system which serves the given files, keyed by their path, on top of base.
+func NewOverlayFS(base fs.FS, files map[string]string) *OverlayFS {
+	o := &OverlayFS{base: base, files: make(map[string][]byte, len(files))}
+	for name, content := range files {
+		o.files[name] = []byte(content)
+	}
+	return o
+}
+
+func (o *OverlayFS) Open(name string) (fs.File, error) {
+	if content, ok := o.files[name]; ok {
+		return &overlayFile{Reader: bytes.NewReader(content), name: path.Base(name)}, nil
+	}
+	return o.base.Open(name)
+}
+
+func (o *OverlayFS) ReadFile(name string) ([]byte, error) {
+	if content, ok := o.files[name]; ok {
+		return content, nil
+	}
+	return fs.ReadFile(o.base, name)
+}
+
+func (o *OverlayFS) Glob(pattern string) ([]string, error) {
+	matches, err := fs.Glob(o.base, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(matches))
+	for _, m := range matches {
+		seen[m] = true
+	}
+
+	for name := range o.files {
+		if ok, err := path.Match(pattern, name); err != nil {
+			return nil, err
+		} else if ok && !seen[name] {
+			matches = append(matches, name)
+		}
+	}
+
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// overrides returns true if templates in the directory of the named template are served from memory. Such
+// templates may change at any time and must therefore not be cached.
+func (o *OverlayFS) overrides(name string) bool {
+	dir := path.Dir(name)
+	for f := range o.files {
+		if path.Dir(f) == dir {
+			return true
+		}
+	}
+	return false
+}
+
+type overlayFile struct {
+	*bytes.Reader
+	name string
+}
+
+func (f *overlayFile) Stat() (fs.FileInfo, error) { return f, nil }
+func (f *overlayFile) Close() error               { return nil }
+func (f *overlayFile) Name() string               { return f.name }
+func (f *overlayFile) Mode() fs.FileMode          { return 0444 }
+func (f *overlayFile) ModTime() time.Time         { return time.Time{} }
+func (f *overlayFile) IsDir() bool                { return false }
+func (f *overlayFile) Sys() interface{}           { return nil }
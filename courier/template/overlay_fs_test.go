@@ -0,0 +1,59 @@
+package template
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverlayFS(t *testing.T) {
+	o := NewOverlayFS(os.DirFS("courier/builtin/templates"), map[string]string{
+		"test_stub/email.subject.gotmpl":    "overlay subject {{ .Subject }}",
+		"test_stub/email.subject.de.gotmpl": "Betreff {{ .Subject }}",
+	})
+
+	t.Run("method=read", func(t *testing.T) {
+		actual, err := fs.ReadFile(o, "test_stub/email.subject.gotmpl")
+		require.NoError(t, err)
+		assert.Equal(t, "overlay subject {{ .Subject }}", string(actual))
+
+		actual, err = fs.ReadFile(o, "test_stub/email.body.gotmpl")
+		require.NoError(t, err)
+		assert.Contains(t, string(actual), "stub email body")
+
+		info, err := fs.Stat(o, "test_stub/email.subject.de.gotmpl")
+		require.NoError(t, err)
+		assert.Equal(t, "email.subject.de.gotmpl", info.Name())
+		assert.False(t, info.IsDir())
+
+		_, err = fs.Stat(o, "test_stub/email.subject.fr.gotmpl")
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("method=glob", func(t *testing.T) {
+		actual, err := fs.Glob(o, "test_stub/email.subject*")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"test_stub/email.subject.de.gotmpl", "test_stub/email.subject.gotmpl"}, actual)
+	})
+
+	t.Run("method=render", func(t *testing.T) {
+		m := map[string]interface{}{"Subject": "foo"}
+
+		actual, err := LoadTextTemplate(o, "test_stub/email.subject.gotmpl", "test_stub/email.subject*", "de_DE", m)
+		require.NoError(t, err)
+		assert.Equal(t, "Betreff foo", actual)
+
+		actual, err = LoadTextTemplate(o, "test_stub/email.subject.gotmpl", "test_stub/email.subject*", "", m)
+		require.NoError(t, err)
+		assert.Equal(t, "overlay subject foo", actual)
+
+		actual, err = LoadTextTemplate(NewOverlayFS(os.DirFS("courier/builtin/templates"), map[string]string{
+			"test_stub/email.subject.gotmpl": "changed subject {{ .Subject }}",
+		}), "test_stub/email.subject.gotmpl", "test_stub/email.subject*", "", m)
+		require.NoError(t, err)
+		assert.Equal(t, "changed subject foo", actual, "templates from memory are not cached")
+	})
+}
@@ -0,0 +1,54 @@
+package template
+
+import (
+	"encoding/json"
+)
+
+type (
+	RecoveryCodeValid struct {
+		c TemplateConfig
+		m *RecoveryCodeValidModel
+	}
+	RecoveryCodeValidModel struct {
+		To           string
+		RecoveryCode string
+		Identity     map[string]interface{}
+		Locale       string
+	}
+)
+
+func NewRecoveryCodeValid(c TemplateConfig, m *RecoveryCodeValidModel) *RecoveryCodeValid {
+	return &RecoveryCodeValid{c: c, m: m}
+}
+
+func (t *RecoveryCodeValid) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *RecoveryCodeValid) EmailLocale() string {
+	return t.m.Locale
+}
+
+func (t *RecoveryCodeValid) EmailSubject() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "recovery_code/valid/email.subject.gotmpl", "recovery_code/valid/email.subject*", t.m.Locale, t.m)
+}
+
+func (t *RecoveryCodeValid) EmailBody() (string, error) {
+	return LoadHTMLTemplate(templatesFS(t.c), "recovery_code/valid/email.body.gotmpl", "recovery_code/valid/email.body*", t.m.Locale, t.m)
+}
+
+func (t *RecoveryCodeValid) EmailBodyPlaintext() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "recovery_code/valid/email.body.plaintext.gotmpl", "recovery_code/valid/email.body.plaintext*", t.m.Locale, t.m)
+}
+
+func (t *RecoveryCodeValid) PhoneNumber() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *RecoveryCodeValid) SMSBody() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "recovery_code/valid/sms.body.gotmpl", "recovery_code/valid/sms.body*", t.m.Locale, t.m)
+}
+
+func (t *RecoveryCodeValid) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
@@ -0,0 +1,36 @@
+package template_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/internal"
+)
+
+func TestRecoveryCodeValid(t *testing.T) {
+	conf, _ := internal.NewFastRegistryWithMocks(t)
+	tpl := template.NewRecoveryCodeValid(conf, &template.RecoveryCodeValidModel{})
+
+	rendered, err := tpl.EmailBody()
+	require.NoError(t, err)
+	assert.NotEmpty(t, rendered)
+
+	rendered, err = tpl.EmailSubject()
+	require.NoError(t, err)
+	assert.NotEmpty(t, rendered)
+
+	t.Run("case=sms", func(t *testing.T) {
+		tpl := template.NewRecoveryCodeValid(conf, &template.RecoveryCodeValidModel{To: "+12065550101", RecoveryCode: "123456"})
+
+		rendered, err := tpl.SMSBody()
+		require.NoError(t, err)
+		assert.Contains(t, rendered, "123456")
+
+		to, err := tpl.PhoneNumber()
+		require.NoError(t, err)
+		assert.Equal(t, "+12065550101", to)
+	})
+}
@@ -2,7 +2,6 @@ package template
 
 import (
 	"encoding/json"
-	"os"
 )
 
 type (
@@ -11,7 +10,8 @@ type (
 		m *RecoveryInvalidModel
 	}
 	RecoveryInvalidModel struct {
-		To string
+		To     string
+		Locale string
 	}
 )
 
@@ -23,16 +23,28 @@ func (t *RecoveryInvalid) EmailRecipient() (string, error) {
 	return t.m.To, nil
 }
 
+func (t *RecoveryInvalid) EmailLocale() string {
+	return t.m.Locale
+}
+
 func (t *RecoveryInvalid) EmailSubject() (string, error) {
-	return LoadTextTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "recovery/invalid/email.subject.gotmpl", "recovery/invalid/email.subject*", t.m)
+	return LoadTextTemplate(templatesFS(t.c), "recovery/invalid/email.subject.gotmpl", "recovery/invalid/email.subject*", t.m.Locale, t.m)
 }
 
 func (t *RecoveryInvalid) EmailBody() (string, error) {
-	return LoadHTMLTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "recovery/invalid/email.body.gotmpl", "recovery/invalid/email.body*", t.m)
+	return LoadHTMLTemplate(templatesFS(t.c), "recovery/invalid/email.body.gotmpl", "recovery/invalid/email.body*", t.m.Locale, t.m)
 }
 
 func (t *RecoveryInvalid) EmailBodyPlaintext() (string, error) {
-	return LoadTextTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "recovery/invalid/email.body.plaintext.gotmpl", "recovery/invalid/email.body.plaintext*", t.m)
+	return LoadTextTemplate(templatesFS(t.c), "recovery/invalid/email.body.plaintext.gotmpl", "recovery/invalid/email.body.plaintext*", t.m.Locale, t.m)
+}
+
+func (t *RecoveryInvalid) PhoneNumber() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *RecoveryInvalid) SMSBody() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "recovery/invalid/sms.body.gotmpl", "recovery/invalid/sms.body*", t.m.Locale, t.m)
 }
 
 func (t *RecoveryInvalid) MarshalJSON() ([]byte, error) {
@@ -2,7 +2,6 @@ package template
 
 import (
 	"encoding/json"
-	"os"
 )
 
 type (
@@ -14,6 +13,7 @@ type (
 		To          string
 		RecoveryURL string
 		Identity    map[string]interface{}
+		Locale      string
 	}
 )
 
@@ -25,16 +25,28 @@ func (t *RecoveryValid) EmailRecipient() (string, error) {
 	return t.m.To, nil
 }
 
+func (t *RecoveryValid) EmailLocale() string {
+	return t.m.Locale
+}
+
 func (t *RecoveryValid) EmailSubject() (string, error) {
-	return LoadTextTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "recovery/valid/email.subject.gotmpl", "recovery/valid/email.subject*", t.m)
+	return LoadTextTemplate(templatesFS(t.c), "recovery/valid/email.subject.gotmpl", "recovery/valid/email.subject*", t.m.Locale, t.m)
 }
 
 func (t *RecoveryValid) EmailBody() (string, error) {
-	return LoadHTMLTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "recovery/valid/email.body.gotmpl", "recovery/valid/email.body*", t.m)
+	return LoadHTMLTemplate(templatesFS(t.c), "recovery/valid/email.body.gotmpl", "recovery/valid/email.body*", t.m.Locale, t.m)
 }
 
 func (t *RecoveryValid) EmailBodyPlaintext() (string, error) {
-	return LoadTextTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "recovery/valid/email.body.plaintext.gotmpl", "recovery/valid/email.body.plaintext*", t.m)
+	return LoadTextTemplate(templatesFS(t.c), "recovery/valid/email.body.plaintext.gotmpl", "recovery/valid/email.body.plaintext*", t.m.Locale, t.m)
+}
+
+func (t *RecoveryValid) PhoneNumber() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *RecoveryValid) SMSBody() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "recovery/valid/sms.body.gotmpl", "recovery/valid/sms.body*", t.m.Locale, t.m)
 }
 
 func (t *RecoveryValid) MarshalJSON() ([]byte, error) {
@@ -0,0 +1,31 @@
+package template
+
+import (
+	"encoding/json"
+)
+
+type SMSTestStub struct {
+	c TemplateConfig
+	m *SMSTestStubModel
+}
+
+type SMSTestStubModel struct {
+	To   string
+	Body string
+}
+
+func NewSMSTestStub(c TemplateConfig, m *SMSTestStubModel) *SMSTestStub {
+	return &SMSTestStub{c: c, m: m}
+}
+
+func (t *SMSTestStub) PhoneNumber() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *SMSTestStub) SMSBody() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "test_stub/sms.body.gotmpl", "test_stub/sms.body*", "", t.m)
+}
+
+func (t *SMSTestStub) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
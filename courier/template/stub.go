@@ -2,7 +2,6 @@ package template
 
 import (
 	"encoding/json"
-	"os"
 )
 
 type TestStub struct {
@@ -14,6 +13,7 @@ type TestStubModel struct {
 	To      string
 	Subject string
 	Body    string
+	Locale  string
 }
 
 func NewTestStub(c TemplateConfig, m *TestStubModel) *TestStub {
@@ -24,16 +24,20 @@ func (t *TestStub) EmailRecipient() (string, error) {
 	return t.m.To, nil
 }
 
+func (t *TestStub) EmailLocale() string {
+	return t.m.Locale
+}
+
 func (t *TestStub) EmailSubject() (string, error) {
-	return LoadTextTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "test_stub/email.subject.gotmpl", "test_stub/email.subject*", t.m)
+	return LoadTextTemplate(templatesFS(t.c), "test_stub/email.subject.gotmpl", "test_stub/email.subject*", t.m.Locale, t.m)
 }
 
 func (t *TestStub) EmailBody() (string, error) {
-	return LoadHTMLTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "test_stub/email.body.gotmpl", "test_stub/email.body*", t.m)
+	return LoadHTMLTemplate(templatesFS(t.c), "test_stub/email.body.gotmpl", "test_stub/email.body*", t.m.Locale, t.m)
 }
 
 func (t *TestStub) EmailBodyPlaintext() (string, error) {
-	return LoadTextTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "test_stub/email.body.plaintext.gotmpl", "test_stub/email.body.plaintext*", t.m)
+	return LoadTextTemplate(templatesFS(t.c), "test_stub/email.body.plaintext.gotmpl", "test_stub/email.body.plaintext*", t.m.Locale, t.m)
 }
 
 func (t *TestStub) MarshalJSON() ([]byte, error) {
@@ -1,7 +1,25 @@
 package template
 
+import (
+	"io/fs"
+	"os"
+)
+
 type (
 	TemplateConfig interface {
 		CourierTemplatesRoot() string
 	}
+
+	// FileSystemProvider can be implemented by a TemplateConfig to load templates from a file system other
+	// than `CourierTemplatesRoot`, for example one which contains templates stored in the database.
+	FileSystemProvider interface {
+		CourierTemplatesFS() fs.FS
+	}
 )
+
+func templatesFS(c TemplateConfig) fs.FS {
+	if p, ok := c.(FileSystemProvider); ok {
+		return p.CourierTemplatesFS()
+	}
+	return os.DirFS(c.CourierTemplatesRoot())
+}
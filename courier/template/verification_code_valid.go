@@ -0,0 +1,54 @@
+package template
+
+import (
+	"encoding/json"
+)
+
+type (
+	VerificationCodeValid struct {
+		c TemplateConfig
+		m *VerificationCodeValidModel
+	}
+	VerificationCodeValidModel struct {
+		To               string
+		VerificationCode string
+		Identity         map[string]interface{}
+		Locale           string
+	}
+)
+
+func NewVerificationCodeValid(c TemplateConfig, m *VerificationCodeValidModel) *VerificationCodeValid {
+	return &VerificationCodeValid{c: c, m: m}
+}
+
+func (t *VerificationCodeValid) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *VerificationCodeValid) EmailLocale() string {
+	return t.m.Locale
+}
+
+func (t *VerificationCodeValid) EmailSubject() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "verification_code/valid/email.subject.gotmpl", "verification_code/valid/email.subject*", t.m.Locale, t.m)
+}
+
+func (t *VerificationCodeValid) EmailBody() (string, error) {
+	return LoadHTMLTemplate(templatesFS(t.c), "verification_code/valid/email.body.gotmpl", "verification_code/valid/email.body*", t.m.Locale, t.m)
+}
+
+func (t *VerificationCodeValid) EmailBodyPlaintext() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "verification_code/valid/email.body.plaintext.gotmpl", "verification_code/valid/email.body.plaintext*", t.m.Locale, t.m)
+}
+
+func (t *VerificationCodeValid) PhoneNumber() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *VerificationCodeValid) SMSBody() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "verification_code/valid/sms.body.gotmpl", "verification_code/valid/sms.body*", t.m.Locale, t.m)
+}
+
+func (t *VerificationCodeValid) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
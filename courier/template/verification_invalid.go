@@ -2,7 +2,6 @@ package template
 
 import (
 	"encoding/json"
-	"os"
 )
 
 type (
@@ -11,7 +10,8 @@ type (
 		m *VerificationInvalidModel
 	}
 	VerificationInvalidModel struct {
-		To string
+		To     string
+		Locale string
 	}
 )
 
@@ -23,16 +23,28 @@ func (t *VerificationInvalid) EmailRecipient() (string, error) {
 	return t.m.To, nil
 }
 
+func (t *VerificationInvalid) EmailLocale() string {
+	return t.m.Locale
+}
+
 func (t *VerificationInvalid) EmailSubject() (string, error) {
-	return LoadTextTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "verification/invalid/email.subject.gotmpl", "verification/invalid/email.subject*", t.m)
+	return LoadTextTemplate(templatesFS(t.c), "verification/invalid/email.subject.gotmpl", "verification/invalid/email.subject*", t.m.Locale, t.m)
 }
 
 func (t *VerificationInvalid) EmailBody() (string, error) {
-	return LoadHTMLTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "verification/invalid/email.body.gotmpl", "verification/invalid/email.body*", t.m)
+	return LoadHTMLTemplate(templatesFS(t.c), "verification/invalid/email.body.gotmpl", "verification/invalid/email.body*", t.m.Locale, t.m)
 }
 
 func (t *VerificationInvalid) EmailBodyPlaintext() (string, error) {
-	return LoadTextTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "verification/invalid/email.body.plaintext.gotmpl", "verification/invalid/email.body.plaintext*", t.m)
+	return LoadTextTemplate(templatesFS(t.c), "verification/invalid/email.body.plaintext.gotmpl", "verification/invalid/email.body.plaintext*", t.m.Locale, t.m)
+}
+
+func (t *VerificationInvalid) PhoneNumber() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *VerificationInvalid) SMSBody() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "verification/invalid/sms.body.gotmpl", "verification/invalid/sms.body*", t.m.Locale, t.m)
 }
 
 func (t *VerificationInvalid) MarshalJSON() ([]byte, error) {
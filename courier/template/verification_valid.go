@@ -2,7 +2,6 @@ package template
 
 import (
 	"encoding/json"
-	"os"
 )
 
 type (
@@ -14,6 +13,7 @@ type (
 		To              string
 		VerificationURL string
 		Identity        map[string]interface{}
+		Locale          string
 	}
 )
 
@@ -25,16 +25,28 @@ func (t *VerificationValid) EmailRecipient() (string, error) {
 	return t.m.To, nil
 }
 
+func (t *VerificationValid) EmailLocale() string {
+	return t.m.Locale
+}
+
 func (t *VerificationValid) EmailSubject() (string, error) {
-	return LoadTextTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "verification/valid/email.subject.gotmpl", "verification/valid/email.subject*", t.m)
+	return LoadTextTemplate(templatesFS(t.c), "verification/valid/email.subject.gotmpl", "verification/valid/email.subject*", t.m.Locale, t.m)
 }
 
 func (t *VerificationValid) EmailBody() (string, error) {
-	return LoadHTMLTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "verification/valid/email.body.gotmpl", "verification/valid/email.body*", t.m)
+	return LoadHTMLTemplate(templatesFS(t.c), "verification/valid/email.body.gotmpl", "verification/valid/email.body*", t.m.Locale, t.m)
 }
 
 func (t *VerificationValid) EmailBodyPlaintext() (string, error) {
-	return LoadTextTemplate(os.DirFS(t.c.CourierTemplatesRoot()), "verification/valid/email.body.plaintext.gotmpl", "verification/valid/email.body.plaintext*", t.m)
+	return LoadTextTemplate(templatesFS(t.c), "verification/valid/email.body.plaintext.gotmpl", "verification/valid/email.body.plaintext*", t.m.Locale, t.m)
+}
+
+func (t *VerificationValid) PhoneNumber() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *VerificationValid) SMSBody() (string, error) {
+	return LoadTextTemplate(templatesFS(t.c), "verification/valid/sms.body.gotmpl", "verification/valid/sms.body*", t.m.Locale, t.m)
 }
 
 func (t *VerificationValid) MarshalJSON() ([]byte, error) {
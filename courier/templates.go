@@ -16,15 +16,29 @@ type (
 		EmailBody() (string, error)
 		EmailBodyPlaintext() (string, error)
 		EmailRecipient() (string, error)
+		EmailLocale() string
 	}
+	SMSTemplate interface {
+		json.Marshaler
+		SMSBody() (string, error)
+		PhoneNumber() (string, error)
+	}
+	SMSTemplateTyper       func(t SMSTemplate) (TemplateType, error)
+	SMSTemplateFromMessage func(c SMSConfig, msg Message) (SMSTemplate, error)
 )
 
 const (
-	TypeRecoveryInvalid     TemplateType = "recovery_invalid"
-	TypeRecoveryValid       TemplateType = "recovery_valid"
-	TypeVerificationInvalid TemplateType = "verification_invalid"
-	TypeVerificationValid   TemplateType = "verification_valid"
-	TypeTestStub            TemplateType = "stub"
+	TypeRecoveryInvalid       TemplateType = "recovery_invalid"
+	TypeRecoveryValid         TemplateType = "recovery_valid"
+	TypeRecoveryCodeValid     TemplateType = "recovery_code_valid"
+	TypeVerificationInvalid   TemplateType = "verification_invalid"
+	TypeVerificationValid     TemplateType = "verification_valid"
+	TypeVerificationCodeValid TemplateType = "verification_code_valid"
+	TypeLoginInvalid          TemplateType = "login_invalid"
+	TypeLoginValid            TemplateType = "login_valid"
+	TypeTestStub              TemplateType = "stub"
+	TypeOTP                   TemplateType = "otp"
+	TypeSMSTestStub           TemplateType = "sms_stub"
 )
 
 func GetTemplateType(t EmailTemplate) (TemplateType, error) {
@@ -33,10 +47,18 @@ func GetTemplateType(t EmailTemplate) (TemplateType, error) {
 		return TypeRecoveryInvalid, nil
 	case *template.RecoveryValid:
 		return TypeRecoveryValid, nil
+	case *template.RecoveryCodeValid:
+		return TypeRecoveryCodeValid, nil
 	case *template.VerificationInvalid:
 		return TypeVerificationInvalid, nil
 	case *template.VerificationValid:
 		return TypeVerificationValid, nil
+	case *template.VerificationCodeValid:
+		return TypeVerificationCodeValid, nil
+	case *template.LoginInvalid:
+		return TypeLoginInvalid, nil
+	case *template.LoginValid:
+		return TypeLoginValid, nil
 	case *template.TestStub:
 		return TypeTestStub, nil
 	default:
@@ -58,6 +80,12 @@ func NewEmailTemplateFromMessage(c SMTPConfig, msg Message) (EmailTemplate, erro
 			return nil, err
 		}
 		return template.NewRecoveryValid(c, &t), nil
+	case TypeRecoveryCodeValid:
+		var t template.RecoveryCodeValidModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewRecoveryCodeValid(c, &t), nil
 	case TypeVerificationInvalid:
 		var t template.VerificationInvalidModel
 		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
@@ -70,6 +98,24 @@ func NewEmailTemplateFromMessage(c SMTPConfig, msg Message) (EmailTemplate, erro
 			return nil, err
 		}
 		return template.NewVerificationValid(c, &t), nil
+	case TypeVerificationCodeValid:
+		var t template.VerificationCodeValidModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewVerificationCodeValid(c, &t), nil
+	case TypeLoginInvalid:
+		var t template.LoginInvalidModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewLoginInvalid(c, &t), nil
+	case TypeLoginValid:
+		var t template.LoginValidModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewLoginValid(c, &t), nil
 	case TypeTestStub:
 		var t template.TestStubModel
 		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
@@ -80,3 +126,81 @@ func NewEmailTemplateFromMessage(c SMTPConfig, msg Message) (EmailTemplate, erro
 		return nil, errors.Errorf("received unexpected message template type: %s", msg.TemplateType)
 	}
 }
+
+func GetSMSTemplateType(t SMSTemplate) (TemplateType, error) {
+	switch t.(type) {
+	case *template.RecoveryInvalid:
+		return TypeRecoveryInvalid, nil
+	case *template.RecoveryValid:
+		return TypeRecoveryValid, nil
+	case *template.RecoveryCodeValid:
+		return TypeRecoveryCodeValid, nil
+	case *template.VerificationInvalid:
+		return TypeVerificationInvalid, nil
+	case *template.VerificationValid:
+		return TypeVerificationValid, nil
+	case *template.VerificationCodeValid:
+		return TypeVerificationCodeValid, nil
+	case *template.OTPMessage:
+		return TypeOTP, nil
+	case *template.SMSTestStub:
+		return TypeSMSTestStub, nil
+	default:
+		return "", errors.Errorf("unexpected template type")
+	}
+}
+
+func NewSMSTemplateFromMessage(c SMSConfig, msg Message) (SMSTemplate, error) {
+	switch msg.TemplateType {
+	case TypeRecoveryInvalid:
+		var t template.RecoveryInvalidModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewRecoveryInvalid(c, &t), nil
+	case TypeRecoveryValid:
+		var t template.RecoveryValidModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewRecoveryValid(c, &t), nil
+	case TypeRecoveryCodeValid:
+		var t template.RecoveryCodeValidModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewRecoveryCodeValid(c, &t), nil
+	case TypeVerificationInvalid:
+		var t template.VerificationInvalidModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewVerificationInvalid(c, &t), nil
+	case TypeVerificationValid:
+		var t template.VerificationValidModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewVerificationValid(c, &t), nil
+	case TypeVerificationCodeValid:
+		var t template.VerificationCodeValidModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewVerificationCodeValid(c, &t), nil
+	case TypeOTP:
+		var t template.OTPMessageModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewOTPMessage(c, &t), nil
+	case TypeSMSTestStub:
+		var t template.SMSTestStubModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewSMSTestStub(c, &t), nil
+	default:
+		return nil, errors.Errorf("received unexpected message template type: %s", msg.TemplateType)
+	}
+}
@@ -14,11 +14,15 @@ import (
 
 func TestGetTemplateType(t *testing.T) {
 	for expectedType, tmpl := range map[courier.TemplateType]courier.EmailTemplate{
-		courier.TypeRecoveryInvalid:     &template.RecoveryInvalid{},
-		courier.TypeRecoveryValid:       &template.RecoveryValid{},
-		courier.TypeVerificationInvalid: &template.VerificationInvalid{},
-		courier.TypeVerificationValid:   &template.VerificationValid{},
-		courier.TypeTestStub:            &template.TestStub{},
+		courier.TypeRecoveryInvalid:       &template.RecoveryInvalid{},
+		courier.TypeRecoveryValid:         &template.RecoveryValid{},
+		courier.TypeRecoveryCodeValid:     &template.RecoveryCodeValid{},
+		courier.TypeVerificationInvalid:   &template.VerificationInvalid{},
+		courier.TypeVerificationValid:     &template.VerificationValid{},
+		courier.TypeVerificationCodeValid: &template.VerificationCodeValid{},
+		courier.TypeLoginInvalid:          &template.LoginInvalid{},
+		courier.TypeLoginValid:            &template.LoginValid{},
+		courier.TypeTestStub:              &template.TestStub{},
 	} {
 		t.Run(fmt.Sprintf("case=%s", expectedType), func(t *testing.T) {
 			actualType, err := courier.GetTemplateType(tmpl)
@@ -33,11 +37,15 @@ func TestGetTemplateType(t *testing.T) {
 func TestNewEmailTemplateFromMessage(t *testing.T) {
 	conf := internal.NewConfigurationWithDefaults(t)
 	for tmplType, expectedTmpl := range map[courier.TemplateType]courier.EmailTemplate{
-		courier.TypeRecoveryInvalid:     template.NewRecoveryInvalid(conf, &template.RecoveryInvalidModel{To: "foo"}),
-		courier.TypeRecoveryValid:       template.NewRecoveryValid(conf, &template.RecoveryValidModel{To: "bar", RecoveryURL: "http://foo.bar"}),
-		courier.TypeVerificationInvalid: template.NewVerificationInvalid(conf, &template.VerificationInvalidModel{To: "baz"}),
-		courier.TypeVerificationValid:   template.NewVerificationValid(conf, &template.VerificationValidModel{To: "faz", VerificationURL: "http://bar.foo"}),
-		courier.TypeTestStub:            template.NewTestStub(conf, &template.TestStubModel{To: "far", Subject: "test subject", Body: "test body"}),
+		courier.TypeRecoveryInvalid:       template.NewRecoveryInvalid(conf, &template.RecoveryInvalidModel{To: "foo"}),
+		courier.TypeRecoveryValid:         template.NewRecoveryValid(conf, &template.RecoveryValidModel{To: "bar", RecoveryURL: "http://foo.bar"}),
+		courier.TypeRecoveryCodeValid:     template.NewRecoveryCodeValid(conf, &template.RecoveryCodeValidModel{To: "bar", RecoveryCode: "123456"}),
+		courier.TypeVerificationInvalid:   template.NewVerificationInvalid(conf, &template.VerificationInvalidModel{To: "baz"}),
+		courier.TypeVerificationValid:     template.NewVerificationValid(conf, &template.VerificationValidModel{To: "faz", VerificationURL: "http://bar.foo"}),
+		courier.TypeVerificationCodeValid: template.NewVerificationCodeValid(conf, &template.VerificationCodeValidModel{To: "faz", VerificationCode: "654321"}),
+		courier.TypeLoginInvalid:          template.NewLoginInvalid(conf, &template.LoginInvalidModel{To: "boo"}),
+		courier.TypeLoginValid:            template.NewLoginValid(conf, &template.LoginValidModel{To: "boo", LoginURL: "http://foo.bar", LoginCode: "123456"}),
+		courier.TypeTestStub:              template.NewTestStub(conf, &template.TestStubModel{To: "far", Subject: "test subject", Body: "test body"}),
 	} {
 		t.Run(fmt.Sprintf("case=%s", tmplType), func(t *testing.T) {
 			tmplData, err := json.Marshal(expectedTmpl)
@@ -76,3 +84,59 @@ func TestNewEmailTemplateFromMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSMSTemplateType(t *testing.T) {
+	for expectedType, tmpl := range map[courier.TemplateType]courier.SMSTemplate{
+		courier.TypeRecoveryInvalid:       &template.RecoveryInvalid{},
+		courier.TypeRecoveryValid:         &template.RecoveryValid{},
+		courier.TypeRecoveryCodeValid:     &template.RecoveryCodeValid{},
+		courier.TypeVerificationInvalid:   &template.VerificationInvalid{},
+		courier.TypeVerificationValid:     &template.VerificationValid{},
+		courier.TypeVerificationCodeValid: &template.VerificationCodeValid{},
+		courier.TypeOTP:                   &template.OTPMessage{},
+		courier.TypeSMSTestStub:           &template.SMSTestStub{},
+	} {
+		t.Run(fmt.Sprintf("case=%s", expectedType), func(t *testing.T) {
+			actualType, err := courier.GetSMSTemplateType(tmpl)
+			require.NoError(t, err)
+			require.Equal(t, expectedType, actualType)
+		})
+	}
+}
+
+func TestNewSMSTemplateFromMessage(t *testing.T) {
+	conf := internal.NewConfigurationWithDefaults(t)
+	for tmplType, expectedTmpl := range map[courier.TemplateType]courier.SMSTemplate{
+		courier.TypeRecoveryInvalid:       template.NewRecoveryInvalid(conf, &template.RecoveryInvalidModel{To: "+12345678901"}),
+		courier.TypeRecoveryValid:         template.NewRecoveryValid(conf, &template.RecoveryValidModel{To: "+12345678901", RecoveryURL: "https://www.ory.sh/"}),
+		courier.TypeRecoveryCodeValid:     template.NewRecoveryCodeValid(conf, &template.RecoveryCodeValidModel{To: "+12345678901", RecoveryCode: "123456"}),
+		courier.TypeVerificationInvalid:   template.NewVerificationInvalid(conf, &template.VerificationInvalidModel{To: "+12345678901"}),
+		courier.TypeVerificationValid:     template.NewVerificationValid(conf, &template.VerificationValidModel{To: "+12345678901", VerificationURL: "https://www.ory.sh/"}),
+		courier.TypeVerificationCodeValid: template.NewVerificationCodeValid(conf, &template.VerificationCodeValidModel{To: "+12345678901", VerificationCode: "123456"}),
+		courier.TypeOTP:                   template.NewOTPMessage(conf, &template.OTPMessageModel{To: "+12345678901", Code: "123456"}),
+		courier.TypeSMSTestStub:           template.NewSMSTestStub(conf, &template.SMSTestStubModel{To: "+12345678901", Body: "test body"}),
+	} {
+		t.Run(fmt.Sprintf("case=%s", tmplType), func(t *testing.T) {
+			tmplData, err := json.Marshal(expectedTmpl)
+			require.NoError(t, err)
+
+			m := courier.Message{Type: courier.MessageTypeSMS, TemplateType: tmplType, TemplateData: tmplData}
+			actualTmpl, err := courier.NewSMSTemplateFromMessage(conf, m)
+			require.NoError(t, err)
+
+			require.IsType(t, expectedTmpl, actualTmpl)
+
+			expectedRecipient, err := expectedTmpl.PhoneNumber()
+			require.NoError(t, err)
+			actualRecipient, err := actualTmpl.PhoneNumber()
+			require.NoError(t, err)
+			require.Equal(t, expectedRecipient, actualRecipient)
+
+			expectedBody, err := expectedTmpl.SMSBody()
+			require.NoError(t, err)
+			actualBody, err := actualTmpl.SMSBody()
+			require.NoError(t, err)
+			require.Equal(t, expectedBody, actualBody)
+		})
+	}
+}
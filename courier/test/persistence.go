@@ -91,6 +91,153 @@ func TestPersister(ctx context.Context, newNetworkUnlessExisting NetworkWrapper,
 			require.ErrorIs(t, err, courier.ErrQueueEmpty)
 		})
 
+		t.Run("case=list messages", func(t *testing.T) {
+			all, total, err := p.ListMessages(ctx, courier.ListMessagesFilter{}, 0, 1000)
+			require.NoError(t, err)
+			assert.EqualValues(t, len(all), total)
+			require.GreaterOrEqual(t, len(all), len(messages))
+
+			ids := make([]uuid.UUID, len(all))
+			for k := range all {
+				ids[k] = all[k].ID
+			}
+			for _, m := range messages {
+				assert.Contains(t, ids, m.ID)
+			}
+
+			t.Run("filter=status", func(t *testing.T) {
+				actual, total, err := p.ListMessages(ctx, courier.ListMessagesFilter{Status: courier.MessageStatusSent}, 0, 1000)
+				require.NoError(t, err)
+				assert.EqualValues(t, len(actual), total)
+				require.Len(t, actual, 1)
+				assert.Equal(t, messages[0].ID, actual[0].ID)
+			})
+
+			t.Run("filter=recipient", func(t *testing.T) {
+				actual, _, err := p.ListMessages(ctx, courier.ListMessagesFilter{Recipient: messages[1].Recipient}, 0, 1000)
+				require.NoError(t, err)
+				require.Len(t, actual, 1)
+				assert.Equal(t, messages[1].ID, actual[0].ID)
+			})
+
+			t.Run("filter=template type", func(t *testing.T) {
+				actual, _, err := p.ListMessages(ctx, courier.ListMessagesFilter{TemplateType: messages[2].TemplateType, Status: courier.MessageStatusProcessing}, 0, 1000)
+				require.NoError(t, err)
+				require.Len(t, actual, 1)
+				assert.Equal(t, messages[2].ID, actual[0].ID)
+			})
+
+			t.Run("pagination", func(t *testing.T) {
+				actual, pageTotal, err := p.ListMessages(ctx, courier.ListMessagesFilter{}, 1, 2)
+				require.NoError(t, err)
+				assert.Len(t, actual, 2)
+				assert.Equal(t, total, pageTotal)
+			})
+		})
+
+		t.Run("case=fetch message", func(t *testing.T) {
+			actual, err := p.FetchMessage(ctx, messages[3].ID)
+			require.NoError(t, err)
+			assert.Equal(t, messages[3].ID, actual.ID)
+			assert.Equal(t, messages[3].Subject, actual.Subject)
+			assert.Equal(t, courier.MessageStatusProcessing, actual.Status)
+
+			_, err = p.FetchMessage(ctx, x.NewUUID())
+			require.ErrorIs(t, err, sqlcon.ErrNoRows)
+		})
+
+		t.Run("case=record message failure", func(t *testing.T) {
+			require.NoError(t, p.RecordMessageFailure(ctx, messages[4].ID, courier.MessageStatusQueued, "some error"))
+
+			actual, err := p.FetchMessage(ctx, messages[4].ID)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusQueued, actual.Status)
+			assert.Equal(t, 1, actual.SendCount)
+			assert.EqualValues(t, "some error", actual.LastError)
+
+			require.NoError(t, p.RecordMessageFailure(ctx, messages[4].ID, courier.MessageStatusAbandoned, "another error"))
+
+			actual, err = p.FetchMessage(ctx, messages[4].ID)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusAbandoned, actual.Status)
+			assert.Equal(t, 2, actual.SendCount)
+			assert.EqualValues(t, "another error", actual.LastError)
+
+			_, err = p.NextMessages(ctx, 255)
+			require.ErrorIs(t, err, courier.ErrQueueEmpty)
+
+			require.ErrorIs(t, p.RecordMessageFailure(ctx, x.NewUUID(), courier.MessageStatusQueued, ""), sqlcon.ErrNoRows)
+		})
+
+		t.Run("case=requeue message", func(t *testing.T) {
+			require.NoError(t, p.RequeueMessage(ctx, messages[4].ID))
+
+			actual, err := p.FetchMessage(ctx, messages[4].ID)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusQueued, actual.Status)
+			assert.Equal(t, 0, actual.SendCount)
+			assert.Empty(t, actual.LastError)
+
+			require.NoError(t, p.SetMessageStatus(ctx, messages[4].ID, courier.MessageStatusAbandoned))
+			require.ErrorIs(t, p.RequeueMessage(ctx, x.NewUUID()), sqlcon.ErrNoRows)
+		})
+
+		var templates []courier.StoredTemplate
+		t.Run("case=manage templates", func(t *testing.T) {
+			actual, err := p.ListTemplates(ctx)
+			require.NoError(t, err)
+			assert.Len(t, actual, 0)
+
+			templates = []courier.StoredTemplate{
+				{TemplateType: courier.TypeRecoveryValid, Locale: "de", Subject: "Konto wiederherstellen"},
+				{TemplateType: courier.TypeRecoveryValid, Subject: "Recover your account", BodyHTML: "<p>{{ .RecoveryURL }}</p>"},
+			}
+			for k := range templates {
+				require.NoError(t, p.CreateTemplate(ctx, &templates[k]))
+				assert.NotEqual(t, uuid.Nil, templates[k].ID)
+				assert.EqualValues(t, nid, templates[k].NID)
+			}
+
+			t.Run("type and locale are unique", func(t *testing.T) {
+				err := p.CreateTemplate(ctx, &courier.StoredTemplate{TemplateType: courier.TypeRecoveryValid, Locale: "de", Subject: "Duplikat"})
+				require.ErrorIs(t, err, sqlcon.ErrUniqueViolation)
+			})
+
+			t.Run("list is sorted by type and locale", func(t *testing.T) {
+				actual, err := p.ListTemplates(ctx)
+				require.NoError(t, err)
+				require.Len(t, actual, 2)
+				assert.Equal(t, templates[1].ID, actual[0].ID)
+				assert.Equal(t, templates[0].ID, actual[1].ID)
+			})
+
+			t.Run("get and update", func(t *testing.T) {
+				templates[0].BodyPlaintext = "Hallo {{ .To }}"
+				require.NoError(t, p.UpdateTemplate(ctx, &templates[0]))
+
+				actual, err := p.GetTemplate(ctx, templates[0].ID)
+				require.NoError(t, err)
+				assert.Equal(t, "Konto wiederherstellen", actual.Subject)
+				assert.Equal(t, "Hallo {{ .To }}", actual.BodyPlaintext)
+				assert.Equal(t, "de", actual.Locale)
+
+				_, err = p.GetTemplate(ctx, x.NewUUID())
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+				require.ErrorIs(t, p.UpdateTemplate(ctx, &courier.StoredTemplate{ID: x.NewUUID(), TemplateType: courier.TypeRecoveryValid}), sqlcon.ErrNoRows)
+			})
+
+			t.Run("delete", func(t *testing.T) {
+				expected := courier.StoredTemplate{TemplateType: courier.TypeLoginValid, Subject: "Sign in"}
+				require.NoError(t, p.CreateTemplate(ctx, &expected))
+				require.NoError(t, p.DeleteTemplate(ctx, expected.ID))
+
+				_, err := p.GetTemplate(ctx, expected.ID)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+				require.ErrorIs(t, p.DeleteTemplate(ctx, expected.ID), sqlcon.ErrNoRows)
+			})
+		})
+
 		t.Run("case=network", func(t *testing.T) {
 			id := x.NewUUID()
 
@@ -125,10 +272,43 @@ func TestPersister(ctx context.Context, newNetworkUnlessExisting NetworkWrapper,
 				require.ErrorIs(t, err, courier.ErrQueueEmpty)
 			})
 
+			t.Run("can not list or fetch on another network", func(t *testing.T) {
+				_, p := newNetwork(t, ctx)
+
+				actual, total, err := p.ListMessages(ctx, courier.ListMessagesFilter{}, 0, 1000)
+				require.NoError(t, err)
+				assert.Len(t, actual, 0)
+				assert.EqualValues(t, 0, total)
+
+				_, err = p.FetchMessage(ctx, id)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+			})
+
 			t.Run("can not update on another network", func(t *testing.T) {
 				_, p := newNetwork(t, ctx)
 				err := p.SetMessageStatus(ctx, id, courier.MessageStatusProcessing)
 				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+				err = p.RecordMessageFailure(ctx, id, courier.MessageStatusAbandoned, "")
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+			})
+
+			t.Run("can not manage templates on another network", func(t *testing.T) {
+				_, p := newNetwork(t, ctx)
+
+				actual, err := p.ListTemplates(ctx)
+				require.NoError(t, err)
+				assert.Len(t, actual, 0)
+
+				_, err = p.GetTemplate(ctx, templates[0].ID)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+				other := templates[0]
+				require.ErrorIs(t, p.UpdateTemplate(ctx, &other), sqlcon.ErrNoRows)
+				require.ErrorIs(t, p.DeleteTemplate(ctx, templates[0].ID), sqlcon.ErrNoRows)
+
+				// The same type and locale can be used on another network.
+				require.NoError(t, p.CreateTemplate(ctx, &courier.StoredTemplate{TemplateType: courier.TypeRecoveryValid, Locale: "de", Subject: "Konto"}))
 			})
 		})
 	}
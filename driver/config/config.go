@@ -7,11 +7,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -57,11 +59,29 @@ const (
 	DefaultCipherAlgorithm                                   = "noop"
 	UnknownVersion                                           = "unknown version"
 	ViperKeyDSN                                              = "dsn"
+	ViperKeyCourierEmailStrategy                             = "courier.delivery_strategy"
+	ViperKeyCourierEmailRequestConfig                        = "courier.http.request_config"
+	ViperKeyCourierMaildirPath                               = "courier.maildir.path"
 	ViperKeyCourierSMTPURL                                   = "courier.smtp.connection_uri"
 	ViperKeyCourierTemplatesPath                             = "courier.template_override_path"
+	ViperKeyCourierLocaleTrait                               = "courier.locale_trait"
 	ViperKeyCourierSMTPFrom                                  = "courier.smtp.from_address"
 	ViperKeyCourierSMTPFromName                              = "courier.smtp.from_name"
 	ViperKeyCourierSMTPHeaders                               = "courier.smtp.headers"
+	ViperKeyCourierSMSRequestConfig                          = "courier.sms.request_config"
+	ViperKeyCourierSMSEnabled                                = "courier.sms.enabled"
+	ViperKeyCourierSMSFrom                                   = "courier.sms.from"
+	ViperKeyCourierMessageRetries                            = "courier.message_retries"
+	ViperKeyWebHookDeliveryMaxAttempts                       = "webhooks.delivery.max_attempts"
+	ViperKeyWebHookDeliveryInitialBackoff                    = "webhooks.delivery.initial_backoff"
+	ViperKeyWebHookDeliveryMaxBackoff                        = "webhooks.delivery.max_backoff"
+	ViperKeyWebHookDeliveryClaimTimeout                      = "webhooks.delivery.claim_timeout"
+	ViperKeyWebHookDeliveryRetention                         = "webhooks.delivery.retention"
+	ViperKeyWebHookSubscriptions                             = "webhooks.subscriptions"
+	ViperKeyRateLimits                                       = "selfservice.rate_limits"
+	ViperKeyRateLimitStore                                   = "selfservice.rate_limits.store"
+	ViperKeyRateLimitLockoutEnabled                          = "selfservice.rate_limits.lockout.enabled"
+	ViperKeyRateLimitLockoutDuration                         = "selfservice.rate_limits.lockout.duration"
 	ViperKeySecretsDefault                                   = "secrets.default"
 	ViperKeySecretsCookie                                    = "secrets.cookie"
 	ViperKeySecretsCipher                                    = "secrets.cipher"
@@ -76,6 +96,7 @@ const (
 	ViperKeyPublicTLSKeyBase64                               = "serve.public.tls.key.base64"
 	ViperKeyPublicTLSCertPath                                = "serve.public.tls.cert.path"
 	ViperKeyPublicTLSKeyPath                                 = "serve.public.tls.key.path"
+	ViperKeyPublicTrustedProxies                             = "serve.public.trusted_proxies"
 	ViperKeyDisableAdminHealthRequestLog                     = "serve.admin.request_log.disable_for_health"
 	ViperKeyAdminBaseURL                                     = "serve.admin.base_url"
 	ViperKeyAdminPort                                        = "serve.admin.port"
@@ -94,6 +115,7 @@ const (
 	ViperKeySessionPath                                      = "session.cookie.path"
 	ViperKeySessionPersistentCookie                          = "session.cookie.persistent"
 	ViperKeySessionWhoAmIAAL                                 = "session.whoami.required_aal"
+	ViperKeySessionDevicesGeolocationDatabase                = "session.devices.geolocation_database"
 	ViperKeyCookieSameSite                                   = "cookies.same_site"
 	ViperKeyCookieDomain                                     = "cookies.domain"
 	ViperKeyCookiePath                                       = "cookies.path"
@@ -128,6 +150,9 @@ const (
 	ViperKeySelfServiceVerificationAfter                     = "selfservice.flows.verification.after"
 	ViperKeyDefaultIdentitySchemaURL                         = "identity.default_schema_url"
 	ViperKeyIdentitySchemas                                  = "identity.schemas"
+	ViperKeyIdentityPhoneDefaultRegion                       = "identity.phone.default_region"
+	ViperKeyIdentityLifecycleDeletionGracePeriod             = "identity.lifecycle.deletion_grace_period"
+	ViperKeyIdentityLifecycleInterval                        = "identity.lifecycle.interval"
 	ViperKeyHasherAlgorithm                                  = "hashers.algorithm"
 	ViperKeyHasherArgon2ConfigMemory                         = "hashers.argon2.memory"
 	ViperKeyHasherArgon2ConfigIterations                     = "hashers.argon2.iterations"
@@ -141,6 +166,10 @@ const (
 	ViperKeyCipherAlgorithm                                  = "ciphers.algorithm"
 	ViperKeyLinkLifespan                                     = "selfservice.methods.link.config.lifespan"
 	ViperKeyLinkBaseURL                                      = "selfservice.methods.link.config.base_url"
+	ViperKeyLinkCodeEnabled                                  = "selfservice.methods.link.config.code.enabled"
+	ViperKeyLinkCodeMaxAttempts                              = "selfservice.methods.link.config.code.max_attempts"
+	ViperKeyMagicLinkLifespan                                = "selfservice.methods.magic_link.config.lifespan"
+	ViperKeyMagicLinkMaxAttempts                             = "selfservice.methods.magic_link.config.max_attempts"
 	ViperKeyPasswordHaveIBeenPwnedHost                       = "selfservice.methods.password.config.haveibeenpwned_host"
 	ViperKeyPasswordHaveIBeenPwnedEnabled                    = "selfservice.methods.password.config.haveibeenpwned_enabled"
 	ViperKeyPasswordMaxBreaches                              = "selfservice.methods.password.config.max_breaches"
@@ -152,6 +181,7 @@ const (
 	ViperKeyWebAuthnRPID                                     = "selfservice.methods.webauthn.config.rp.id"
 	ViperKeyWebAuthnRPOrigin                                 = "selfservice.methods.webauthn.config.rp.origin"
 	ViperKeyWebAuthnRPIcon                                   = "selfservice.methods.webauthn.config.rp.issuer"
+	ViperKeyWebAuthnPasswordless                             = "selfservice.methods.webauthn.config.passwordless"
 	ViperKeyClientHTTPNoPrivateIPRanges                      = "clients.http.disallow_private_ip_ranges"
 	ViperKeyVersion                                          = "version"
 )
@@ -189,6 +219,16 @@ type (
 		Name   string          `json:"hook"`
 		Config json.RawMessage `json:"config"`
 	}
+	WebHookSubscription struct {
+		Events []string        `json:"events"`
+		Config json.RawMessage `json:"config"`
+	}
+	RateLimit struct {
+		// Limit is the maximum number of submissions within the window.
+		Limit int
+		// Window is the duration after which the number of submissions is reset.
+		Window time.Duration
+	}
 	SelfServiceStrategy struct {
 		Enabled bool            `json:"enabled"`
 		Config  json.RawMessage `json:"config"`
@@ -211,6 +251,9 @@ type (
 		p              *configx.Provider
 		identitySchema *jsonschema.Schema
 		stdOutOrErr    io.Writer
+
+		// revision is incremented whenever the configuration changes.
+		revision uint64
 	}
 
 	Provider interface {
@@ -275,7 +318,7 @@ func New(ctx context.Context, l *logrusx.Logger, stdOutOrErr io.Writer, opts ...
 
 	opts = append([]configx.OptionModifier{
 		configx.WithStderrValidationReporter(),
-		configx.OmitKeysFromTracing("dsn", "courier.smtp.connection_uri", "secrets.default", "secrets.cookie", "secrets.cipher", "client_secret"),
+		configx.OmitKeysFromTracing("dsn", "courier.smtp.connection_uri", "courier.sms.request_config", "courier.http.request_config", "secrets.default", "secrets.cookie", "secrets.cipher", "client_secret"),
 		configx.WithImmutables("serve", "profiling", "log"),
 		configx.WithLogrusWatcher(l),
 		configx.WithLogger(l),
@@ -284,6 +327,7 @@ func New(ctx context.Context, l *logrusx.Logger, stdOutOrErr io.Writer, opts ...
 			if c == nil {
 				panic(errors.New("the config provider did not initialise correctly in time"))
 			}
+			atomic.AddUint64(&c.revision, 1)
 			if err := c.validateIdentitySchemas(); err != nil {
 				l.WithError(err).
 					Errorf("The changed identity schema configuration is invalid and could not be loaded. Rolling back to the last working configuration revision. Please address the validation errors before restarting the process.")
@@ -381,15 +425,22 @@ func (p *Config) cors(prefix string) (cors.Options, bool) {
 }
 
 func (p *Config) Set(key string, value interface{}) error {
+	defer atomic.AddUint64(&p.revision, 1)
 	return p.p.Set(key, value)
 }
 
 func (p *Config) MustSet(key string, value interface{}) {
-	if err := p.p.Set(key, value); err != nil {
+	if err := p.Set(key, value); err != nil {
 		p.l.WithError(err).Fatalf("Unable to set \"%s\" to \"%s\".", key, value)
 	}
 }
 
+// Revision changes whenever the configuration changes. Use it to discard state which was derived from the
+// configuration.
+func (p *Config) Revision() uint64 {
+	return atomic.LoadUint64(&p.revision)
+}
+
 func (p *Config) SessionName() string {
 	return stringsx.Coalesce(p.p.String(ViperKeySessionName), DefaultSessionCookieName)
 }
@@ -442,6 +493,24 @@ func (p *Config) TOTPIssuer() string {
 	return p.Source().StringF(ViperKeyTOTPIssuer, p.SelfPublicURL().Hostname())
 }
 
+// IdentityPhoneDefaultRegion returns the ISO 3166-1 alpha-2 region used to normalize phone
+// numbers which are not in international format. Returns an empty string if not set.
+func (p *Config) IdentityPhoneDefaultRegion() string {
+	return strings.ToUpper(p.p.String(ViperKeyIdentityPhoneDefaultRegion))
+}
+
+// IdentityDeletionGracePeriod returns the time after which deactivated identities are erased. Returns zero if
+// deactivated identities are not scheduled for deletion automatically.
+func (p *Config) IdentityDeletionGracePeriod() time.Duration {
+	return p.p.DurationF(ViperKeyIdentityLifecycleDeletionGracePeriod, 0)
+}
+
+// IdentityLifecycleInterval returns how often the identity lifecycle worker looks for identities which are due
+// to be deactivated or erased.
+func (p *Config) IdentityLifecycleInterval() time.Duration {
+	return p.p.DurationF(ViperKeyIdentityLifecycleInterval, time.Minute)
+}
+
 func (p *Config) IdentityTraitsSchemas() Schemas {
 	ds := Schema{
 		ID:  DefaultIdentityTraitsSchemaID,
@@ -488,6 +557,29 @@ func (p *Config) PublicSocketPermission() *configx.UnixPermission {
 	}
 }
 
+// PublicTrustedProxies returns the networks of the proxies in front of the public API. Entries may be IP addresses
+// or CIDR ranges; invalid entries are ignored.
+func (p *Config) PublicTrustedProxies() []*net.IPNet {
+	var proxies []*net.IPNet
+	for _, entry := range p.p.Strings(ViperKeyPublicTrustedProxies) {
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+
+		_, n, err := net.ParseCIDR(entry)
+		if err != nil {
+			p.l.WithError(err).Warnf("Ignoring invalid trusted proxy \"%s\".", entry)
+			continue
+		}
+		proxies = append(proxies, n)
+	}
+	return proxies
+}
+
 func (p *Config) AdminSocketPermission() *configx.UnixPermission {
 	return &configx.UnixPermission{
 		Owner: p.p.String(ViperKeyAdminSocketOwner),
@@ -759,6 +851,12 @@ func (p *Config) SessionLifespan() time.Duration {
 	return p.p.DurationF(ViperKeySessionLifespan, time.Hour*24)
 }
 
+// SessionDevicesGeolocationDatabase returns the path to the database used to determine the location of the
+// devices sessions are used on. Returns an empty string if no database is configured.
+func (p *Config) SessionDevicesGeolocationDatabase() string {
+	return p.p.String(ViperKeySessionDevicesGeolocationDatabase)
+}
+
 func (p *Config) SessionPersistentCookie() bool {
 	return p.p.Bool(ViperKeySessionPersistentCookie)
 }
@@ -810,6 +908,33 @@ func (p *Config) SelfServiceFlowLogoutRedirectURL() *url.URL {
 	return p.p.RequestURIF(ViperKeySelfServiceLogoutBrowserDefaultReturnTo, p.SelfServiceBrowserDefaultReturnTo())
 }
 
+func (p *Config) CourierEmailStrategy() string {
+	return p.p.StringF(ViperKeyCourierEmailStrategy, "smtp")
+}
+
+func (p *Config) CourierEmailRequestConfig() json.RawMessage {
+	if p.CourierEmailStrategy() != "http" {
+		return nil
+	}
+
+	out, err := p.p.Marshal(kjson.Parser())
+	if err != nil {
+		p.l.WithError(err).Warn("Unable to marshal mail courier request configuration.")
+		return nil
+	}
+
+	config := gjson.GetBytes(out, ViperKeyCourierEmailRequestConfig).Raw
+	if len(config) == 0 {
+		return nil
+	}
+
+	return json.RawMessage(config)
+}
+
+func (p *Config) CourierMaildirPath() string {
+	return p.p.String(ViperKeyCourierMaildirPath)
+}
+
 func (p *Config) CourierSMTPFrom() string {
 	return p.p.StringF(ViperKeyCourierSMTPFrom, "noreply@kratos.ory.sh")
 }
@@ -822,10 +947,128 @@ func (p *Config) CourierTemplatesRoot() string {
 	return p.p.StringF(ViperKeyCourierTemplatesPath, "courier/builtin/templates")
 }
 
+func (p *Config) CourierLocaleTrait() string {
+	return p.p.StringF(ViperKeyCourierLocaleTrait, "locale")
+}
+
 func (p *Config) CourierSMTPHeaders() map[string]string {
 	return p.p.StringMap(ViperKeyCourierSMTPHeaders)
 }
 
+func (p *Config) CourierSMSEnabled() bool {
+	return p.p.Bool(ViperKeyCourierSMSEnabled)
+}
+
+func (p *Config) CourierSMSFrom() string {
+	return p.p.StringF(ViperKeyCourierSMSFrom, "Ory Kratos")
+}
+
+func (p *Config) CourierMessageRetries() int {
+	return p.p.IntF(ViperKeyCourierMessageRetries, 5)
+}
+
+// WebHookDeliveryMaxAttempts returns how often the web hook worker tries to deliver a request before it
+// gives up.
+func (p *Config) WebHookDeliveryMaxAttempts() int {
+	return p.p.IntF(ViperKeyWebHookDeliveryMaxAttempts, 10)
+}
+
+// WebHookDeliveryInitialBackoff returns how long the web hook worker waits before it retries a failed delivery
+// for the first time.
+func (p *Config) WebHookDeliveryInitialBackoff() time.Duration {
+	return p.p.DurationF(ViperKeyWebHookDeliveryInitialBackoff, 30*time.Second)
+}
+
+// WebHookDeliveryMaxBackoff returns the maximum time the web hook worker waits before it retries a failed
+// delivery.
+func (p *Config) WebHookDeliveryMaxBackoff() time.Duration {
+	return p.p.DurationF(ViperKeyWebHookDeliveryMaxBackoff, time.Hour)
+}
+
+// WebHookDeliveryClaimTimeout returns how long a delivery stays claimed by a web hook worker. Deliveries which
+// are still processing afterwards are claimed again, for example because the worker crashed.
+func (p *Config) WebHookDeliveryClaimTimeout() time.Duration {
+	return p.p.DurationF(ViperKeyWebHookDeliveryClaimTimeout, 5*time.Minute)
+}
+
+// WebHookDeliveryRetention returns how long delivered and abandoned deliveries are kept before they are deleted.
+func (p *Config) WebHookDeliveryRetention() time.Duration {
+	return p.p.DurationF(ViperKeyWebHookDeliveryRetention, 24*time.Hour)
+}
+
+// WebHookSubscriptions returns the web hooks which are called when identity or session events occur.
+func (p *Config) WebHookSubscriptions() []WebHookSubscription {
+	var subscriptions []WebHookSubscription
+	if !p.p.Exists(ViperKeyWebHookSubscriptions) {
+		return []WebHookSubscription{}
+	}
+
+	out, err := p.p.Marshal(kjson.Parser())
+	if err != nil {
+		p.l.WithError(err).Fatalf("Unable to decode values from configuration key: %s", ViperKeyWebHookSubscriptions)
+	}
+
+	config := gjson.GetBytes(out, ViperKeyWebHookSubscriptions).Raw
+	if len(config) == 0 {
+		return []WebHookSubscription{}
+	}
+
+	if err := jsonx.NewStrictDecoder(bytes.NewBufferString(config)).Decode(&subscriptions); err != nil {
+		p.l.WithError(err).Fatalf("Unable to encode value \"%s\" from configuration key: %s", config, ViperKeyWebHookSubscriptions)
+	}
+
+	return subscriptions
+}
+
+// SelfServiceRateLimit returns the rate limit of the flow's submissions for the scope, for example
+// `login` and `per_ip`. It returns nil if the submissions are not limited.
+func (p *Config) SelfServiceRateLimit(flow, scope string) *RateLimit {
+	key := ViperKeyRateLimits + "." + flow + "." + scope
+	limit := p.p.IntF(key+".limit", 0)
+	if limit < 1 {
+		return nil
+	}
+
+	return &RateLimit{
+		Limit:  limit,
+		Window: p.p.DurationF(key+".window", time.Hour),
+	}
+}
+
+// SelfServiceRateLimitStore returns where rate limit counters are stored, either `sql` or `memory`.
+func (p *Config) SelfServiceRateLimitStore() string {
+	return p.p.StringF(ViperKeyRateLimitStore, "sql")
+}
+
+// SelfServiceRateLimitLockoutEnabled returns true if identifiers which exceed their rate limit are locked.
+func (p *Config) SelfServiceRateLimitLockoutEnabled() bool {
+	return p.p.Bool(ViperKeyRateLimitLockoutEnabled)
+}
+
+// SelfServiceRateLimitLockoutDuration returns how long identifiers which exceed their rate limit are locked.
+func (p *Config) SelfServiceRateLimitLockoutDuration() time.Duration {
+	return p.p.DurationF(ViperKeyRateLimitLockoutDuration, 15*time.Minute)
+}
+
+func (p *Config) CourierSMSRequestConfig() json.RawMessage {
+	if !p.CourierSMSEnabled() {
+		return nil
+	}
+
+	out, err := p.p.Marshal(kjson.Parser())
+	if err != nil {
+		p.l.WithError(err).Warn("Unable to marshal SMS courier request configuration.")
+		return nil
+	}
+
+	config := gjson.GetBytes(out, ViperKeyCourierSMSRequestConfig).Raw
+	if len(config) == 0 {
+		return nil
+	}
+
+	return json.RawMessage(config)
+}
+
 func splitUrlAndFragment(s string) (string, string) {
 	i := strings.IndexByte(s, '#')
 	if i < 0 {
@@ -869,6 +1112,14 @@ func (p *Config) IsBackgroundCourierEnabled() bool {
 	return p.Source().Bool("watch-courier")
 }
 
+func (p *Config) IsBackgroundIdentityLifecycleEnabled() bool {
+	return p.Source().Bool("watch-identity-lifecycle")
+}
+
+func (p *Config) IsBackgroundWebHookDeliveryEnabled() bool {
+	return p.Source().Bool("watch-webhooks")
+}
+
 func (p *Config) CourierExposeMetricsPort() int {
 	return p.Source().Int("expose-metrics-port")
 }
@@ -909,6 +1160,22 @@ func (p *Config) SelfServiceLinkMethodBaseURL() *url.URL {
 	return p.p.RequestURIF(ViperKeyLinkBaseURL, p.SelfPublicURL())
 }
 
+func (p *Config) SelfServiceLinkMethodCodeEnabled() bool {
+	return p.p.Bool(ViperKeyLinkCodeEnabled)
+}
+
+func (p *Config) SelfServiceLinkMethodCodeMaxAttempts() int {
+	return p.p.IntF(ViperKeyLinkCodeMaxAttempts, 5)
+}
+
+func (p *Config) SelfServiceMagicLinkMethodLifespan() time.Duration {
+	return p.p.DurationF(ViperKeyMagicLinkLifespan, 15*time.Minute)
+}
+
+func (p *Config) SelfServiceMagicLinkMethodMaxAttempts() int {
+	return p.p.IntF(ViperKeyMagicLinkMaxAttempts, 5)
+}
+
 func (p *Config) SelfServiceFlowRecoveryAfterHooks(strategy string) []SelfServiceHook {
 	return p.selfServiceHooks(HookStrategyKey(ViperKeySelfServiceRecoveryAfter, strategy))
 }
@@ -1028,6 +1295,10 @@ func (p *Config) WebAuthnConfig() *webauthn.Config {
 	}
 }
 
+func (p *Config) WebAuthnForPasswordless() bool {
+	return p.p.Bool(ViperKeyWebAuthnPasswordless)
+}
+
 func (p *Config) HasherPasswordHashingAlgorithm() string {
 	configValue := p.p.StringF(ViperKeyHasherAlgorithm, DefaultPasswordHashingAlgorithm)
 	switch configValue {
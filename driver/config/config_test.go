@@ -362,6 +362,23 @@ func TestViperProvider(t *testing.T) {
 			assert.Equal(t, []config.SelfServiceHook{{Name: "web_hook", Config: json.RawMessage(`{"body":"/path/to/template.jsonnet","method":"GET","url":"https://test.kratos.ory.sh/after_verification_hook"}`)}}, hooks)
 		})
 
+		t.Run("group=web hook subscriptions", func(t *testing.T) {
+			assert.Equal(t, []config.WebHookSubscription{{
+				Events: []string{"identity.created", "session.revoked"},
+				Config: json.RawMessage(`{"async":true,"auth":{"config":{"client_id":"kratos","client_secret":"super-secret","scopes":["events"],"token_url":"https://auth.test.kratos.ory.sh/oauth2/token"},"type":"oauth2_client_credentials"},"method":"POST","url":"https://test.kratos.ory.sh/identity_and_session_events"}`),
+			}}, p.WebHookSubscriptions())
+		})
+
+		t.Run("group=rate limits", func(t *testing.T) {
+			assert.Equal(t, "memory", p.SelfServiceRateLimitStore())
+			assert.True(t, p.SelfServiceRateLimitLockoutEnabled())
+			assert.Equal(t, 30*time.Minute, p.SelfServiceRateLimitLockoutDuration())
+			assert.Equal(t, &config.RateLimit{Limit: 5, Window: 15 * time.Minute}, p.SelfServiceRateLimit("login", "per_identifier"))
+			assert.Equal(t, &config.RateLimit{Limit: 50, Window: time.Hour}, p.SelfServiceRateLimit("login", "per_ip"))
+			assert.Nil(t, p.SelfServiceRateLimit("login", "per_flow"))
+			assert.Nil(t, p.SelfServiceRateLimit("recovery", "per_identifier"))
+		})
+
 		t.Run("group=hashers", func(t *testing.T) {
 			c := p.HasherArgon2()
 			assert.Equal(t, &config.Argon2{Memory: 1048576, Iterations: 2, Parallelism: 4,
@@ -577,6 +594,24 @@ func TestSession(t *testing.T) {
 	assert.Equal(t, true, p.SessionPersistentCookie())
 	p.MustSet(config.ViperKeySessionPersistentCookie, false)
 	assert.Equal(t, false, p.SessionPersistentCookie())
+
+	assert.Equal(t, "", p.SessionDevicesGeolocationDatabase())
+	p.MustSet(config.ViperKeySessionDevicesGeolocationDatabase, "/etc/config/kratos/geolocation.csv")
+	assert.Equal(t, "/etc/config/kratos/geolocation.csv", p.SessionDevicesGeolocationDatabase())
+}
+
+func TestPublicTrustedProxies(t *testing.T) {
+	l := logrusx.New("", "")
+	p := config.MustNew(t, l, os.Stderr, configx.SkipValidation())
+
+	assert.Empty(t, p.PublicTrustedProxies())
+
+	p.MustSet(config.ViperKeyPublicTrustedProxies, []string{"10.0.0.0/8", "192.0.2.1", "2001:db8::1", "not-an-ip"})
+	var actual []string
+	for _, n := range p.PublicTrustedProxies() {
+		actual = append(actual, n.String())
+	}
+	assert.Equal(t, []string{"10.0.0.0/8", "192.0.2.1/32", "2001:db8::1/128"}, actual)
 }
 
 func TestCookies(t *testing.T) {
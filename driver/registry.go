@@ -25,6 +25,7 @@ import (
 	"github.com/ory/x/healthx"
 
 	"github.com/ory/kratos/persistence"
+	"github.com/ory/kratos/ratelimit"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/logout"
 	"github.com/ory/kratos/selfservice/flow/registration"
@@ -36,8 +37,10 @@ import (
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/export"
 	password2 "github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/webhook"
 )
 
 type Registry interface {
@@ -72,6 +75,14 @@ type Registry interface {
 	continuity.PersistenceProvider
 
 	courier.Provider
+	courier.HandlerProvider
+
+	webhook.PersistenceProvider
+	webhook.DispatcherProvider
+	webhook.EmitterProvider
+
+	ratelimit.PersistenceProvider
+	ratelimit.LimiterProvider
 
 	persistence.Provider
 
@@ -79,6 +90,8 @@ type Registry interface {
 	errorx.HandlerProvider
 	errorx.PersistenceProvider
 
+	export.HandlerProvider
+
 	hash.HashProvider
 
 	identity.HandlerProvider
@@ -86,6 +99,7 @@ type Registry interface {
 	identity.PoolProvider
 	identity.PrivilegedPoolProvider
 	identity.ManagementProvider
+	identity.LifecycleProvider
 	identity.ActiveCredentialsCounterStrategyProvider
 
 	schema.HandlerProvider
@@ -95,6 +109,7 @@ type Registry interface {
 	session.HandlerProvider
 	session.ManagementProvider
 	session.PersistenceProvider
+	session.GeolocatorProvider
 
 	settings.HandlerProvider
 	settings.ErrorHandlerProvider
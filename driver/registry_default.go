@@ -55,6 +55,8 @@ import (
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/persistence"
 	"github.com/ory/kratos/persistence/sql"
+	"github.com/ory/kratos/ratelimit"
+	"github.com/ory/kratos/request"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/logout"
 	"github.com/ory/kratos/selfservice/flow/registration"
@@ -65,8 +67,10 @@ import (
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/export"
 	password2 "github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/webhook"
 )
 
 type RegistryDefault struct {
@@ -90,16 +94,30 @@ type RegistryDefault struct {
 	hookSessionDestroyer *hook.SessionDestroyer
 	hookAddressVerifier  *hook.AddressVerifier
 
+	courierHandler *courier.Handler
+
 	identityHandler   *identity.Handler
 	identityValidator *identity.Validator
 	identityManager   *identity.Manager
+	identityLifecycle *identity.Lifecycle
+
+	webHookDispatcher *webhook.Dispatcher
+	webHookEmitter    *webhook.Emitter
+
+	requestAuthCache         *request.AuthCache
+	requestAuthCacheRevision uint64
+
+	rateLimiter          *ratelimit.Limiter
+	rateLimitMemoryStore *ratelimit.MemoryPersister
 
 	continuityManager continuity.Manager
 
 	schemaHandler *schema.Handler
 
-	sessionHandler *session.Handler
-	sessionManager session.Manager
+	sessionHandler     *session.Handler
+	sessionManager     session.Manager
+	geolocator         session.Geolocator
+	geolocatorRevision uint64
 
 	passwordHasher    hash.Hasher
 	passwordValidator password2.Validator
@@ -109,6 +127,8 @@ type RegistryDefault struct {
 	errorHandler *errorx.Handler
 	errorManager *errorx.Manager
 
+	selfserviceDataExportHandler *export.Handler
+
 	selfserviceRegistrationExecutor            *registration.HookExecutor
 	selfserviceRegistrationHandler             *registration.Handler
 	seflserviceRegistrationErrorHandler        *registration.ErrorHandler
@@ -154,11 +174,13 @@ func (m *RegistryDefault) RegisterPublicRoutes(ctx context.Context, router *x.Ro
 	m.LogoutHandler().RegisterPublicRoutes(router)
 	m.SettingsHandler().RegisterPublicRoutes(router)
 	m.IdentityHandler().RegisterPublicRoutes(router)
+	m.CourierHandler().RegisterPublicRoutes(router)
 	m.AllLoginStrategies().RegisterPublicRoutes(router)
 	m.AllSettingsStrategies().RegisterPublicRoutes(router)
 	m.AllRegistrationStrategies().RegisterPublicRoutes(router)
 	m.SessionHandler().RegisterPublicRoutes(router)
 	m.SelfServiceErrorHandler().RegisterPublicRoutes(router)
+	m.SelfServiceDataExportHandler().RegisterPublicRoutes(router)
 	m.SchemaHandler().RegisterPublicRoutes(router)
 
 	m.AllRecoveryStrategies().RegisterPublicRoutes(router)
@@ -177,7 +199,9 @@ func (m *RegistryDefault) RegisterAdminRoutes(ctx context.Context, router *x.Rou
 	m.SchemaHandler().RegisterAdminRoutes(router)
 	m.SettingsHandler().RegisterAdminRoutes(router)
 	m.IdentityHandler().RegisterAdminRoutes(router)
+	m.CourierHandler().RegisterAdminRoutes(router)
 	m.SelfServiceErrorHandler().RegisterAdminRoutes(router)
+	m.SelfServiceDataExportHandler().RegisterAdminRoutes(router)
 
 	m.RecoveryHandler().RegisterAdminRoutes(router)
 	m.AllRecoveryStrategies().RegisterAdminRoutes(router)
@@ -263,7 +287,7 @@ func (m *RegistryDefault) Config(ctx context.Context) *config.Config {
 	return corp.ContextualizeConfig(ctx, m.c)
 }
 
-func (m *RegistryDefault) CourierConfig(ctx context.Context) courier.SMTPConfig {
+func (m *RegistryDefault) CourierConfig(ctx context.Context) courier.Config {
 	return m.Config(ctx)
 }
 
@@ -427,6 +451,13 @@ func (m *RegistryDefault) SelfServiceErrorHandler() *errorx.Handler {
 	return m.errorHandler
 }
 
+func (m *RegistryDefault) SelfServiceDataExportHandler() *export.Handler {
+	if m.selfserviceDataExportHandler == nil {
+		m.selfserviceDataExportHandler = export.NewHandler(m)
+	}
+	return m.selfserviceDataExportHandler
+}
+
 func (m *RegistryDefault) CookieManager(ctx context.Context) sessions.StoreExact {
 	cs := sessions.NewCookieStore(m.Config(ctx).SecretsSession()...)
 	cs.Options.Secure = !m.Config(ctx).IsInsecureDevMode()
@@ -480,6 +511,49 @@ func (m *RegistryDefault) SessionManager() session.Manager {
 	return m.sessionManager
 }
 
+// SessionGeolocator returns the geolocator which determines the location of session devices. The geolocation
+// database is loaded by Init, which fails if the database can not be loaded, and is reloaded whenever the
+// configuration changes. If reloading fails, the previously loaded database is kept.
+func (m *RegistryDefault) SessionGeolocator(ctx context.Context) session.Geolocator {
+	revision := m.Config(ctx).Revision()
+
+	m.rwl.RLock()
+	g, current := m.geolocator, m.geolocatorRevision == revision
+	m.rwl.RUnlock()
+	if g != nil && current {
+		return g
+	}
+
+	m.rwl.Lock()
+	defer m.rwl.Unlock()
+	if m.geolocator == nil || m.geolocatorRevision != revision {
+		g, err := m.newSessionGeolocator(ctx)
+		if err != nil {
+			m.Logger().WithError(err).Error("Unable to reload the geolocation database, keeping the previously loaded one.")
+		} else {
+			m.geolocator = g
+		}
+		if m.geolocator == nil {
+			m.geolocator = new(session.NoopGeolocator)
+		}
+		m.geolocatorRevision = revision
+	}
+	return m.geolocator
+}
+
+func (m *RegistryDefault) newSessionGeolocator(ctx context.Context) (session.Geolocator, error) {
+	path := m.Config(ctx).SessionDevicesGeolocationDatabase()
+	if path == "" {
+		return new(session.NoopGeolocator), nil
+	}
+
+	g, err := session.NewFileGeolocator(path)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
 func (m *RegistryDefault) SelfServiceErrorManager() *errorx.Manager {
 	if m.errorManager == nil {
 		m.errorManager = errorx.NewManager(m)
@@ -511,6 +585,18 @@ func (m *RegistryDefault) Init(ctx context.Context, opts ...RegistryOption) erro
 
 	o := newOptions(opts)
 
+	// The geolocation database is loaded at startup so that an invalid database fails fast instead of failing
+	// requests.
+	revision := m.Config(ctx).Revision()
+	g, err := m.newSessionGeolocator(ctx)
+	if err != nil {
+		m.Logger().WithError(err).Error("Unable to load the geolocation database.")
+		return err
+	}
+	m.rwl.Lock()
+	m.geolocator, m.geolocatorRevision = g, revision
+	m.rwl.Unlock()
+
 	bc := backoff.NewExponentialBackOff()
 	bc.MaxElapsedTime = time.Minute * 5
 	bc.Reset()
@@ -591,7 +677,14 @@ func (m *RegistryDefault) SetPersister(p persistence.Persister) {
 }
 
 func (m *RegistryDefault) Courier(ctx context.Context) *courier.Courier {
-	return courier.NewSMTP(ctx, m)
+	return courier.NewCourier(ctx, m)
+}
+
+func (m *RegistryDefault) CourierHandler() *courier.Handler {
+	if m.courierHandler == nil {
+		m.courierHandler = courier.NewHandler(m)
+	}
+	return m.courierHandler
 }
 
 func (m *RegistryDefault) ContinuityManager() continuity.Manager {
@@ -649,6 +742,45 @@ func (m *RegistryDefault) VerificationTokenPersister() link.VerificationTokenPer
 	return m.Persister()
 }
 
+func (m *RegistryDefault) LoginTokenPersister() link.LoginTokenPersister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) WebHookPersister() webhook.Persister {
+	return m.persister
+}
+
+func (m *RegistryDefault) WebHookDispatcher() *webhook.Dispatcher {
+	if m.webHookDispatcher == nil {
+		m.webHookDispatcher = webhook.NewDispatcher(m)
+	}
+	return m.webHookDispatcher
+}
+
+func (m *RegistryDefault) WebHookEmitter() *webhook.Emitter {
+	if m.webHookEmitter == nil {
+		m.webHookEmitter = webhook.NewEmitter(m)
+	}
+	return m.webHookEmitter
+}
+
+func (m *RegistryDefault) RateLimitPersister() ratelimit.Persister {
+	if m.c.SelfServiceRateLimitStore() == "memory" {
+		if m.rateLimitMemoryStore == nil {
+			m.rateLimitMemoryStore = ratelimit.NewMemoryPersister()
+		}
+		return m.rateLimitMemoryStore
+	}
+	return m.persister
+}
+
+func (m *RegistryDefault) RateLimiter() *ratelimit.Limiter {
+	if m.rateLimiter == nil {
+		m.rateLimiter = ratelimit.NewLimiter(m)
+	}
+	return m.rateLimiter
+}
+
 func (m *RegistryDefault) Persister() persistence.Persister {
 	return m.persister
 }
@@ -675,6 +807,13 @@ func (m *RegistryDefault) IdentityManager() *identity.Manager {
 	return m.identityManager
 }
 
+func (m *RegistryDefault) IdentityLifecycle() *identity.Lifecycle {
+	if m.identityLifecycle == nil {
+		m.identityLifecycle = identity.NewLifecycle(m)
+	}
+	return m.identityLifecycle
+}
+
 func (m *RegistryDefault) PrometheusManager() *prometheus.MetricsManager {
 	m.rwl.Lock()
 	defer m.rwl.Unlock()
@@ -696,3 +835,20 @@ func (m *RegistryDefault) HTTPClient(ctx context.Context) *retryablehttp.Client
 	}
 	return httpx.NewResilientClient(opts...)
 }
+
+// RequestAuthCache returns the cache of OAuth2 access tokens and mTLS transports which are used to authenticate
+// outgoing requests. The cache is replaced whenever the configuration changes.
+func (m *RegistryDefault) RequestAuthCache(ctx context.Context) *request.AuthCache {
+	revision := m.Config(ctx).Revision()
+
+	m.rwl.Lock()
+	defer m.rwl.Unlock()
+	if m.requestAuthCache == nil || m.requestAuthCacheRevision != revision {
+		if m.requestAuthCache != nil {
+			m.requestAuthCache.Close()
+		}
+		m.requestAuthCache = request.NewAuthCache(m.HTTPClient(ctx))
+		m.requestAuthCacheRevision = revision
+	}
+	return m.requestAuthCache
+}
@@ -9,7 +9,13 @@ import (
 )
 
 func (m *RegistryDefault) PostRegistrationPrePersistHooks(ctx context.Context, credentialsType identity.CredentialsType) (b []registration.PostHookPrePersistExecutor) {
-	for _, v := range m.getHooks(string(credentialsType), m.Config(ctx).SelfServiceFlowRegistrationAfterHooks(string(credentialsType))) {
+	hooks := m.Config(ctx).SelfServiceFlowRegistrationAfterHooks(string(credentialsType))
+	if len(hooks) == 0 {
+		// global hooks are only used if no strategy specific hooks are defined, just like for the post persist hooks
+		hooks = m.Config(ctx).SelfServiceFlowRegistrationAfterHooks(config.HookGlobal)
+	}
+
+	for _, v := range m.getHooks(string(credentialsType), hooks) {
 		if hook, ok := v.(registration.PostHookPrePersistExecutor); ok {
 			b = append(b, hook)
 		}
@@ -8,7 +8,13 @@ import (
 )
 
 func (m *RegistryDefault) PostSettingsPrePersistHooks(ctx context.Context, settingsType string) (b []settings.PostHookPrePersistExecutor) {
-	for _, v := range m.getHooks(settingsType, m.Config(ctx).SelfServiceFlowSettingsAfterHooks(settingsType)) {
+	hooks := m.Config(ctx).SelfServiceFlowSettingsAfterHooks(settingsType)
+	if len(hooks) == 0 {
+		// global hooks are only used if no strategy specific hooks are defined, just like for the post persist hooks
+		hooks = m.Config(ctx).SelfServiceFlowSettingsAfterHooks(config.HookGlobal)
+	}
+
+	for _, v := range m.getHooks(settingsType, hooks) {
 		if hook, ok := v.(settings.PostHookPrePersistExecutor); ok {
 			b = append(b, hook)
 		}
@@ -25,6 +25,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow/registration"
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/hook"
+	"github.com/ory/kratos/session"
 )
 
 func TestDriverDefault_Hooks(t *testing.T) {
@@ -242,6 +243,60 @@ func TestDriverDefault_Hooks(t *testing.T) {
 				assert.Equal(t, expectedExecutors, h)
 			})
 		}
+
+		// AFTER hooks which run before the identity is persisted
+		for _, tc := range []struct {
+			uc     string
+			prep   func(conf *config.Config)
+			expect func(reg *driver.RegistryDefault) []registration.PostHookPrePersistExecutor
+		}{
+			{
+				uc:     "No hooks configured",
+				prep:   func(conf *config.Config) {},
+				expect: func(reg *driver.RegistryDefault) []registration.PostHookPrePersistExecutor { return nil },
+			},
+			{
+				uc: "A web_hook is configured for password strategy",
+				prep: func(conf *config.Config) {
+					conf.MustSet(config.ViperKeySelfServiceRegistrationAfter+".password.hooks", []map[string]interface{}{
+						{"hook": "web_hook", "config": map[string]interface{}{"url": "foo", "method": "POST", "response": map[string]interface{}{"parse": true}}},
+						{"hook": "session"},
+					})
+					conf.MustSet(config.ViperKeySelfServiceRegistrationAfter+".hooks", []map[string]interface{}{
+						{"hook": "web_hook", "config": map[string]interface{}{"url": "bar", "method": "POST"}},
+					})
+				},
+				expect: func(reg *driver.RegistryDefault) []registration.PostHookPrePersistExecutor {
+					return []registration.PostHookPrePersistExecutor{
+						hook.NewWebHook(reg, json.RawMessage(`{"method":"POST","response":{"parse":true},"url":"foo"}`)),
+					}
+				},
+			},
+			{
+				uc: "A web_hook is configured on a global level",
+				prep: func(conf *config.Config) {
+					conf.MustSet(config.ViperKeySelfServiceRegistrationAfter+".hooks", []map[string]interface{}{
+						{"hook": "web_hook", "config": map[string]interface{}{"url": "bar", "method": "POST"}},
+					})
+				},
+				expect: func(reg *driver.RegistryDefault) []registration.PostHookPrePersistExecutor {
+					return []registration.PostHookPrePersistExecutor{
+						hook.NewWebHook(reg, json.RawMessage(`{"method":"POST","url":"bar"}`)),
+					}
+				},
+			},
+		} {
+			t.Run(fmt.Sprintf("after/pre-persist/uc=%s", tc.uc), func(t *testing.T) {
+				conf, reg := internal.NewFastRegistryWithMocks(t)
+				tc.prep(conf)
+
+				h := reg.PostRegistrationPrePersistHooks(ctx, identity.CredentialsTypePassword)
+
+				expectedExecutors := tc.expect(reg)
+				require.Len(t, h, len(expectedExecutors))
+				assert.Equal(t, expectedExecutors, h)
+			})
+		}
 	})
 
 	t.Run("type=login", func(t *testing.T) {
@@ -686,7 +741,7 @@ func TestDefaultRegistry_AllStrategies(t *testing.T) {
 	_, reg := internal.NewFastRegistryWithMocks(t)
 
 	t.Run("case=all login strategies", func(t *testing.T) {
-		expects := []string{"password", "oidc", "totp", "webauthn", "lookup_secret"}
+		expects := []string{"password", "oidc", "magic_link", "totp", "webauthn", "lookup_secret"}
 		s := reg.AllLoginStrategies()
 		require.Len(t, s, len(expects))
 		for k, e := range expects {
@@ -695,7 +750,7 @@ func TestDefaultRegistry_AllStrategies(t *testing.T) {
 	})
 
 	t.Run("case=all registration strategies", func(t *testing.T) {
-		expects := []string{"password", "oidc"}
+		expects := []string{"password", "oidc", "webauthn"}
 		s := reg.AllRegistrationStrategies()
 		require.Len(t, s, len(expects))
 		for k, e := range expects {
@@ -721,3 +776,41 @@ func TestDefaultRegistry_AllStrategies(t *testing.T) {
 		}
 	})
 }
+
+func TestDefaultRegistry_RequestAuthCache(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+
+	cache := reg.RequestAuthCache(ctx)
+	assert.Same(t, cache, reg.RequestAuthCache(ctx))
+
+	conf.MustSet(config.ViperKeyClientHTTPNoPrivateIPRanges, true)
+	assert.NotSame(t, cache, reg.RequestAuthCache(ctx), "the cache must be replaced when the configuration changes")
+}
+
+func TestDefaultRegistry_SessionGeolocator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("case=fails to initialize with an invalid geolocation database", func(t *testing.T) {
+		conf := internal.NewConfigurationWithDefaults(t)
+		conf.MustSet(config.ViperKeyDSN, config.DefaultSQLiteMemoryDSN)
+		conf.MustSet(config.ViperKeySessionDevicesGeolocationDatabase, "does-not-exist.csv")
+
+		reg, err := driver.NewRegistryFromDSN(conf, logrusx.New("", ""))
+		require.NoError(t, err)
+		require.Error(t, reg.Init(ctx, driver.SkipNetworkInit))
+	})
+
+	t.Run("case=keeps the geolocation database if reloading fails", func(t *testing.T) {
+		conf, reg := internal.NewFastRegistryWithMocks(t)
+		assert.IsType(t, new(session.NoopGeolocator), reg.SessionGeolocator(ctx))
+
+		conf.MustSet(config.ViperKeySessionDevicesGeolocationDatabase, "../session/stub/geolocation.csv")
+		g := reg.SessionGeolocator(ctx)
+		require.IsType(t, new(session.FileGeolocator), g)
+		assert.Same(t, g, reg.SessionGeolocator(ctx))
+
+		conf.MustSet(config.ViperKeySessionDevicesGeolocationDatabase, "does-not-exist.csv")
+		assert.Same(t, g, reg.SessionGeolocator(ctx))
+	})
+}
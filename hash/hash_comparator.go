@@ -2,9 +2,16 @@ package hash
 
 import (
 	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"  // #nosec G501 only used for comparing legacy hashes
+	"crypto/sha1" // #nosec G505 only used for comparing legacy hashes
+	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/subtle"
 	"encoding/base64"
 	"fmt"
+	gohash "hash"
 	"regexp"
 	"strings"
 
@@ -12,6 +19,7 @@ import (
 	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
 
 	"github.com/ory/kratos/driver/config"
 )
@@ -26,6 +34,14 @@ func Compare(ctx context.Context, password []byte, hash []byte) error {
 		return CompareArgon2id(ctx, password, hash)
 	case IsPbkdf2Hash(hash):
 		return ComparePbkdf2(ctx, password, hash)
+	case IsDigestHash(hash):
+		return CompareDigest(ctx, password, hash)
+	case IsScryptHash(hash):
+		return CompareScrypt(ctx, password, hash)
+	case IsFirebaseScryptHash(hash):
+		return CompareFirebaseScrypt(ctx, password, hash)
+	case IsPHPassHash(hash):
+		return ComparePHPass(ctx, password, hash)
 	default:
 		return errors.WithStack(ErrUnknownHashAlgorithm)
 	}
@@ -84,12 +100,154 @@ func ComparePbkdf2(_ context.Context, password []byte, hash []byte) error {
 	return errors.WithStack(ErrMismatchedHashAndPassword)
 }
 
+// CompareDigest compares the password with a salted message digest as used by many legacy systems.
+// format: $<md5|sha1|sha256|sha512>$[pf=<format>$]<salt>$<hash>
+//
+// The optional password format is a base64 encoded template which describes how the salt and password were
+// concatenated using the `{SALT}` and `{PASSWORD}` placeholders. It defaults to `{SALT}{PASSWORD}`.
+func CompareDigest(_ context.Context, password []byte, hash []byte) error {
+	algorithm, format, salt, hash, err := decodeDigestHash(string(hash))
+	if err != nil {
+		return err
+	}
+
+	h, err := getDigest(algorithm)
+	if err != nil {
+		return err
+	}
+
+	input := strings.NewReplacer("{SALT}", string(salt), "{PASSWORD}", string(password)).Replace(format)
+	_, _ = h.Write([]byte(input))
+
+	if subtle.ConstantTimeCompare(hash, h.Sum(nil)) == 1 {
+		return nil
+	}
+	return errors.WithStack(ErrMismatchedHashAndPassword)
+}
+
+// CompareScrypt compares the password with a scrypt hash.
+// format: $scrypt$ln=<cost exponent>,r=<block size>,p=<parallelism>$<salt>$<hash>
+func CompareScrypt(_ context.Context, password []byte, hash []byte) error {
+	p, salt, hash, err := decodeScryptHash(string(hash), 5)
+	if err != nil {
+		return err
+	}
+
+	otherHash, err := scrypt.Key(password, salt, 1<<p.CostExponent, p.BlockSize, p.Parallelism, len(hash))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if subtle.ConstantTimeCompare(hash, otherHash) == 1 {
+		return nil
+	}
+	return errors.WithStack(ErrMismatchedHashAndPassword)
+}
+
+// CompareFirebaseScrypt compares the password with a hash exported from Firebase Authentication.
+// format: $firescrypt$ln=<memory cost>,r=<rounds>,p=<parallelism>$<salt>$<hash>$<salt separator>$<signer key>
+//
+// The memory cost, rounds, salt separator and signer key are the hash parameters of the Firebase project.
+func CompareFirebaseScrypt(_ context.Context, password []byte, hash []byte) error {
+	p, salt, hash, saltSeparator, signerKey, err := decodeFirebaseScryptHash(string(hash))
+	if err != nil {
+		return err
+	}
+
+	key, err := scrypt.Key(password, append(salt, saltSeparator...), 1<<p.CostExponent, p.BlockSize, p.Parallelism, 32)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	otherHash := make([]byte, len(signerKey))
+	cipher.NewCTR(block, make([]byte, aes.BlockSize)).XORKeyStream(otherHash, signerKey)
+
+	if subtle.ConstantTimeCompare(hash, otherHash) == 1 {
+		return nil
+	}
+	return errors.WithStack(ErrMismatchedHashAndPassword)
+}
+
+// ComparePHPass compares the password with a portable PHPass hash as used by WordPress, phpBB and Drupal.
+// format: $P$<cost><salt><hash>
+func ComparePHPass(_ context.Context, password []byte, hash []byte) error {
+	rounds, err := decodePHPassRounds(hash)
+	if err != nil {
+		return err
+	}
+
+	/* #nosec G401 PHPass is a legacy format which is only supported for migrating users */
+	sum := md5.Sum(append(hash[4:12:12], password...))
+	for count := 1 << rounds; count > 0; count-- {
+		/* #nosec G401 PHPass is a legacy format which is only supported for migrating users */
+		sum = md5.Sum(append(sum[:], password...))
+	}
+
+	otherHash := append(hash[:12:12], encodePHPassBase64(sum[:])...)
+	if subtle.ConstantTimeCompare(hash, otherHash) == 1 {
+		return nil
+	}
+	return errors.WithStack(ErrMismatchedHashAndPassword)
+}
+
 var (
-	isBcryptHash   = regexp.MustCompile(`^\$2[abzy]?\$`)
-	isArgon2idHash = regexp.MustCompile(`^\$argon2id\$`)
-	isPbkdf2Hash   = regexp.MustCompile(`^\$pbkdf2-sha[0-9]{1,3}\$`)
+	isBcryptHash         = regexp.MustCompile(`^\$2[abzy]?\$`)
+	isArgon2idHash       = regexp.MustCompile(`^\$argon2id\$`)
+	isPbkdf2Hash         = regexp.MustCompile(`^\$pbkdf2-sha[0-9]{1,3}\$`)
+	isDigestHash         = regexp.MustCompile(`^\$(md5|sha1|sha256|sha512)\$`)
+	isScryptHash         = regexp.MustCompile(`^\$scrypt\$`)
+	isFirebaseScryptHash = regexp.MustCompile(`^\$firescrypt\$`)
+	isPHPassHash         = regexp.MustCompile(`^\$[PH]\$`)
 )
 
+// ValidateHash returns an error if the hash does not use one of the formats which can be compared, or if it can
+// not be decoded, for example because its parameters exceed the supported bounds. Use it to validate hashes which
+// are imported, as IsValidHashFormat only checks the prefix of the hash.
+func ValidateHash(hash []byte) error {
+	var err error
+	switch {
+	case IsBcryptHash(hash):
+		_, err = bcrypt.Cost(hash)
+	case IsArgon2idHash(hash):
+		_, _, _, err = decodeArgon2idHash(string(hash))
+	case IsPbkdf2Hash(hash):
+		_, _, _, err = decodePbkdf2Hash(string(hash))
+	case IsDigestHash(hash):
+		var algorithm string
+		if algorithm, _, _, _, err = decodeDigestHash(string(hash)); err == nil {
+			_, err = getDigest(algorithm)
+		}
+	case IsScryptHash(hash):
+		_, _, _, err = decodeScryptHash(string(hash), 5)
+	case IsFirebaseScryptHash(hash):
+		_, _, _, _, _, err = decodeFirebaseScryptHash(string(hash))
+	case IsPHPassHash(hash):
+		_, err = decodePHPassRounds(hash)
+	default:
+		return errors.WithStack(ErrUnknownHashAlgorithm)
+	}
+	if err != nil {
+		return errors.WithStack(ErrInvalidHash)
+	}
+	return nil
+}
+
+// IsValidHashFormat returns whether the hash uses one of the formats which can be compared.
+func IsValidHashFormat(hash []byte) bool {
+	return IsBcryptHash(hash) ||
+		IsArgon2idHash(hash) ||
+		IsPbkdf2Hash(hash) ||
+		IsDigestHash(hash) ||
+		IsScryptHash(hash) ||
+		IsFirebaseScryptHash(hash) ||
+		IsPHPassHash(hash)
+}
+
 func IsBcryptHash(hash []byte) bool {
 	return isBcryptHash.Match(hash)
 }
@@ -102,6 +260,22 @@ func IsPbkdf2Hash(hash []byte) bool {
 	return isPbkdf2Hash.Match(hash)
 }
 
+func IsDigestHash(hash []byte) bool {
+	return isDigestHash.Match(hash)
+}
+
+func IsScryptHash(hash []byte) bool {
+	return isScryptHash.Match(hash)
+}
+
+func IsFirebaseScryptHash(hash []byte) bool {
+	return isFirebaseScryptHash.Match(hash)
+}
+
+func IsPHPassHash(hash []byte) bool {
+	return isPHPassHash.Match(hash)
+}
+
 func decodeArgon2idHash(encodedHash string) (p *config.Argon2, salt, hash []byte, err error) {
 	parts := strings.Split(encodedHash, "$")
 	if len(parts) != 6 {
@@ -172,3 +346,177 @@ func decodePbkdf2Hash(encodedHash string) (p *Pbkdf2, salt, hash []byte, err err
 
 	return p, salt, hash, nil
 }
+
+// decodeDigestHash decodes a salted message digest.
+// format: $<digest>$[pf=<format>$]<salt>$<hash>
+func decodeDigestHash(encodedHash string) (algorithm, format string, salt, hash []byte, err error) {
+	parts := strings.Split(encodedHash, "$")
+	format = "{SALT}{PASSWORD}"
+	switch {
+	case len(parts) == 4:
+	case len(parts) == 5 && strings.HasPrefix(parts[2], "pf="):
+		f, err := decodeBase64(strings.TrimPrefix(parts[2], "pf="))
+		if err != nil {
+			return "", "", nil, nil, err
+		}
+		format = string(f)
+		parts = append(parts[:2], parts[3:]...)
+	default:
+		return "", "", nil, nil, ErrInvalidHash
+	}
+
+	salt, err = decodeBase64(parts[2])
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+
+	hash, err = decodeBase64(parts[3])
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+
+	return parts[1], format, salt, hash, nil
+}
+
+// The bounds of scrypt parameters. Hashes are imported by administrators, but are compared whenever the user
+// signs in, which is why the parameters must not make the comparison allocate unbounded memory.
+const (
+	scryptMaxCostExponent = 20
+	scryptMaxBlockSize    = 32
+	scryptMaxParallelism  = 16
+	scryptMaxMemory       = 256 << 20
+	scryptMaxKeyLength    = 128
+)
+
+type scryptParameters struct {
+	CostExponent int
+	BlockSize    int
+	Parallelism  int
+}
+
+// decodeScryptHash decodes the parameters, salt and hash of scrypt and Firebase scrypt hashes.
+// format: $<scrypt|firescrypt>$ln=<cost exponent>,r=<block size>,p=<parallelism>$<salt>$<hash>[$...]
+func decodeScryptHash(encodedHash string, expectedParts int) (p *scryptParameters, salt, hash []byte, err error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != expectedParts {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	p = new(scryptParameters)
+	_, err = fmt.Sscanf(parts[2], "ln=%d,r=%d,p=%d", &p.CostExponent, &p.BlockSize, &p.Parallelism)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if p.CostExponent < 1 || p.CostExponent > scryptMaxCostExponent ||
+		p.BlockSize < 1 || p.BlockSize > scryptMaxBlockSize ||
+		p.Parallelism < 1 || p.Parallelism > scryptMaxParallelism ||
+		128*(1<<p.CostExponent)*p.BlockSize > scryptMaxMemory {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	salt, err = decodeBase64(parts[3])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	hash, err = decodeBase64(parts[4])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(hash) == 0 || len(hash) > scryptMaxKeyLength {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	return p, salt, hash, nil
+}
+
+// decodeFirebaseScryptHash decodes the parameters, salt, hash, salt separator and signer key of a Firebase
+// scrypt hash.
+// format: $firescrypt$ln=<memory cost>,r=<rounds>,p=<parallelism>$<salt>$<hash>$<salt separator>$<signer key>
+func decodeFirebaseScryptHash(encodedHash string) (p *scryptParameters, salt, hash, saltSeparator, signerKey []byte, err error) {
+	p, salt, hash, err = decodeScryptHash(encodedHash, 7)
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+
+	parts := strings.Split(encodedHash, "$")
+	saltSeparator, err = decodeBase64(parts[5])
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+
+	signerKey, err = decodeBase64(parts[6])
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+	if len(signerKey) == 0 || len(signerKey) > scryptMaxKeyLength {
+		return nil, nil, nil, nil, nil, ErrInvalidHash
+	}
+
+	return p, salt, hash, saltSeparator, signerKey, nil
+}
+
+// decodePHPassRounds returns the base-2 logarithm of the number of rounds of a portable PHPass hash.
+func decodePHPassRounds(hash []byte) (int, error) {
+	if len(hash) != 34 {
+		return 0, errors.WithStack(ErrInvalidHash)
+	}
+
+	rounds := strings.IndexByte(phpassItoa64, hash[3])
+	if rounds < 7 || rounds > 30 {
+		return 0, errors.WithStack(ErrInvalidHash)
+	}
+	return rounds, nil
+}
+
+// decodeBase64 decodes standard base64 with or without padding, as legacy systems use both.
+func decodeBase64(encoded string) ([]byte, error) {
+	return base64.RawStdEncoding.Strict().DecodeString(strings.TrimRight(encoded, "="))
+}
+
+func getDigest(algorithm string) (gohash.Hash, error) {
+	switch algorithm {
+	case "md5":
+		/* #nosec G401 MD5 is only supported for migrating users from legacy systems */
+		return md5.New(), nil
+	case "sha1":
+		/* #nosec G401 SHA-1 is only supported for migrating users from legacy systems */
+		return sha1.New(), nil
+	case "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	default:
+		return nil, errors.WithStack(ErrUnknownHashAlgorithm)
+	}
+}
+
+const phpassItoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// encodePHPassBase64 implements the custom base64 encoding of PHPass.
+func encodePHPassBase64(src []byte) []byte {
+	var out []byte
+	for i := 0; i < len(src); {
+		value := int(src[i])
+		i++
+		out = append(out, phpassItoa64[value&0x3f])
+		if i < len(src) {
+			value |= int(src[i]) << 8
+		}
+		out = append(out, phpassItoa64[(value>>6)&0x3f])
+		if i >= len(src) {
+			break
+		}
+		i++
+		if i < len(src) {
+			value |= int(src[i]) << 16
+		}
+		out = append(out, phpassItoa64[(value>>12)&0x3f])
+		if i >= len(src) {
+			break
+		}
+		i++
+		out = append(out, phpassItoa64[(value>>18)&0x3f])
+	}
+	return out
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -227,4 +228,99 @@ func TestCompare(t *testing.T) {
 	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$pbkdf2-sha256$i=100000,l=32$1jP+5Zxpxgtee/iPxGgOz0RfE9/KJuDElP1ley4VxXcc$QJxzfvdbHYBpydCbHoFg3GJEqMFULwskiuqiJctoYpI")))
 	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$pbkdf2-sha256$i=100000,l=32$1jP+5Zxpxgtee/iPxGgOz0RfE9/KJuDElP1ley4VxXc$QJxzfvdbHYBpydCbHoFg3GJEqMFULwskiuqiJctoYpII")))
 	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$pbkdf2-sha512$I=100000,l=32$bdHBpn7OWOivJMVJypy2UqR0UnaD5prQXRZevj/05YU$+wArTfv1a+bNGO1iZrmEdVjhA+lL11wF4/IxpgYfPwc")))
+
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$md5$a28wbkdMOHQ=$ah3/7dvGaxRf+lHW5k/eWg==")))
+	assert.Nil(t, hash.CompareDigest(context.Background(), []byte("test"), []byte("$md5$a28wbkdMOHQ=$ah3/7dvGaxRf+lHW5k/eWg==")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$md5$a28wbkdMOHQ=$ah3/7dvGaxRf+lHW5k/eWw==")))
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$md5$$CY9rzUYh03PK3k6DJie09g==")))
+
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$sha1$a28wbkdMOHQ=$3SzekVCjgh7LdlyYnj67auMBmDg=")))
+	assert.Nil(t, hash.CompareDigest(context.Background(), []byte("test"), []byte("$sha1$a28wbkdMOHQ=$3SzekVCjgh7LdlyYnj67auMBmDg=")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$sha1$a28wbkdMOHR=$3SzekVCjgh7LdlyYnj67auMBmDg=")))
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$sha1$pf=e1BBU1NXT1JEfXtTQUxUfQ==$a28wbkdMOHQ=$dPr5nKHcqicYrnwrASWFCZk3eTc=")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$sha1$a28wbkdMOHQ=$dPr5nKHcqicYrnwrASWFCZk3eTc=")))
+
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$sha256$a28wbkdMOHQ=$OHBXAzDERsN4HYbczn0EWVlgCNCE6w2MzKUG0HT8E20=")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$sha256$a28wbkdMOHQ=$OHBXAzDERsN4HYbczn0EWVlgCNCE6w2MzKUG0HT8E21=")))
+
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$sha512$a28wbkdMOHQ=$K/WHbPlotitO+kr+3a02OShi/yA8cE1BTpmuTBlQa7MCWk1ZPGFxhJuA7dTHV+9nLLQPPbMy7aMLXmE0LDktsQ==")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$sha512$a28wbkdMOHQ=$K/WHbPlotitO+kr+3a02OShi/yA8cE1BTpmuTBlQa7MCWk1ZPGFxhJuA7dTHV+9nLLQPPbMy7aMLXmE0LDktsR==")))
+
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$sha256$pf=e1BBU1NXT1JEfQ==$a28wbkdMOHQ=")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$sha256$a28wbkdMOHQ=$$OHBXAzDERsN4HYbczn0EWVlgCNCE6w2MzKUG0HT8E20=")))
+
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$scrypt$ln=10,r=8,p=1$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzI")))
+	assert.Nil(t, hash.CompareScrypt(context.Background(), []byte("test"), []byte("$scrypt$ln=10,r=8,p=1$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzI")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$scrypt$ln=10,r=8,p=1$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzJ")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$scrypt$ln=11,r=8,p=1$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzI")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$scrypt$ln=64,r=8,p=1$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzI")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$scrypt$n=10,r=8,p=1$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzI")))
+
+	// Example from https://github.com/firebase/scrypt
+	firebaseHash := "$firescrypt$ln=14,r=8,p=1$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ==$Bw==$jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA=="
+	assert.Nil(t, hash.Compare(context.Background(), []byte("user1password"), []byte(firebaseHash)))
+	assert.Nil(t, hash.CompareFirebaseScrypt(context.Background(), []byte("user1password"), []byte(firebaseHash)))
+	assert.Error(t, hash.Compare(context.Background(), []byte("user2password"), []byte(firebaseHash)))
+	assert.Error(t, hash.Compare(context.Background(), []byte("user1password"), []byte("$firescrypt$ln=14,r=8,p=1$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ==$Bw==")))
+
+	// Example from the PHPass test suite
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test12345"), []byte("$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0")))
+	assert.Nil(t, hash.ComparePHPass(context.Background(), []byte("test12345"), []byte("$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test12346"), []byte("$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test12345"), []byte("$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test12345"), []byte("$P$.IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0")))
+}
+
+func TestIsValidHashFormat(t *testing.T) {
+	for _, h := range []string{
+		"$2a$12$o6hx.Wog/wvFSkT/Bp/6DOxCtLRTDj7lm9on9suF/WaCGNVHbkfL6",
+		"$argon2id$v=19$m=32,t=2,p=4$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw",
+		"$pbkdf2-sha256$i=100000,l=32$1jP+5Zxpxgtee/iPxGgOz0RfE9/KJuDElP1ley4VxXc$QJxzfvdbHYBpydCbHoFg3GJEqMFULwskiuqiJctoYpI",
+		"$md5$a28wbkdMOHQ=$ah3/7dvGaxRf+lHW5k/eWg==",
+		"$sha256$a28wbkdMOHQ=$OHBXAzDERsN4HYbczn0EWVlgCNCE6w2MzKUG0HT8E20=",
+		"$scrypt$ln=10,r=8,p=1$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzI",
+		"$firescrypt$ln=14,r=8,p=1$42xEC+ixf3L2lw==$lSrfV15cpx95$Bw==$jxspr8Ki0RYycVU8",
+		"$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0",
+		"$H$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0",
+	} {
+		assert.True(t, hash.IsValidHashFormat([]byte(h)), h)
+	}
+
+	for _, h := range []string{"", "plaintext", "{SHA}fEqNCco3Yq9h5ZUglD3CZJT4lBs=", "$sha3$a28wbkdMOHQ=$ah3/7dvGaxRf+lHW5k/eWg=="} {
+		assert.False(t, hash.IsValidHashFormat([]byte(h)), h)
+	}
+}
+
+func TestValidateHash(t *testing.T) {
+	for _, h := range []string{
+		"$2a$12$o6hx.Wog/wvFSkT/Bp/6DOxCtLRTDj7lm9on9suF/WaCGNVHbkfL6",
+		"$argon2id$v=19$m=32,t=2,p=4$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw",
+		"$pbkdf2-sha256$i=100000,l=32$1jP+5Zxpxgtee/iPxGgOz0RfE9/KJuDElP1ley4VxXc$QJxzfvdbHYBpydCbHoFg3GJEqMFULwskiuqiJctoYpI",
+		"$md5$a28wbkdMOHQ=$ah3/7dvGaxRf+lHW5k/eWg==",
+		"$scrypt$ln=10,r=8,p=1$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzI",
+		"$firescrypt$ln=14,r=8,p=1$42xEC+ixf3L2lw==$lSrfV15cpx95$Bw==$jxspr8Ki0RYycVU8",
+		"$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0",
+	} {
+		assert.NoError(t, hash.ValidateHash([]byte(h)), h)
+	}
+
+	for _, h := range []string{
+		"",
+		"plaintext",
+		"$2a$12$o6hx",
+		"$argon2id$v=19$m=32,t=2,p=4$cm94YnRVOW5jZzFzcVE4bQ",
+		"$pbkdf2-sha256$i=100000,l=32$not base64$QJxzfvdbHYBpydCbHoFg3GJEqMFULwskiuqiJctoYpI",
+		"$md4$a28wbkdMOHQ=$ah3/7dvGaxRf+lHW5k/eWg==",
+		"$scrypt$ln=10,r=8,p=1$c2FsdHNhbHQ",
+		"$scrypt$ln=21,r=8,p=1$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzI",
+		"$scrypt$ln=10,r=1048576,p=1$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzI",
+		"$scrypt$ln=10,r=8,p=1073741823$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzI",
+		"$scrypt$ln=20,r=32,p=1$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzI",
+		"$scrypt$ln=10,r=8,p=1$c2FsdHNhbHQ$" + strings.Repeat("A", 256),
+		"$firescrypt$ln=14,r=1048576,p=1$42xEC+ixf3L2lw==$lSrfV15cpx95$Bw==$jxspr8Ki0RYycVU8",
+		"$firescrypt$ln=14,r=8,p=1$42xEC+ixf3L2lw==$lSrfV15cpx95$Bw==",
+		"$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L",
+	} {
+		assert.Error(t, hash.ValidateHash([]byte(h)), h)
+	}
 }
@@ -1,14 +1,46 @@
 package identity
 
+// CompletedAuthenticationMethod is a credentials type which was used to authenticate.
+//
+// Some credentials, such as WebAuthn passkeys, can be used both as first and second factor. If
+// such a credential was used on its own, AAL contains the assurance level the credential asserted.
+type CompletedAuthenticationMethod struct {
+	Method CredentialsType
+	AAL    AuthenticatorAssuranceLevel
+}
+
 func DetermineAAL(cts []CredentialsType) AuthenticatorAssuranceLevel {
+	methods := make([]CompletedAuthenticationMethod, len(cts))
+	for k := range cts {
+		methods[k] = CompletedAuthenticationMethod{Method: cts[k]}
+	}
+	return DetermineAALFromMethods(methods)
+}
+
+func DetermineAALFromMethods(methods []CompletedAuthenticationMethod) AuthenticatorAssuranceLevel {
 	aal := NoAuthenticatorAssuranceLevel
 
 	var firstFactor bool
 	var secondFactor bool
-	for _, a := range cts {
-		switch a {
+	for _, m := range methods {
+		switch m.AAL {
+		case AuthenticatorAssuranceLevel1:
+			// For example a WebAuthn passkey used without user verification.
+			firstFactor = true
+			continue
+		case AuthenticatorAssuranceLevel2:
+			// For example a WebAuthn passkey which verified the user (e.g. PIN or biometrics),
+			// which makes it a multi-factor authenticator.
+			firstFactor = true
+			secondFactor = true
+			continue
+		}
+
+		switch m.Method {
 		case CredentialsTypeRecoveryLink:
 			fallthrough
+		case CredentialsTypeMagicLink:
+			fallthrough
 		case CredentialsTypeOIDC:
 			fallthrough
 		case "v0.6_legacy_session":
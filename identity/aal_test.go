@@ -32,6 +32,11 @@ func TestDetermineAAL(t *testing.T) {
 			methods:  []CredentialsType{CredentialsTypeRecoveryLink},
 			expected: AuthenticatorAssuranceLevel1,
 		},
+		{
+			d:        "magic link is aal1",
+			methods:  []CredentialsType{CredentialsTypeMagicLink},
+			expected: AuthenticatorAssuranceLevel1,
+		},
 		{
 			d:        "legacy is aal1",
 			methods:  []CredentialsType{"v0.6_legacy_session"},
@@ -113,3 +118,47 @@ func TestDetermineAAL(t *testing.T) {
 		})
 	}
 }
+
+func TestDetermineAALFromMethods(t *testing.T) {
+	for _, tc := range []struct {
+		d        string
+		methods  []CompletedAuthenticationMethod
+		expected AuthenticatorAssuranceLevel
+	}{
+		{
+			d:        "webauthn as second factor only is aal0",
+			methods:  []CompletedAuthenticationMethod{{Method: CredentialsTypeWebAuthn}},
+			expected: NoAuthenticatorAssuranceLevel,
+		},
+		{
+			d:        "passwordless webauthn without user verification is aal1",
+			methods:  []CompletedAuthenticationMethod{{Method: CredentialsTypeWebAuthn, AAL: AuthenticatorAssuranceLevel1}},
+			expected: AuthenticatorAssuranceLevel1,
+		},
+		{
+			d:        "passwordless webauthn with user verification is aal2",
+			methods:  []CompletedAuthenticationMethod{{Method: CredentialsTypeWebAuthn, AAL: AuthenticatorAssuranceLevel2}},
+			expected: AuthenticatorAssuranceLevel2,
+		},
+		{
+			d: "passwordless webauthn without user verification + totp is aal2",
+			methods: []CompletedAuthenticationMethod{
+				{Method: CredentialsTypeWebAuthn, AAL: AuthenticatorAssuranceLevel1},
+				{Method: CredentialsTypeTOTP},
+			},
+			expected: AuthenticatorAssuranceLevel2,
+		},
+		{
+			d: "password + webauthn is aal2",
+			methods: []CompletedAuthenticationMethod{
+				{Method: CredentialsTypePassword},
+				{Method: CredentialsTypeWebAuthn},
+			},
+			expected: AuthenticatorAssuranceLevel2,
+		},
+	} {
+		t.Run("case="+tc.d, func(t *testing.T) {
+			assert.Equal(t, tc.expected, DetermineAALFromMethods(tc.methods))
+		})
+	}
+}
@@ -55,6 +55,10 @@ const (
 	// CredentialsTypeRecoveryLink is a special credential type linked to the link strategy (recovery flow).
 	// It is not used within the credentials object itself.
 	CredentialsTypeRecoveryLink CredentialsType = "link_recovery"
+
+	// CredentialsTypeMagicLink is a special credential type linked to the link strategy (login flow).
+	// It is not used within the credentials object itself.
+	CredentialsTypeMagicLink CredentialsType = "magic_link"
 )
 
 // Credentials represents a specific credential type
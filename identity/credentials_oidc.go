@@ -0,0 +1,26 @@
+package identity
+
+import "fmt"
+
+// CredentialsOIDC is the configuration of credentials of the type oidc.
+//
+// swagger:model identityCredentialsOidc
+type CredentialsOIDC struct {
+	Providers []CredentialsOIDCProvider `json:"providers"`
+}
+
+// CredentialsOIDCProvider links an identity to the subject of an OpenID Connect provider.
+//
+// swagger:model identityCredentialsOidcProvider
+type CredentialsOIDCProvider struct {
+	Subject             string `json:"subject"`
+	Provider            string `json:"provider"`
+	InitialIDToken      string `json:"initial_id_token"`
+	InitialAccessToken  string `json:"initial_access_token"`
+	InitialRefreshToken string `json:"initial_refresh_token"`
+}
+
+// OIDCUniqueID returns the credentials identifier of the given provider and subject.
+func OIDCUniqueID(provider, subject string) string {
+	return fmt.Sprintf("%s:%s", provider, subject)
+}
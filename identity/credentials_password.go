@@ -0,0 +1,9 @@
+package identity
+
+// CredentialsPassword is the configuration of credentials of the type password.
+//
+// swagger:model identityCredentialsPassword
+type CredentialsPassword struct {
+	// HashedPassword is a hash-representation of the password.
+	HashedPassword string `json:"hashed_password"`
+}
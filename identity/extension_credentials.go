@@ -13,16 +13,17 @@ import (
 )
 
 type SchemaExtensionCredentials struct {
-	i *Identity
-	v []string
-	l sync.Mutex
+	i           *Identity
+	v           []string
+	phoneRegion string
+	l           sync.Mutex
 }
 
-func NewSchemaExtensionCredentials(i *Identity) *SchemaExtensionCredentials {
-	return &SchemaExtensionCredentials{i: i}
+func NewSchemaExtensionCredentials(i *Identity, phoneRegion string) *SchemaExtensionCredentials {
+	return &SchemaExtensionCredentials{i: i, phoneRegion: phoneRegion}
 }
 
-func (r *SchemaExtensionCredentials) Run(_ jsonschema.ValidationContext, s schema.ExtensionConfig, value interface{}) error {
+func (r *SchemaExtensionCredentials) Run(ctx jsonschema.ValidationContext, s schema.ExtensionConfig, value interface{}) error {
 	r.l.Lock()
 	defer r.l.Unlock()
 	if s.Credentials.Password.Identifier {
@@ -35,7 +36,18 @@ func (r *SchemaExtensionCredentials) Run(_ jsonschema.ValidationContext, s schem
 			}
 		}
 
-		r.v = stringslice.Unique(append(r.v, strings.ToLower(fmt.Sprintf("%s", value))))
+		identifier := strings.ToLower(fmt.Sprintf("%s", value))
+		if s.Credentials.Password.IdentifierType == AddressTypePhone {
+			// Phone numbers are stored in E.164 format so that different notations of
+			// the same number resolve to the same identity.
+			phone, err := NormalizePhoneNumber(identifier, r.phoneRegion)
+			if err != nil {
+				return ctx.Error("format", "%q is not valid %q", value, "phone")
+			}
+			identifier = phone
+		}
+
+		r.v = stringslice.Unique(append(r.v, identifier))
 		cred.Identifiers = r.v
 		r.i.SetCredentials(CredentialsTypePassword, *cred)
 	}
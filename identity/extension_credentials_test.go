@@ -2,6 +2,7 @@ package identity_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -22,6 +23,7 @@ func TestSchemaExtensionCredentials(t *testing.T) {
 		doc       string
 		expect    []string
 		existing  *identity.Credentials
+		region    string
 	}{
 		{
 			doc:    `{"email":"foo@ory.sh"}`,
@@ -41,6 +43,23 @@ func TestSchemaExtensionCredentials(t *testing.T) {
 				Identifiers: []string{"not-foo@ory.sh"},
 			},
 		},
+		{
+			doc:    `{"phone":"0170 1234567", "username": "01701234567"}`,
+			schema: "file://./stub/extension/credentials/phone.schema.json",
+			expect: []string{"+491701234567", "01701234567"},
+			region: "DE",
+		},
+		{
+			doc:       `{"phone":"0170 1234567"}`,
+			schema:    "file://./stub/extension/credentials/phone.schema.json",
+			expectErr: errors.New("I[#/phone] S[#/properties/phone/format] \"0170 1234567\" is not valid \"phone\""),
+		},
+		{
+			doc:    `{"phone":"0170 1234567", "mobile": "0170 7654321"}`,
+			schema: "file://./stub/extension/credentials/phone-identifier.schema.json",
+			expect: []string{"+491701234567", "0170 7654321"},
+			region: "DE",
+		},
 	} {
 		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
 			c := jsonschema.NewCompiler()
@@ -48,7 +67,7 @@ func TestSchemaExtensionCredentials(t *testing.T) {
 			require.NoError(t, err)
 
 			i := new(identity.Identity)
-			e := identity.NewSchemaExtensionCredentials(i)
+			e := identity.NewSchemaExtensionCredentials(i, tc.region)
 			if tc.existing != nil {
 				i.SetCredentials(identity.CredentialsTypePassword, *tc.existing)
 			}
@@ -57,6 +76,7 @@ func TestSchemaExtensionCredentials(t *testing.T) {
 			err = c.MustCompile(tc.schema).Validate(bytes.NewBufferString(tc.doc))
 			if tc.expectErr != nil {
 				require.EqualError(t, err, tc.expectErr.Error())
+				return
 			}
 			require.NoError(t, e.Finish())
 
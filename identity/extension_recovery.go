@@ -10,13 +10,14 @@ import (
 )
 
 type SchemaExtensionRecovery struct {
-	l sync.Mutex
-	v []RecoveryAddress
-	i *Identity
+	phoneRegion string
+	l           sync.Mutex
+	v           []RecoveryAddress
+	i           *Identity
 }
 
-func NewSchemaExtensionRecovery(i *Identity) *SchemaExtensionRecovery {
-	return &SchemaExtensionRecovery{i: i}
+func NewSchemaExtensionRecovery(i *Identity, phoneRegion string) *SchemaExtensionRecovery {
+	return &SchemaExtensionRecovery{i: i, phoneRegion: phoneRegion}
 }
 
 func (r *SchemaExtensionRecovery) Run(ctx jsonschema.ValidationContext, s schema.ExtensionConfig, value interface{}) error {
@@ -29,18 +30,16 @@ func (r *SchemaExtensionRecovery) Run(ctx jsonschema.ValidationContext, s schema
 			return ctx.Error("format", "%q is not valid %q", value, "email")
 		}
 
-		address := NewRecoveryEmailAddress(fmt.Sprintf("%s", value), r.i.ID)
+		r.appendAddress(NewRecoveryEmailAddress(fmt.Sprintf("%s", value), r.i.ID))
 
-		if has := r.has(r.i.RecoveryAddresses, address); has != nil {
-			if r.has(r.v, address) == nil {
-				r.v = append(r.v, *has)
-			}
-			return nil
+		return nil
+	case "phone":
+		phone, err := NormalizePhoneNumber(fmt.Sprintf("%s", value), r.phoneRegion)
+		if err != nil {
+			return ctx.Error("format", "%q is not valid %q", value, "phone")
 		}
 
-		if has := r.has(r.v, address); has == nil {
-			r.v = append(r.v, *address)
-		}
+		r.appendAddress(NewRecoveryPhoneAddress(phone, r.i.ID))
 
 		return nil
 	case "":
@@ -50,9 +49,29 @@ func (r *SchemaExtensionRecovery) Run(ctx jsonschema.ValidationContext, s schema
 	return ctx.Error("", "recovery.via has unknown value %q", s.Recovery.Via)
 }
 
+func (r *SchemaExtensionRecovery) appendAddress(address *RecoveryAddress) {
+	if has := r.has(r.i.RecoveryAddresses, address); has != nil {
+		if r.has(r.v, address) == nil {
+			// Addresses stored before phone numbers were normalized keep their ID but use the normalized value.
+			has.Value = address.Value
+			r.v = append(r.v, *has)
+		}
+		return
+	}
+
+	if has := r.has(r.v, address); has == nil {
+		r.v = append(r.v, *address)
+	}
+}
+
 func (r *SchemaExtensionRecovery) has(haystack []RecoveryAddress, needle *RecoveryAddress) *RecoveryAddress {
 	for _, has := range haystack {
-		if has.Value == needle.Value && has.Via == needle.Via {
+		value := has.Value
+		if has.Via == RecoveryAddressTypePhone {
+			value = normalizePhoneNumberOrSelf(value, r.phoneRegion)
+		}
+
+		if value == needle.Value && has.Via == needle.Via {
 			return &has
 		}
 	}
@@ -25,6 +25,7 @@ func TestSchemaExtensionRecovery(t *testing.T) {
 		doc       string
 		expect    []RecoveryAddress
 		existing  []RecoveryAddress
+		region    string
 	}{
 		{
 			doc:    `{"username":"foo@ory.sh"}`,
@@ -137,6 +138,44 @@ func TestSchemaExtensionRecovery(t *testing.T) {
 				},
 			},
 		},
+		{
+			doc:    `{"phone":"0170 1234567"}`,
+			schema: "file://./stub/extension/recovery/phone.schema.json",
+			region: "DE",
+			expect: []RecoveryAddress{
+				{
+					Value:      "+491701234567",
+					Via:        RecoveryAddressTypePhone,
+					IdentityID: iid,
+				},
+			},
+		},
+		{
+			doc:    `{"phone":"+49 170 1234567"}`,
+			schema: "file://./stub/extension/recovery/phone.schema.json",
+			region: "DE",
+			existing: []RecoveryAddress{
+				{
+					ID:         iid,
+					Value:      "01701234567",
+					Via:        RecoveryAddressTypePhone,
+					IdentityID: iid,
+				},
+			},
+			expect: []RecoveryAddress{
+				{
+					ID:         iid,
+					Value:      "+491701234567",
+					Via:        RecoveryAddressTypePhone,
+					IdentityID: iid,
+				},
+			},
+		},
+		{
+			doc:       `{"phone":"01701234567"}`,
+			schema:    "file://./stub/extension/recovery/phone.schema.json",
+			expectErr: errors.New("I[#/phone] S[#/properties/phone/format] \"01701234567\" is not valid \"phone\""),
+		},
 	} {
 		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
 			id := &Identity{ID: iid, RecoveryAddresses: tc.existing}
@@ -144,7 +183,7 @@ func TestSchemaExtensionRecovery(t *testing.T) {
 			runner, err := schema.NewExtensionRunner()
 			require.NoError(t, err)
 
-			e := NewSchemaExtensionRecovery(id)
+			e := NewSchemaExtensionRecovery(id, tc.region)
 			runner.AddRunner(e).Register(c)
 
 			err = c.MustCompile(tc.schema).Validate(bytes.NewBufferString(tc.doc))
@@ -10,14 +10,15 @@ import (
 )
 
 type SchemaExtensionVerification struct {
-	lifespan time.Duration
-	l        sync.Mutex
-	v        []VerifiableAddress
-	i        *Identity
+	lifespan    time.Duration
+	phoneRegion string
+	l           sync.Mutex
+	v           []VerifiableAddress
+	i           *Identity
 }
 
-func NewSchemaExtensionVerification(i *Identity, lifespan time.Duration) *SchemaExtensionVerification {
-	return &SchemaExtensionVerification{i: i, lifespan: lifespan}
+func NewSchemaExtensionVerification(i *Identity, lifespan time.Duration, phoneRegion string) *SchemaExtensionVerification {
+	return &SchemaExtensionVerification{i: i, lifespan: lifespan, phoneRegion: phoneRegion}
 }
 
 func (r *SchemaExtensionVerification) Run(ctx jsonschema.ValidationContext, s schema.ExtensionConfig, value interface{}) error {
@@ -37,11 +38,12 @@ func (r *SchemaExtensionVerification) Run(ctx jsonschema.ValidationContext, s sc
 		return nil
 
 	case AddressTypePhone:
-		if !jsonschema.Formats["tel"](value) {
+		phone, err := NormalizePhoneNumber(fmt.Sprintf("%s", value), r.phoneRegion)
+		if err != nil {
 			return ctx.Error("format", "%q is not valid %q", value, "phone")
 		}
 
-		address := NewVerifiablePhoneAddress(fmt.Sprintf("%s", value), r.i.ID)
+		address := NewVerifiablePhoneAddress(phone, r.i.ID)
 
 		r.appendAddress(address)
 
@@ -60,21 +62,28 @@ func (r *SchemaExtensionVerification) Finish() error {
 }
 
 func (r *SchemaExtensionVerification) appendAddress(address *VerifiableAddress) {
-	if h := has(r.i.VerifiableAddresses, address); h != nil {
-		if has(r.v, address) == nil {
+	if h := r.has(r.i.VerifiableAddresses, address); h != nil {
+		if r.has(r.v, address) == nil {
+			// Addresses stored before phone numbers were normalized keep their state but use the normalized value.
+			h.Value = address.Value
 			r.v = append(r.v, *h)
 		}
 		return
 	}
 
-	if has(r.v, address) == nil {
+	if r.has(r.v, address) == nil {
 		r.v = append(r.v, *address)
 	}
 }
 
-func has(haystack []VerifiableAddress, needle *VerifiableAddress) *VerifiableAddress {
+func (r *SchemaExtensionVerification) has(haystack []VerifiableAddress, needle *VerifiableAddress) *VerifiableAddress {
 	for _, has := range haystack {
-		if has.Value == needle.Value && has.Via == needle.Via {
+		value := has.Value
+		if has.Via == VerifiableAddressTypePhone {
+			value = normalizePhoneNumberOrSelf(value, r.phoneRegion)
+		}
+
+		if value == needle.Value && has.Via == needle.Via {
 			return &has
 		}
 	}
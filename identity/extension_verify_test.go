@@ -33,6 +33,7 @@ func TestSchemaExtensionVerification(t *testing.T) {
 			doc       string
 			existing  []VerifiableAddress
 			expect    []VerifiableAddress
+			region    string
 		}{
 			{
 				name:   "email:must create new address",
@@ -324,6 +325,52 @@ func TestSchemaExtensionVerification(t *testing.T) {
 				doc:       `{"phones":["+18004444444","+18004444444","12112112"], "username": "+380634872774"}`,
 				expectErr: errors.New("I[#/phones/2] S[#/properties/phones/items/format] \"12112112\" is not valid \"phone\""),
 			},
+			{
+				name:   "phone:must normalize to E.164",
+				schema: phoneSchemaPath,
+				doc:    `{"phones":["+1 (800) 444-4444","+18004444444"], "username": "01701234567"}`,
+				region: "DE",
+				expect: []VerifiableAddress{
+					{
+						Value:      "+18004444444",
+						Verified:   false,
+						Status:     VerifiableAddressStatusPending,
+						Via:        VerifiableAddressTypePhone,
+						IdentityID: iid,
+					},
+					{
+						Value:      "+491701234567",
+						Verified:   false,
+						Status:     VerifiableAddressStatusPending,
+						Via:        VerifiableAddressTypePhone,
+						IdentityID: iid,
+					},
+				},
+			},
+			{
+				name:   "phone:must keep state of existing address stored before normalization",
+				schema: phoneSchemaPath,
+				doc:    `{"username": "+49 170 1234567"}`,
+				region: "DE",
+				existing: []VerifiableAddress{
+					{
+						Value:      "0170 1234567",
+						Verified:   true,
+						Status:     VerifiableAddressStatusCompleted,
+						Via:        VerifiableAddressTypePhone,
+						IdentityID: iid,
+					},
+				},
+				expect: []VerifiableAddress{
+					{
+						Value:      "+491701234567",
+						Verified:   true,
+						Status:     VerifiableAddressStatusCompleted,
+						Via:        VerifiableAddressTypePhone,
+						IdentityID: iid,
+					},
+				},
+			},
 		} {
 			t.Run(fmt.Sprintf("case=%v", tc.name), func(t *testing.T) {
 				id := &Identity{ID: iid, VerifiableAddresses: tc.existing}
@@ -333,7 +380,7 @@ func TestSchemaExtensionVerification(t *testing.T) {
 				runner, err := schema.NewExtensionRunner()
 				require.NoError(t, err)
 
-				e := NewSchemaExtensionVerification(id, time.Minute)
+				e := NewSchemaExtensionVerification(id, time.Minute, tc.region)
 				runner.AddRunner(e).Register(c)
 
 				err = c.MustCompile(tc.schema).Validate(bytes.NewBufferString(tc.doc))
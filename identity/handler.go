@@ -12,6 +12,7 @@ import (
 
 	"github.com/ory/herodot"
 
+	"github.com/gofrs/uuid"
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
 
@@ -20,10 +21,13 @@ import (
 	"github.com/ory/x/urlx"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/hash"
+	"github.com/ory/kratos/webhook"
 )
 
 const RouteCollection = "/identities"
 const RouteItem = RouteCollection + "/:id"
+const RouteErase = RouteItem + "/erase"
 
 type (
 	handlerDependencies interface {
@@ -34,6 +38,9 @@ type (
 		config.Provider
 		x.CSRFProvider
 		cipher.Provider
+		hash.HashProvider
+		x.LoggingProvider
+		webhook.EmitterProvider
 	}
 	HandlerProvider interface {
 		IdentityHandler() *Handler
@@ -52,21 +59,28 @@ func NewHandler(r handlerDependencies) *Handler {
 }
 
 func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
-	h.r.CSRFHandler().IgnoreGlobs(RouteCollection, RouteCollection+"/*")
+	h.r.CSRFHandler().IgnoreGlobs(RouteCollection, RouteCollection+"/*", RouteCollection+"/*/erase")
 	public.GET(RouteCollection, x.RedirectToAdminRoute(h.r))
 	public.GET(RouteItem, x.RedirectToAdminRoute(h.r))
 	public.DELETE(RouteItem, x.RedirectToAdminRoute(h.r))
+	public.DELETE(RouteErase, x.RedirectToAdminRoute(h.r))
 	public.POST(RouteCollection, x.RedirectToAdminRoute(h.r))
 	public.PUT(RouteItem, x.RedirectToAdminRoute(h.r))
+	public.PATCH(RouteItem, x.RedirectToAdminRoute(h.r))
+	public.POST(RouteImport, x.RedirectToAdminRoute(h.r))
 }
 
 func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
 	admin.GET(RouteCollection, h.list)
-	admin.GET(RouteItem, h.get)
+	admin.GET(RouteItem, h.serveExport(h.get))
 	admin.DELETE(RouteItem, h.delete)
+	admin.DELETE(RouteErase, h.erase)
 
 	admin.POST(RouteCollection, h.create)
 	admin.PUT(RouteItem, h.update)
+	admin.PATCH(RouteItem, h.patch)
+
+	admin.POST(RouteImport, h.importIdentities)
 }
 
 // A list of identities.
@@ -78,13 +92,78 @@ type identityList []Identity
 // nolint:deadcode,unused
 type adminListIdentities struct {
 	x.PaginationParams
+
+	// Keyset Pagination Token
+	//
+	// Returns the identities listed after the identity with this ID. The token of the next page is returned in the
+	// `X-Next-Page-Token` header. If set, `page` is ignored.
+	//
+	// required: false
+	// in: query
+	// format: uuid
+	PageToken string `json:"page_token"`
+
+	// Only return identities having a credential with this identifier, for example the email address
+	// used to sign in with a password.
+	//
+	// required: false
+	// in: query
+	CredentialsIdentifier string `json:"credentials_identifier"`
+
+	// Only return identities having this verifiable address.
+	//
+	// required: false
+	// in: query
+	VerifiableAddress string `json:"verifiable_address"`
+
+	// Only return identities having this recovery address.
+	//
+	// required: false
+	// in: query
+	RecoveryAddress string `json:"recovery_address"`
+
+	// Only return identities in this state.
+	//
+	// required: false
+	// in: query
+	// enum: active,inactive
+	State string `json:"state"`
+
+	// Only return identities using this identity schema.
+	//
+	// required: false
+	// in: query
+	SchemaID string `json:"schema_id"`
+
+	// Only return identities created at or after this time.
+	//
+	// required: false
+	// in: query
+	CreatedAfter time.Time `json:"created_after"`
+
+	// Only return identities created before this time.
+	//
+	// required: false
+	// in: query
+	CreatedBefore time.Time `json:"created_before"`
+
+	// Only return identities whose traits match. Each filter has the format `<path>=<value>`, where the
+	// path is a dot-separated path into the traits such as `name.first`. The filter can be repeated.
+	//
+	// required: false
+	// in: query
+	Trait []string `json:"trait"`
 }
 
 // swagger:route GET /identities v0alpha2 adminListIdentities
 //
 // List Identities
 //
-// Lists all identities. Does not support search at the moment.
+// Lists all identities matching the filters. All filters which are set must match.
+//
+// The endpoint supports offset pagination using `page` and keyset pagination using `page_token`. Keyset
+// pagination stays fast for deep pages on large tables but does not return the total count. Every full page
+// contains the token of the next page in the `X-Next-Page-Token` header.
 //
 // Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
 //
@@ -100,21 +179,91 @@ type adminListIdentities struct {
 //       200: identityList
 //       500: jsonError
 func (h *Handler) list(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	page, itemsPerPage := x.ParsePagination(r)
-	is, err := h.r.IdentityPool().ListIdentities(r.Context(), page, itemsPerPage)
+	params, err := parseListIdentitiesParameters(r)
 	if err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
-	total, err := h.r.IdentityPool().CountIdentities(r.Context())
+	is, err := h.r.IdentityPool().SearchIdentities(r.Context(), params)
 	if err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
-	x.PaginationHeader(w, urlx.AppendPaths(h.r.Config(r.Context()).SelfAdminURL(), RouteCollection), total, page, itemsPerPage)
-	h.r.Writer().Write(w, r, is)
+	var nextPageToken string
+	if len(is) == params.PerPage {
+		nextPageToken = is[len(is)-1].ID.String()
+	}
+
+	u := urlx.AppendPaths(h.r.Config(r.Context()).SelfAdminURL(), RouteCollection)
+	u.RawQuery = r.URL.Query().Encode()
+
+	if params.IsKeyset() {
+		x.TokenPaginationHeader(w, u, nextPageToken, params.PerPage)
+		h.r.Writer().Write(w, r, withAdminMetadataInJSON(is))
+		return
+	}
+
+	total, err := h.r.IdentityPool().CountSearchedIdentities(r.Context(), params)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	x.PaginationHeader(w, u, total, params.Page, params.PerPage)
+	if nextPageToken != "" {
+		w.Header().Set(x.HeaderNextPageToken, nextPageToken)
+	}
+	h.r.Writer().Write(w, r, withAdminMetadataInJSON(is))
+}
+
+func parseListIdentitiesParameters(r *http.Request) (params ListIdentitiesParameters, err error) {
+	query := r.URL.Query()
+	params.Page, params.PerPage = x.ParsePagination(r)
+	params.CredentialsIdentifier = query.Get("credentials_identifier")
+	params.VerifiableAddress = query.Get("verifiable_address")
+	params.RecoveryAddress = query.Get("recovery_address")
+	params.SchemaID = query.Get("schema_id")
+
+	if v := query.Get("page_token"); v != "" {
+		if params.PageToken, err = uuid.FromString(v); err != nil {
+			return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The page token must be a UUID: %s", err).WithWrap(err))
+		}
+	}
+
+	if v := query.Get("state"); v != "" {
+		params.State = State(v)
+		if err := params.State.IsValid(); err != nil {
+			return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf(`Unable to filter by state "%s": %s`, v, err).WithWrap(err))
+		}
+	}
+
+	for key, t := range map[string]*time.Time{"created_after": &params.CreatedAfter, "created_before": &params.CreatedBefore} {
+		if v := query.Get(key); v != "" {
+			if *t, err = time.Parse(time.RFC3339, v); err != nil {
+				return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf(`Parameter "%s" must be a RFC 3339 date-time: %s`, key, err).WithWrap(err))
+			}
+		}
+	}
+
+	for _, v := range query["trait"] {
+		f, err := NewTraitFilter(v)
+		if err != nil {
+			return params, err
+		}
+		params.Traits = append(params.Traits, f)
+	}
+
+	return params, nil
+}
+
+func withAdminMetadataInJSON(is []Identity) []WithAdminMetadataInJSON {
+	out := make([]WithAdminMetadataInJSON, len(is))
+	for k := range is {
+		out[k] = WithAdminMetadataInJSON(is[k])
+	}
+	return out
 }
 
 // swagger:parameters adminGetIdentity
@@ -178,6 +327,7 @@ func (h *Handler) get(w http.ResponseWriter, r *http.Request, ps httprouter.Para
 
 	}
 
+	w.Header().Set("ETag", etag(i))
 	h.r.Writer().Write(w, r, WithCredentialsMetadataInJSON(*i))
 }
 
@@ -202,18 +352,73 @@ type AdminCreateIdentityBody struct {
 	// required: true
 	Traits json.RawMessage `json:"traits"`
 
+	// Credentials represents all credentials that can be used for authenticating this identity.
+	//
+	// Use this structure to import credentials for a user. Password credentials can be imported as
+	// plain text or as a bcrypt, argon2id or pbkdf2 hash. OpenID Connect credentials link the identity
+	// to the subject of a provider.
+	//
+	// required: false
+	Credentials *AdminIdentityImportCredentials `json:"credentials,omitempty"`
+
+	// VerifiableAddresses contains all the addresses that can be verified by the user.
+	//
+	// Use this structure to import verified addresses for an identity. Addresses which are not
+	// represented in the identity's traits are ignored.
+	//
+	// required: false
+	VerifiableAddresses []AdminIdentityImportVerifiableAddress `json:"verifiable_addresses,omitempty"`
+
+	// RecoveryAddresses contains all the addresses that can be used to recover an identity.
+	//
+	// Use this structure to import recovery addresses for an identity. Addresses which are not
+	// represented in the identity's traits are ignored.
+	//
+	// required: false
+	RecoveryAddresses []AdminIdentityImportRecoveryAddress `json:"recovery_addresses,omitempty"`
+
 	// State is the identity's state.
 	//
 	// required: false
-	State State `json:"state"`
+	State State `json:"state,omitempty"`
+
+	// StateChangeReason describes why the identity has its state, for example `fraud` or `user request`.
+	//
+	// required: false
+	StateChangeReason string `json:"state_change_reason,omitempty"`
+
+	// InactiveAt is the time at which the identity is deactivated automatically.
+	//
+	// required: false
+	InactiveAt *time.Time `json:"inactive_at,omitempty"`
+
+	// DeleteAt is the time at which the identity is erased permanently, together with its sessions,
+	// self-service flows, tokens, and the courier messages sent to its addresses. If not set, inactive
+	// identities are scheduled to be erased after the configured deletion grace period.
+	//
+	// required: false
+	DeleteAt *time.Time `json:"delete_at,omitempty"`
+
+	// Store metadata about the identity which the identity itself can see when calling for example the
+	// session endpoint. Do not store sensitive information (e.g. credit score) about the identity in this field.
+	//
+	// required: false
+	MetadataPublic json.RawMessage `json:"metadata_public,omitempty"`
+
+	// Store metadata about the identity which the identity itself can not see when calling for example the
+	// session endpoint. Use this field to store internal IDs, plan tiers or risk flags.
+	//
+	// required: false
+	MetadataAdmin json.RawMessage `json:"metadata_admin,omitempty"`
 }
 
 // swagger:route POST /identities v0alpha2 adminCreateIdentity
 //
 // Create an Identity
 //
-// This endpoint creates an identity. It is NOT possible to set an identity's credentials (password, ...)
-// using this method! A way to achieve that will be introduced in the future.
+// This endpoint creates an identity. Credentials, such as passwords or links to OpenID Connect providers,
+// and the state of verifiable and recovery addresses can be imported as well, which is useful when
+// migrating users from another system.
 //
 // Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
 //
@@ -240,31 +445,60 @@ func (h *Handler) create(w http.ResponseWriter, r *http.Request, _ httprouter.Pa
 		return
 	}
 
-	stateChangedAt := sqlxx.NullTime(time.Now())
-	state := StateActive
-	if cr.State != "" {
-		if err := cr.State.IsValid(); err != nil {
-			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err).WithWrap(err)))
-			return
-		}
-		state = cr.State
+	i, err := h.identityFromCreateBody(r.Context(), &cr)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
 	}
-	i := &Identity{SchemaID: cr.SchemaID, Traits: []byte(cr.Traits), State: state, StateChangedAt: &stateChangedAt}
+
 	if err := h.r.IdentityManager().Create(r.Context(), i); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
+	h.emit(r.Context(), webhook.EventIdentityCreated, i)
 	h.r.Writer().WriteCreated(w, r,
 		urlx.AppendPaths(
 			h.r.Config(r.Context()).SelfAdminURL(),
 			"identities",
 			i.ID.String(),
 		).String(),
-		i,
+		WithAdminMetadataInJSON(*i),
 	)
 }
 
+// identityFromCreateBody builds the identity described by the request body, including imported credentials and
+// addresses. The identity is not validated nor persisted.
+func (h *Handler) identityFromCreateBody(ctx context.Context, cr *AdminCreateIdentityBody) (*Identity, error) {
+	stateChangedAt := sqlxx.NullTime(time.Now())
+	state := StateActive
+	if cr.State != "" {
+		if err := cr.State.IsValid(); err != nil {
+			return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err).WithWrap(err))
+		}
+		state = cr.State
+	}
+	i := &Identity{
+		SchemaID:            cr.SchemaID,
+		Traits:              []byte(cr.Traits),
+		State:               state,
+		StateChangedAt:      &stateChangedAt,
+		VerifiableAddresses: importVerifiableAddresses(cr.VerifiableAddresses),
+		RecoveryAddresses:   importRecoveryAddresses(cr.RecoveryAddresses),
+		StateChangeReason:   cr.StateChangeReason,
+		InactiveAt:          nullTime(cr.InactiveAt),
+		DeleteAt:            nullTime(cr.DeleteAt),
+		MetadataPublic:      sqlxx.NullJSONRawMessage(cr.MetadataPublic),
+		MetadataAdmin:       sqlxx.NullJSONRawMessage(cr.MetadataAdmin),
+	}
+	i.scheduleErasure(h.r.Config(ctx).IdentityDeletionGracePeriod())
+	if err := h.importCredentials(ctx, i, cr.Credentials); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
 // swagger:parameters adminUpdateIdentity
 // nolint:deadcode,unused
 type adminUpdateIdentity struct {
@@ -294,6 +528,35 @@ type AdminUpdateIdentityBody struct {
 	//
 	// required: true
 	State State `json:"state"`
+
+	// StateChangeReason describes why the identity's state is changed, for example `fraud` or `user request`.
+	//
+	// If the state does not change, the previous reason is kept unless a new reason is set.
+	StateChangeReason string `json:"state_change_reason,omitempty"`
+
+	// InactiveAt is the time at which the identity is deactivated automatically.
+	//
+	// If omitted, the scheduled deactivation is canceled.
+	InactiveAt *time.Time `json:"inactive_at,omitempty"`
+
+	// DeleteAt is the time at which the identity is erased permanently, together with its sessions,
+	// self-service flows, tokens, and the courier messages sent to its addresses.
+	//
+	// If omitted, the scheduled erasure is canceled. Identities which are deactivated by this request are
+	// scheduled to be erased after the configured deletion grace period instead.
+	DeleteAt *time.Time `json:"delete_at,omitempty"`
+
+	// Store metadata about the identity which the identity itself can see when calling for example the
+	// session endpoint. Do not store sensitive information (e.g. credit score) about the identity in this field.
+	//
+	// If omitted, the public metadata is removed.
+	MetadataPublic json.RawMessage `json:"metadata_public,omitempty"`
+
+	// Store metadata about the identity which the identity itself can not see when calling for example the
+	// session endpoint. Use this field to store internal IDs, plan tiers or risk flags.
+	//
+	// If omitted, the admin metadata is removed.
+	MetadataAdmin json.RawMessage `json:"metadata_admin,omitempty"`
 }
 
 // swagger:route PUT /identities/{id} v0alpha2 adminUpdateIdentity
@@ -303,7 +566,8 @@ type AdminUpdateIdentityBody struct {
 // This endpoint updates an identity. It is NOT possible to set an identity's credentials (password, ...)
 // using this method! A way to achieve that will be introduced in the future.
 //
-// The full identity payload (except credentials) is expected. This endpoint does not support patching.
+// The full identity payload (except credentials) is expected. To update only parts of the identity, use
+// the `PATCH /identities/{id}` endpoint instead.
 //
 // Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
 //
@@ -342,19 +606,22 @@ func (h *Handler) update(w http.ResponseWriter, r *http.Request, ps httprouter.P
 		identity.SchemaID = ur.SchemaID
 	}
 
+	identity.InactiveAt = nullTime(ur.InactiveAt)
+	identity.DeleteAt = nullTime(ur.DeleteAt)
 	if ur.State != "" && identity.State != ur.State {
 		if err := ur.State.IsValid(); err != nil {
 			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err).WithWrap(err)))
 			return
 		}
 
-		stateChangedAt := sqlxx.NullTime(time.Now())
-
-		identity.State = ur.State
-		identity.StateChangedAt = &stateChangedAt
+		identity.changeState(ur.State, ur.StateChangeReason, h.r.Config(r.Context()).IdentityDeletionGracePeriod())
+	} else if ur.StateChangeReason != "" {
+		identity.StateChangeReason = ur.StateChangeReason
 	}
 
 	identity.Traits = []byte(ur.Traits)
+	identity.MetadataPublic = sqlxx.NullJSONRawMessage(ur.MetadataPublic)
+	identity.MetadataAdmin = sqlxx.NullJSONRawMessage(ur.MetadataAdmin)
 	if err := h.r.IdentityManager().Update(
 		r.Context(),
 		identity,
@@ -364,7 +631,8 @@ func (h *Handler) update(w http.ResponseWriter, r *http.Request, ps httprouter.P
 		return
 	}
 
-	h.r.Writer().Write(w, r, identity)
+	h.emit(r.Context(), webhook.EventIdentityUpdated, identity)
+	h.r.Writer().Write(w, r, WithAdminMetadataInJSON(*identity))
 }
 
 // swagger:parameters adminDeleteIdentity
@@ -400,10 +668,89 @@ type adminDeleteIdentity struct {
 //       404: jsonError
 //       500: jsonError
 func (h *Handler) delete(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	if err := h.r.IdentityPool().(PrivilegedPool).DeleteIdentity(r.Context(), x.ParseUUID(ps.ByName("id"))); err != nil {
+	id := x.ParseUUID(ps.ByName("id"))
+	deleted, err := h.identityForDeletedEvent(r.Context(), id)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.IdentityPool().(PrivilegedPool).DeleteIdentity(r.Context(), id); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
+	if deleted != nil {
+		h.emit(r.Context(), webhook.EventIdentityDeleted, deleted)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// swagger:parameters adminEraseIdentity
+// nolint:deadcode,unused
+type adminEraseIdentity struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route DELETE /identities/{id}/erase v0alpha2 adminEraseIdentity
+//
+// Erase an Identity
+//
+// Calling this endpoint irrecoverably and permanently deletes the identity given its ID together with all data
+// belonging to it: its sessions, self-service flows and tokens, and the courier messages which were sent to its
+// verifiable and recovery addresses. Use this endpoint to erase an identity's personal data, for example when
+// the user requests it. This action can not be undone.
+//
+// Identities can also be erased at a scheduled time by setting their `delete_at` field.
+//
+// Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       204: emptyResponse
+//       404: jsonError
+//       500: jsonError
+func (h *Handler) erase(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := x.ParseUUID(ps.ByName("id"))
+	erased, err := h.identityForDeletedEvent(r.Context(), id)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.PrivilegedIdentityPool().EraseIdentity(r.Context(), id); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Logger().WithField("identity_id", id).Info("Erased identity.")
+	if erased != nil {
+		h.emit(r.Context(), webhook.EventIdentityDeleted, erased)
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// identityForDeletedEvent loads the identity before it is deleted so that it can be sent to the web hooks which
+// are subscribed to the `identity.deleted` event. It returns nil if no web hook is subscribed.
+func (h *Handler) identityForDeletedEvent(ctx context.Context, id uuid.UUID) (*Identity, error) {
+	if !h.r.WebHookEmitter().HasSubscribers(ctx, webhook.EventIdentityDeleted) {
+		return nil, nil
+	}
+	return h.r.PrivilegedIdentityPool().GetIdentity(ctx, id)
+}
+
+// emit calls the web hooks which are subscribed to the identity event.
+func (h *Handler) emit(ctx context.Context, event webhook.Event, i *Identity) {
+	h.r.WebHookEmitter().Emit(ctx, event, webhook.EventData{IdentityID: i.ID, Identity: WithAdminMetadataInJSON(*i)})
+}
@@ -0,0 +1,380 @@
+package identity
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/jsonx"
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/webhook"
+)
+
+const (
+	RouteImport = RouteCollection + "/import"
+	RouteExport = RouteCollection + "/export"
+
+	// ContentTypeNDJSON is the content type of newline-delimited JSON streams.
+	ContentTypeNDJSON = "application/x-ndjson"
+
+	// importChunkSize is the number of identities which are written in a single transaction.
+	importChunkSize = 100
+
+	// exportPageSize is the number of identities which are loaded at once when exporting identities.
+	exportPageSize = 250
+)
+
+// The result of importing a single identity
+//
+// swagger:model identityImportResult
+type IdentityImportResult struct {
+	// The line of the record in the imported payload, starting at 1.
+	//
+	// required: true
+	Line int `json:"line"`
+
+	// The ID of the created identity. Only set if the record was imported.
+	//
+	// format: uuid4
+	IdentityID *uuid.UUID `json:"identity_id,omitempty"`
+
+	// The reason the record could not be imported. Only set if the record was not imported.
+	Error *herodot.DefaultError `json:"error,omitempty"`
+}
+
+// swagger:parameters adminImportIdentities
+// nolint:deadcode,unused
+type adminImportIdentities struct {
+	// A stream of newline-delimited JSON records, each having the format of the `adminCreateIdentityBody`.
+	//
+	// in: body
+	Body string
+}
+
+// swagger:response adminImportIdentitiesResponse
+// nolint:deadcode,unused
+type adminImportIdentitiesResponse struct {
+	// A stream of newline-delimited JSON records, each having the format of the `identityImportResult`.
+	//
+	// in: body
+	Body string
+}
+
+// swagger:route POST /identities/import v0alpha2 adminImportIdentities
+//
+// Import Identities
+//
+// This endpoint imports a stream of identities in the newline-delimited JSON format. Each line
+// must contain a single identity in the format used to create identities, including credentials
+// and addresses.
+//
+// Identities are written in transactional chunks. A record which can not be imported, for example because
+// it is invalid or conflicts with an existing identity, does not abort the import. Instead, the endpoint
+// streams one result per record, which either contains the ID of the created identity or the reason
+// the record was not imported. If the stream can not be read to the end, the last result carries the
+// line after the last record which was read and the reason the import stopped.
+//
+// Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+//
+//     Consumes:
+//     - application/x-ndjson
+//
+//     Produces:
+//     - application/x-ndjson
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: adminImportIdentitiesResponse
+//       500: jsonError
+func (h *Handler) importIdentities(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	w.Header().Set("Content-Type", ContentTypeNDJSON)
+	w.WriteHeader(http.StatusOK)
+
+	var (
+		in      = bufio.NewReader(r.Body)
+		out     = json.NewEncoder(w)
+		results = make([]IdentityImportResult, 0, importChunkSize)
+		chunk   = make([]*Identity, 0, importChunkSize)
+		line    int
+	)
+
+	flush := func() error {
+		h.importChunk(r.Context(), chunk, results)
+		for _, result := range results {
+			if err := out.Encode(result); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+
+		chunk, results = chunk[:0], results[:0]
+		return nil
+	}
+
+	for {
+		raw, readErr := in.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			// The records read so far are still imported, but the client must learn that the stream was cut short.
+			h.r.Logger().WithRequest(r).WithError(readErr).Error("Unable to read identity import stream.")
+			raw = nil
+		}
+
+		if raw = bytes.TrimSpace(raw); len(raw) > 0 {
+			line++
+			i, err := h.decodeImportedIdentity(r.Context(), raw)
+			results = append(results, newIdentityImportResult(line, err))
+			chunk = append(chunk, i)
+		}
+
+		if len(results) == importChunkSize || (readErr != nil && len(results) > 0) {
+			if err := flush(); err != nil {
+				h.r.Logger().WithRequest(r).WithError(err).Error("Unable to write identity import results.")
+				return
+			}
+		}
+
+		if readErr != nil {
+			if !errors.Is(readErr, io.EOF) {
+				_ = out.Encode(newIdentityImportResult(line+1, errors.WithStack(herodot.ErrBadRequest.
+					WithReasonf("Unable to read the import stream, records after line %d were not imported: %s", line, readErr).
+					WithWrap(readErr))))
+			}
+			return
+		}
+	}
+}
+
+func (h *Handler) decodeImportedIdentity(ctx context.Context, raw []byte) (*Identity, error) {
+	var cr AdminCreateIdentityBody
+	if err := jsonx.NewStrictDecoder(bytes.NewReader(raw)).Decode(&cr); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to decode the identity: %s", err).WithWrap(err))
+	}
+
+	return h.identityFromCreateBody(ctx, &cr)
+}
+
+// importChunk writes the decoded identities of the chunk in a single transaction. If the transaction fails, the
+// identities are written one by one so that each result carries its own error. Identities which could not be
+// decoded are nil and their result already contains the decoding error.
+func (h *Handler) importChunk(ctx context.Context, chunk []*Identity, results []IdentityImportResult) {
+	valid := make([]*Identity, 0, len(chunk))
+	for _, i := range chunk {
+		if i != nil {
+			valid = append(valid, i)
+		}
+	}
+
+	if len(valid) == 0 {
+		return
+	} else if err := h.r.IdentityManager().CreateIdentities(ctx, valid); err == nil {
+		for k, i := range chunk {
+			if i != nil {
+				results[k] = newIdentityImportResult(results[k].Line, nil)
+				results[k].IdentityID = &i.ID
+				h.emit(ctx, webhook.EventIdentityCreated, i)
+			}
+		}
+		return
+	}
+
+	for k, i := range chunk {
+		if i == nil {
+			continue
+		}
+
+		results[k] = newIdentityImportResult(results[k].Line, h.r.IdentityManager().Create(ctx, i))
+		if results[k].Error == nil {
+			results[k].IdentityID = &i.ID
+			h.emit(ctx, webhook.EventIdentityCreated, i)
+		}
+	}
+}
+
+func newIdentityImportResult(line int, err error) IdentityImportResult {
+	result := IdentityImportResult{Line: line}
+	if err != nil {
+		result.Error = herodot.ToDefaultError(err, "")
+	}
+	return result
+}
+
+// swagger:response adminExportIdentitiesResponse
+// nolint:deadcode,unused
+type adminExportIdentitiesResponse struct {
+	// A stream of newline-delimited JSON records, each having the format of the `adminCreateIdentityBody`.
+	//
+	// in: body
+	Body string
+}
+
+// swagger:route GET /identities/export v0alpha2 adminExportIdentities
+//
+// Export Identities
+//
+// This endpoint exports all identities as a stream of newline-delimited JSON records. Each record has the format
+// used to create identities and includes the identity's password and OpenID Connect credentials as well as the
+// state of its verifiable and recovery addresses. The stream can therefore be imported again using the
+// `/identities/import` endpoint, for example to restore a backup or to copy identities to another environment.
+//
+// Other credentials, such as TOTP, WebAuthn and lookup secrets, are not exported. Identities receive new IDs when
+// they are imported. If the export fails after the stream started, the last line contains the error instead
+// of an identity.
+//
+// Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+//
+//     Produces:
+//     - application/x-ndjson
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: adminExportIdentitiesResponse
+//       500: jsonError
+func (h *Handler) exportIdentities(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// The first page is loaded before writing the header, so that an unavailable store results in a proper error.
+	params := ListIdentitiesParameters{PerPage: exportPageSize}
+	is, err := h.r.IdentityPool().SearchIdentities(r.Context(), params)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentTypeNDJSON)
+	w.WriteHeader(http.StatusOK)
+
+	out := json.NewEncoder(w)
+	for len(is) > 0 {
+		for k := range is {
+			record, err := h.exportIdentity(r.Context(), is[k].ID)
+			if err != nil {
+				h.r.Logger().WithRequest(r).WithError(err).Error("Unable to export identity.")
+				writeExportError(out, err)
+				return
+			}
+
+			if err := out.Encode(record); err != nil {
+				h.r.Logger().WithRequest(r).WithError(err).Error("Unable to write identity export.")
+				return
+			}
+		}
+
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+
+		if len(is) < exportPageSize {
+			return
+		}
+
+		params.PageToken = is[len(is)-1].ID
+		if is, err = h.r.IdentityPool().SearchIdentities(r.Context(), params); err != nil {
+			h.r.Logger().WithRequest(r).WithError(err).Error("Unable to list identities for export.")
+			writeExportError(out, err)
+			return
+		}
+	}
+}
+
+// writeExportError ends the export stream with a record carrying the error, so that a truncated export can be
+// told apart from a complete one.
+func writeExportError(out *json.Encoder, err error) {
+	_ = out.Encode(struct {
+		Error *herodot.DefaultError `json:"error"`
+	}{Error: herodot.ToDefaultError(err, "")})
+}
+
+// serveExport serves the identity export for `GET /identities/export`, which shares its path with
+// `GET /identities/:id`, and passes all other requests on to the given handler.
+func (h *Handler) serveExport(handler httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if ps.ByName("id") == "export" {
+			h.exportIdentities(w, r, ps)
+			return
+		}
+		handler(w, r, ps)
+	}
+}
+
+// exportIdentity converts the identity into a record which can be imported again.
+func (h *Handler) exportIdentity(ctx context.Context, id uuid.UUID) (*AdminCreateIdentityBody, error) {
+	i, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &AdminCreateIdentityBody{
+		SchemaID:       i.SchemaID,
+		Traits:         json.RawMessage(i.Traits),
+		State:          i.State,
+		MetadataPublic: exportMetadata(i.MetadataPublic),
+		MetadataAdmin:  exportMetadata(i.MetadataAdmin),
+	}
+
+	for _, a := range i.VerifiableAddresses {
+		record.VerifiableAddresses = append(record.VerifiableAddresses, AdminIdentityImportVerifiableAddress{
+			Value:      a.Value,
+			Via:        a.Via,
+			Verified:   a.Verified,
+			VerifiedAt: a.VerifiedAt,
+		})
+	}
+
+	for _, a := range i.RecoveryAddresses {
+		record.RecoveryAddresses = append(record.RecoveryAddresses, AdminIdentityImportRecoveryAddress{
+			Value: a.Value,
+			Via:   a.Via,
+		})
+	}
+
+	var password CredentialsPassword
+	if _, err := i.ParseCredentials(CredentialsTypePassword, &password); err == nil && len(password.HashedPassword) > 0 {
+		record.Credentials = &AdminIdentityImportCredentials{
+			Password: &AdminIdentityImportCredentialsPassword{
+				Config: AdminIdentityImportCredentialsPasswordConfig{HashedPassword: password.HashedPassword},
+			},
+		}
+	}
+
+	var oidc CredentialsOIDC
+	if _, err := i.ParseCredentials(CredentialsTypeOIDC, &oidc); err == nil && len(oidc.Providers) > 0 {
+		if record.Credentials == nil {
+			record.Credentials = new(AdminIdentityImportCredentials)
+		}
+
+		record.Credentials.OIDC = new(AdminIdentityImportCredentialsOIDC)
+		for _, p := range oidc.Providers {
+			record.Credentials.OIDC.Config.Providers = append(record.Credentials.OIDC.Config.Providers,
+				AdminIdentityImportCredentialsOIDCProvider{Subject: p.Subject, Provider: p.Provider})
+		}
+	}
+
+	return record, nil
+}
+
+// exportMetadata omits metadata which is not set.
+func exportMetadata(m sqlxx.NullJSONRawMessage) json.RawMessage {
+	if len(m) == 0 || string(m) == "null" {
+		return nil
+	}
+	return json.RawMessage(m)
+}
@@ -0,0 +1,205 @@
+package identity
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/hash"
+)
+
+// AdminIdentityImportCredentials are the credentials which are imported when creating an identity.
+//
+// swagger:model adminIdentityImportCredentials
+type AdminIdentityImportCredentials struct {
+	// Password if set will import a password credential.
+	Password *AdminIdentityImportCredentialsPassword `json:"password"`
+
+	// OIDC if set will import an OIDC credential.
+	OIDC *AdminIdentityImportCredentialsOIDC `json:"oidc"`
+}
+
+// swagger:model adminIdentityImportCredentialsPassword
+type AdminIdentityImportCredentialsPassword struct {
+	// Configuration options for the import.
+	Config AdminIdentityImportCredentialsPasswordConfig `json:"config"`
+}
+
+// swagger:model adminIdentityImportCredentialsPasswordConfig
+type AdminIdentityImportCredentialsPasswordConfig struct {
+	// The hashed password in [PHC format]( https://www.ory.sh/docs/kratos/concepts/credentials/username-email-password#hashed-password-format).
+	// Supported are bcrypt, argon2id and pbkdf2 hashes as well as the legacy formats salted md5, sha1, sha256 and sha512,
+	// scrypt, Firebase scrypt and PHPass. Legacy hashes are replaced by the configured hasher on the next login.
+	HashedPassword string `json:"hashed_password"`
+
+	// The password in plain text if no hash is available. The password is hashed using the configured hasher.
+	Password string `json:"password"`
+}
+
+// swagger:model adminIdentityImportCredentialsOidc
+type AdminIdentityImportCredentialsOIDC struct {
+	// Configuration options for the import.
+	Config AdminIdentityImportCredentialsOIDCConfig `json:"config"`
+}
+
+// swagger:model adminIdentityImportCredentialsOidcConfig
+type AdminIdentityImportCredentialsOIDCConfig struct {
+	// A list of OpenID Connect Providers
+	Providers []AdminIdentityImportCredentialsOIDCProvider `json:"providers"`
+}
+
+// swagger:model adminIdentityImportCredentialsOidcProvider
+type AdminIdentityImportCredentialsOIDCProvider struct {
+	// The subject (`sub`) of the OpenID Connect ID Token.
+	//
+	// required: true
+	// example: 12345
+	Subject string `json:"subject"`
+
+	// The OpenID Connect provider to link the subject to. Usually something like `google` or `github`.
+	//
+	// required: true
+	// example: google
+	Provider string `json:"provider"`
+}
+
+// swagger:model adminIdentityImportVerifiableAddress
+type AdminIdentityImportVerifiableAddress struct {
+	// The address value
+	//
+	// required: true
+	// example: foo@user.com
+	Value string `json:"value"`
+
+	// The delivery method
+	//
+	// required: true
+	// example: email
+	Via VerifiableAddressType `json:"via"`
+
+	// Indicates if the address has already been verified
+	//
+	// example: true
+	Verified bool `json:"verified"`
+
+	// When the address was verified. Defaults to the time of the import if the address is verified.
+	//
+	// example: 2014-01-01T23:28:56.782Z
+	VerifiedAt *sqlxx.NullTime `json:"verified_at,omitempty"`
+}
+
+// swagger:model adminIdentityImportRecoveryAddress
+type AdminIdentityImportRecoveryAddress struct {
+	// The address value
+	//
+	// required: true
+	// example: foo@user.com
+	Value string `json:"value"`
+
+	// The delivery method
+	//
+	// required: true
+	// example: email
+	Via RecoveryAddressType `json:"via"`
+}
+
+func (h *Handler) importCredentials(ctx context.Context, i *Identity, creds *AdminIdentityImportCredentials) error {
+	if creds == nil {
+		return nil
+	}
+
+	if creds.Password != nil {
+		if err := h.importPasswordCredentials(ctx, i, creds.Password); err != nil {
+			return err
+		}
+	}
+
+	if creds.OIDC != nil {
+		if err := h.importOIDCCredentials(ctx, i, creds.OIDC); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) importPasswordCredentials(ctx context.Context, i *Identity, creds *AdminIdentityImportCredentialsPassword) error {
+	// Password policies are deliberately not enforced here, as imported users must be able to sign in with
+	// the password they have used before.
+	hashed := []byte(creds.Config.HashedPassword)
+	if len(creds.Config.Password) > 0 {
+		if len(hashed) > 0 {
+			return errors.WithStack(herodot.ErrBadRequest.WithReason("Only one of password and hashed_password can be imported."))
+		}
+
+		var err error
+		hashed, err = h.r.Hasher().Generate(ctx, []byte(creds.Config.Password))
+		if err != nil {
+			return err
+		}
+	}
+
+	// The hash is decoded completely, so that hashes which can not be compared, for example because their
+	// parameters exceed the supported bounds, are rejected now instead of when the user signs in.
+	if err := hash.ValidateHash(hashed); err != nil {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The imported password hash is invalid or does not match any known hash format. Supported are bcrypt, argon2id, pbkdf2, md5, sha1, sha256, sha512, scrypt, Firebase scrypt and PHPass hashes: %s", err))
+	}
+
+	return i.SetCredentialsWithConfig(CredentialsTypePassword, Credentials{}, CredentialsPassword{HashedPassword: string(hashed)})
+}
+
+func (h *Handler) importOIDCCredentials(_ context.Context, i *Identity, creds *AdminIdentityImportCredentialsOIDC) error {
+	var (
+		providers   []CredentialsOIDCProvider
+		identifiers []string
+	)
+	for _, p := range creds.Config.Providers {
+		if len(p.Provider) == 0 || len(p.Subject) == 0 {
+			return errors.WithStack(herodot.ErrBadRequest.WithReason("Imported OpenID Connect credentials must contain a provider and a subject."))
+		}
+
+		identifiers = append(identifiers, OIDCUniqueID(p.Provider, p.Subject))
+		providers = append(providers, CredentialsOIDCProvider{Subject: p.Subject, Provider: p.Provider})
+	}
+
+	return i.SetCredentialsWithConfig(CredentialsTypeOIDC, Credentials{Identifiers: identifiers}, CredentialsOIDC{Providers: providers})
+}
+
+// importVerifiableAddresses converts the imported verifiable addresses. Addresses which are not represented in
+// the identity's traits are dropped when the identity is validated.
+func importVerifiableAddresses(imported []AdminIdentityImportVerifiableAddress) []VerifiableAddress {
+	addresses := make([]VerifiableAddress, len(imported))
+	for k, a := range imported {
+		addresses[k] = VerifiableAddress{
+			Value:    a.Value,
+			Via:      a.Via,
+			Verified: a.Verified,
+			Status:   VerifiableAddressStatusPending,
+		}
+
+		if a.Verified {
+			verifiedAt := a.VerifiedAt
+			if verifiedAt == nil {
+				now := sqlxx.NullTime(time.Now().UTC())
+				verifiedAt = &now
+			}
+			addresses[k].Status = VerifiableAddressStatusCompleted
+			addresses[k].VerifiedAt = verifiedAt
+		}
+	}
+	return addresses
+}
+
+// importRecoveryAddresses converts the imported recovery addresses. Addresses which are not represented in
+// the identity's traits are dropped when the identity is validated.
+func importRecoveryAddresses(imported []AdminIdentityImportRecoveryAddress) []RecoveryAddress {
+	addresses := make([]RecoveryAddress, len(imported))
+	for k, a := range imported {
+		addresses[k] = RecoveryAddress{Value: a.Value, Via: a.Via}
+	}
+	return addresses
+}
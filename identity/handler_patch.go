@@ -0,0 +1,278 @@
+package identity
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/jsonx"
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/webhook"
+	"github.com/ory/kratos/x"
+)
+
+// ContentTypeJSONPatch is the content type of RFC 6902 JSON Patch documents.
+const ContentTypeJSONPatch = "application/json-patch+json"
+
+// ErrIdentityPreconditionFailed is returned if the `If-Match` header does not match the identity's entity tag.
+var ErrIdentityPreconditionFailed = herodot.DefaultError{
+	CodeField:   http.StatusPreconditionFailed,
+	StatusField: http.StatusText(http.StatusPreconditionFailed),
+	ErrorField:  "The identity was modified concurrently",
+	ReasonField: "The If-Match header does not match the current version of the identity. Fetch the identity again and retry the update.",
+}
+
+// A JSONPatch document as defined by RFC 6902
+//
+// swagger:model jsonPatchDocument
+// nolint:deadcode,unused
+type jsonPatchDocument []jsonPatch
+
+// A JSONPatch operation as defined by RFC 6902
+//
+// swagger:model jsonPatch
+// nolint:deadcode,unused
+type jsonPatch struct {
+	// The operation to be performed. One of "add", "remove", "replace", "move", "copy", or "test".
+	//
+	// required: true
+	// example: replace
+	Op string `json:"op"`
+
+	// The path to the target path. Uses JSON pointer notation.
+	//
+	// Learn more [about JSON Pointers](https://datatracker.ietf.org/doc/html/rfc6901#section-5).
+	//
+	// required: true
+	// example: /traits/email
+	Path string `json:"path"`
+
+	// The value to be used within the operations.
+	//
+	// Learn more [about JSON Pointers](https://datatracker.ietf.org/doc/html/rfc6901#section-5).
+	//
+	// example: foo@example.com
+	Value interface{} `json:"value,omitempty"`
+
+	// This field is used together with operation "move" and uses JSON Pointer notation.
+	//
+	// Learn more [about JSON Pointers](https://datatracker.ietf.org/doc/html/rfc6901#section-5).
+	//
+	// example: /traits/username
+	From string `json:"from,omitempty"`
+}
+
+// identityPatchDocument is the part of an identity which can be patched.
+type identityPatchDocument struct {
+	SchemaID          string          `json:"schema_id"`
+	State             State           `json:"state"`
+	StateChangeReason string          `json:"state_change_reason"`
+	InactiveAt        *time.Time      `json:"inactive_at"`
+	DeleteAt          *time.Time      `json:"delete_at"`
+	Traits            json.RawMessage `json:"traits"`
+	MetadataPublic    json.RawMessage `json:"metadata_public"`
+	MetadataAdmin     json.RawMessage `json:"metadata_admin"`
+}
+
+// swagger:parameters adminPatchIdentity
+// nolint:deadcode,unused
+type adminPatchIdentity struct {
+	// ID must be set to the ID of identity you want to update
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// Only apply the patch if the identity's entity tag, as returned in the `ETag` header
+	// when getting the identity, matches this value.
+	//
+	// in: header
+	IfMatch string `json:"If-Match"`
+
+	// in: body
+	Body jsonPatchDocument
+}
+
+// swagger:route PATCH /identities/{id} v0alpha2 adminPatchIdentity
+//
+// Patch an Identity
+//
+// This endpoint applies a [JSON Patch](https://datatracker.ietf.org/doc/html/rfc6902) to an identity's
+// `schema_id`, `state`, `state_change_reason`, `inactive_at`, `delete_at`, `traits`, `metadata_public` and
+// `metadata_admin`, for example `[{"op": "replace", "path": "/traits/email", "value": "foo@example.com"}]`.
+// The patched identity is validated against its identity schema. It is NOT possible to patch credentials.
+//
+// If the patch changes the state but not the `state_change_reason`, the reason is removed. Identities which are
+// deactivated by the patch are scheduled to be erased after the configured deletion grace period, unless
+// `delete_at` is set.
+//
+// The update uses optimistic concurrency control: It fails with 409 if the identity was modified while the patch was
+// applied. To make sure that the patch is applied to the version of the identity you have seen, set the `If-Match`
+// header to the `ETag` returned when getting the identity, or add a `test` operation to the patch.
+//
+// Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+//
+//     Consumes:
+//     - application/json-patch+json
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: identity
+//       400: jsonError
+//       404: jsonError
+//       409: jsonError
+//       412: jsonError
+//       500: jsonError
+func (h *Handler) patch(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to read the request body: %s", err).WithWrap(err)))
+		return
+	}
+
+	patch, err := jsonpatch.DecodePatch(body)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to decode the JSON Patch: %s", err).WithWrap(err)))
+		return
+	}
+
+	identity, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if match := r.Header.Get("If-Match"); match != "" && match != "*" && !etagMatches(match, identity) {
+		h.r.Writer().WriteError(w, r, errors.WithStack(ErrIdentityPreconditionFailed))
+		return
+	}
+
+	if err := applyIdentityPatch(identity, patch, h.r.Config(r.Context()).IdentityDeletionGracePeriod()); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.IdentityManager().Update(
+		r.Context(),
+		identity,
+		ManagerAllowWriteProtectedTraits,
+		ManagerExpectUpdatedAt(identity.UpdatedAt),
+	); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	// The identity is loaded again so that the entity tag reflects the stored update time.
+	updated, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), identity.ID)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.emit(r.Context(), webhook.EventIdentityUpdated, updated)
+	w.Header().Set("ETag", etag(updated))
+	h.r.Writer().Write(w, r, WithCredentialsMetadataInJSON(*updated))
+}
+
+func applyIdentityPatch(i *Identity, patch jsonpatch.Patch, gracePeriod time.Duration) error {
+	original, err := json.Marshal(&identityPatchDocument{
+		SchemaID:          i.SchemaID,
+		State:             i.State,
+		StateChangeReason: i.StateChangeReason,
+		InactiveAt:        timePtr(i.InactiveAt),
+		DeleteAt:          timePtr(i.DeleteAt),
+		Traits:            json.RawMessage(i.Traits),
+		MetadataPublic:    nullJSON(i.MetadataPublic),
+		MetadataAdmin:     nullJSON(i.MetadataAdmin),
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	patched, err := patch.Apply(original)
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return errors.WithStack(ErrIdentityModified.WithReasonf("A test operation of the JSON Patch failed: %s", err).WithWrap(err))
+	} else if err != nil {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to apply the JSON Patch: %s", err).WithWrap(err))
+	}
+
+	var doc identityPatchDocument
+	if err := jsonx.NewStrictDecoder(strings.NewReader(string(patched))).Decode(&doc); err != nil {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("Only the paths /schema_id, /state, /state_change_reason, /inactive_at, /delete_at, /traits, /metadata_public and /metadata_admin can be patched: %s", err).WithWrap(err))
+	}
+
+	if doc.SchemaID == "" {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("The schema_id must not be empty."))
+	}
+
+	i.InactiveAt = nullTime(doc.InactiveAt)
+	i.DeleteAt = nullTime(doc.DeleteAt)
+	if doc.State != i.State {
+		if err := doc.State.IsValid(); err != nil {
+			return errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err).WithWrap(err))
+		}
+
+		reason := doc.StateChangeReason
+		if reason == i.StateChangeReason {
+			// The reason belongs to the previous state change.
+			reason = ""
+		}
+		i.changeState(doc.State, reason, gracePeriod)
+	} else {
+		i.StateChangeReason = doc.StateChangeReason
+	}
+
+	i.SchemaID = doc.SchemaID
+	i.Traits = Traits(doc.Traits)
+	i.MetadataPublic = sqlxx.NullJSONRawMessage(doc.MetadataPublic)
+	i.MetadataAdmin = sqlxx.NullJSONRawMessage(doc.MetadataAdmin)
+	return nil
+}
+
+func timePtr(t *sqlxx.NullTime) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	tt := time.Time(*t)
+	return &tt
+}
+
+func nullJSON(raw sqlxx.NullJSONRawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(raw)
+}
+
+// etag returns the entity tag of the identity, which changes whenever the identity is updated.
+func etag(i *Identity) string {
+	return fmt.Sprintf(`"%d"`, i.UpdatedAt.UnixNano())
+}
+
+func etagMatches(header string, i *Identity) bool {
+	current := etag(i)
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == current {
+			return true
+		}
+	}
+	return false
+}
@@ -1,14 +1,21 @@
 package identity_test
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/ory/kratos/selfservice/strategy/oidc"
@@ -22,6 +29,7 @@ import (
 	"github.com/ory/x/urlx"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
 	"github.com/ory/kratos/internal/testhelpers"
@@ -460,6 +468,276 @@ func TestHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("suite=import credentials and addresses", func(t *testing.T) {
+		var create = func(t *testing.T, ts *httptest.Server, cr *identity.AdminCreateIdentityBody) *identity.Identity {
+			res := send(t, ts, "POST", "/identities", http.StatusCreated, cr)
+			assert.False(t, res.Get("credentials").Exists(), "credentials must not be returned: %s", res.Raw)
+
+			actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), x.ParseUUID(res.Get("id").String()))
+			require.NoError(t, err)
+			return actual
+		}
+
+		var newBody = func(email string) *identity.AdminCreateIdentityBody {
+			return &identity.AdminCreateIdentityBody{SchemaID: "customer", Traits: []byte(`{"email":"` + email + `"}`)}
+		}
+
+		var comparePassword = func(t *testing.T, i *identity.Identity, email, password string) {
+			var conf identity.CredentialsPassword
+			c, err := i.ParseCredentials(identity.CredentialsTypePassword, &conf)
+			require.NoError(t, err)
+			assert.Equal(t, []string{email}, c.Identifiers)
+			require.NoError(t, hash.Compare(context.Background(), []byte(password), []byte(conf.HashedPassword)))
+		}
+
+		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
+			t.Run("endpoint="+name, func(t *testing.T) {
+				t.Run("case=should import a cleartext password", func(t *testing.T) {
+					email := x.NewUUID().String() + "@ory.sh"
+					cr := newBody(email)
+					cr.Credentials = &identity.AdminIdentityImportCredentials{Password: &identity.AdminIdentityImportCredentialsPassword{
+						Config: identity.AdminIdentityImportCredentialsPasswordConfig{Password: "123456"}}}
+
+					comparePassword(t, create(t, ts, cr), email, "123456")
+				})
+
+				for hasher, hashed := range map[string]string{
+					"bcrypt":   "$2a$04$ZjSb1dnbdB5vdCY1XVOCC.NO9HuG4xBJ2ZVkOhQlMjYQ6SX3p9WMS",
+					"argon2id": "$argon2id$v=19$m=16,t=2,p=1$bVI1aE1SaTV6SGQ3bzdXdw$fnjCcZYmEPOUOjYXsT92Cg",
+					"pbkdf2":   "$pbkdf2-sha256$i=1000,l=32$1jP+5Zxpxgtee/iPxGgOz0RfE9/KJuDElP1ley4VxXc$QJxzfvdbHYBpydCbHoFg3GJEqMFULwskiuqiJctoYpI",
+					"sha256":   "$sha256$a28wbkdMOHQ=$OHBXAzDERsN4HYbczn0EWVlgCNCE6w2MzKUG0HT8E20=",
+					"scrypt":   "$scrypt$ln=10,r=8,p=1$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzI",
+					"phpass":   "$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0",
+				} {
+					t.Run("case=should import a "+hasher+" hashed password", func(t *testing.T) {
+						email := x.NewUUID().String() + "@ory.sh"
+						cr := newBody(email)
+						cr.Credentials = &identity.AdminIdentityImportCredentials{Password: &identity.AdminIdentityImportCredentialsPassword{
+							Config: identity.AdminIdentityImportCredentialsPasswordConfig{HashedPassword: hashed}}}
+
+						i := create(t, ts, cr)
+						var conf identity.CredentialsPassword
+						_, err := i.ParseCredentials(identity.CredentialsTypePassword, &conf)
+						require.NoError(t, err)
+						assert.Equal(t, hashed, conf.HashedPassword, "the hash is imported as is")
+					})
+				}
+
+				for name, hashed := range map[string]string{
+					"unknown hash formats":                           "{SHA}fEqNCco3Yq9h5ZUglD3CZJT4lBs=",
+					"truncated hashes":                               "$scrypt$ln=10,r=8,p=1$c2FsdHNhbHQ",
+					"scrypt hashes with a huge block size":           "$scrypt$ln=10,r=1048576,p=1$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzI",
+					"Firebase scrypt hashes with a huge parallelism": "$firescrypt$ln=14,r=8,p=1073741823$42xEC+ixf3L2lw==$lSrfV15cpx95$Bw==$jxspr8Ki0RYycVU8",
+				} {
+					t.Run("case=should reject "+name, func(t *testing.T) {
+						cr := newBody(x.NewUUID().String() + "@ory.sh")
+						cr.Credentials = &identity.AdminIdentityImportCredentials{Password: &identity.AdminIdentityImportCredentialsPassword{
+							Config: identity.AdminIdentityImportCredentialsPasswordConfig{HashedPassword: hashed}}}
+
+						res := send(t, ts, "POST", "/identities", http.StatusBadRequest, cr)
+						assert.Contains(t, res.Get("error.reason").String(), "known hash format", "%s", res.Raw)
+					})
+				}
+
+				t.Run("case=should import oidc credentials", func(t *testing.T) {
+					subject := x.NewUUID().String()
+					cr := newBody(x.NewUUID().String() + "@ory.sh")
+					cr.Credentials = &identity.AdminIdentityImportCredentials{OIDC: &identity.AdminIdentityImportCredentialsOIDC{
+						Config: identity.AdminIdentityImportCredentialsOIDCConfig{Providers: []identity.AdminIdentityImportCredentialsOIDCProvider{
+							{Provider: "google", Subject: subject},
+							{Provider: "github", Subject: subject},
+						}}}}
+
+					i := create(t, ts, cr)
+					var conf identity.CredentialsOIDC
+					c, err := i.ParseCredentials(identity.CredentialsTypeOIDC, &conf)
+					require.NoError(t, err)
+					assert.ElementsMatch(t, []string{"google:" + subject, "github:" + subject}, c.Identifiers)
+					require.Len(t, conf.Providers, 2)
+					assert.Equal(t, "google", conf.Providers[0].Provider)
+					assert.Equal(t, subject, conf.Providers[0].Subject)
+
+					found, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(context.Background(), identity.CredentialsTypeOIDC, "github:"+subject)
+					require.NoError(t, err)
+					assert.Equal(t, i.ID, found.ID)
+
+					cr.Traits = []byte(`{"email":"` + x.NewUUID().String() + `@ory.sh"}`)
+					_ = send(t, ts, "POST", "/identities", http.StatusConflict, cr)
+				})
+
+				t.Run("case=should import verified and recovery addresses", func(t *testing.T) {
+					email := x.NewUUID().String() + "@ory.sh"
+					verifiedAt := sqlxx.NullTime(time.Now().UTC().Add(-time.Hour).Round(time.Second))
+					cr := newBody(email)
+					cr.VerifiableAddresses = []identity.AdminIdentityImportVerifiableAddress{
+						{Value: email, Via: identity.VerifiableAddressTypeEmail, Verified: true, VerifiedAt: &verifiedAt},
+						{Value: "not-in-traits@ory.sh", Via: identity.VerifiableAddressTypeEmail, Verified: true},
+					}
+					cr.RecoveryAddresses = []identity.AdminIdentityImportRecoveryAddress{
+						{Value: email, Via: identity.RecoveryAddressTypeEmail},
+					}
+
+					i := create(t, ts, cr)
+					require.Len(t, i.VerifiableAddresses, 1, "addresses which are not in the traits are ignored")
+					assert.Equal(t, email, i.VerifiableAddresses[0].Value)
+					assert.True(t, i.VerifiableAddresses[0].Verified)
+					assert.Equal(t, identity.VerifiableAddressStatusCompleted, i.VerifiableAddresses[0].Status)
+					require.NotNil(t, i.VerifiableAddresses[0].VerifiedAt)
+					assert.Equal(t, time.Time(verifiedAt).Unix(), time.Time(*i.VerifiableAddresses[0].VerifiedAt).Unix())
+
+					require.Len(t, i.RecoveryAddresses, 1)
+					assert.Equal(t, email, i.RecoveryAddresses[0].Value)
+				})
+
+				t.Run("case=should default to unverified addresses", func(t *testing.T) {
+					email := x.NewUUID().String() + "@ory.sh"
+					i := create(t, ts, newBody(email))
+					require.Len(t, i.VerifiableAddresses, 1)
+					assert.False(t, i.VerifiableAddresses[0].Verified)
+					assert.Equal(t, identity.VerifiableAddressStatusPending, i.VerifiableAddresses[0].Status)
+				})
+			})
+		}
+	})
+
+	t.Run("suite=batch import and export", func(t *testing.T) {
+		var stream = func(t *testing.T, ts *httptest.Server, method, href string, body string) []gjson.Result {
+			req, err := http.NewRequest(method, ts.URL+href, bytes.NewBufferString(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", identity.ContentTypeNDJSON)
+
+			res, err := ts.Client().Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			require.EqualValues(t, http.StatusOK, res.StatusCode)
+			assert.Equal(t, identity.ContentTypeNDJSON, res.Header.Get("Content-Type"))
+
+			var lines []gjson.Result
+			scanner := bufio.NewScanner(res.Body)
+			for scanner.Scan() {
+				lines = append(lines, gjson.ParseBytes(scanner.Bytes()))
+			}
+			require.NoError(t, scanner.Err())
+			return lines
+		}
+
+		var record = func(t *testing.T, cr *identity.AdminCreateIdentityBody) string {
+			out, err := json.Marshal(cr)
+			require.NoError(t, err)
+			return string(out)
+		}
+
+		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
+			t.Run("endpoint="+name, func(t *testing.T) {
+				subject := x.NewUUID().String()
+				emails := []string{x.NewUUID().String() + "@ory.sh", x.NewUUID().String() + "@ory.sh", x.NewUUID().String() + "@ory.sh"}
+				withOIDC := &identity.AdminCreateIdentityBody{
+					SchemaID: "customer",
+					Traits:   []byte(`{"email":"` + emails[0] + `"}`),
+					Credentials: &identity.AdminIdentityImportCredentials{
+						Password: &identity.AdminIdentityImportCredentialsPassword{Config: identity.AdminIdentityImportCredentialsPasswordConfig{
+							HashedPassword: "$2a$04$ZjSb1dnbdB5vdCY1XVOCC.NO9HuG4xBJ2ZVkOhQlMjYQ6SX3p9WMS"}},
+						OIDC: &identity.AdminIdentityImportCredentialsOIDC{Config: identity.AdminIdentityImportCredentialsOIDCConfig{
+							Providers: []identity.AdminIdentityImportCredentialsOIDCProvider{{Provider: "google", Subject: subject}}}},
+					},
+					VerifiableAddresses: []identity.AdminIdentityImportVerifiableAddress{{Value: emails[0], Via: identity.VerifiableAddressTypeEmail, Verified: true}},
+					MetadataPublic:      []byte(`{"plan":"pro"}`),
+					MetadataAdmin:       []byte(`{"risk":"low"}`),
+				}
+				conflicting := &identity.AdminCreateIdentityBody{
+					SchemaID:    "customer",
+					Traits:      []byte(`{"email":"` + emails[2] + `"}`),
+					Credentials: &identity.AdminIdentityImportCredentials{OIDC: withOIDC.Credentials.OIDC},
+				}
+
+				var imported []gjson.Result
+				t.Run("case=should import identities and report errors per record", func(t *testing.T) {
+					imported = stream(t, ts, "POST", "/identities/import", record(t, withOIDC)+"\n"+
+						"not json\n\n"+
+						record(t, &identity.AdminCreateIdentityBody{SchemaID: "customer", Traits: []byte(`{"email":"` + emails[1] + `"}`)})+"\n"+
+						record(t, conflicting))
+					require.Len(t, imported, 4)
+
+					for k, line := range imported {
+						assert.EqualValues(t, k+1, line.Get("line").Int(), "%s", line.Raw)
+					}
+
+					assert.False(t, imported[0].Get("error").Exists(), "%s", imported[0].Raw)
+					assert.EqualValues(t, http.StatusBadRequest, imported[1].Get("error.code").Int(), "%s", imported[1].Raw)
+					assert.False(t, imported[1].Get("identity_id").Exists(), "%s", imported[1].Raw)
+					assert.False(t, imported[2].Get("error").Exists(), "%s", imported[2].Raw)
+					assert.EqualValues(t, http.StatusConflict, imported[3].Get("error.code").Int(), "%s", imported[3].Raw)
+
+					for k, email := range emails[:2] {
+						i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), x.ParseUUID(imported[k*2].Get("identity_id").String()))
+						require.NoError(t, err)
+						assert.Equal(t, email, gjson.GetBytes(i.Traits, "email").String())
+					}
+
+					_, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(context.Background(), identity.CredentialsTypePassword, emails[2])
+					assert.Error(t, err, "the conflicting record must not be imported")
+				})
+
+				t.Run("case=should export identities in the import format", func(t *testing.T) {
+					var exported *identity.AdminCreateIdentityBody
+					for _, line := range stream(t, ts, "GET", "/identities/export", "") {
+						if line.Get("traits.email").String() == emails[0] {
+							require.NoError(t, json.Unmarshal([]byte(line.Raw), &exported))
+						}
+					}
+					require.NotNil(t, exported)
+
+					assert.Equal(t, "customer", exported.SchemaID)
+					assert.Equal(t, identity.StateActive, exported.State)
+					require.NotNil(t, exported.Credentials)
+					assert.Equal(t, withOIDC.Credentials.Password, exported.Credentials.Password)
+					assert.Equal(t, withOIDC.Credentials.OIDC, exported.Credentials.OIDC)
+					require.Len(t, exported.VerifiableAddresses, 1)
+					assert.True(t, exported.VerifiableAddresses[0].Verified)
+					require.Len(t, exported.RecoveryAddresses, 1)
+					assert.Equal(t, emails[0], exported.RecoveryAddresses[0].Value)
+					assert.JSONEq(t, `{"plan":"pro"}`, string(exported.MetadataPublic))
+					assert.JSONEq(t, `{"risk":"low"}`, string(exported.MetadataAdmin))
+
+					t.Run("case=should import the exported identity again", func(t *testing.T) {
+						remove(t, ts, "/identities/"+imported[0].Get("identity_id").String(), http.StatusNoContent)
+
+						reimported := stream(t, ts, "POST", "/identities/import", record(t, exported))
+						require.Len(t, reimported, 1)
+						assert.False(t, reimported[0].Get("error").Exists(), "%s", reimported[0].Raw)
+
+						i, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(context.Background(), identity.CredentialsTypeOIDC, "google:"+subject)
+						require.NoError(t, err)
+						assert.Equal(t, reimported[0].Get("identity_id").String(), i.ID.String())
+						require.Len(t, i.VerifiableAddresses, 1)
+						assert.True(t, i.VerifiableAddresses[0].Verified)
+					})
+				})
+			})
+		}
+
+		t.Run("case=should end the import with an error if the stream breaks off", func(t *testing.T) {
+			router := x.NewRouterAdmin()
+			reg.IdentityHandler().RegisterAdminRoutes(router)
+
+			email := x.NewUUID().String() + "@ory.sh"
+			req := httptest.NewRequest("POST", "/identities/import", io.MultiReader(
+				strings.NewReader(record(t, &identity.AdminCreateIdentityBody{SchemaID: "customer", Traits: []byte(`{"email":"` + email + `"}`)})+"\n"),
+				iotest.ErrReader(errors.New("connection reset by peer")),
+			))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+			require.Len(t, lines, 2, "%s", rec.Body.String())
+			assert.False(t, gjson.Get(lines[0], "error").Exists(), "the records read before the error are imported: %s", lines[0])
+			assert.EqualValues(t, 2, gjson.Get(lines[1], "line").Int(), "%s", lines[1])
+			assert.EqualValues(t, http.StatusBadRequest, gjson.Get(lines[1], "error.code").Int(), "%s", lines[1])
+			assert.Contains(t, gjson.Get(lines[1], "error.reason").String(), "connection reset by peer", "%s", lines[1])
+		})
+	})
+
 	t.Run("case=should create and sync metadata and update privileged traits", func(t *testing.T) {
 		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
 			t.Run("endpoint="+name, func(t *testing.T) {
@@ -575,6 +853,286 @@ func TestHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("suite=metadata", func(t *testing.T) {
+		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
+			t.Run("endpoint="+name, func(t *testing.T) {
+				res := send(t, ts, "POST", "/identities", http.StatusCreated, json.RawMessage(`{
+	"traits": {"bar":"baz"},
+	"metadata_public": {"plan":"pro"},
+	"metadata_admin": {"risk":"low"}
+}`))
+				id := res.Get("id").String()
+				assert.JSONEq(t, `{"plan":"pro"}`, res.Get("metadata_public").Raw, "%s", res.Raw)
+				assert.JSONEq(t, `{"risk":"low"}`, res.Get("metadata_admin").Raw, "%s", res.Raw)
+
+				t.Run("case=should return metadata when getting and listing identities", func(t *testing.T) {
+					res := get(t, ts, "/identities/"+id, http.StatusOK)
+					assert.JSONEq(t, `{"plan":"pro"}`, res.Get("metadata_public").Raw, "%s", res.Raw)
+					assert.JSONEq(t, `{"risk":"low"}`, res.Get("metadata_admin").Raw, "%s", res.Raw)
+
+					res = get(t, ts, "/identities?per_page=1000", http.StatusOK)
+					assert.JSONEq(t, `{"risk":"low"}`, res.Get(`#(id=="`+id+`").metadata_admin`).Raw, "%s", res.Raw)
+				})
+
+				t.Run("case=should patch metadata", func(t *testing.T) {
+					res := send(t, ts, "PATCH", "/identities/"+id, http.StatusOK, json.RawMessage(`[
+	{"op": "replace", "path": "/metadata_admin/risk", "value": "high"},
+	{"op": "add", "path": "/metadata_public/seats", "value": 5}
+]`))
+					assert.JSONEq(t, `{"plan":"pro","seats":5}`, res.Get("metadata_public").Raw, "%s", res.Raw)
+					assert.JSONEq(t, `{"risk":"high"}`, res.Get("metadata_admin").Raw, "%s", res.Raw)
+				})
+
+				t.Run("case=should replace metadata on update", func(t *testing.T) {
+					res := send(t, ts, "PUT", "/identities/"+id, http.StatusOK, json.RawMessage(`{
+	"traits": {"bar":"baz"},
+	"state": "active",
+	"metadata_public": {"plan":"free"}
+}`))
+					assert.JSONEq(t, `{"plan":"free"}`, res.Get("metadata_public").Raw, "%s", res.Raw)
+					assert.False(t, res.Get("metadata_admin").Exists() && res.Get("metadata_admin").Type != gjson.Null, "%s", res.Raw)
+
+					res = get(t, ts, "/identities/"+id, http.StatusOK)
+					assert.JSONEq(t, `{"plan":"free"}`, res.Get("metadata_public").Raw, "%s", res.Raw)
+					assert.EqualValues(t, gjson.Null, res.Get("metadata_admin").Type, "%s", res.Raw)
+				})
+			})
+		}
+	})
+
+	t.Run("suite=lifecycle", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyIdentityLifecycleDeletionGracePeriod, "24h")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyIdentityLifecycleDeletionGracePeriod, "0s")
+		})
+
+		inactiveAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+
+		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
+			t.Run("endpoint="+name, func(t *testing.T) {
+				t.Run("case=should create an identity with a scheduled deactivation", func(t *testing.T) {
+					res := send(t, ts, "POST", "/identities", http.StatusCreated, json.RawMessage(`{
+	"traits": {"bar":"baz"},
+	"state_change_reason": "trial",
+	"inactive_at": "`+inactiveAt.Format(time.RFC3339)+`"
+}`))
+					assert.EqualValues(t, identity.StateActive, res.Get("state").String(), "%s", res.Raw)
+					assert.EqualValues(t, "trial", res.Get("state_change_reason").String(), "%s", res.Raw)
+					assert.True(t, inactiveAt.Equal(res.Get("inactive_at").Time()), "%s", res.Raw)
+					assert.False(t, res.Get("delete_at").Exists(), "%s", res.Raw)
+				})
+
+				t.Run("case=should schedule the erasure of inactive identities", func(t *testing.T) {
+					res := send(t, ts, "POST", "/identities", http.StatusCreated, json.RawMessage(`{"traits": {"bar":"baz"}, "state": "inactive", "state_change_reason": "fraud"}`))
+					assert.EqualValues(t, "fraud", res.Get("state_change_reason").String(), "%s", res.Raw)
+					assert.WithinDuration(t, time.Now().Add(24*time.Hour), res.Get("delete_at").Time(), time.Minute, "%s", res.Raw)
+				})
+
+				t.Run("case=should change the state with a reason on update", func(t *testing.T) {
+					id := send(t, ts, "POST", "/identities", http.StatusCreated, json.RawMessage(`{"traits": {"bar":"baz"}}`)).Get("id").String()
+
+					res := send(t, ts, "PUT", "/identities/"+id, http.StatusOK, json.RawMessage(`{"traits": {"bar":"baz"}, "state": "inactive", "state_change_reason": "user request"}`))
+					assert.EqualValues(t, identity.StateInactive, res.Get("state").String(), "%s", res.Raw)
+					assert.EqualValues(t, "user request", res.Get("state_change_reason").String(), "%s", res.Raw)
+					assert.WithinDuration(t, time.Now().Add(24*time.Hour), res.Get("delete_at").Time(), time.Minute, "%s", res.Raw)
+
+					res = send(t, ts, "PUT", "/identities/"+id, http.StatusOK, json.RawMessage(`{"traits": {"bar":"baz"}, "state": "active"}`))
+					assert.EqualValues(t, identity.StateActive, res.Get("state").String(), "%s", res.Raw)
+					assert.False(t, res.Get("state_change_reason").Exists(), "%s", res.Raw)
+					assert.False(t, res.Get("delete_at").Exists(), "%s", res.Raw)
+				})
+
+				t.Run("case=should patch the schedule", func(t *testing.T) {
+					id := send(t, ts, "POST", "/identities", http.StatusCreated, json.RawMessage(`{"traits": {"bar":"baz"}}`)).Get("id").String()
+
+					res := send(t, ts, "PATCH", "/identities/"+id, http.StatusOK, json.RawMessage(`[
+	{"op": "add", "path": "/inactive_at", "value": "`+inactiveAt.Format(time.RFC3339)+`"},
+	{"op": "replace", "path": "/state_change_reason", "value": "contract ends"}
+]`))
+					assert.EqualValues(t, identity.StateActive, res.Get("state").String(), "%s", res.Raw)
+					assert.EqualValues(t, "contract ends", res.Get("state_change_reason").String(), "%s", res.Raw)
+					assert.True(t, inactiveAt.Equal(res.Get("inactive_at").Time()), "%s", res.Raw)
+
+					res = send(t, ts, "PATCH", "/identities/"+id, http.StatusOK, json.RawMessage(`[{"op": "replace", "path": "/inactive_at", "value": null}]`))
+					assert.False(t, res.Get("inactive_at").Exists(), "%s", res.Raw)
+				})
+
+				t.Run("case=should erase an identity", func(t *testing.T) {
+					id := send(t, ts, "POST", "/identities", http.StatusCreated, json.RawMessage(`{"traits": {"bar":"baz"}}`)).Get("id").String()
+
+					remove(t, ts, "/identities/"+id+"/erase", http.StatusNoContent)
+					get(t, ts, "/identities/"+id, http.StatusNotFound)
+					remove(t, ts, "/identities/"+id+"/erase", http.StatusNotFound)
+				})
+			})
+		}
+	})
+
+	t.Run("suite=patch", func(t *testing.T) {
+		var patch = func(t *testing.T, ts *httptest.Server, id string, header http.Header, expectCode int, ops string) (gjson.Result, http.Header) {
+			req, err := http.NewRequest("PATCH", ts.URL+"/identities/"+id, strings.NewReader(ops))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", identity.ContentTypeJSONPatch)
+			for k := range header {
+				req.Header.Set(k, header.Get(k))
+			}
+
+			res, err := ts.Client().Do(req)
+			require.NoError(t, err)
+			body, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			require.EqualValues(t, expectCode, res.StatusCode, "%s", body)
+			return gjson.ParseBytes(body), res.Header
+		}
+
+		var etag = func(t *testing.T, id string) string {
+			res, err := adminTS.Client().Get(adminTS.URL + "/identities/" + id)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			require.NotEmpty(t, res.Header.Get("ETag"))
+			return res.Header.Get("ETag")
+		}
+
+		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
+			t.Run("endpoint="+name, func(t *testing.T) {
+				id := send(t, adminTS, "POST", "/identities", http.StatusCreated, json.RawMessage(`{"traits": {"bar":"baz","email":"patch-`+name+`@ory.sh"}}`)).Get("id").String()
+
+				t.Run("case=should patch traits and state", func(t *testing.T) {
+					res, header := patch(t, ts, id, nil, http.StatusOK, `[
+	{"op": "replace", "path": "/traits/bar", "value": "patched"},
+	{"op": "remove", "path": "/traits/email"},
+	{"op": "replace", "path": "/state", "value": "inactive"}
+]`)
+					assert.EqualValues(t, "patched", res.Get("traits.bar").String(), "%s", res.Raw)
+					assert.False(t, res.Get("traits.email").Exists(), "%s", res.Raw)
+					assert.EqualValues(t, identity.StateInactive, res.Get("state").String(), "%s", res.Raw)
+					assert.Equal(t, etag(t, id), header.Get("ETag"))
+
+					res = get(t, ts, "/identities/"+id, http.StatusOK)
+					assert.EqualValues(t, "patched", res.Get("traits.bar").String(), "%s", res.Raw)
+					assert.EqualValues(t, identity.StateInactive, res.Get("state").String(), "%s", res.Raw)
+				})
+
+				t.Run("case=should apply the patch if test operations succeed", func(t *testing.T) {
+					res, _ := patch(t, ts, id, nil, http.StatusOK, `[
+	{"op": "test", "path": "/traits/bar", "value": "patched"},
+	{"op": "add", "path": "/traits/email", "value": "patch-`+name+`@ory.sh"}
+]`)
+					assert.EqualValues(t, "patch-"+name+"@ory.sh", res.Get("traits.email").String(), "%s", res.Raw)
+				})
+
+				t.Run("case=should fail if a test operation fails", func(t *testing.T) {
+					res, _ := patch(t, ts, id, nil, http.StatusConflict, `[
+	{"op": "test", "path": "/traits/bar", "value": "stale"},
+	{"op": "replace", "path": "/traits/bar", "value": "lost"}
+]`)
+					assert.Contains(t, res.Get("error.reason").String(), "test operation", "%s", res.Raw)
+					assert.EqualValues(t, "patched", get(t, ts, "/identities/"+id, http.StatusOK).Get("traits.bar").String())
+				})
+
+				t.Run("case=should respect If-Match", func(t *testing.T) {
+					current := etag(t, id)
+					_, header := patch(t, ts, id, http.Header{"If-Match": {current}}, http.StatusOK, `[{"op": "replace", "path": "/traits/bar", "value": "matched"}]`)
+					assert.NotEqual(t, current, header.Get("ETag"))
+
+					res, _ := patch(t, ts, id, http.Header{"If-Match": {current}}, http.StatusPreconditionFailed, `[{"op": "replace", "path": "/traits/bar", "value": "stale"}]`)
+					assert.Contains(t, res.Get("error.reason").String(), "If-Match", "%s", res.Raw)
+					assert.EqualValues(t, "matched", get(t, ts, "/identities/"+id, http.StatusOK).Get("traits.bar").String())
+				})
+
+				t.Run("case=should validate the patched identity", func(t *testing.T) {
+					for _, ops := range []string{
+						`[{"op": "replace", "path": "/traits/bar", "value": 1234}]`,
+						`[{"op": "replace", "path": "/state", "value": "unknown"}]`,
+						`[{"op": "remove", "path": "/schema_id"}]`,
+						`[{"op": "replace", "path": "/schema_id", "value": "does-not-exist"}]`,
+						`[{"op": "add", "path": "/credentials", "value": {}}]`,
+						`[{"op": "replace", "path": "/traits/does/not/exist", "value": "foo"}]`,
+						`{"op": "replace", "path": "/traits/bar", "value": "foo"}`,
+					} {
+						patch(t, ts, id, nil, http.StatusBadRequest, ops)
+					}
+					assert.EqualValues(t, "matched", get(t, ts, "/identities/"+id, http.StatusOK).Get("traits.bar").String())
+				})
+
+				t.Run("case=should return 404 for unknown identities", func(t *testing.T) {
+					patch(t, ts, x.NewUUID().String(), nil, http.StatusNotFound, `[{"op": "replace", "path": "/traits/bar", "value": "foo"}]`)
+				})
+			})
+		}
+	})
+
+	t.Run("suite=search", func(t *testing.T) {
+		marker := x.NewUUID().String()
+		var ids []string
+		for _, state := range []identity.State{identity.StateActive, identity.StateActive, identity.StateInactive} {
+			i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			i.Traits = identity.Traits(`{"bar":"` + marker + `"}`)
+			i.State = state
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+			ids = append(ids, i.ID.String())
+		}
+
+		list := func(t *testing.T, query url.Values, expectCode int) (gjson.Result, http.Header) {
+			res, err := adminTS.Client().Get(adminTS.URL + "/identities?" + query.Encode())
+			require.NoError(t, err)
+			body, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			require.EqualValues(t, expectCode, res.StatusCode, "%s", body)
+			return gjson.ParseBytes(body), res.Header
+		}
+
+		t.Run("case=should filter by traits", func(t *testing.T) {
+			res, header := list(t, url.Values{"trait": {"bar=" + marker}}, http.StatusOK)
+			assert.ElementsMatch(t, ids, gjson.Get(res.Raw, "#.id").Value(), "%s", res.Raw)
+			assert.Equal(t, "3", header.Get("X-Total-Count"))
+		})
+
+		t.Run("case=should combine filters", func(t *testing.T) {
+			res, header := list(t, url.Values{"trait": {"bar=" + marker}, "state": {"inactive"}, "schema_id": {config.DefaultIdentityTraitsSchemaID}}, http.StatusOK)
+			assert.ElementsMatch(t, ids[2:], gjson.Get(res.Raw, "#.id").Value(), "%s", res.Raw)
+			assert.Equal(t, "1", header.Get("X-Total-Count"))
+			assert.Contains(t, header.Get("Link"), "state=inactive")
+		})
+
+		t.Run("case=should paginate using page tokens", func(t *testing.T) {
+			res, header := list(t, url.Values{"trait": {"bar=" + marker}, "per_page": {"2"}}, http.StatusOK)
+			require.Len(t, res.Array(), 2, "%s", res.Raw)
+			token := header.Get(x.HeaderNextPageToken)
+			assert.Equal(t, res.Get("1.id").String(), token)
+
+			next, header := list(t, url.Values{"trait": {"bar=" + marker}, "per_page": {"2"}, "page_token": {token}}, http.StatusOK)
+			require.Len(t, next.Array(), 1, "%s", next.Raw)
+			assert.Empty(t, header.Get(x.HeaderNextPageToken))
+			assert.Empty(t, header.Get("X-Total-Count"))
+			assert.ElementsMatch(t, ids, []interface{}{res.Get("0.id").String(), res.Get("1.id").String(), next.Get("0.id").String()})
+
+			t.Run("case=should link to the next page", func(t *testing.T) {
+				_, header := list(t, url.Values{"trait": {"bar=" + marker}, "per_page": {"1"}, "page_token": {token}}, http.StatusOK)
+				assert.Equal(t, next.Get("0.id").String(), header.Get(x.HeaderNextPageToken))
+				assert.Contains(t, header.Get("Link"), "page_token="+next.Get("0.id").String())
+				assert.Contains(t, header.Get("Link"), `rel="next"`)
+			})
+		})
+
+		t.Run("case=should reject invalid filters", func(t *testing.T) {
+			for _, query := range []url.Values{
+				{"state": {"unknown"}},
+				{"page_token": {"not-a-uuid"}},
+				{"created_after": {"yesterday"}},
+				{"trait": {"bar"}},
+				{"trait": {"bar'); DROP TABLE identities; --=baz"}},
+			} {
+				res, _ := list(t, query, http.StatusBadRequest)
+				assert.NotEmpty(t, res.Get("error.reason").String(), "%s", res.Raw)
+			}
+		})
+	})
+
 	t.Run("case=should not be able to update an identity that does not exist yet", func(t *testing.T) {
 		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
 			t.Run("endpoint="+name, func(t *testing.T) {
@@ -591,4 +1149,59 @@ func TestHandler(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("suite=web hook events", func(t *testing.T) {
+		events := make(chan gjson.Result, 10)
+		hookTS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			events <- gjson.ParseBytes(body)
+		}))
+		t.Cleanup(hookTS.Close)
+
+		conf.MustSet(config.ViperKeyWebHookSubscriptions, []map[string]interface{}{{
+			"events": []string{"identity.created", "identity.updated", "identity.deleted"},
+			"config": map[string]interface{}{
+				"url":    hookTS.URL,
+				"method": "POST",
+				"body": "base64://" + base64.StdEncoding.EncodeToString([]byte(`function(ctx) {
+  event: ctx.event,
+  identity: ctx.identity,
+}`)),
+			},
+		}})
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyWebHookSubscriptions, nil)
+		})
+
+		var expectEvent = func(t *testing.T, event, id, email string) {
+			e := <-events
+			assert.Equal(t, event, e.Get("event").String(), "%s", e.Raw)
+			assert.Equal(t, id, e.Get("identity.id").String(), "%s", e.Raw)
+			assert.Equal(t, email, e.Get("identity.traits.email").String(), "%s", e.Raw)
+			assert.Equal(t, "admin", e.Get("identity.metadata_admin.visible_to").String(), "%s", e.Raw)
+			assert.False(t, e.Get("identity.credentials").Exists(), "%s", e.Raw)
+		}
+
+		email := x.NewUUID().String() + "@ory.sh"
+		created := send(t, adminTS, "POST", "/identities", http.StatusCreated, &identity.AdminCreateIdentityBody{
+			SchemaID:      "customer",
+			Traits:        []byte(`{"email":"` + email + `"}`),
+			MetadataAdmin: []byte(`{"visible_to":"admin"}`),
+			Credentials: &identity.AdminIdentityImportCredentials{Password: &identity.AdminIdentityImportCredentialsPassword{
+				Config: identity.AdminIdentityImportCredentialsPasswordConfig{Password: "123456"}}},
+		})
+		id := created.Get("id").String()
+		expectEvent(t, "identity.created", id, email)
+
+		updatedEmail := x.NewUUID().String() + "@ory.sh"
+		send(t, adminTS, "PUT", "/identities/"+id, http.StatusOK, &identity.AdminUpdateIdentityBody{
+			Traits:        []byte(`{"email":"` + updatedEmail + `"}`),
+			MetadataAdmin: []byte(`{"visible_to":"admin"}`),
+		})
+		expectEvent(t, "identity.updated", id, updatedEmail)
+
+		remove(t, adminTS, "/identities/"+id, http.StatusNoContent)
+		expectEvent(t, "identity.deleted", id, updatedEmail)
+		assert.Len(t, events, 0)
+	})
 }
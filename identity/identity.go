@@ -96,6 +96,22 @@ type Identity struct {
 	// StateChangedAt contains the last time when the identity's state changed.
 	StateChangedAt *sqlxx.NullTime `json:"state_changed_at,omitempty" faker:"-" db:"state_changed_at"`
 
+	// StateChangeReason describes why the identity's state was last changed, for example `fraud` or `user request`.
+	//
+	// It is only visible using the admin API.
+	StateChangeReason string `json:"state_change_reason,omitempty" faker:"-" db:"state_change_reason"`
+
+	// InactiveAt is the time at which the identity is deactivated automatically.
+	//
+	// It is only visible using the admin API.
+	InactiveAt *sqlxx.NullTime `json:"inactive_at,omitempty" faker:"-" db:"inactive_at"`
+
+	// DeleteAt is the time at which the identity is erased permanently, together with its sessions,
+	// self-service flows, tokens, and the courier messages sent to its addresses.
+	//
+	// It is only visible using the admin API.
+	DeleteAt *sqlxx.NullTime `json:"delete_at,omitempty" faker:"-" db:"delete_at"`
+
 	// Traits represent an identity's traits. The identity is able to create, modify, and delete traits
 	// in a self-service manner. The input will always be validated against the JSON Schema defined
 	// in `schema_url`.
@@ -119,6 +135,13 @@ type Identity struct {
 	// ---
 	RecoveryAddresses []RecoveryAddress `json:"recovery_addresses,omitempty" faker:"-" has_many:"identity_recovery_addresses" fk_id:"identity_id"`
 
+	// MetadataPublic contains metadata which is visible to the identity, for example in the session, but can
+	// only be changed using the admin API.
+	MetadataPublic sqlxx.NullJSONRawMessage `json:"metadata_public" faker:"-" db:"metadata_public"`
+
+	// MetadataAdmin contains metadata which is only visible and changeable using the admin API.
+	MetadataAdmin sqlxx.NullJSONRawMessage `json:"metadata_admin,omitempty" faker:"-" db:"metadata_admin"`
+
 	// CreatedAt is a helper struct field for gobuffalo.pop.
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 
@@ -189,6 +212,18 @@ func (i *Identity) SetCredentials(t CredentialsType, c Credentials) {
 	i.Credentials[t] = c
 }
 
+// SetCredentialsWithConfig encodes the given configuration as JSON and sets the credentials of the given type.
+func (i *Identity) SetCredentialsWithConfig(t CredentialsType, c Credentials, config interface{}) (err error) {
+	c.Config, err = json.Marshal(config)
+	if err != nil {
+		return errors.WithStack(x.PseudoPanic.
+			WithDebugf("Unable to encode %s credentials to JSON: %s", t, err))
+	}
+
+	i.SetCredentials(t, c)
+	return nil
+}
+
 func (i *Identity) DeleteCredentialsType(t CredentialsType) {
 	i.lock().Lock()
 	defer i.lock().Unlock()
@@ -259,6 +294,10 @@ func (i Identity) GetNID() uuid.UUID {
 func (i Identity) MarshalJSON() ([]byte, error) {
 	type localIdentity Identity
 	i.Credentials = nil
+	i.MetadataAdmin = nil
+	i.StateChangeReason = ""
+	i.InactiveAt = nil
+	i.DeleteAt = nil
 	result, err := json.Marshal(localIdentity(i))
 	if err != nil {
 		return nil, err
@@ -273,6 +312,16 @@ func (i *Identity) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// WithAdminMetadataInJSON encodes the identity including the admin metadata and the lifecycle information, such
+// as the state change reason and the deletion schedule, but without credentials.
+type WithAdminMetadataInJSON Identity
+
+func (i WithAdminMetadataInJSON) MarshalJSON() ([]byte, error) {
+	type localIdentity Identity
+	i.Credentials = nil
+	return json.Marshal(localIdentity(i))
+}
+
 type WithCredentialsInJSON Identity
 
 func (i WithCredentialsInJSON) MarshalJSON() ([]byte, error) {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/ory/kratos/x"
 
@@ -138,6 +139,48 @@ func TestMarshalIdentityWithCredentials(t *testing.T) {
 	assert.Equal(t, credentials, i.Credentials, "Original credentials should not be touched by marshalling")
 }
 
+func TestMarshalIdentityWithAdminMetadata(t *testing.T) {
+	i := NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	i.MetadataPublic = sqlxx.NullJSONRawMessage(`{"plan":"pro"}`)
+	i.MetadataAdmin = sqlxx.NullJSONRawMessage(`{"risk":"low"}`)
+	i.Credentials = map[CredentialsType]Credentials{CredentialsTypePassword: {Type: CredentialsTypePassword}}
+
+	var b bytes.Buffer
+	require.NoError(t, json.NewEncoder(&b).Encode(i))
+	assert.JSONEq(t, `{"plan":"pro"}`, gjson.Get(b.String(), "metadata_public").Raw)
+	assert.False(t, gjson.Get(b.String(), "metadata_admin").Exists(), "Admin metadata should not be rendered to json")
+
+	b.Reset()
+	require.NoError(t, json.NewEncoder(&b).Encode(WithAdminMetadataInJSON(*i)))
+	assert.JSONEq(t, `{"plan":"pro"}`, gjson.Get(b.String(), "metadata_public").Raw)
+	assert.JSONEq(t, `{"risk":"low"}`, gjson.Get(b.String(), "metadata_admin").Raw)
+	assert.False(t, gjson.Get(b.String(), "credentials").Exists())
+
+	assert.NotEmpty(t, i.MetadataAdmin, "Original admin metadata should not be touched by marshalling")
+}
+
+func TestMarshalIdentityWithLifecycle(t *testing.T) {
+	at := sqlxx.NullTime(time.Now().UTC())
+	i := NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	i.StateChangeReason = "fraud"
+	i.InactiveAt = &at
+	i.DeleteAt = &at
+
+	var b bytes.Buffer
+	require.NoError(t, json.NewEncoder(&b).Encode(i))
+	for _, path := range []string{"state_change_reason", "inactive_at", "delete_at"} {
+		assert.False(t, gjson.Get(b.String(), path).Exists(), "%s should not be rendered to json", path)
+	}
+
+	b.Reset()
+	require.NoError(t, json.NewEncoder(&b).Encode(WithAdminMetadataInJSON(*i)))
+	assert.Equal(t, "fraud", gjson.Get(b.String(), "state_change_reason").String())
+	assert.True(t, gjson.Get(b.String(), "inactive_at").Exists())
+	assert.True(t, gjson.Get(b.String(), "delete_at").Exists())
+
+	assert.Equal(t, "fraud", i.StateChangeReason, "Original lifecycle information should not be touched by marshalling")
+}
+
 func TestValidateNID(t *testing.T) {
 	nid := x.NewUUID()
 	for k, tc := range []struct {
@@ -0,0 +1,166 @@
+package identity
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/ratelimit"
+	"github.com/ory/kratos/x"
+)
+
+const lifecycleBatchSize = 100
+
+type (
+	lifecycleDependencies interface {
+		PrivilegedPoolProvider
+		ratelimit.PersistenceProvider
+		config.Provider
+		x.LoggingProvider
+	}
+	LifecycleProvider interface {
+		IdentityLifecycle() *Lifecycle
+	}
+	// Lifecycle deactivates and erases identities once their scheduled time has come, and deletes expired rate
+	// limit counters.
+	Lifecycle struct {
+		r lifecycleDependencies
+	}
+)
+
+func NewLifecycle(r lifecycleDependencies) *Lifecycle {
+	return &Lifecycle{r: r}
+}
+
+// Work executes the lifecycle schedule in the configured interval until the context is canceled.
+func (l *Lifecycle) Work(ctx context.Context) error {
+	for {
+		if err := backoff.Retry(func() error {
+			return l.ExecuteSchedule(ctx)
+		}, backoff.NewExponentialBackOff()); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+			return ctx.Err()
+		case <-time.After(l.r.Config(ctx).IdentityLifecycleInterval()):
+		}
+	}
+}
+
+// ExecuteSchedule deactivates all identities whose deactivation is due and then erases all identities whose
+// erasure is due. Finally, it deletes all rate limit counters whose window ended.
+func (l *Lifecycle) ExecuteSchedule(ctx context.Context) error {
+	if err := l.deactivateDue(ctx); err != nil {
+		return err
+	}
+	if err := l.eraseDue(ctx); err != nil {
+		return err
+	}
+	return l.r.RateLimitPersister().DeleteExpiredRateLimitCounters(ctx, time.Now())
+}
+
+func (l *Lifecycle) deactivateDue(ctx context.Context) error {
+	pool := l.r.PrivilegedIdentityPool()
+	gracePeriod := l.r.Config(ctx).IdentityDeletionGracePeriod()
+
+	for {
+		ids, err := pool.ListIdentityIDsDueForDeactivation(ctx, time.Now(), lifecycleBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			i, err := pool.GetIdentityConfidential(ctx, id)
+			if errors.Is(err, sqlcon.ErrNoRows) {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			updatedAt := i.UpdatedAt
+			i.InactiveAt = nil
+			if i.State != StateInactive {
+				// The reason is set by the administrator when scheduling the deactivation.
+				i.changeState(StateInactive, i.StateChangeReason, gracePeriod)
+			}
+
+			// The identity is not updated if an administrator updated it in the meantime, for example to cancel
+			// the deactivation. If it is still due, it is deactivated in the next iteration.
+			if err := pool.UpdateIdentityIfUnmodified(ctx, i, updatedAt); errors.Is(err, ErrIdentityModified) || errors.Is(err, sqlcon.ErrNoRows) {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			l.r.Logger().WithField("identity_id", id).Info("Deactivated identity as scheduled.")
+		}
+
+		if len(ids) < lifecycleBatchSize {
+			return nil
+		}
+	}
+}
+
+func (l *Lifecycle) eraseDue(ctx context.Context) error {
+	pool := l.r.PrivilegedIdentityPool()
+
+	for {
+		ids, err := pool.ListIdentityIDsDueForErasure(ctx, time.Now(), lifecycleBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err := pool.EraseIdentity(ctx, id); err != nil && !errors.Is(err, sqlcon.ErrNoRows) {
+				return err
+			}
+
+			l.r.Logger().WithField("identity_id", id).Info("Erased identity as scheduled.")
+		}
+
+		if len(ids) < lifecycleBatchSize {
+			return nil
+		}
+	}
+}
+
+// changeState sets the identity's state and records when and why it was changed. Deactivated identities are
+// scheduled to be erased after the grace period.
+func (i *Identity) changeState(state State, reason string, gracePeriod time.Duration) {
+	changedAt := sqlxx.NullTime(time.Now().UTC())
+	i.State = state
+	i.StateChangedAt = &changedAt
+	i.StateChangeReason = reason
+	i.scheduleErasure(gracePeriod)
+}
+
+// scheduleErasure schedules an inactive identity to be erased after the grace period, unless its erasure is
+// scheduled already or the grace period is zero.
+func (i *Identity) scheduleErasure(gracePeriod time.Duration) {
+	if i.State != StateInactive || i.DeleteAt != nil || gracePeriod <= 0 {
+		return
+	}
+
+	deleteAt := sqlxx.NullTime(time.Now().UTC().Add(gracePeriod))
+	i.DeleteAt = &deleteAt
+}
+
+func nullTime(t *time.Time) *sqlxx.NullTime {
+	if t == nil {
+		return nil
+	}
+
+	nt := sqlxx.NullTime(t.UTC())
+	return &nt
+}
@@ -0,0 +1,144 @@
+package identity_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+func TestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/identity.schema.json")
+	conf.MustSet(config.ViperKeyIdentityLifecycleDeletionGracePeriod, "24h")
+
+	at := func(d time.Duration) *sqlxx.NullTime {
+		t := sqlxx.NullTime(time.Now().UTC().Add(d))
+		return &t
+	}
+
+	create := func(t *testing.T, inactiveAt, deleteAt *sqlxx.NullTime) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.InactiveAt = inactiveAt
+		i.DeleteAt = deleteAt
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		return i
+	}
+
+	get := func(t *testing.T, i *identity.Identity) *identity.Identity {
+		actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, i.ID)
+		require.NoError(t, err)
+		return actual
+	}
+
+	t.Run("case=deactivates due identities and schedules their erasure", func(t *testing.T) {
+		due := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		due.InactiveAt = at(-time.Minute)
+		due.StateChangeReason = "contract ended"
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, due))
+		notDue := create(t, at(time.Hour), nil)
+
+		require.NoError(t, reg.IdentityLifecycle().ExecuteSchedule(ctx))
+
+		actual := get(t, due)
+		assert.Equal(t, identity.StateInactive, actual.State)
+		assert.Equal(t, "contract ended", actual.StateChangeReason)
+		assert.Nil(t, actual.InactiveAt)
+		require.NotNil(t, actual.DeleteAt)
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), time.Time(*actual.DeleteAt), time.Minute)
+
+		actual = get(t, notDue)
+		assert.Equal(t, identity.StateActive, actual.State)
+		assert.NotNil(t, actual.InactiveAt)
+		assert.Nil(t, actual.DeleteAt)
+	})
+
+	t.Run("case=keeps the scheduled erasure when deactivating", func(t *testing.T) {
+		i := create(t, at(-time.Minute), at(time.Hour))
+
+		require.NoError(t, reg.IdentityLifecycle().ExecuteSchedule(ctx))
+
+		actual := get(t, i)
+		assert.Equal(t, identity.StateInactive, actual.State)
+		require.NotNil(t, actual.DeleteAt)
+		assert.WithinDuration(t, time.Time(*i.DeleteAt), time.Time(*actual.DeleteAt), time.Second)
+	})
+
+	t.Run("case=does not schedule the erasure without grace period", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyIdentityLifecycleDeletionGracePeriod, "0s")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyIdentityLifecycleDeletionGracePeriod, "24h")
+		})
+
+		i := create(t, at(-time.Minute), nil)
+
+		require.NoError(t, reg.IdentityLifecycle().ExecuteSchedule(ctx))
+
+		actual := get(t, i)
+		assert.Equal(t, identity.StateInactive, actual.State)
+		assert.Nil(t, actual.DeleteAt)
+	})
+
+	t.Run("case=erases due identities", func(t *testing.T) {
+		due := create(t, nil, at(-time.Minute))
+		notDue := create(t, nil, at(time.Hour))
+
+		s, err := session.NewActiveSession(due, conf, time.Now(), identity.CredentialsTypePassword)
+		require.NoError(t, err)
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+
+		require.NoError(t, reg.IdentityLifecycle().ExecuteSchedule(ctx))
+
+		_, err = reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, due.ID)
+		assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+		_, err = reg.SessionPersister().GetSession(ctx, s.ID)
+		assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+		get(t, notDue)
+	})
+
+	t.Run("case=deletes expired rate limit counters", func(t *testing.T) {
+		expired, active := x.NewUUID().String(), x.NewUUID().String()
+		_, err := reg.RateLimitPersister().HitRateLimitCounter(ctx, expired, time.Minute, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		_, err = reg.RateLimitPersister().HitRateLimitCounter(ctx, active, time.Hour, time.Now())
+		require.NoError(t, err)
+
+		require.NoError(t, reg.IdentityLifecycle().ExecuteSchedule(ctx))
+
+		assert.ErrorIs(t, reg.RateLimitPersister().LockRateLimitCounter(ctx, expired, time.Now().Add(time.Hour)), sqlcon.ErrNoRows)
+		assert.NoError(t, reg.RateLimitPersister().LockRateLimitCounter(ctx, active, time.Now().Add(time.Hour)))
+	})
+
+	t.Run("case=works until the context is canceled", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyIdentityLifecycleInterval, "10ms")
+
+		ctx, cancel := context.WithCancel(ctx)
+		done := make(chan error)
+		go func() {
+			done <- reg.IdentityLifecycle().Work(ctx)
+		}()
+
+		i := create(t, nil, at(-time.Minute))
+		assert.Eventually(t, func() bool {
+			_, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, i.ID)
+			return err != nil
+		}, 5*time.Second, 10*time.Millisecond)
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
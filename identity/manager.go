@@ -3,6 +3,7 @@ package identity
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/gofrs/uuid"
 
@@ -19,6 +20,11 @@ import (
 var ErrProtectedFieldModified = herodot.ErrForbidden.
 	WithReasonf(`A field was modified that updates one or more credentials-related settings. This action was blocked because an unprivileged method was used to execute the update. This is either a configuration issue or a bug and should be reported to the system administrator.`)
 
+// ErrIdentityModified is returned if an identity was modified after it was loaded for an update.
+var ErrIdentityModified = herodot.ErrConflict.
+	WithError("The identity was modified concurrently").
+	WithReasonf(`The identity was updated after it was loaded. Fetch the identity again and retry the update.`)
+
 type (
 	managerDependencies interface {
 		PoolProvider
@@ -35,6 +41,7 @@ type (
 	managerOptions struct {
 		ExposeValidationErrors    bool
 		AllowWriteProtectedTraits bool
+		ExpectedUpdatedAt         *time.Time
 	}
 
 	ManagerOption func(*managerOptions)
@@ -52,6 +59,14 @@ func ManagerAllowWriteProtectedTraits(options *managerOptions) {
 	options.AllowWriteProtectedTraits = true
 }
 
+// ManagerExpectUpdatedAt makes updates fail with ErrIdentityModified if the stored identity was updated at a
+// different time, which means that it was modified concurrently.
+func ManagerExpectUpdatedAt(updatedAt time.Time) ManagerOption {
+	return func(options *managerOptions) {
+		options.ExpectedUpdatedAt = &updatedAt
+	}
+}
+
 func newManagerOptions(opts []ManagerOption) *managerOptions {
 	var o managerOptions
 	for _, f := range opts {
@@ -69,6 +84,19 @@ func (m *Manager) Create(ctx context.Context, i *Identity, opts ...ManagerOption
 	return m.r.IdentityPool().(PrivilegedPool).CreateIdentity(ctx, i)
 }
 
+// CreateIdentities validates the given identities and creates them in a single transaction. Either all or none of
+// the identities are created.
+func (m *Manager) CreateIdentities(ctx context.Context, identities []*Identity, opts ...ManagerOption) error {
+	o := newManagerOptions(opts)
+	for _, i := range identities {
+		if err := m.validate(ctx, i, o); err != nil {
+			return err
+		}
+	}
+
+	return m.r.IdentityPool().(PrivilegedPool).CreateIdentities(ctx, identities...)
+}
+
 func (m *Manager) requiresPrivilegedAccess(_ context.Context, original, updated *Identity, o *managerOptions) error {
 	if !o.AllowWriteProtectedTraits {
 		if !CredentialsEqual(updated.Credentials, original.Credentials) {
@@ -103,6 +131,10 @@ func (m *Manager) Update(ctx context.Context, updated *Identity, opts ...Manager
 		return err
 	}
 
+	if o.ExpectedUpdatedAt != nil {
+		return m.r.IdentityPool().(PrivilegedPool).UpdateIdentityIfUnmodified(ctx, updated, *o.ExpectedUpdatedAt)
+	}
+
 	return m.r.IdentityPool().(PrivilegedPool).UpdateIdentity(ctx, updated)
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -108,6 +109,25 @@ func TestManager(t *testing.T) {
 			checkExtensionFields(fromStore, "email-update-1@ory.sh")(t)
 		})
 
+		t.Run("case=should not update identity if it was modified concurrently", func(t *testing.T) {
+			original := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			original.Traits = newTraits("email-concurrent-1@ory.sh", "")
+			require.NoError(t, reg.IdentityManager().Create(context.Background(), original))
+
+			fromStore, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), original.ID)
+			require.NoError(t, err)
+
+			fromStore.Traits = newTraits("email-concurrent-2@ory.sh", "")
+			err = reg.IdentityManager().Update(context.Background(), fromStore, identity.ManagerAllowWriteProtectedTraits,
+				identity.ManagerExpectUpdatedAt(fromStore.UpdatedAt.Add(-time.Second)))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, identity.ErrIdentityModified)
+
+			require.NoError(t, reg.IdentityManager().Update(context.Background(), fromStore, identity.ManagerAllowWriteProtectedTraits,
+				identity.ManagerExpectUpdatedAt(fromStore.UpdatedAt)))
+			checkExtensionFieldsForIdentities(t, "email-concurrent-2@ory.sh", fromStore)
+		})
+
 		t.Run("case=changing recovery address removes it from the store", func(t *testing.T) {
 			originalEmail := x.NewUUID().String() + "@ory.sh"
 			original := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
@@ -0,0 +1,37 @@
+package identity
+
+import (
+	"strings"
+
+	"github.com/nyaruka/phonenumbers"
+	"github.com/pkg/errors"
+)
+
+var ErrInvalidPhoneNumber = errors.New("the value is not a valid phone number")
+
+// NormalizePhoneNumber parses the given phone number and returns it in the E.164 format
+// (e.g. `+491701234567`). Numbers without an international prefix are interpreted
+// as national numbers of the given default region (an ISO 3166-1 alpha-2 code such as
+// `DE`). If no default region is set, only numbers in international format are accepted.
+func NormalizePhoneNumber(value, defaultRegion string) (string, error) {
+	n, err := phonenumbers.Parse(strings.TrimSpace(value), strings.ToUpper(defaultRegion))
+	if err != nil {
+		return "", errors.WithStack(ErrInvalidPhoneNumber)
+	}
+
+	if !phonenumbers.IsValidNumber(n) {
+		return "", errors.WithStack(ErrInvalidPhoneNumber)
+	}
+
+	return phonenumbers.Format(n, phonenumbers.E164), nil
+}
+
+// normalizePhoneNumberOrSelf returns the E.164 representation of the phone number or the value
+// itself if it can not be normalized. It is used to match values which were stored before phone
+// numbers were normalized.
+func normalizePhoneNumberOrSelf(value, defaultRegion string) string {
+	if normalized, err := NormalizePhoneNumber(value, defaultRegion); err == nil {
+		return normalized
+	}
+	return value
+}
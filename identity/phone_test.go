@@ -0,0 +1,35 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	for k, tc := range []struct {
+		in, region, expected string
+		err                  bool
+	}{
+		{in: "+49 170 1234567", expected: "+491701234567"},
+		{in: "+49 (0)170 1234567", expected: "+491701234567"},
+		{in: "01701234567", region: "DE", expected: "+491701234567"},
+		{in: "0170 123 45 67", region: "de", expected: "+491701234567"},
+		{in: "+1 (415) 555-2671", region: "DE", expected: "+14155552671"},
+		{in: "(415) 555-2671", region: "US", expected: "+14155552671"},
+		{in: "01701234567", err: true},
+		{in: "not-a-number", region: "DE", err: true},
+		{in: "+49 1", err: true},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			actual, err := NormalizePhoneNumber(tc.in, tc.region)
+			if tc.err {
+				require.ErrorIs(t, err, ErrInvalidPhoneNumber, "%d", k)
+				return
+			}
+			require.NoError(t, err, "%d", k)
+			assert.Equal(t, tc.expected, actual, "%d", k)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package identity
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
@@ -11,9 +12,17 @@ type (
 		// ListIdentities lists all identities in the store given the page and itemsPerPage.
 		ListIdentities(ctx context.Context, page, itemsPerPage int) ([]Identity, error)
 
+		// SearchIdentities lists the identities in the store which match the parameters, ordered by their ID in
+		// descending order.
+		SearchIdentities(ctx context.Context, params ListIdentitiesParameters) ([]Identity, error)
+
 		// CountIdentities counts the number of identities in the store.
 		CountIdentities(ctx context.Context) (int64, error)
 
+		// CountSearchedIdentities counts the number of identities in the store which match the parameters. Pagination
+		// parameters are ignored.
+		CountSearchedIdentities(ctx context.Context, params ListIdentitiesParameters) (int64, error)
+
 		// GetIdentity returns an identity by its id. Will return an error if the identity does not exist or backend
 		// connectivity is broken.
 		GetIdentity(context.Context, uuid.UUID) (*Identity, error)
@@ -43,6 +52,19 @@ type (
 		// if identity exists, backend connectivity is broken, or trait validation fails.
 		DeleteIdentity(context.Context, uuid.UUID) error
 
+		// EraseIdentity removes an identity by its id together with all data referencing it, such as sessions,
+		// self-service flows and tokens, the courier messages sent to its verifiable and recovery addresses, the
+		// queued web hook deliveries containing its data, and the rate limit counters of its identifiers.
+		EraseIdentity(context.Context, uuid.UUID) error
+
+		// ListIdentityIDsDueForDeactivation returns the IDs of up to limit identities which are scheduled to be
+		// deactivated at or before the given time, ordered by the scheduled time.
+		ListIdentityIDsDueForDeactivation(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error)
+
+		// ListIdentityIDsDueForErasure returns the IDs of up to limit identities which are scheduled to be erased at
+		// or before the given time, ordered by the scheduled time.
+		ListIdentityIDsDueForErasure(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error)
+
 		// UpdateVerifiableAddress updates an identity's verifiable address.
 		UpdateVerifiableAddress(ctx context.Context, address *VerifiableAddress) error
 
@@ -50,9 +72,17 @@ type (
 		// if identity exists, backend connectivity is broken, or trait validation fails.
 		CreateIdentity(context.Context, *Identity) error
 
+		// CreateIdentities creates multiple identities in a single transaction using CreateIdentity. Either all or
+		// none of the identities are created.
+		CreateIdentities(context.Context, ...*Identity) error
+
 		// UpdateIdentity updates an identity including its confidential / privileged / protected data.
 		UpdateIdentity(context.Context, *Identity) error
 
+		// UpdateIdentityIfUnmodified updates an identity like UpdateIdentity, but only if the stored identity was last
+		// updated at updatedAt. Otherwise, ErrIdentityModified is returned.
+		UpdateIdentityIfUnmodified(ctx context.Context, i *Identity, updatedAt time.Time) error
+
 		// GetIdentityConfidential returns the identity including it's raw credentials. This should only be used internally.
 		GetIdentityConfidential(context.Context, uuid.UUID) (*Identity, error)
 
@@ -0,0 +1,87 @@
+package identity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+)
+
+var traitPathPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+
+// ListIdentitiesParameters filters and paginates the identities returned by Pool.SearchIdentities.
+//
+// Zero values do not filter. All filters which are set must match.
+type ListIdentitiesParameters struct {
+	// CredentialsIdentifier matches identities having a credential with this identifier, for example
+	// the email address used to sign in with a password or `<provider>:<subject>` for OpenID Connect.
+	CredentialsIdentifier string
+
+	// VerifiableAddress matches identities having this verifiable address.
+	VerifiableAddress string
+
+	// RecoveryAddress matches identities having this recovery address.
+	RecoveryAddress string
+
+	// State matches identities in this state.
+	State State
+
+	// SchemaID matches identities using this identity schema.
+	SchemaID string
+
+	// CreatedAfter matches identities created at or after this time.
+	CreatedAfter time.Time
+
+	// CreatedBefore matches identities created before this time.
+	CreatedBefore time.Time
+
+	// Traits matches identities whose traits have the given values.
+	Traits []TraitFilter
+
+	// Page is the page to return when using offset pagination. It is ignored if PageToken is set.
+	Page int
+
+	// PerPage is the maximum number of identities to return.
+	PerPage int
+
+	// PageToken enables keyset pagination. Only identities listed after the identity with this ID are returned.
+	PageToken uuid.UUID
+}
+
+// TraitFilter matches identities whose trait at Path has the value Value.
+type TraitFilter struct {
+	// Path is a dot-separated path into the traits, for example `name.first`.
+	Path string
+
+	// Value is compared with the string representation of the trait.
+	Value string
+}
+
+// NewTraitFilter parses a filter in the format `<path>=<value>`.
+func NewTraitFilter(filter string) (TraitFilter, error) {
+	parts := strings.SplitN(filter, "=", 2)
+	if len(parts) != 2 {
+		return TraitFilter{}, errors.WithStack(herodot.ErrBadRequest.WithReasonf(`Trait filter "%s" must have the format "<path>=<value>".`, filter))
+	}
+
+	f := TraitFilter{Path: parts[0], Value: parts[1]}
+	if !traitPathPattern.MatchString(f.Path) {
+		return TraitFilter{}, errors.WithStack(herodot.ErrBadRequest.WithReasonf(`Trait path "%s" must consist of dot-separated keys containing only letters, digits, "_" and "-".`, f.Path))
+	}
+
+	return f, nil
+}
+
+// PathSegments returns the keys of the path.
+func (f TraitFilter) PathSegments() []string {
+	return strings.Split(f.Path, ".")
+}
+
+// IsKeyset returns true if the parameters request keyset pagination.
+func (p *ListIdentitiesParameters) IsKeyset() bool {
+	return p.PageToken != uuid.Nil
+}
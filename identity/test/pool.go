@@ -12,8 +12,12 @@ import (
 
 	"github.com/ory/kratos/internal/testhelpers"
 
+	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/persistence"
+	"github.com/ory/kratos/ratelimit"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/webhook"
 
 	"github.com/bxcodec/faker/v3"
 
@@ -306,6 +310,223 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 			require.Contains(t, err.Error(), "malformed")
 		})
 
+		t.Run("case=create and update metadata", func(t *testing.T) {
+			expected := passwordIdentity("", x.NewUUID().String())
+			expected.MetadataPublic = sqlxx.NullJSONRawMessage(`{"plan":"pro"}`)
+			expected.MetadataAdmin = sqlxx.NullJSONRawMessage(`{"risk":"low"}`)
+			require.NoError(t, p.CreateIdentity(ctx, expected))
+			createdIDs = append(createdIDs, expected.ID)
+
+			actual, err := p.GetIdentity(ctx, expected.ID)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"plan":"pro"}`, string(actual.MetadataPublic))
+			assert.JSONEq(t, `{"risk":"low"}`, string(actual.MetadataAdmin))
+
+			actual.MetadataPublic = nil
+			actual.MetadataAdmin = sqlxx.NullJSONRawMessage(`{"risk":"high"}`)
+			require.NoError(t, p.UpdateIdentity(ctx, actual))
+
+			actual, err = p.GetIdentity(ctx, expected.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "null", string(actual.MetadataPublic))
+			assert.JSONEq(t, `{"risk":"high"}`, string(actual.MetadataAdmin))
+		})
+
+		t.Run("case=list identities due for deactivation and erasure", func(t *testing.T) {
+			now := time.Now().UTC()
+			schedule := func(d time.Duration) *sqlxx.NullTime {
+				t := sqlxx.NullTime(now.Add(d))
+				return &t
+			}
+
+			dueLater := passwordIdentity("", x.NewUUID().String())
+			dueLater.InactiveAt = schedule(-time.Minute)
+			dueLater.DeleteAt = schedule(-time.Minute)
+			dueFirst := passwordIdentity("", x.NewUUID().String())
+			dueFirst.InactiveAt = schedule(-time.Hour)
+			dueFirst.DeleteAt = schedule(-time.Hour)
+			notDue := passwordIdentity("", x.NewUUID().String())
+			notDue.InactiveAt = schedule(time.Hour)
+			notDue.DeleteAt = schedule(time.Hour)
+			for _, i := range []*identity.Identity{dueLater, dueFirst, notDue} {
+				require.NoError(t, p.CreateIdentity(ctx, i))
+				createdIDs = append(createdIDs, i.ID)
+			}
+
+			for name, list := range map[string]func(context.Context, time.Time, int) ([]uuid.UUID, error){
+				"deactivation": p.ListIdentityIDsDueForDeactivation,
+				"erasure":      p.ListIdentityIDsDueForErasure,
+			} {
+				t.Run("schedule="+name, func(t *testing.T) {
+					ids, err := list(ctx, now, 10)
+					require.NoError(t, err)
+					assert.Equal(t, []uuid.UUID{dueFirst.ID, dueLater.ID}, ids)
+
+					ids, err = list(ctx, now, 1)
+					require.NoError(t, err)
+					assert.Equal(t, []uuid.UUID{dueFirst.ID}, ids)
+				})
+			}
+
+			t.Run("can not list on another network", func(t *testing.T) {
+				_, p := testhelpers.NewNetwork(t, ctx, p)
+				ids, err := p.ListIdentityIDsDueForDeactivation(ctx, now, 10)
+				require.NoError(t, err)
+				assert.Empty(t, ids)
+
+				ids, err = p.ListIdentityIDsDueForErasure(ctx, now, 10)
+				require.NoError(t, err)
+				assert.Empty(t, ids)
+			})
+
+			for _, i := range []*identity.Identity{dueLater, dueFirst, notDue} {
+				i.InactiveAt, i.DeleteAt = nil, nil
+				require.NoError(t, p.UpdateIdentity(ctx, i))
+			}
+		})
+
+		t.Run("case=erase identity", func(t *testing.T) {
+			email := x.NewUUID().String() + "@ory.sh"
+			username := "user-" + x.NewUUID().String()
+			i := passwordIdentity("", username)
+			i.VerifiableAddresses = []identity.VerifiableAddress{*identity.NewVerifiableEmailAddress(email, i.ID)}
+			i.RecoveryAddresses = []identity.RecoveryAddress{*identity.NewRecoveryEmailAddress(email, i.ID)}
+			require.NoError(t, p.CreateIdentity(ctx, i))
+
+			s, err := session.NewActiveSession(i, conf, time.Now(), identity.CredentialsTypePassword)
+			require.NoError(t, err)
+			require.NoError(t, p.UpsertSession(ctx, s))
+
+			erased := &courier.Message{Type: courier.MessageTypeEmail, Status: courier.MessageStatusSent, Recipient: email, Subject: "subject", Body: "body", TemplateType: courier.TypeTestStub}
+			kept := &courier.Message{Type: courier.MessageTypeEmail, Status: courier.MessageStatusSent, Recipient: "kept-" + email, Subject: "subject", Body: "body", TemplateType: courier.TypeTestStub}
+			require.NoError(t, p.AddMessage(ctx, erased))
+			require.NoError(t, p.AddMessage(ctx, kept))
+
+			erasedDelivery := &webhook.Delivery{Method: "POST", URL: "https://www.ory.sh/", Headers: webhook.DeliveryHeader{}, Body: email, IdentityID: uuid.NullUUID{UUID: i.ID, Valid: true}, NextAttemptAt: time.Now()}
+			keptDelivery := &webhook.Delivery{Method: "POST", URL: "https://www.ory.sh/", Headers: webhook.DeliveryHeader{}, Body: "{}", NextAttemptAt: time.Now()}
+			require.NoError(t, p.AddDelivery(ctx, erasedDelivery))
+			require.NoError(t, p.AddDelivery(ctx, keptDelivery))
+
+			keptKeys := ratelimit.IdentifierCounterKeys("kept-" + email)
+			counterKeys := append(ratelimit.IdentifierCounterKeys(email), ratelimit.IdentifierCounterKeys(username)...)
+			for _, key := range append(counterKeys, keptKeys...) {
+				_, err := p.HitRateLimitCounter(ctx, key, time.Hour, time.Now())
+				require.NoError(t, err)
+			}
+
+			t.Run("can not erase on another network", func(t *testing.T) {
+				_, p := testhelpers.NewNetwork(t, ctx, p)
+				require.ErrorIs(t, p.EraseIdentity(ctx, i.ID), sqlcon.ErrNoRows)
+			})
+
+			require.NoError(t, p.EraseIdentity(ctx, i.ID))
+
+			_, err = p.GetIdentity(ctx, i.ID)
+			require.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+			_, err = p.GetSession(ctx, s.ID)
+			require.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+			_, err = p.FetchMessage(ctx, erased.ID)
+			require.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+			_, err = p.FetchMessage(ctx, kept.ID)
+			require.NoError(t, err)
+
+			_, err = p.GetDelivery(ctx, erasedDelivery.ID)
+			require.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+			_, err = p.GetDelivery(ctx, keptDelivery.ID)
+			require.NoError(t, err)
+
+			for _, key := range counterKeys {
+				c, err := p.HitRateLimitCounter(ctx, key, time.Hour, time.Now())
+				require.NoError(t, err)
+				assert.Equal(t, 1, c.Hits, "the counter must have been deleted")
+			}
+			for _, key := range keptKeys {
+				c, err := p.HitRateLimitCounter(ctx, key, time.Hour, time.Now())
+				require.NoError(t, err)
+				assert.Equal(t, 2, c.Hits)
+			}
+
+			require.ErrorIs(t, p.EraseIdentity(ctx, i.ID), sqlcon.ErrNoRows)
+		})
+
+		t.Run("case=update an identity only if it was not modified", func(t *testing.T) {
+			initial := oidcIdentity("", x.NewUUID().String())
+			require.NoError(t, p.CreateIdentity(ctx, initial))
+			createdIDs = append(createdIDs, initial.ID)
+
+			stored, err := p.GetIdentityConfidential(ctx, initial.ID)
+			require.NoError(t, err)
+			seen := stored.UpdatedAt
+
+			stored.Traits = identity.Traits(`{"update":"unmodified"}`)
+			require.NoError(t, p.UpdateIdentityIfUnmodified(ctx, stored, seen))
+			assert.True(t, stored.UpdatedAt.After(seen))
+
+			actual, err := p.GetIdentityConfidential(ctx, initial.ID)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"update":"unmodified"}`, string(actual.Traits))
+			assert.True(t, actual.UpdatedAt.Equal(stored.UpdatedAt), "%s != %s", actual.UpdatedAt, stored.UpdatedAt)
+
+			t.Run("fails if the identity was modified", func(t *testing.T) {
+				actual.Traits = identity.Traits(`{"update":"stale"}`)
+				require.ErrorIs(t, p.UpdateIdentityIfUnmodified(ctx, actual, seen), identity.ErrIdentityModified)
+
+				current, err := p.GetIdentityConfidential(ctx, initial.ID)
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"update":"unmodified"}`, string(current.Traits))
+			})
+
+			t.Run("fails if the identity was updated in the meantime", func(t *testing.T) {
+				read, err := p.GetIdentityConfidential(ctx, initial.ID)
+				require.NoError(t, err)
+				seen := read.UpdatedAt
+
+				concurrent, err := p.GetIdentityConfidential(ctx, initial.ID)
+				require.NoError(t, err)
+				concurrent.Traits = identity.Traits(`{"update":"concurrent"}`)
+				require.NoError(t, p.UpdateIdentity(ctx, concurrent))
+				assert.True(t, concurrent.UpdatedAt.After(seen), "every update must bump the update time")
+
+				read.Traits = identity.Traits(`{"update":"stale"}`)
+				require.ErrorIs(t, p.UpdateIdentityIfUnmodified(ctx, read, seen), identity.ErrIdentityModified)
+				assert.True(t, read.UpdatedAt.Equal(seen))
+
+				current, err := p.GetIdentityConfidential(ctx, initial.ID)
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"update":"concurrent"}`, string(current.Traits))
+				assert.True(t, current.UpdatedAt.Equal(concurrent.UpdatedAt), "%s != %s", current.UpdatedAt, concurrent.UpdatedAt)
+			})
+
+			t.Run("fails on different network", func(t *testing.T) {
+				_, p := testhelpers.NewNetwork(t, ctx, p)
+				require.ErrorIs(t, p.UpdateIdentityIfUnmodified(ctx, actual, actual.UpdatedAt), sqlcon.ErrNoRows)
+			})
+		})
+
+		t.Run("case=should create multiple identities in a single transaction", func(t *testing.T) {
+			first, second := passwordIdentity("", x.NewUUID().String()), oidcIdentity("", x.NewUUID().String())
+			require.NoError(t, p.CreateIdentities(ctx, first, second))
+			createdIDs = append(createdIDs, first.ID, second.ID)
+
+			for _, expected := range []*identity.Identity{first, second} {
+				actual, err := p.GetIdentity(ctx, expected.ID)
+				require.NoError(t, err)
+				assertEqual(t, expected, actual)
+			}
+
+			t.Run("case=should roll back all identities if one fails", func(t *testing.T) {
+				valid, conflicting := passwordIdentity("", x.NewUUID().String()), oidcIdentity("", second.Credentials[identity.CredentialsTypeOIDC].Identifiers[0])
+				require.Error(t, p.CreateIdentities(ctx, valid, conflicting))
+
+				_, err := p.GetIdentity(ctx, valid.ID)
+				assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+			})
+		})
+
 		t.Run("case=should fail to insert identity because credentials from traits exist", func(t *testing.T) {
 			first := passwordIdentity("", "test-identity@ory.sh")
 			first.Traits = identity.Traits(`{}`)
@@ -416,6 +637,121 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 			})
 		})
 
+		t.Run("case=search", func(t *testing.T) {
+			_, p := testhelpers.NewNetwork(t, ctx, p)
+
+			alice := passwordIdentity("", "search-alice@ory.sh")
+			alice.Traits = identity.Traits(`{"email":"search-alice@ory.sh","name":{"first":"Alice"},"age":30}`)
+			alice.CreatedAt = time.Now().Add(-time.Hour)
+			alice.VerifiableAddresses = []identity.VerifiableAddress{*identity.NewVerifiableEmailAddress("search-alice@ory.sh", alice.ID)}
+			require.NoError(t, p.CreateIdentity(ctx, alice))
+
+			bob := oidcIdentity(altSchema.ID, "google:search-bob")
+			bob.Traits = identity.Traits(`{"name":{"first":"Bob"}}`)
+			bob.State = identity.StateInactive
+			bob.RecoveryAddresses = []identity.RecoveryAddress{*identity.NewRecoveryEmailAddress("search-bob@ory.sh", bob.ID)}
+			require.NoError(t, p.CreateIdentity(ctx, bob))
+
+			carol := oidcIdentity("", "google:search-carol")
+			carol.Traits = identity.Traits(`{"name":{"first":"Alice"}}`)
+			require.NoError(t, p.CreateIdentity(ctx, carol))
+
+			search := func(t *testing.T, params identity.ListIdentitiesParameters) []uuid.UUID {
+				is, err := p.SearchIdentities(ctx, params)
+				require.NoError(t, err, "%+v", err)
+
+				count, err := p.CountSearchedIdentities(ctx, params)
+				require.NoError(t, err)
+				assert.EqualValues(t, len(is), count)
+
+				ids := make([]uuid.UUID, len(is))
+				for k := range is {
+					ids[k] = is[k].ID
+				}
+				return ids
+			}
+
+			halfAnHourAgo := time.Now().Add(-30 * time.Minute)
+			for k, tc := range []struct {
+				params   identity.ListIdentitiesParameters
+				expected []*identity.Identity
+			}{
+				{params: identity.ListIdentitiesParameters{}, expected: []*identity.Identity{alice, bob, carol}},
+				{params: identity.ListIdentitiesParameters{CredentialsIdentifier: " Search-Alice@ory.sh"}, expected: []*identity.Identity{alice}},
+				{params: identity.ListIdentitiesParameters{CredentialsIdentifier: "google:search-bob"}, expected: []*identity.Identity{bob}},
+				{params: identity.ListIdentitiesParameters{CredentialsIdentifier: "search-unknown@ory.sh"}},
+				{params: identity.ListIdentitiesParameters{VerifiableAddress: "search-alice@ory.sh"}, expected: []*identity.Identity{alice}},
+				{params: identity.ListIdentitiesParameters{RecoveryAddress: "SEARCH-BOB@ory.sh"}, expected: []*identity.Identity{bob}},
+				{params: identity.ListIdentitiesParameters{State: identity.StateInactive}, expected: []*identity.Identity{bob}},
+				{params: identity.ListIdentitiesParameters{SchemaID: config.DefaultIdentityTraitsSchemaID}, expected: []*identity.Identity{alice, carol}},
+				{params: identity.ListIdentitiesParameters{CreatedBefore: halfAnHourAgo}, expected: []*identity.Identity{alice}},
+				{params: identity.ListIdentitiesParameters{CreatedAfter: halfAnHourAgo}, expected: []*identity.Identity{bob, carol}},
+				{params: identity.ListIdentitiesParameters{Traits: []identity.TraitFilter{{Path: "name.first", Value: "Alice"}}}, expected: []*identity.Identity{alice, carol}},
+				{params: identity.ListIdentitiesParameters{Traits: []identity.TraitFilter{{Path: "name.first", Value: "Alice"}, {Path: "email", Value: "search-alice@ory.sh"}}}, expected: []*identity.Identity{alice}},
+				{params: identity.ListIdentitiesParameters{Traits: []identity.TraitFilter{{Path: "age", Value: "30"}}}, expected: []*identity.Identity{alice}},
+				{params: identity.ListIdentitiesParameters{Traits: []identity.TraitFilter{{Path: "name.last", Value: "Alice"}}}},
+				{params: identity.ListIdentitiesParameters{State: identity.StateActive, Traits: []identity.TraitFilter{{Path: "name.first", Value: "Bob"}}}},
+			} {
+				t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+					tc.params.PerPage = 25
+					expected := make([]uuid.UUID, len(tc.expected))
+					for k, i := range tc.expected {
+						expected[k] = i.ID
+					}
+					assert.ElementsMatch(t, expected, search(t, tc.params))
+				})
+			}
+
+			t.Run("case=keyset pagination", func(t *testing.T) {
+				first, err := p.SearchIdentities(ctx, identity.ListIdentitiesParameters{PerPage: 2})
+				require.NoError(t, err)
+				require.Len(t, first, 2)
+
+				second, err := p.SearchIdentities(ctx, identity.ListIdentitiesParameters{PerPage: 2, PageToken: first[1].ID})
+				require.NoError(t, err)
+				require.Len(t, second, 1)
+
+				third, err := p.SearchIdentities(ctx, identity.ListIdentitiesParameters{PerPage: 2, PageToken: second[0].ID})
+				require.NoError(t, err)
+				assert.Len(t, third, 0)
+
+				assert.ElementsMatch(t, []uuid.UUID{alice.ID, bob.ID, carol.ID}, []uuid.UUID{first[0].ID, first[1].ID, second[0].ID})
+
+				t.Run("pages match offset pagination", func(t *testing.T) {
+					offset, err := p.SearchIdentities(ctx, identity.ListIdentitiesParameters{Page: 2, PerPage: 2})
+					require.NoError(t, err)
+					require.Len(t, offset, 1)
+					assert.Equal(t, second[0].ID, offset[0].ID)
+				})
+
+				t.Run("pages with trait filters", func(t *testing.T) {
+					alices := []identity.TraitFilter{{Path: "name.first", Value: "Alice"}}
+
+					first, err := p.SearchIdentities(ctx, identity.ListIdentitiesParameters{PerPage: 1, Traits: alices})
+					require.NoError(t, err)
+					require.Len(t, first, 1)
+
+					second, err := p.SearchIdentities(ctx, identity.ListIdentitiesParameters{PerPage: 1, Traits: alices, PageToken: first[0].ID})
+					require.NoError(t, err)
+					require.Len(t, second, 1)
+					assert.ElementsMatch(t, []uuid.UUID{alice.ID, carol.ID}, []uuid.UUID{first[0].ID, second[0].ID})
+
+					third, err := p.SearchIdentities(ctx, identity.ListIdentitiesParameters{PerPage: 1, Traits: alices, PageToken: second[0].ID})
+					require.NoError(t, err)
+					assert.Len(t, third, 0)
+
+					offset, err := p.SearchIdentities(ctx, identity.ListIdentitiesParameters{Page: 2, PerPage: 1, Traits: alices})
+					require.NoError(t, err)
+					require.Len(t, offset, 1)
+					assert.Equal(t, second[0].ID, offset[0].ID)
+
+					count, err := p.CountSearchedIdentities(ctx, identity.ListIdentitiesParameters{PerPage: 1, Traits: alices})
+					require.NoError(t, err)
+					assert.EqualValues(t, 2, count)
+				})
+			})
+		})
+
 		t.Run("case=find identity by its credentials identifier", func(t *testing.T) {
 			expected := passwordIdentity("", "find-credentials-identifier@ory.sh")
 			expected.Traits = identity.Traits(`{}`)
@@ -47,9 +47,10 @@ func (v *Validator) ValidateWithRunner(ctx context.Context, i *Identity, runners
 }
 
 func (v *Validator) Validate(ctx context.Context, i *Identity) error {
+	phoneRegion := v.d.Config(ctx).IdentityPhoneDefaultRegion()
 	return v.ValidateWithRunner(ctx, i,
-		NewSchemaExtensionCredentials(i),
-		NewSchemaExtensionVerification(i, v.d.Config(ctx).SelfServiceFlowVerificationRequestLifespan()),
-		NewSchemaExtensionRecovery(i),
+		NewSchemaExtensionCredentials(i, phoneRegion),
+		NewSchemaExtensionVerification(i, v.d.Config(ctx).SelfServiceFlowVerificationRequestLifespan(), phoneRegion),
+		NewSchemaExtensionRecovery(i, phoneRegion),
 	)
 }
@@ -13,15 +13,31 @@ package client
 
 import (
 	"encoding/json"
+	"time"
 )
 
 // AdminCreateIdentityBody struct for AdminCreateIdentityBody
 type AdminCreateIdentityBody struct {
+	Credentials *AdminIdentityImportCredentials `json:"credentials,omitempty"`
+	// DeleteAt is the time at which the identity is erased permanently, together with its sessions, self-service flows, tokens, and the courier messages sent to its addresses. If not set, inactive identities are scheduled to be erased after the configured deletion grace period.
+	DeleteAt *time.Time `json:"delete_at,omitempty"`
+	// InactiveAt is the time at which the identity is deactivated automatically.
+	InactiveAt *time.Time `json:"inactive_at,omitempty"`
+	// Store metadata about the identity which the identity itself can not see when calling for example the session endpoint. Use this field to store internal IDs, plan tiers or risk flags.
+	MetadataAdmin map[string]interface{} `json:"metadata_admin,omitempty"`
+	// Store metadata about the identity which the identity itself can see when calling for example the session endpoint. Do not store sensitive information (e.g. credit score) about the identity in this field.
+	MetadataPublic map[string]interface{} `json:"metadata_public,omitempty"`
+	// RecoveryAddresses contains all the addresses that can be used to recover an identity.  Use this structure to import recovery addresses for an identity. Addresses which are not represented in the identity's traits are ignored.
+	RecoveryAddresses []AdminIdentityImportRecoveryAddress `json:"recovery_addresses,omitempty"`
 	// SchemaID is the ID of the JSON Schema to be used for validating the identity's traits.
 	SchemaId string         `json:"schema_id"`
 	State    *IdentityState `json:"state,omitempty"`
+	// StateChangeReason describes why the identity has its state, for example `fraud` or `user request`.
+	StateChangeReason *string `json:"state_change_reason,omitempty"`
 	// Traits represent an identity's traits. The identity is able to create, modify, and delete traits in a self-service manner. The input will always be validated against the JSON Schema defined in `schema_url`.
 	Traits map[string]interface{} `json:"traits"`
+	// VerifiableAddresses contains all the addresses that can be verified by the user.  Use this structure to import verified addresses for an identity. Addresses which are not represented in the identity's traits are ignored.
+	VerifiableAddresses []AdminIdentityImportVerifiableAddress `json:"verifiable_addresses,omitempty"`
 }
 
 // NewAdminCreateIdentityBody instantiates a new AdminCreateIdentityBody object
@@ -43,6 +59,198 @@ func NewAdminCreateIdentityBodyWithDefaults() *AdminCreateIdentityBody {
 	return &this
 }
 
+// GetCredentials returns the Credentials field value if set, zero value otherwise.
+func (o *AdminCreateIdentityBody) GetCredentials() AdminIdentityImportCredentials {
+	if o == nil || o.Credentials == nil {
+		var ret AdminIdentityImportCredentials
+		return ret
+	}
+	return *o.Credentials
+}
+
+// GetCredentialsOk returns a tuple with the Credentials field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminCreateIdentityBody) GetCredentialsOk() (*AdminIdentityImportCredentials, bool) {
+	if o == nil || o.Credentials == nil {
+		return nil, false
+	}
+	return o.Credentials, true
+}
+
+// HasCredentials returns a boolean if a field has been set.
+func (o *AdminCreateIdentityBody) HasCredentials() bool {
+	if o != nil && o.Credentials != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetCredentials gets a reference to the given AdminIdentityImportCredentials and assigns it to the Credentials field.
+func (o *AdminCreateIdentityBody) SetCredentials(v AdminIdentityImportCredentials) {
+	o.Credentials = &v
+}
+
+// GetDeleteAt returns the DeleteAt field value if set, zero value otherwise.
+func (o *AdminCreateIdentityBody) GetDeleteAt() time.Time {
+	if o == nil || o.DeleteAt == nil {
+		var ret time.Time
+		return ret
+	}
+	return *o.DeleteAt
+}
+
+// GetDeleteAtOk returns a tuple with the DeleteAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminCreateIdentityBody) GetDeleteAtOk() (*time.Time, bool) {
+	if o == nil || o.DeleteAt == nil {
+		return nil, false
+	}
+	return o.DeleteAt, true
+}
+
+// HasDeleteAt returns a boolean if a field has been set.
+func (o *AdminCreateIdentityBody) HasDeleteAt() bool {
+	if o != nil && o.DeleteAt != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetDeleteAt gets a reference to the given time.Time and assigns it to the DeleteAt field.
+func (o *AdminCreateIdentityBody) SetDeleteAt(v time.Time) {
+	o.DeleteAt = &v
+}
+
+// GetInactiveAt returns the InactiveAt field value if set, zero value otherwise.
+func (o *AdminCreateIdentityBody) GetInactiveAt() time.Time {
+	if o == nil || o.InactiveAt == nil {
+		var ret time.Time
+		return ret
+	}
+	return *o.InactiveAt
+}
+
+// GetInactiveAtOk returns a tuple with the InactiveAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminCreateIdentityBody) GetInactiveAtOk() (*time.Time, bool) {
+	if o == nil || o.InactiveAt == nil {
+		return nil, false
+	}
+	return o.InactiveAt, true
+}
+
+// HasInactiveAt returns a boolean if a field has been set.
+func (o *AdminCreateIdentityBody) HasInactiveAt() bool {
+	if o != nil && o.InactiveAt != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetInactiveAt gets a reference to the given time.Time and assigns it to the InactiveAt field.
+func (o *AdminCreateIdentityBody) SetInactiveAt(v time.Time) {
+	o.InactiveAt = &v
+}
+
+// GetMetadataAdmin returns the MetadataAdmin field value if set, zero value otherwise.
+func (o *AdminCreateIdentityBody) GetMetadataAdmin() map[string]interface{} {
+	if o == nil || o.MetadataAdmin == nil {
+		var ret map[string]interface{}
+		return ret
+	}
+	return o.MetadataAdmin
+}
+
+// GetMetadataAdminOk returns a tuple with the MetadataAdmin field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminCreateIdentityBody) GetMetadataAdminOk() (map[string]interface{}, bool) {
+	if o == nil || o.MetadataAdmin == nil {
+		return nil, false
+	}
+	return o.MetadataAdmin, true
+}
+
+// HasMetadataAdmin returns a boolean if a field has been set.
+func (o *AdminCreateIdentityBody) HasMetadataAdmin() bool {
+	if o != nil && o.MetadataAdmin != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetMetadataAdmin gets a reference to the given map[string]interface{} and assigns it to the MetadataAdmin field.
+func (o *AdminCreateIdentityBody) SetMetadataAdmin(v map[string]interface{}) {
+	o.MetadataAdmin = v
+}
+
+// GetMetadataPublic returns the MetadataPublic field value if set, zero value otherwise.
+func (o *AdminCreateIdentityBody) GetMetadataPublic() map[string]interface{} {
+	if o == nil || o.MetadataPublic == nil {
+		var ret map[string]interface{}
+		return ret
+	}
+	return o.MetadataPublic
+}
+
+// GetMetadataPublicOk returns a tuple with the MetadataPublic field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminCreateIdentityBody) GetMetadataPublicOk() (map[string]interface{}, bool) {
+	if o == nil || o.MetadataPublic == nil {
+		return nil, false
+	}
+	return o.MetadataPublic, true
+}
+
+// HasMetadataPublic returns a boolean if a field has been set.
+func (o *AdminCreateIdentityBody) HasMetadataPublic() bool {
+	if o != nil && o.MetadataPublic != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetMetadataPublic gets a reference to the given map[string]interface{} and assigns it to the MetadataPublic field.
+func (o *AdminCreateIdentityBody) SetMetadataPublic(v map[string]interface{}) {
+	o.MetadataPublic = v
+}
+
+// GetRecoveryAddresses returns the RecoveryAddresses field value if set, zero value otherwise.
+func (o *AdminCreateIdentityBody) GetRecoveryAddresses() []AdminIdentityImportRecoveryAddress {
+	if o == nil || o.RecoveryAddresses == nil {
+		var ret []AdminIdentityImportRecoveryAddress
+		return ret
+	}
+	return o.RecoveryAddresses
+}
+
+// GetRecoveryAddressesOk returns a tuple with the RecoveryAddresses field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminCreateIdentityBody) GetRecoveryAddressesOk() ([]AdminIdentityImportRecoveryAddress, bool) {
+	if o == nil || o.RecoveryAddresses == nil {
+		return nil, false
+	}
+	return o.RecoveryAddresses, true
+}
+
+// HasRecoveryAddresses returns a boolean if a field has been set.
+func (o *AdminCreateIdentityBody) HasRecoveryAddresses() bool {
+	if o != nil && o.RecoveryAddresses != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetRecoveryAddresses gets a reference to the given []AdminIdentityImportRecoveryAddress and assigns it to the RecoveryAddresses field.
+func (o *AdminCreateIdentityBody) SetRecoveryAddresses(v []AdminIdentityImportRecoveryAddress) {
+	o.RecoveryAddresses = v
+}
+
 // GetSchemaId returns the SchemaId field value
 func (o *AdminCreateIdentityBody) GetSchemaId() string {
 	if o == nil {
@@ -99,6 +307,38 @@ func (o *AdminCreateIdentityBody) SetState(v IdentityState) {
 	o.State = &v
 }
 
+// GetStateChangeReason returns the StateChangeReason field value if set, zero value otherwise.
+func (o *AdminCreateIdentityBody) GetStateChangeReason() string {
+	if o == nil || o.StateChangeReason == nil {
+		var ret string
+		return ret
+	}
+	return *o.StateChangeReason
+}
+
+// GetStateChangeReasonOk returns a tuple with the StateChangeReason field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminCreateIdentityBody) GetStateChangeReasonOk() (*string, bool) {
+	if o == nil || o.StateChangeReason == nil {
+		return nil, false
+	}
+	return o.StateChangeReason, true
+}
+
+// HasStateChangeReason returns a boolean if a field has been set.
+func (o *AdminCreateIdentityBody) HasStateChangeReason() bool {
+	if o != nil && o.StateChangeReason != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetStateChangeReason gets a reference to the given string and assigns it to the StateChangeReason field.
+func (o *AdminCreateIdentityBody) SetStateChangeReason(v string) {
+	o.StateChangeReason = &v
+}
+
 // GetTraits returns the Traits field value
 func (o *AdminCreateIdentityBody) GetTraits() map[string]interface{} {
 	if o == nil {
@@ -123,17 +363,73 @@ func (o *AdminCreateIdentityBody) SetTraits(v map[string]interface{}) {
 	o.Traits = v
 }
 
+// GetVerifiableAddresses returns the VerifiableAddresses field value if set, zero value otherwise.
+func (o *AdminCreateIdentityBody) GetVerifiableAddresses() []AdminIdentityImportVerifiableAddress {
+	if o == nil || o.VerifiableAddresses == nil {
+		var ret []AdminIdentityImportVerifiableAddress
+		return ret
+	}
+	return o.VerifiableAddresses
+}
+
+// GetVerifiableAddressesOk returns a tuple with the VerifiableAddresses field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminCreateIdentityBody) GetVerifiableAddressesOk() ([]AdminIdentityImportVerifiableAddress, bool) {
+	if o == nil || o.VerifiableAddresses == nil {
+		return nil, false
+	}
+	return o.VerifiableAddresses, true
+}
+
+// HasVerifiableAddresses returns a boolean if a field has been set.
+func (o *AdminCreateIdentityBody) HasVerifiableAddresses() bool {
+	if o != nil && o.VerifiableAddresses != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetVerifiableAddresses gets a reference to the given []AdminIdentityImportVerifiableAddress and assigns it to the VerifiableAddresses field.
+func (o *AdminCreateIdentityBody) SetVerifiableAddresses(v []AdminIdentityImportVerifiableAddress) {
+	o.VerifiableAddresses = v
+}
+
 func (o AdminCreateIdentityBody) MarshalJSON() ([]byte, error) {
 	toSerialize := map[string]interface{}{}
+	if o.Credentials != nil {
+		toSerialize["credentials"] = o.Credentials
+	}
+	if o.DeleteAt != nil {
+		toSerialize["delete_at"] = o.DeleteAt
+	}
+	if o.InactiveAt != nil {
+		toSerialize["inactive_at"] = o.InactiveAt
+	}
+	if o.MetadataAdmin != nil {
+		toSerialize["metadata_admin"] = o.MetadataAdmin
+	}
+	if o.MetadataPublic != nil {
+		toSerialize["metadata_public"] = o.MetadataPublic
+	}
+	if o.RecoveryAddresses != nil {
+		toSerialize["recovery_addresses"] = o.RecoveryAddresses
+	}
 	if true {
 		toSerialize["schema_id"] = o.SchemaId
 	}
 	if o.State != nil {
 		toSerialize["state"] = o.State
 	}
+	if o.StateChangeReason != nil {
+		toSerialize["state_change_reason"] = o.StateChangeReason
+	}
 	if true {
 		toSerialize["traits"] = o.Traits
 	}
+	if o.VerifiableAddresses != nil {
+		toSerialize["verifiable_addresses"] = o.VerifiableAddresses
+	}
 	return json.Marshal(toSerialize)
 }
 
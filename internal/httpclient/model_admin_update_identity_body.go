@@ -13,13 +13,24 @@ package client
 
 import (
 	"encoding/json"
+	"time"
 )
 
 // AdminUpdateIdentityBody struct for AdminUpdateIdentityBody
 type AdminUpdateIdentityBody struct {
+	// DeleteAt is the time at which the identity is erased permanently, together with its sessions, self-service flows, tokens, and the courier messages sent to its addresses.  If omitted, the scheduled erasure is canceled. Identities which are deactivated by this request are scheduled to be erased after the configured deletion grace period instead.
+	DeleteAt *time.Time `json:"delete_at,omitempty"`
+	// InactiveAt is the time at which the identity is deactivated automatically.  If omitted, the scheduled deactivation is canceled.
+	InactiveAt *time.Time `json:"inactive_at,omitempty"`
+	// Store metadata about the identity which the identity itself can not see when calling for example the session endpoint. Use this field to store internal IDs, plan tiers or risk flags.  If omitted, the admin metadata is removed.
+	MetadataAdmin map[string]interface{} `json:"metadata_admin,omitempty"`
+	// Store metadata about the identity which the identity itself can see when calling for example the session endpoint. Do not store sensitive information (e.g. credit score) about the identity in this field.  If omitted, the public metadata is removed.
+	MetadataPublic map[string]interface{} `json:"metadata_public,omitempty"`
 	// SchemaID is the ID of the JSON Schema to be used for validating the identity's traits. If set will update the Identity's SchemaID.
 	SchemaId *string       `json:"schema_id,omitempty"`
 	State    IdentityState `json:"state"`
+	// StateChangeReason describes why the identity's state is changed, for example `fraud` or `user request`.  If the state does not change, the previous reason is kept unless a new reason is set.
+	StateChangeReason *string `json:"state_change_reason,omitempty"`
 	// Traits represent an identity's traits. The identity is able to create, modify, and delete traits in a self-service manner. The input will always be validated against the JSON Schema defined in `schema_id`.
 	Traits map[string]interface{} `json:"traits"`
 }
@@ -43,6 +54,134 @@ func NewAdminUpdateIdentityBodyWithDefaults() *AdminUpdateIdentityBody {
 	return &this
 }
 
+// GetDeleteAt returns the DeleteAt field value if set, zero value otherwise.
+func (o *AdminUpdateIdentityBody) GetDeleteAt() time.Time {
+	if o == nil || o.DeleteAt == nil {
+		var ret time.Time
+		return ret
+	}
+	return *o.DeleteAt
+}
+
+// GetDeleteAtOk returns a tuple with the DeleteAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminUpdateIdentityBody) GetDeleteAtOk() (*time.Time, bool) {
+	if o == nil || o.DeleteAt == nil {
+		return nil, false
+	}
+	return o.DeleteAt, true
+}
+
+// HasDeleteAt returns a boolean if a field has been set.
+func (o *AdminUpdateIdentityBody) HasDeleteAt() bool {
+	if o != nil && o.DeleteAt != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetDeleteAt gets a reference to the given time.Time and assigns it to the DeleteAt field.
+func (o *AdminUpdateIdentityBody) SetDeleteAt(v time.Time) {
+	o.DeleteAt = &v
+}
+
+// GetInactiveAt returns the InactiveAt field value if set, zero value otherwise.
+func (o *AdminUpdateIdentityBody) GetInactiveAt() time.Time {
+	if o == nil || o.InactiveAt == nil {
+		var ret time.Time
+		return ret
+	}
+	return *o.InactiveAt
+}
+
+// GetInactiveAtOk returns a tuple with the InactiveAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminUpdateIdentityBody) GetInactiveAtOk() (*time.Time, bool) {
+	if o == nil || o.InactiveAt == nil {
+		return nil, false
+	}
+	return o.InactiveAt, true
+}
+
+// HasInactiveAt returns a boolean if a field has been set.
+func (o *AdminUpdateIdentityBody) HasInactiveAt() bool {
+	if o != nil && o.InactiveAt != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetInactiveAt gets a reference to the given time.Time and assigns it to the InactiveAt field.
+func (o *AdminUpdateIdentityBody) SetInactiveAt(v time.Time) {
+	o.InactiveAt = &v
+}
+
+// GetMetadataAdmin returns the MetadataAdmin field value if set, zero value otherwise.
+func (o *AdminUpdateIdentityBody) GetMetadataAdmin() map[string]interface{} {
+	if o == nil || o.MetadataAdmin == nil {
+		var ret map[string]interface{}
+		return ret
+	}
+	return o.MetadataAdmin
+}
+
+// GetMetadataAdminOk returns a tuple with the MetadataAdmin field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminUpdateIdentityBody) GetMetadataAdminOk() (map[string]interface{}, bool) {
+	if o == nil || o.MetadataAdmin == nil {
+		return nil, false
+	}
+	return o.MetadataAdmin, true
+}
+
+// HasMetadataAdmin returns a boolean if a field has been set.
+func (o *AdminUpdateIdentityBody) HasMetadataAdmin() bool {
+	if o != nil && o.MetadataAdmin != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetMetadataAdmin gets a reference to the given map[string]interface{} and assigns it to the MetadataAdmin field.
+func (o *AdminUpdateIdentityBody) SetMetadataAdmin(v map[string]interface{}) {
+	o.MetadataAdmin = v
+}
+
+// GetMetadataPublic returns the MetadataPublic field value if set, zero value otherwise.
+func (o *AdminUpdateIdentityBody) GetMetadataPublic() map[string]interface{} {
+	if o == nil || o.MetadataPublic == nil {
+		var ret map[string]interface{}
+		return ret
+	}
+	return o.MetadataPublic
+}
+
+// GetMetadataPublicOk returns a tuple with the MetadataPublic field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminUpdateIdentityBody) GetMetadataPublicOk() (map[string]interface{}, bool) {
+	if o == nil || o.MetadataPublic == nil {
+		return nil, false
+	}
+	return o.MetadataPublic, true
+}
+
+// HasMetadataPublic returns a boolean if a field has been set.
+func (o *AdminUpdateIdentityBody) HasMetadataPublic() bool {
+	if o != nil && o.MetadataPublic != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetMetadataPublic gets a reference to the given map[string]interface{} and assigns it to the MetadataPublic field.
+func (o *AdminUpdateIdentityBody) SetMetadataPublic(v map[string]interface{}) {
+	o.MetadataPublic = v
+}
+
 // GetSchemaId returns the SchemaId field value if set, zero value otherwise.
 func (o *AdminUpdateIdentityBody) GetSchemaId() string {
 	if o == nil || o.SchemaId == nil {
@@ -99,6 +238,38 @@ func (o *AdminUpdateIdentityBody) SetState(v IdentityState) {
 	o.State = v
 }
 
+// GetStateChangeReason returns the StateChangeReason field value if set, zero value otherwise.
+func (o *AdminUpdateIdentityBody) GetStateChangeReason() string {
+	if o == nil || o.StateChangeReason == nil {
+		var ret string
+		return ret
+	}
+	return *o.StateChangeReason
+}
+
+// GetStateChangeReasonOk returns a tuple with the StateChangeReason field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminUpdateIdentityBody) GetStateChangeReasonOk() (*string, bool) {
+	if o == nil || o.StateChangeReason == nil {
+		return nil, false
+	}
+	return o.StateChangeReason, true
+}
+
+// HasStateChangeReason returns a boolean if a field has been set.
+func (o *AdminUpdateIdentityBody) HasStateChangeReason() bool {
+	if o != nil && o.StateChangeReason != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetStateChangeReason gets a reference to the given string and assigns it to the StateChangeReason field.
+func (o *AdminUpdateIdentityBody) SetStateChangeReason(v string) {
+	o.StateChangeReason = &v
+}
+
 // GetTraits returns the Traits field value
 func (o *AdminUpdateIdentityBody) GetTraits() map[string]interface{} {
 	if o == nil {
@@ -125,12 +296,27 @@ func (o *AdminUpdateIdentityBody) SetTraits(v map[string]interface{}) {
 
 func (o AdminUpdateIdentityBody) MarshalJSON() ([]byte, error) {
 	toSerialize := map[string]interface{}{}
+	if o.DeleteAt != nil {
+		toSerialize["delete_at"] = o.DeleteAt
+	}
+	if o.InactiveAt != nil {
+		toSerialize["inactive_at"] = o.InactiveAt
+	}
+	if o.MetadataAdmin != nil {
+		toSerialize["metadata_admin"] = o.MetadataAdmin
+	}
+	if o.MetadataPublic != nil {
+		toSerialize["metadata_public"] = o.MetadataPublic
+	}
 	if o.SchemaId != nil {
 		toSerialize["schema_id"] = o.SchemaId
 	}
 	if true {
 		toSerialize["state"] = o.State
 	}
+	if o.StateChangeReason != nil {
+		toSerialize["state_change_reason"] = o.StateChangeReason
+	}
 	if true {
 		toSerialize["traits"] = o.Traits
 	}
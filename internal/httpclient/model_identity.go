@@ -22,15 +22,25 @@ type Identity struct {
 	CreatedAt *time.Time `json:"created_at,omitempty"`
 	// Credentials represents all credentials that can be used for authenticating this identity.
 	Credentials *map[string]IdentityCredentials `json:"credentials,omitempty"`
-	Id          string                          `json:"id"`
+	// DeleteAt is the time at which the identity is erased permanently, together with its sessions, self-service flows, tokens, and the courier messages sent to its addresses.  It is only visible using the admin API.
+	DeleteAt *time.Time `json:"delete_at,omitempty"`
+	Id       string     `json:"id"`
+	// InactiveAt is the time at which the identity is deactivated automatically.  It is only visible using the admin API.
+	InactiveAt *time.Time `json:"inactive_at,omitempty"`
+	// NullJSONRawMessage represents a json.RawMessage that works well with JSON, SQL, and Swagger and is NULLable-
+	MetadataAdmin interface{} `json:"metadata_admin,omitempty"`
+	// NullJSONRawMessage represents a json.RawMessage that works well with JSON, SQL, and Swagger and is NULLable-
+	MetadataPublic interface{} `json:"metadata_public,omitempty"`
 	// RecoveryAddresses contains all the addresses that can be used to recover an identity.
 	RecoveryAddresses []RecoveryAddress `json:"recovery_addresses,omitempty"`
 	// SchemaID is the ID of the JSON Schema to be used for validating the identity's traits.
 	SchemaId string `json:"schema_id"`
 	// SchemaURL is the URL of the endpoint where the identity's traits schema can be fetched from.  format: url
-	SchemaUrl      string         `json:"schema_url"`
-	State          *IdentityState `json:"state,omitempty"`
-	StateChangedAt *time.Time     `json:"state_changed_at,omitempty"`
+	SchemaUrl string         `json:"schema_url"`
+	State     *IdentityState `json:"state,omitempty"`
+	// StateChangeReason describes why the identity's state was last changed, for example `fraud` or `user request`.  It is only visible using the admin API.
+	StateChangeReason *string    `json:"state_change_reason,omitempty"`
+	StateChangedAt    *time.Time `json:"state_changed_at,omitempty"`
 	// Traits represent an identity's traits. The identity is able to create, modify, and delete traits in a self-service manner. The input will always be validated against the JSON Schema defined in `schema_url`.
 	Traits interface{} `json:"traits"`
 	// UpdatedAt is a helper struct field for gobuffalo.pop.
@@ -124,6 +134,38 @@ func (o *Identity) SetCredentials(v map[string]IdentityCredentials) {
 	o.Credentials = &v
 }
 
+// GetDeleteAt returns the DeleteAt field value if set, zero value otherwise.
+func (o *Identity) GetDeleteAt() time.Time {
+	if o == nil || o.DeleteAt == nil {
+		var ret time.Time
+		return ret
+	}
+	return *o.DeleteAt
+}
+
+// GetDeleteAtOk returns a tuple with the DeleteAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Identity) GetDeleteAtOk() (*time.Time, bool) {
+	if o == nil || o.DeleteAt == nil {
+		return nil, false
+	}
+	return o.DeleteAt, true
+}
+
+// HasDeleteAt returns a boolean if a field has been set.
+func (o *Identity) HasDeleteAt() bool {
+	if o != nil && o.DeleteAt != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetDeleteAt gets a reference to the given time.Time and assigns it to the DeleteAt field.
+func (o *Identity) SetDeleteAt(v time.Time) {
+	o.DeleteAt = &v
+}
+
 // GetId returns the Id field value
 func (o *Identity) GetId() string {
 	if o == nil {
@@ -148,6 +190,102 @@ func (o *Identity) SetId(v string) {
 	o.Id = v
 }
 
+// GetInactiveAt returns the InactiveAt field value if set, zero value otherwise.
+func (o *Identity) GetInactiveAt() time.Time {
+	if o == nil || o.InactiveAt == nil {
+		var ret time.Time
+		return ret
+	}
+	return *o.InactiveAt
+}
+
+// GetInactiveAtOk returns a tuple with the InactiveAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Identity) GetInactiveAtOk() (*time.Time, bool) {
+	if o == nil || o.InactiveAt == nil {
+		return nil, false
+	}
+	return o.InactiveAt, true
+}
+
+// HasInactiveAt returns a boolean if a field has been set.
+func (o *Identity) HasInactiveAt() bool {
+	if o != nil && o.InactiveAt != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetInactiveAt gets a reference to the given time.Time and assigns it to the InactiveAt field.
+func (o *Identity) SetInactiveAt(v time.Time) {
+	o.InactiveAt = &v
+}
+
+// GetMetadataAdmin returns the MetadataAdmin field value if set, zero value otherwise.
+func (o *Identity) GetMetadataAdmin() interface{} {
+	if o == nil || o.MetadataAdmin == nil {
+		var ret interface{}
+		return ret
+	}
+	return o.MetadataAdmin
+}
+
+// GetMetadataAdminOk returns a tuple with the MetadataAdmin field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Identity) GetMetadataAdminOk() (interface{}, bool) {
+	if o == nil || o.MetadataAdmin == nil {
+		return nil, false
+	}
+	return o.MetadataAdmin, true
+}
+
+// HasMetadataAdmin returns a boolean if a field has been set.
+func (o *Identity) HasMetadataAdmin() bool {
+	if o != nil && o.MetadataAdmin != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetMetadataAdmin gets a reference to the given interface{} and assigns it to the MetadataAdmin field.
+func (o *Identity) SetMetadataAdmin(v interface{}) {
+	o.MetadataAdmin = v
+}
+
+// GetMetadataPublic returns the MetadataPublic field value if set, zero value otherwise.
+func (o *Identity) GetMetadataPublic() interface{} {
+	if o == nil || o.MetadataPublic == nil {
+		var ret interface{}
+		return ret
+	}
+	return o.MetadataPublic
+}
+
+// GetMetadataPublicOk returns a tuple with the MetadataPublic field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Identity) GetMetadataPublicOk() (interface{}, bool) {
+	if o == nil || o.MetadataPublic == nil {
+		return nil, false
+	}
+	return o.MetadataPublic, true
+}
+
+// HasMetadataPublic returns a boolean if a field has been set.
+func (o *Identity) HasMetadataPublic() bool {
+	if o != nil && o.MetadataPublic != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetMetadataPublic gets a reference to the given interface{} and assigns it to the MetadataPublic field.
+func (o *Identity) SetMetadataPublic(v interface{}) {
+	o.MetadataPublic = v
+}
+
 // GetRecoveryAddresses returns the RecoveryAddresses field value if set, zero value otherwise.
 func (o *Identity) GetRecoveryAddresses() []RecoveryAddress {
 	if o == nil || o.RecoveryAddresses == nil {
@@ -260,6 +398,38 @@ func (o *Identity) SetState(v IdentityState) {
 	o.State = &v
 }
 
+// GetStateChangeReason returns the StateChangeReason field value if set, zero value otherwise.
+func (o *Identity) GetStateChangeReason() string {
+	if o == nil || o.StateChangeReason == nil {
+		var ret string
+		return ret
+	}
+	return *o.StateChangeReason
+}
+
+// GetStateChangeReasonOk returns a tuple with the StateChangeReason field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Identity) GetStateChangeReasonOk() (*string, bool) {
+	if o == nil || o.StateChangeReason == nil {
+		return nil, false
+	}
+	return o.StateChangeReason, true
+}
+
+// HasStateChangeReason returns a boolean if a field has been set.
+func (o *Identity) HasStateChangeReason() bool {
+	if o != nil && o.StateChangeReason != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetStateChangeReason gets a reference to the given string and assigns it to the StateChangeReason field.
+func (o *Identity) SetStateChangeReason(v string) {
+	o.StateChangeReason = &v
+}
+
 // GetStateChangedAt returns the StateChangedAt field value if set, zero value otherwise.
 func (o *Identity) GetStateChangedAt() time.Time {
 	if o == nil || o.StateChangedAt == nil {
@@ -390,9 +560,21 @@ func (o Identity) MarshalJSON() ([]byte, error) {
 	if o.Credentials != nil {
 		toSerialize["credentials"] = o.Credentials
 	}
+	if o.DeleteAt != nil {
+		toSerialize["delete_at"] = o.DeleteAt
+	}
 	if true {
 		toSerialize["id"] = o.Id
 	}
+	if o.InactiveAt != nil {
+		toSerialize["inactive_at"] = o.InactiveAt
+	}
+	if o.MetadataAdmin != nil {
+		toSerialize["metadata_admin"] = o.MetadataAdmin
+	}
+	if o.MetadataPublic != nil {
+		toSerialize["metadata_public"] = o.MetadataPublic
+	}
 	if o.RecoveryAddresses != nil {
 		toSerialize["recovery_addresses"] = o.RecoveryAddresses
 	}
@@ -405,6 +587,9 @@ func (o Identity) MarshalJSON() ([]byte, error) {
 	if o.State != nil {
 		toSerialize["state"] = o.State
 	}
+	if o.StateChangeReason != nil {
+		toSerialize["state_change_reason"] = o.StateChangeReason
+	}
 	if o.StateChangedAt != nil {
 		toSerialize["state_changed_at"] = o.StateChangedAt
 	}
@@ -13,10 +13,19 @@ package client
 
 import (
 	"encoding/json"
+	"time"
 )
 
-// SessionDevice struct for SessionDevice
+// SessionDevice Describes a device the session was used on.
 type SessionDevice struct {
+	Aal *AuthenticatorAssuranceLevel `json:"aal,omitempty"`
+	// FirstSeenAt is the time at which the session was first used on this device.
+	FirstSeenAt *time.Time `json:"first_seen_at,omitempty"`
+	// IPAddress is the IP address the device used.
+	IpAddress *string `json:"ip_address,omitempty"`
+	// LastSeenAt is the time at which the session was last used on this device.
+	LastSeenAt *time.Time             `json:"last_seen_at,omitempty"`
+	Location   *SessionDeviceLocation `json:"location,omitempty"`
 	// UserAgent of this device
 	UserAgent *string `json:"user_agent,omitempty"`
 }
@@ -38,6 +47,166 @@ func NewSessionDeviceWithDefaults() *SessionDevice {
 	return &this
 }
 
+// GetAal returns the Aal field value if set, zero value otherwise.
+func (o *SessionDevice) GetAal() AuthenticatorAssuranceLevel {
+	if o == nil || o.Aal == nil {
+		var ret AuthenticatorAssuranceLevel
+		return ret
+	}
+	return *o.Aal
+}
+
+// GetAalOk returns a tuple with the Aal field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SessionDevice) GetAalOk() (*AuthenticatorAssuranceLevel, bool) {
+	if o == nil || o.Aal == nil {
+		return nil, false
+	}
+	return o.Aal, true
+}
+
+// HasAal returns a boolean if a field has been set.
+func (o *SessionDevice) HasAal() bool {
+	if o != nil && o.Aal != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetAal gets a reference to the given AuthenticatorAssuranceLevel and assigns it to the Aal field.
+func (o *SessionDevice) SetAal(v AuthenticatorAssuranceLevel) {
+	o.Aal = &v
+}
+
+// GetFirstSeenAt returns the FirstSeenAt field value if set, zero value otherwise.
+func (o *SessionDevice) GetFirstSeenAt() time.Time {
+	if o == nil || o.FirstSeenAt == nil {
+		var ret time.Time
+		return ret
+	}
+	return *o.FirstSeenAt
+}
+
+// GetFirstSeenAtOk returns a tuple with the FirstSeenAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SessionDevice) GetFirstSeenAtOk() (*time.Time, bool) {
+	if o == nil || o.FirstSeenAt == nil {
+		return nil, false
+	}
+	return o.FirstSeenAt, true
+}
+
+// HasFirstSeenAt returns a boolean if a field has been set.
+func (o *SessionDevice) HasFirstSeenAt() bool {
+	if o != nil && o.FirstSeenAt != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetFirstSeenAt gets a reference to the given time.Time and assigns it to the FirstSeenAt field.
+func (o *SessionDevice) SetFirstSeenAt(v time.Time) {
+	o.FirstSeenAt = &v
+}
+
+// GetIpAddress returns the IpAddress field value if set, zero value otherwise.
+func (o *SessionDevice) GetIpAddress() string {
+	if o == nil || o.IpAddress == nil {
+		var ret string
+		return ret
+	}
+	return *o.IpAddress
+}
+
+// GetIpAddressOk returns a tuple with the IpAddress field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SessionDevice) GetIpAddressOk() (*string, bool) {
+	if o == nil || o.IpAddress == nil {
+		return nil, false
+	}
+	return o.IpAddress, true
+}
+
+// HasIpAddress returns a boolean if a field has been set.
+func (o *SessionDevice) HasIpAddress() bool {
+	if o != nil && o.IpAddress != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetIpAddress gets a reference to the given string and assigns it to the IpAddress field.
+func (o *SessionDevice) SetIpAddress(v string) {
+	o.IpAddress = &v
+}
+
+// GetLastSeenAt returns the LastSeenAt field value if set, zero value otherwise.
+func (o *SessionDevice) GetLastSeenAt() time.Time {
+	if o == nil || o.LastSeenAt == nil {
+		var ret time.Time
+		return ret
+	}
+	return *o.LastSeenAt
+}
+
+// GetLastSeenAtOk returns a tuple with the LastSeenAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SessionDevice) GetLastSeenAtOk() (*time.Time, bool) {
+	if o == nil || o.LastSeenAt == nil {
+		return nil, false
+	}
+	return o.LastSeenAt, true
+}
+
+// HasLastSeenAt returns a boolean if a field has been set.
+func (o *SessionDevice) HasLastSeenAt() bool {
+	if o != nil && o.LastSeenAt != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetLastSeenAt gets a reference to the given time.Time and assigns it to the LastSeenAt field.
+func (o *SessionDevice) SetLastSeenAt(v time.Time) {
+	o.LastSeenAt = &v
+}
+
+// GetLocation returns the Location field value if set, zero value otherwise.
+func (o *SessionDevice) GetLocation() SessionDeviceLocation {
+	if o == nil || o.Location == nil {
+		var ret SessionDeviceLocation
+		return ret
+	}
+	return *o.Location
+}
+
+// GetLocationOk returns a tuple with the Location field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SessionDevice) GetLocationOk() (*SessionDeviceLocation, bool) {
+	if o == nil || o.Location == nil {
+		return nil, false
+	}
+	return o.Location, true
+}
+
+// HasLocation returns a boolean if a field has been set.
+func (o *SessionDevice) HasLocation() bool {
+	if o != nil && o.Location != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetLocation gets a reference to the given SessionDeviceLocation and assigns it to the Location field.
+func (o *SessionDevice) SetLocation(v SessionDeviceLocation) {
+	o.Location = &v
+}
+
 // GetUserAgent returns the UserAgent field value if set, zero value otherwise.
 func (o *SessionDevice) GetUserAgent() string {
 	if o == nil || o.UserAgent == nil {
@@ -72,6 +241,21 @@ func (o *SessionDevice) SetUserAgent(v string) {
 
 func (o SessionDevice) MarshalJSON() ([]byte, error) {
 	toSerialize := map[string]interface{}{}
+	if o.Aal != nil {
+		toSerialize["aal"] = o.Aal
+	}
+	if o.FirstSeenAt != nil {
+		toSerialize["first_seen_at"] = o.FirstSeenAt
+	}
+	if o.IpAddress != nil {
+		toSerialize["ip_address"] = o.IpAddress
+	}
+	if o.LastSeenAt != nil {
+		toSerialize["last_seen_at"] = o.LastSeenAt
+	}
+	if o.Location != nil {
+		toSerialize["location"] = o.Location
+	}
 	if o.UserAgent != nil {
 		toSerialize["user_agent"] = o.UserAgent
 	}
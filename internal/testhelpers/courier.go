@@ -33,3 +33,10 @@ func CourierExpectLinkInMessage(t *testing.T, message *courier.Message, offset i
 
 	return match[offset]
 }
+
+func CourierExpectCodeInMessage(t *testing.T, message *courier.Message) string {
+	match := regexp.MustCompile(`\b([0-9]{6})\b`).FindStringSubmatch(message.Body)
+	require.Len(t, match, 2)
+
+	return match[1]
+}
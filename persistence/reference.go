@@ -14,6 +14,7 @@ import (
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/ratelimit"
 	"github.com/ory/kratos/selfservice/errorx"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/recovery"
@@ -22,6 +23,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/strategy/link"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/webhook"
 )
 
 type Provider interface {
@@ -36,12 +38,15 @@ type Persister interface {
 	login.FlowPersister
 	settings.FlowPersister
 	courier.Persister
+	webhook.Persister
 	session.Persister
 	errorx.Persister
 	verification.FlowPersister
 	recovery.FlowPersister
 	link.RecoveryTokenPersister
 	link.VerificationTokenPersister
+	link.LoginTokenPersister
+	ratelimit.Persister
 
 	Close(context.Context) error
 	Ping() error
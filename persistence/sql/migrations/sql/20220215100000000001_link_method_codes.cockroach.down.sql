@@ -0,0 +1,2 @@
+ALTER TABLE "identity_verification_tokens" DROP COLUMN "code_attempts";
+ALTER TABLE "identity_verification_tokens" DROP COLUMN "code";
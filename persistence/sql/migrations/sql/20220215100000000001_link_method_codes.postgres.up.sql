@@ -0,0 +1,2 @@
+ALTER TABLE "identity_verification_tokens" ADD COLUMN "code" VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE "identity_verification_tokens" ADD COLUMN "code_attempts" INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,21 @@
+CREATE TABLE "identity_login_tokens" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"token" VARCHAR (64) NOT NULL,
+"code" VARCHAR (64) NOT NULL,
+"code_attempts" INT NOT NULL DEFAULT 0,
+"used" bool NOT NULL DEFAULT 'false',
+"used_at" timestamp,
+"expires_at" timestamp NOT NULL,
+"issued_at" timestamp NOT NULL,
+"identity_id" UUID NOT NULL,
+"identity_verifiable_address_id" UUID NOT NULL,
+"selfservice_login_flow_id" UUID NOT NULL,
+"nid" UUID,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL,
+CONSTRAINT "identity_login_tokens_identities_id_fk" FOREIGN KEY ("identity_id") REFERENCES "identities" ("id") ON DELETE cascade,
+CONSTRAINT "identity_login_tokens_identity_verifiable_addresses_id_fk" FOREIGN KEY ("identity_verifiable_address_id") REFERENCES "identity_verifiable_addresses" ("id") ON DELETE cascade,
+CONSTRAINT "identity_login_tokens_selfservice_login_flows_id_fk" FOREIGN KEY ("selfservice_login_flow_id") REFERENCES "selfservice_login_flows" ("id") ON DELETE cascade,
+CONSTRAINT "identity_login_tokens_nid_fk_idx" FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON UPDATE RESTRICT ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+DROP TABLE `identity_login_tokens`;
\ No newline at end of file
@@ -0,0 +1,20 @@
+CREATE TABLE "identity_login_tokens" (
+"id" TEXT PRIMARY KEY,
+"token" TEXT NOT NULL,
+"code" TEXT NOT NULL,
+"code_attempts" INTEGER NOT NULL DEFAULT 0,
+"used" bool NOT NULL DEFAULT 'false',
+"used_at" DATETIME,
+"expires_at" DATETIME NOT NULL,
+"issued_at" DATETIME NOT NULL,
+"identity_id" char(36) NOT NULL,
+"identity_verifiable_address_id" char(36) NOT NULL,
+"selfservice_login_flow_id" char(36) NOT NULL,
+"nid" char(36),
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+FOREIGN KEY (identity_id) REFERENCES identities (id) ON DELETE cascade,
+FOREIGN KEY (identity_verifiable_address_id) REFERENCES identity_verifiable_addresses (id) ON DELETE cascade,
+FOREIGN KEY (selfservice_login_flow_id) REFERENCES selfservice_login_flows (id) ON DELETE cascade,
+FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
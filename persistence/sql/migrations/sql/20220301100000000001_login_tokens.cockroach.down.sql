@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "identity_login_tokens_token_nid_idx";
\ No newline at end of file
@@ -0,0 +1 @@
+DROP INDEX `identity_login_tokens_token_nid_idx` ON `identity_login_tokens`;
\ No newline at end of file
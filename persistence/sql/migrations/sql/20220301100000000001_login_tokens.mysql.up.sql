@@ -0,0 +1 @@
+CREATE UNIQUE INDEX `identity_login_tokens_token_nid_idx` ON `identity_login_tokens` (`nid`, `token`);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE `courier_messages` ADD COLUMN `send_count` INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE `courier_messages` ADD COLUMN `last_error` TEXT NULL;
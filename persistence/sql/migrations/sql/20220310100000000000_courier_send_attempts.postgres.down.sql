@@ -0,0 +1,2 @@
+ALTER TABLE "courier_messages" DROP COLUMN "last_error";
+ALTER TABLE "courier_messages" DROP COLUMN "send_count";
@@ -0,0 +1 @@
+ALTER TABLE "courier_messages" ADD COLUMN "locale" VARCHAR(32) NOT NULL DEFAULT '';
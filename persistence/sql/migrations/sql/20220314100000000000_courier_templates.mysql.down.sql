@@ -0,0 +1 @@
+DROP TABLE `courier_templates`;
\ No newline at end of file
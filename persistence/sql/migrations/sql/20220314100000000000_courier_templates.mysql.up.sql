@@ -0,0 +1,13 @@
+CREATE TABLE `courier_templates` (
+`id` char(36) NOT NULL,
+PRIMARY KEY(`id`),
+`template_type` VARCHAR (64) NOT NULL,
+`locale` VARCHAR (32) NOT NULL DEFAULT '',
+`subject` TEXT NOT NULL,
+`body_plaintext` TEXT NOT NULL,
+`body_html` TEXT NOT NULL,
+`nid` char(36),
+`created_at` DATETIME NOT NULL,
+`updated_at` DATETIME NOT NULL,
+FOREIGN KEY (`nid`) REFERENCES `networks` (`id`) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
@@ -0,0 +1,13 @@
+CREATE TABLE "courier_templates" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"template_type" VARCHAR (64) NOT NULL,
+"locale" VARCHAR (32) NOT NULL DEFAULT '',
+"subject" TEXT NOT NULL,
+"body_plaintext" TEXT NOT NULL,
+"body_html" TEXT NOT NULL,
+"nid" UUID,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL,
+FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON UPDATE RESTRICT ON DELETE CASCADE
+);
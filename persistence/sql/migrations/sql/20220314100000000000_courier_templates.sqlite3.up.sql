@@ -0,0 +1,12 @@
+CREATE TABLE "courier_templates" (
+"id" TEXT PRIMARY KEY,
+"template_type" TEXT NOT NULL,
+"locale" TEXT NOT NULL DEFAULT '',
+"subject" TEXT NOT NULL,
+"body_plaintext" TEXT NOT NULL,
+"body_html" TEXT NOT NULL,
+"nid" char(36),
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "courier_templates_nid_template_type_locale_idx";
\ No newline at end of file
@@ -0,0 +1 @@
+DROP INDEX `courier_templates_nid_template_type_locale_idx` ON `courier_templates`;
\ No newline at end of file
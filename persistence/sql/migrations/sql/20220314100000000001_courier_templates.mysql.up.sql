@@ -0,0 +1 @@
+CREATE UNIQUE INDEX `courier_templates_nid_template_type_locale_idx` ON `courier_templates` (`nid`, `template_type`, `locale`);
\ No newline at end of file
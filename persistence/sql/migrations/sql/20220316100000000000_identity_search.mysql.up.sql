@@ -0,0 +1 @@
+CREATE INDEX `identities_nid_id_idx` ON `identities` (`nid`, `id`);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP INDEX `identities_nid_created_at_idx` ON `identities`;
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX "identities_nid_created_at_idx" ON "identities" (nid, created_at);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE `identities` ADD COLUMN `metadata_public` JSON NULL;
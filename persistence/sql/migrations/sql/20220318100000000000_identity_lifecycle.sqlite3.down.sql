@@ -0,0 +1 @@
+ALTER TABLE "identities" DROP COLUMN "state_change_reason";
@@ -0,0 +1 @@
+ALTER TABLE "identities" ADD COLUMN "state_change_reason" TEXT NOT NULL DEFAULT '';
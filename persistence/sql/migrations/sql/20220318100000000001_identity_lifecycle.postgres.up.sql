@@ -0,0 +1 @@
+ALTER TABLE "identities" ADD COLUMN "inactive_at" timestamp NULL;
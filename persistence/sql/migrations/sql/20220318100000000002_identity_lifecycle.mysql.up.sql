@@ -0,0 +1 @@
+ALTER TABLE `identities` ADD COLUMN `delete_at` DATETIME NULL;
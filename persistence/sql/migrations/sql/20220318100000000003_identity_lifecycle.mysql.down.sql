@@ -0,0 +1 @@
+DROP INDEX `identities_nid_inactive_at_idx` ON `identities`;
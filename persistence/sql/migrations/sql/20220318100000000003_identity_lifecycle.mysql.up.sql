@@ -0,0 +1 @@
+CREATE INDEX `identities_nid_inactive_at_idx` ON `identities` (`nid`, `inactive_at`);
@@ -0,0 +1 @@
+DROP INDEX `identities_nid_delete_at_idx` ON `identities`;
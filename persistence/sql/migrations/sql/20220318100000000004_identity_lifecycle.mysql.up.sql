@@ -0,0 +1 @@
+CREATE INDEX `identities_nid_delete_at_idx` ON `identities` (`nid`, `delete_at`);
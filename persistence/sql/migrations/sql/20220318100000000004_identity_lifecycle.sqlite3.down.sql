@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "identities_nid_delete_at_idx";
@@ -0,0 +1 @@
+UPDATE sessions SET devices='[]';
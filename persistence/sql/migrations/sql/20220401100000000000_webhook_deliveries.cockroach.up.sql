@@ -0,0 +1,16 @@
+CREATE TABLE "webhook_deliveries" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"status" INT NOT NULL,
+"method" VARCHAR (16) NOT NULL,
+"url" TEXT NOT NULL,
+"headers" TEXT NOT NULL,
+"body" TEXT NOT NULL,
+"attempts" INT NOT NULL DEFAULT 0,
+"last_error" TEXT,
+"next_attempt_at" timestamp NOT NULL,
+"nid" UUID,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL,
+CONSTRAINT "webhook_deliveries_nid_fk_idx" FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON UPDATE RESTRICT ON DELETE CASCADE
+);
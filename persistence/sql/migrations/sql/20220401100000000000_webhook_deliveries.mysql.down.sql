@@ -0,0 +1 @@
+DROP TABLE `webhook_deliveries`;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE `webhook_deliveries` (
+`id` char(36) NOT NULL,
+PRIMARY KEY(`id`),
+`status` INTEGER NOT NULL,
+`method` VARCHAR (16) NOT NULL,
+`url` TEXT NOT NULL,
+`headers` TEXT NOT NULL,
+`body` MEDIUMTEXT NOT NULL,
+`attempts` INTEGER NOT NULL DEFAULT 0,
+`last_error` TEXT,
+`next_attempt_at` DATETIME NOT NULL,
+`nid` char(36),
+`created_at` DATETIME NOT NULL,
+`updated_at` DATETIME NOT NULL,
+FOREIGN KEY (`nid`) REFERENCES `networks` (`id`) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
@@ -0,0 +1,15 @@
+CREATE TABLE "webhook_deliveries" (
+"id" TEXT PRIMARY KEY,
+"status" INTEGER NOT NULL,
+"method" TEXT NOT NULL,
+"url" TEXT NOT NULL,
+"headers" TEXT NOT NULL,
+"body" TEXT NOT NULL,
+"attempts" INTEGER NOT NULL DEFAULT 0,
+"last_error" TEXT,
+"next_attempt_at" DATETIME NOT NULL,
+"nid" char(36),
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
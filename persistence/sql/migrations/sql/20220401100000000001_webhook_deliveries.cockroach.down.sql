@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "webhook_deliveries_nid_status_next_attempt_at_idx";
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX `webhook_deliveries_nid_status_next_attempt_at_idx` ON `webhook_deliveries` (`nid`, `status`, `next_attempt_at`);
@@ -0,0 +1,12 @@
+CREATE TABLE `selfservice_rate_limit_counters` (
+`id` char(36) NOT NULL,
+PRIMARY KEY(`id`),
+`counter_key` VARCHAR (64) NOT NULL,
+`hits` INTEGER NOT NULL DEFAULT 0,
+`window_ends_at` DATETIME NOT NULL,
+`locked_until` DATETIME,
+`nid` char(36),
+`created_at` DATETIME NOT NULL,
+`updated_at` DATETIME NOT NULL,
+FOREIGN KEY (`nid`) REFERENCES `networks` (`id`) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
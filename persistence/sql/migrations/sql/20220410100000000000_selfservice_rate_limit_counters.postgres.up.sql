@@ -0,0 +1,12 @@
+CREATE TABLE "selfservice_rate_limit_counters" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"counter_key" VARCHAR (64) NOT NULL,
+"hits" INTEGER NOT NULL DEFAULT 0,
+"window_ends_at" timestamp NOT NULL,
+"locked_until" timestamp,
+"nid" UUID,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL,
+FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON UPDATE RESTRICT ON DELETE CASCADE
+);
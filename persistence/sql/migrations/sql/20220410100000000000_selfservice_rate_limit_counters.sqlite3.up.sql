@@ -0,0 +1,11 @@
+CREATE TABLE "selfservice_rate_limit_counters" (
+"id" TEXT PRIMARY KEY,
+"counter_key" TEXT NOT NULL,
+"hits" INTEGER NOT NULL DEFAULT 0,
+"window_ends_at" DATETIME NOT NULL,
+"locked_until" DATETIME,
+"nid" char(36),
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
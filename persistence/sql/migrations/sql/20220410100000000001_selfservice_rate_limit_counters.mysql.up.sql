@@ -0,0 +1 @@
+CREATE UNIQUE INDEX `selfservice_rate_limit_counters_nid_counter_key_uq_idx` ON `selfservice_rate_limit_counters` (`nid`, `counter_key`);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "selfservice_rate_limit_counters_nid_counter_key_uq_idx";
@@ -0,0 +1 @@
+DROP INDEX `selfservice_rate_limit_counters_nid_window_ends_at_idx` ON `selfservice_rate_limit_counters`;
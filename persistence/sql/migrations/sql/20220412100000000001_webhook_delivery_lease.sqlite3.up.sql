@@ -0,0 +1 @@
+CREATE INDEX "webhook_deliveries_nid_status_updated_at_idx" ON "webhook_deliveries" (nid, status, updated_at);
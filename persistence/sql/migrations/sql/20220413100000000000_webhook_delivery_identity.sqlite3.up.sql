@@ -0,0 +1 @@
+ALTER TABLE "webhook_deliveries" ADD COLUMN "identity_id" char(36);
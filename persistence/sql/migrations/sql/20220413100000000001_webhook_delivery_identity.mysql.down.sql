@@ -0,0 +1 @@
+DROP INDEX `webhook_deliveries_nid_identity_id_idx` ON `webhook_deliveries`;
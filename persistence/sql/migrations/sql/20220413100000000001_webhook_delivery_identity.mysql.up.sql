@@ -0,0 +1 @@
+CREATE INDEX `webhook_deliveries_nid_identity_id_idx` ON `webhook_deliveries` (`nid`, `identity_id`);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "webhook_deliveries_nid_identity_id_idx";
@@ -0,0 +1 @@
+ALTER TABLE `courier_messages` ADD COLUMN `html_body` TEXT NOT NULL;
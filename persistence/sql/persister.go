@@ -47,6 +47,8 @@ type (
 		r        persisterDependencies
 		p        *networkx.Manager
 		isSQLite bool
+
+		templates *templateCache
 	}
 )
 
@@ -59,7 +61,8 @@ func NewPersister(ctx context.Context, r persisterDependencies, c *pop.Connectio
 
 	return &Persister{
 		c: c, mb: m, r: r, isSQLite: c.Dialect.Name() == "sqlite3",
-		p: networkx.NewManager(c, r.Logger(), r.Tracer(ctx)),
+		p:         networkx.NewManager(c, r.Logger(), r.Tracer(ctx)),
+		templates: newTemplateCache(),
 	}, nil
 }
 
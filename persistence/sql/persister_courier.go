@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/gobuffalo/pop/v6"
 	"github.com/gofrs/uuid"
@@ -66,6 +68,28 @@ func (p *Persister) NextMessages(ctx context.Context, limit uint8) (messages []c
 	return messages, nil
 }
 
+func (p *Persister) RequeueMessage(ctx context.Context, id uuid.UUID) error {
+	count, err := p.GetConnection(ctx).RawQuery(
+		// #nosec G201
+		fmt.Sprintf(
+			"UPDATE %s SET status = ?, send_count = 0, last_error = NULL WHERE id = ? AND nid = ?",
+			corp.ContextualizeTableName(ctx, "courier_messages"),
+		),
+		courier.MessageStatusQueued,
+		id,
+		corp.ContextualizeNID(ctx, p.nid),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	return nil
+}
+
 func (p *Persister) LatestQueuedMessage(ctx context.Context) (*courier.Message, error) {
 	var m courier.Message
 	if err := p.GetConnection(ctx).
@@ -105,3 +129,170 @@ func (p *Persister) SetMessageStatus(ctx context.Context, id uuid.UUID, ms couri
 
 	return nil
 }
+
+func (p *Persister) RecordMessageFailure(ctx context.Context, id uuid.UUID, ms courier.MessageStatus, lastError string) error {
+	count, err := p.GetConnection(ctx).RawQuery(
+		// #nosec G201
+		fmt.Sprintf(
+			"UPDATE %s SET status = ?, send_count = send_count + 1, last_error = ? WHERE id = ? AND nid = ?",
+			corp.ContextualizeTableName(ctx, "courier_messages"),
+		),
+		ms,
+		lastError,
+		id,
+		corp.ContextualizeNID(ctx, p.nid),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (p *Persister) ListMessages(ctx context.Context, filter courier.ListMessagesFilter, page, itemsPerPage int) ([]courier.Message, int64, error) {
+	q := p.GetConnection(ctx).Where("nid = ?", corp.ContextualizeNID(ctx, p.nid))
+
+	if filter.Status != 0 {
+		q = q.Where("status = ?", filter.Status)
+	}
+
+	if len(filter.Recipient) > 0 {
+		q = q.Where("recipient = ?", filter.Recipient)
+	}
+
+	if len(filter.TemplateType) > 0 {
+		q = q.Where("template_type = ?", filter.TemplateType)
+	}
+
+	count, err := q.Count(new(courier.Message))
+	if err != nil {
+		return nil, 0, sqlcon.HandleError(err)
+	}
+
+	messages := make([]courier.Message, 0)
+	if err := q.Paginate(page, itemsPerPage).Order("created_at DESC").All(&messages); err != nil {
+		return nil, 0, sqlcon.HandleError(err)
+	}
+
+	return messages, int64(count), nil
+}
+
+func (p *Persister) FetchMessage(ctx context.Context, id uuid.UUID) (*courier.Message, error) {
+	var m courier.Message
+	if err := p.GetConnection(ctx).
+		Where("id = ? AND nid = ?", id, corp.ContextualizeNID(ctx, p.nid)).
+		First(&m); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return &m, nil
+}
+
+// templateCacheTTL bounds how long templates changed through another Kratos instance remain unnoticed.
+const templateCacheTTL = time.Minute
+
+// templateCache holds the stored templates per network because they are needed to render every email
+// but change rarely.
+type templateCache struct {
+	sync.RWMutex
+	entries map[uuid.UUID]templateCacheEntry
+}
+
+type templateCacheEntry struct {
+	templates []courier.StoredTemplate
+	expiresAt time.Time
+}
+
+func newTemplateCache() *templateCache {
+	return &templateCache{entries: make(map[uuid.UUID]templateCacheEntry)}
+}
+
+func (c *templateCache) get(nid uuid.UUID) ([]courier.StoredTemplate, bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	e, ok := c.entries[nid]
+	if !ok || time.Now().After(e.expiresAt) {
+		return nil, false
+	}
+	return append(make([]courier.StoredTemplate, 0, len(e.templates)), e.templates...), true
+}
+
+func (c *templateCache) set(nid uuid.UUID, templates []courier.StoredTemplate) {
+	c.Lock()
+	defer c.Unlock()
+	c.entries[nid] = templateCacheEntry{
+		templates: append(make([]courier.StoredTemplate, 0, len(templates)), templates...),
+		expiresAt: time.Now().Add(templateCacheTTL),
+	}
+}
+
+func (c *templateCache) invalidate(nid uuid.UUID) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.entries, nid)
+}
+
+func (p *Persister) ListTemplates(ctx context.Context) ([]courier.StoredTemplate, error) {
+	nid := corp.ContextualizeNID(ctx, p.nid)
+	if templates, ok := p.templates.get(nid); ok {
+		return templates, nil
+	}
+
+	templates := make([]courier.StoredTemplate, 0)
+	if err := p.GetConnection(ctx).
+		Where("nid = ?", nid).
+		Order("template_type ASC, locale ASC").
+		All(&templates); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	p.templates.set(nid, templates)
+	return templates, nil
+}
+
+func (p *Persister) GetTemplate(ctx context.Context, id uuid.UUID) (*courier.StoredTemplate, error) {
+	var t courier.StoredTemplate
+	if err := p.GetConnection(ctx).
+		Where("id = ? AND nid = ?", id, corp.ContextualizeNID(ctx, p.nid)).
+		First(&t); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return &t, nil
+}
+
+func (p *Persister) CreateTemplate(ctx context.Context, t *courier.StoredTemplate) error {
+	t.NID = corp.ContextualizeNID(ctx, p.nid)
+	defer p.templates.invalidate(t.NID)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(t))
+}
+
+func (p *Persister) UpdateTemplate(ctx context.Context, t *courier.StoredTemplate) error {
+	t.NID = corp.ContextualizeNID(ctx, p.nid)
+	defer p.templates.invalidate(t.NID)
+	return p.update(ctx, t)
+}
+
+func (p *Persister) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	defer p.templates.invalidate(corp.ContextualizeNID(ctx, p.nid))
+	count, err := p.GetConnection(ctx).RawQuery(
+		// #nosec G201
+		fmt.Sprintf("DELETE FROM %s WHERE id = ? AND nid = ?", corp.ContextualizeTableName(ctx, "courier_templates")),
+		id,
+		corp.ContextualizeNID(ctx, p.nid),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	return nil
+}
@@ -14,11 +14,13 @@ import (
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/otp"
+	"github.com/ory/kratos/ratelimit"
 	"github.com/ory/kratos/x"
 
 	"github.com/gobuffalo/pop/v6"
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
 
 	"github.com/ory/herodot"
 	"github.com/ory/x/errorsx"
@@ -30,6 +32,9 @@ import (
 var _ identity.Pool = new(Persister)
 var _ identity.PrivilegedPool = new(Persister)
 
+// traitScanBatchSize is the number of identities loaded at once when matching traits on SQLite.
+const traitScanBatchSize = 500
+
 func (p *Persister) ListVerifiableAddresses(ctx context.Context, page, itemsPerPage int) (a []identity.VerifiableAddress, err error) {
 	if err := p.GetConnection(ctx).Where("nid = ?", corp.ContextualizeNID(ctx, p.nid)).Order("id DESC").Paginate(page, x.MaxItemsPerPage(itemsPerPage)).All(&a); err != nil {
 		return nil, sqlcon.HandleError(err)
@@ -207,6 +212,174 @@ func (p *Persister) CountIdentities(ctx context.Context) (int64, error) {
 	return int64(count), nil
 }
 
+func (p *Persister) CountSearchedIdentities(ctx context.Context, params identity.ListIdentitiesParameters) (int64, error) {
+	if p.isSQLite && len(params.Traits) > 0 {
+		var count int64
+		err := p.scanIdentitiesByTraits(ctx, params, uuid.Nil, func(uuid.UUID) bool {
+			count++
+			return true
+		})
+		return count, err
+	}
+
+	q, err := p.identitySearchQuery(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := q.Count(new(identity.Identity))
+	if err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+	return int64(count), nil
+}
+
+func (p *Persister) identitySearchQuery(ctx context.Context, params identity.ListIdentitiesParameters) (*pop.Query, error) {
+	nid := corp.ContextualizeNID(ctx, p.nid)
+	q := p.GetConnection(ctx).Where("nid = ?", nid)
+
+	// Identifiers and addresses are usually stored in lower case, so we match the value as is and normalized.
+	normalize := func(value string) string {
+		return strings.ToLower(strings.TrimSpace(value))
+	}
+
+	if v := params.CredentialsIdentifier; v != "" {
+		// #nosec G201
+		q = q.Where(fmt.Sprintf(`id IN (SELECT ic.identity_id FROM %s ic
+    INNER JOIN %s ici ON ic.id = ici.identity_credential_id
+WHERE ici.identifier IN (?, ?) AND ic.nid = ? AND ici.nid = ?)`,
+			corp.ContextualizeTableName(ctx, "identity_credentials"),
+			corp.ContextualizeTableName(ctx, "identity_credential_identifiers"),
+		), v, normalize(v), nid, nid)
+	}
+
+	if v := params.VerifiableAddress; v != "" {
+		// #nosec G201
+		q = q.Where(fmt.Sprintf(`id IN (SELECT identity_id FROM %s WHERE value IN (?, ?) AND nid = ?)`,
+			corp.ContextualizeTableName(ctx, "identity_verifiable_addresses"),
+		), v, normalize(v), nid)
+	}
+
+	if v := params.RecoveryAddress; v != "" {
+		// #nosec G201
+		q = q.Where(fmt.Sprintf(`id IN (SELECT identity_id FROM %s WHERE value IN (?, ?) AND nid = ?)`,
+			corp.ContextualizeTableName(ctx, "identity_recovery_addresses"),
+		), v, normalize(v), nid)
+	}
+
+	if params.State != "" {
+		q = q.Where("state = ?", params.State)
+	}
+
+	if params.SchemaID != "" {
+		q = q.Where("schema_id = ?", params.SchemaID)
+	}
+
+	if !params.CreatedAfter.IsZero() {
+		q = q.Where("created_at >= ?", params.CreatedAfter.UTC())
+	}
+
+	if !params.CreatedBefore.IsZero() {
+		q = q.Where("created_at < ?", params.CreatedBefore.UTC())
+	}
+
+	if len(params.Traits) == 0 {
+		return q, nil
+	}
+
+	if p.isSQLite {
+		// SQLite is built without the JSON extension, which is why traits are matched in Go instead. See
+		// scanIdentitiesByTraits.
+		return q, nil
+	}
+
+	for _, f := range params.Traits {
+		q = q.Where(p.traitSelector(f)+" = ?", f.Value)
+	}
+
+	return q, nil
+}
+
+// traitSelector returns the dialect-specific SQL expression which extracts the trait at the filter's path as text.
+// The path segments are validated by identity.NewTraitFilter and therefore safe to embed.
+func (p *Persister) traitSelector(f identity.TraitFilter) string {
+	segments := f.PathSegments()
+
+	if p.c.Dialect.Name() == "mysql" {
+		return fmt.Sprintf(`JSON_UNQUOTE(JSON_EXTRACT(traits, '$."%s"'))`, strings.Join(segments, `"."`))
+	}
+	return fmt.Sprintf(`traits #>> '{%s}'`, strings.Join(segments, ","))
+}
+
+// findIdentityIDsByTraits returns the IDs of the identities on the requested page whose traits match the filters.
+func (p *Persister) findIdentityIDsByTraits(ctx context.Context, params identity.ListIdentitiesParameters) ([]uuid.UUID, error) {
+	perPage := params.PerPage
+	if perPage < 1 {
+		perPage = pop.PaginatorPerPageDefault
+	}
+
+	var skip int
+	if !params.IsKeyset() && params.Page > 1 {
+		skip = (params.Page - 1) * perPage
+	}
+
+	ids := make([]uuid.UUID, 0, perPage)
+	err := p.scanIdentitiesByTraits(ctx, params, params.PageToken, func(id uuid.UUID) bool {
+		if skip > 0 {
+			skip--
+			return true
+		}
+		ids = append(ids, id)
+		return len(ids) < perPage
+	})
+	return ids, err
+}
+
+// scanIdentitiesByTraits calls fn with the ID of each identity listed before the given ID, or of all identities
+// if it is nil, which matches the parameters including the trait filters, until fn returns false. Candidates are
+// loaded in batches so that neither memory usage nor the number of bound parameters grow with the number of
+// identities.
+func (p *Persister) scanIdentitiesByTraits(ctx context.Context, params identity.ListIdentitiesParameters, before uuid.UUID, fn func(uuid.UUID) bool) error {
+	filters := params.Traits
+	params.Traits = nil
+
+	for {
+		q, err := p.identitySearchQuery(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		if before != uuid.Nil {
+			q = q.Where("id < ?", before)
+		}
+
+		var candidates []identity.Identity
+		if err := q.Select("id", "traits").Order("id DESC").Limit(traitScanBatchSize).All(&candidates); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		for _, i := range candidates {
+			if matchesTraits(i.Traits, filters) && !fn(i.ID) {
+				return nil
+			}
+		}
+
+		if len(candidates) < traitScanBatchSize {
+			return nil
+		}
+		before = candidates[len(candidates)-1].ID
+	}
+}
+
+func matchesTraits(traits identity.Traits, filters []identity.TraitFilter) bool {
+	for _, f := range filters {
+		if v := gjson.GetBytes(traits, f.Path); !v.Exists() || v.String() != f.Value {
+			return false
+		}
+	}
+	return true
+}
+
 func (p *Persister) CreateIdentity(ctx context.Context, i *identity.Identity) error {
 	i.NID = corp.ContextualizeNID(ctx, p.nid)
 
@@ -249,14 +422,48 @@ func (p *Persister) CreateIdentity(ctx context.Context, i *identity.Identity) er
 	})
 }
 
+func (p *Persister) CreateIdentities(ctx context.Context, is ...*identity.Identity) error {
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		for _, i := range is {
+			if err := p.CreateIdentity(ctx, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (p *Persister) ListIdentities(ctx context.Context, page, perPage int) ([]identity.Identity, error) {
+	return p.SearchIdentities(ctx, identity.ListIdentitiesParameters{Page: page, PerPage: perPage})
+}
+
+func (p *Persister) SearchIdentities(ctx context.Context, params identity.ListIdentitiesParameters) ([]identity.Identity, error) {
 	is := make([]identity.Identity, 0)
 
-	/* #nosec G201 TableName is static */
-	if err := sqlcon.HandleError(p.GetConnection(ctx).Where("nid = ?", corp.ContextualizeNID(ctx, p.nid)).
-		EagerPreload("VerifiableAddresses", "RecoveryAddresses").
-		Paginate(page, perPage).Order("id DESC").
-		All(&is)); err != nil {
+	q, err := p.identitySearchQuery(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	q = q.EagerPreload("VerifiableAddresses", "RecoveryAddresses").Order("id DESC")
+	if p.isSQLite && len(params.Traits) > 0 {
+		// The IDs are already limited to the requested page.
+		ids, err := p.findIdentityIDsByTraits(ctx, params)
+		if err != nil {
+			return nil, err
+		} else if len(ids) == 0 {
+			return is, nil
+		}
+		q = q.Where("id IN (?)", ids)
+	} else if params.IsKeyset() {
+		// Keyset pagination continues after the last identity of the previous page, which allows the database
+		// to use the index instead of skipping all previous rows.
+		q = q.Where("id < ?", params.PageToken).Limit(params.PerPage)
+	} else {
+		q = q.Paginate(params.Page, params.PerPage)
+	}
+
+	if err := sqlcon.HandleError(q.All(&is)); err != nil {
 		return nil, err
 	}
 
@@ -284,16 +491,63 @@ func (p *Persister) ListIdentities(ctx context.Context, page, perPage int) ([]id
 }
 
 func (p *Persister) UpdateIdentity(ctx context.Context, i *identity.Identity) error {
+	return p.updateIdentity(ctx, i, nil)
+}
+
+func (p *Persister) UpdateIdentityIfUnmodified(ctx context.Context, i *identity.Identity, updatedAt time.Time) error {
+	return p.updateIdentity(ctx, i, &updatedAt)
+}
+
+// updateIdentity updates the identity and bumps its updated_at time, so that every update is detected by
+// conditional updates. If expectedUpdatedAt is set, the identity is only updated if it was last updated at
+// that time.
+func (p *Persister) updateIdentity(ctx context.Context, i *identity.Identity, expectedUpdatedAt *time.Time) error {
 	if err := p.validateIdentity(ctx, i); err != nil {
 		return err
 	}
 
-	i.NID = corp.ContextualizeNID(ctx, p.nid)
-	return sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
-		if count, err := tx.Where("id = ? AND nid = ?", i.ID, corp.ContextualizeNID(ctx, p.nid)).Count(i); err != nil {
+	nid := corp.ContextualizeNID(ctx, p.nid)
+	previous := i.UpdatedAt
+	updatedAt := time.Now().UTC().Truncate(time.Microsecond)
+	if expectedUpdatedAt != nil && !updatedAt.After(*expectedUpdatedAt) {
+		updatedAt = expectedUpdatedAt.UTC().Add(time.Microsecond)
+	}
+
+	i.NID = nid
+	i.UpdatedAt = updatedAt
+	return sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) (err error) {
+		defer func() {
+			if err != nil {
+				i.UpdatedAt = previous
+			}
+		}()
+
+		// The update of updated_at locks the row until the transaction completes, so that concurrent updates
+		// can not slip in between the check and the update below.
+		where, args := "id = ? AND nid = ?", []interface{}{i.ID, nid}
+		if expectedUpdatedAt != nil {
+			where, args = where+" AND updated_at = ?", append(args, *expectedUpdatedAt)
+		}
+
+		/* #nosec G201 TableName is static */
+		touched, err := tx.RawQuery(fmt.Sprintf(`UPDATE %s SET updated_at = ? WHERE %s`,
+			corp.ContextualizeTableName(ctx, "identities"), where), append([]interface{}{updatedAt}, args...)...).ExecWithCount()
+		if err != nil {
 			return err
-		} else if count == 0 {
-			return sql.ErrNoRows
+		} else if touched == 0 {
+			// MySQL does not count matched rows whose value did not change, for example because its timestamps
+			// are less precise, which is why the condition is checked again.
+			matched, err := tx.Where(where, args...).Count(i)
+			if err != nil {
+				return err
+			} else if matched == 0 {
+				if count, err := tx.Where("id = ? AND nid = ?", i.ID, nid).Count(i); err != nil {
+					return err
+				} else if count == 0 {
+					return sql.ErrNoRows
+				}
+				return errors.WithStack(identity.ErrIdentityModified)
+			}
 		}
 
 		for _, tn := range []string{
@@ -303,7 +557,7 @@ func (p *Persister) UpdateIdentity(ctx context.Context, i *identity.Identity) er
 		} {
 			/* #nosec G201 TableName is static */
 			if err := tx.RawQuery(fmt.Sprintf(
-				`DELETE FROM %s WHERE identity_id = ? AND nid = ?`, tn), i.ID, corp.ContextualizeNID(ctx, p.nid)).Exec(); err != nil {
+				`DELETE FROM %s WHERE identity_id = ? AND nid = ?`, tn), i.ID, nid).Exec(); err != nil {
 				return err
 			}
 		}
@@ -328,6 +582,106 @@ func (p *Persister) DeleteIdentity(ctx context.Context, id uuid.UUID) error {
 	return p.delete(ctx, new(identity.Identity), id)
 }
 
+func (p *Persister) EraseIdentity(ctx context.Context, id uuid.UUID) error {
+	nid := corp.ContextualizeNID(ctx, p.nid)
+	return sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		var i identity.Identity
+		if err := tx.Where("id = ? AND nid = ?", id, nid).First(&i); err != nil {
+			return err
+		}
+
+		if err := p.findVerifiableAddresses(ctx, &i); err != nil {
+			return err
+		}
+
+		if err := p.findRecoveryAddresses(ctx, &i); err != nil {
+			return err
+		}
+
+		recipients := map[string]struct{}{}
+		for _, a := range i.VerifiableAddresses {
+			recipients[a.Value] = struct{}{}
+		}
+		for _, a := range i.RecoveryAddresses {
+			recipients[a.Value] = struct{}{}
+		}
+
+		for recipient := range recipients {
+			/* #nosec G201 TableName is static */
+			if err := tx.RawQuery(fmt.Sprintf(`DELETE FROM %s WHERE recipient = ? AND nid = ?`,
+				corp.ContextualizeTableName(ctx, "courier_messages"),
+			), recipient, nid).Exec(); err != nil {
+				return err
+			}
+		}
+
+		/* #nosec G201 TableName is static */
+		if err := tx.RawQuery(fmt.Sprintf(`DELETE FROM %s WHERE identity_id = ? AND nid = ?`,
+			corp.ContextualizeTableName(ctx, "webhook_deliveries"),
+		), id, nid).Exec(); err != nil {
+			return err
+		}
+
+		var cids identity.CredentialIdentifierCollection
+		/* #nosec G201 TableName is static */
+		if err := tx.Where(fmt.Sprintf(`identity_credential_id IN (SELECT id FROM %s WHERE identity_id = ? AND nid = ?) AND nid = ?`,
+			corp.ContextualizeTableName(ctx, "identity_credentials"),
+		), id, nid, nid).All(&cids); err != nil {
+			return err
+		}
+
+		// The rate limit counters of the identity's identifiers and addresses are keyed by hashes of their values.
+		identifiers := map[string]struct{}{}
+		for recipient := range recipients {
+			identifiers[recipient] = struct{}{}
+		}
+		for _, cid := range cids {
+			identifiers[cid.Identifier] = struct{}{}
+		}
+
+		for identifier := range identifiers {
+			for _, key := range ratelimit.IdentifierCounterKeys(identifier) {
+				/* #nosec G201 TableName is static */
+				if err := tx.RawQuery(fmt.Sprintf(`DELETE FROM %s WHERE counter_key = ? AND nid = ?`,
+					corp.ContextualizeTableName(ctx, "selfservice_rate_limit_counters"),
+				), key, nid).Exec(); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Sessions, self-service flows and tokens reference the identity and are deleted by the database.
+		return p.delete(ctx, new(identity.Identity), id)
+	}))
+}
+
+func (p *Persister) ListIdentityIDsDueForDeactivation(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	return p.listIdentityIDsScheduledBefore(ctx, "inactive_at", before, limit)
+}
+
+func (p *Persister) ListIdentityIDsDueForErasure(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	return p.listIdentityIDsScheduledBefore(ctx, "delete_at", before, limit)
+}
+
+func (p *Persister) listIdentityIDsScheduledBefore(ctx context.Context, column string, before time.Time, limit int) ([]uuid.UUID, error) {
+	var is []identity.Identity
+	// #nosec G201 column is static
+	if err := p.GetConnection(ctx).
+		Where(fmt.Sprintf("nid = ? AND %[1]s IS NOT NULL AND %[1]s <= ?", column), corp.ContextualizeNID(ctx, p.nid), before.UTC()).
+		Order(column + " ASC").
+		Limit(limit).
+		Select("id").
+		All(&is); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	ids := make([]uuid.UUID, len(is))
+	for k, i := range is {
+		ids[k] = i.ID
+	}
+	return ids, nil
+}
+
 func (p *Persister) GetIdentity(ctx context.Context, id uuid.UUID) (*identity.Identity, error) {
 	var i identity.Identity
 	if err := p.GetConnection(ctx).Where("id = ? AND nid = ?", id, corp.ContextualizeNID(ctx, p.nid)).First(&i); err != nil {
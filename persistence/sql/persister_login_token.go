@@ -0,0 +1,135 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/strategy/link"
+	"github.com/ory/x/sqlcon"
+)
+
+var _ link.LoginTokenPersister = new(Persister)
+
+func (p *Persister) CreateLoginToken(ctx context.Context, token *link.LoginToken) error {
+	t, c := token.Token, token.Code
+	token.Token = p.hmacValue(ctx, t)
+	token.Code = p.hmacValue(ctx, c)
+	token.NID = corp.ContextualizeNID(ctx, p.nid)
+
+	// This should not create the request eagerly because otherwise we might accidentally create an address that isn't
+	// supposed to be in the database.
+	if err := p.GetConnection(ctx).Create(token); err != nil {
+		return err
+	}
+
+	token.Token, token.Code = t, c
+	return nil
+}
+
+func (p *Persister) UseLoginToken(ctx context.Context, token string) (*link.LoginToken, error) {
+	var rt link.LoginToken
+
+	nid := corp.ContextualizeNID(ctx, p.nid)
+	if err := sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) (err error) {
+		for _, secret := range p.r.Config(ctx).SecretsSession() {
+			if err = tx.Where("token = ? AND nid = ? AND NOT used", p.hmacValueWithSecret(token, secret), nid).First(&rt); err != nil {
+				if !errors.Is(sqlcon.HandleError(err), sqlcon.ErrNoRows) {
+					return err
+				}
+			} else {
+				break
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		var va identity.VerifiableAddress
+		if err := tx.Where("id = ? AND nid = ?", rt.VerifiableAddressID, nid).First(&va); err != nil {
+			return err
+		}
+		rt.VerifiableAddress = &va
+
+		/* #nosec G201 TableName is static */
+		return tx.RawQuery(fmt.Sprintf("UPDATE %s SET used=true, used_at=? WHERE id=? AND nid = ?", rt.TableName(ctx)), time.Now().UTC(), rt.ID, nid).Exec()
+	})); err != nil {
+		return nil, err
+	}
+
+	return &rt, nil
+}
+
+func (p *Persister) UseLoginCode(ctx context.Context, flowID uuid.UUID, code string, maxAttempts int) (*link.LoginToken, error) {
+	var rt *link.LoginToken
+	var useErr error
+
+	nid := corp.ContextualizeNID(ctx, p.nid)
+	if err := sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		var tokens []link.LoginToken
+		if err := tx.Where("selfservice_login_flow_id = ? AND nid = ? AND NOT used", flowID, nid).All(&tokens); err != nil {
+			return err
+		} else if len(tokens) == 0 {
+			return sqlcon.ErrNoRows
+		}
+
+		for k := range tokens {
+			// The attempt is counted before the code is compared, and only if the
+			// token has attempts left, in a single write. This locks the token so
+			// that parallel guesses can not exceed the maximum number of attempts.
+			/* #nosec G201 TableName is static */
+			counted, err := tx.RawQuery(fmt.Sprintf("UPDATE %s SET code_attempts = code_attempts + 1 WHERE id = ? AND nid = ? AND NOT used AND code_attempts < ?", tokens[k].TableName(ctx)), tokens[k].ID, nid, maxAttempts).ExecWithCount()
+			if err != nil {
+				return err
+			} else if counted == 0 {
+				continue
+			}
+
+			if p.hmacConstantCompare(ctx, code, tokens[k].Code) {
+				rt = &tokens[k]
+				break
+			}
+		}
+
+		if rt == nil {
+			remaining, err := tx.Where("selfservice_login_flow_id = ? AND nid = ? AND NOT used AND code_attempts < ?", flowID, nid, maxAttempts).Count(new(link.LoginToken))
+			if err != nil {
+				return err
+			}
+
+			useErr = link.ErrCodeInvalid
+			if remaining == 0 {
+				useErr = link.ErrCodeAttemptsExceeded
+			}
+
+			// Returning nil commits the counted attempts.
+			return nil
+		}
+
+		var va identity.VerifiableAddress
+		if err := tx.Where("id = ? AND nid = ?", rt.VerifiableAddressID, nid).First(&va); err != nil {
+			return err
+		}
+		rt.VerifiableAddress = &va
+
+		/* #nosec G201 TableName is static */
+		return tx.RawQuery(fmt.Sprintf("UPDATE %s SET used=true, used_at=? WHERE id=? AND nid = ?", rt.TableName(ctx)), time.Now().UTC(), rt.ID, nid).Exec()
+	})); err != nil {
+		return nil, err
+	} else if useErr != nil {
+		return nil, useErr
+	}
+
+	return rt, nil
+}
+
+func (p *Persister) DeleteLoginToken(ctx context.Context, token string) error {
+	/* #nosec G201 TableName is static */
+	return p.GetConnection(ctx).RawQuery(fmt.Sprintf("DELETE FROM %s WHERE token=? AND nid = ?", new(link.LoginToken).TableName(ctx)), token, corp.ContextualizeNID(ctx, p.nid)).Exec()
+}
@@ -0,0 +1,109 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/ratelimit"
+)
+
+var _ ratelimit.Persister = new(Persister)
+
+func (p *Persister) HitRateLimitCounter(ctx context.Context, key string, window time.Duration, now time.Time) (*ratelimit.Counter, error) {
+	c, err := p.hitRateLimitCounter(ctx, key, window, now)
+	if errors.Is(err, sqlcon.ErrUniqueViolation) {
+		// The same key started a new window concurrently, which is why we count the hit in that window instead.
+		return p.hitRateLimitCounter(ctx, key, window, now)
+	}
+	return c, err
+}
+
+func (p *Persister) hitRateLimitCounter(ctx context.Context, key string, window time.Duration, now time.Time) (*ratelimit.Counter, error) {
+	now = now.UTC()
+	nid := corp.ContextualizeNID(ctx, p.nid)
+	table := corp.ContextualizeTableName(ctx, "selfservice_rate_limit_counters")
+
+	var c ratelimit.Counter
+	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		// The hits are incremented in SQL so that concurrent hits of the same key are not lost. The window of
+		// locked keys ends when the lock ends, which is why checking the window is enough.
+		count, err := tx.RawQuery(
+			// #nosec G201
+			fmt.Sprintf("UPDATE %s SET hits = hits + 1, updated_at = ? WHERE nid = ? AND counter_key = ? AND window_ends_at > ?", table),
+			now,
+			nid,
+			key,
+			now,
+		).ExecWithCount()
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return tx.Where("nid = ? AND counter_key = ?", nid, key).First(&c)
+		}
+
+		// The key's window ended or the key was never counted. Other expired counters are deleted by the identity
+		// lifecycle worker.
+		if err := tx.RawQuery(
+			// #nosec G201
+			fmt.Sprintf("DELETE FROM %s WHERE nid = ? AND counter_key = ?", table),
+			nid,
+			key,
+		).Exec(); err != nil {
+			return err
+		}
+
+		c = ratelimit.Counter{
+			NID:          nid,
+			Key:          key,
+			Hits:         1,
+			WindowEndsAt: now.Add(window),
+		}
+		return tx.Create(&c)
+	}); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return &c, nil
+}
+
+func (p *Persister) LockRateLimitCounter(ctx context.Context, key string, until time.Time) error {
+	count, err := p.GetConnection(ctx).RawQuery(
+		// #nosec G201
+		fmt.Sprintf(
+			"UPDATE %s SET locked_until = ?, window_ends_at = ?, updated_at = ? WHERE nid = ? AND counter_key = ?",
+			corp.ContextualizeTableName(ctx, "selfservice_rate_limit_counters"),
+		),
+		until.UTC(),
+		until.UTC(),
+		time.Now().UTC(),
+		corp.ContextualizeNID(ctx, p.nid),
+		key,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (p *Persister) DeleteExpiredRateLimitCounters(ctx context.Context, before time.Time) error {
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(
+		// #nosec G201
+		fmt.Sprintf("DELETE FROM %s WHERE nid = ? AND window_ends_at <= ?", corp.ContextualizeTableName(ctx, "selfservice_rate_limit_counters")),
+		corp.ContextualizeNID(ctx, p.nid),
+		before.UTC(),
+	).Exec())
+}
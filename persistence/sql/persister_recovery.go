@@ -40,8 +40,11 @@ func (p Persister) UpdateRecoveryFlow(ctx context.Context, r *recovery.Flow) err
 }
 
 func (p *Persister) CreateRecoveryToken(ctx context.Context, token *link.RecoveryToken) error {
-	t := token.Token
+	t, c := token.Token, token.Code
 	token.Token = p.hmacValue(ctx, t)
+	if len(c) > 0 {
+		token.Code = p.hmacValue(ctx, c)
+	}
 	token.NID = corp.ContextualizeNID(ctx, p.nid)
 
 	// This should not create the request eagerly because otherwise we might accidentally create an address that isn't
@@ -50,7 +53,7 @@ func (p *Persister) CreateRecoveryToken(ctx context.Context, token *link.Recover
 		return err
 	}
 
-	token.Token = t
+	token.Token, token.Code = t, c
 	return nil
 }
 
@@ -89,6 +92,71 @@ func (p *Persister) UseRecoveryToken(ctx context.Context, token string) (*link.R
 	return &rt, nil
 }
 
+func (p *Persister) UseRecoveryCode(ctx context.Context, flowID uuid.UUID, code string, maxAttempts int) (*link.RecoveryToken, error) {
+	var rt *link.RecoveryToken
+	var useErr error
+
+	nid := corp.ContextualizeNID(ctx, p.nid)
+	if err := sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		var tokens []link.RecoveryToken
+		if err := tx.Where("selfservice_recovery_flow_id = ? AND nid = ? AND code <> '' AND NOT used", flowID, nid).All(&tokens); err != nil {
+			return err
+		} else if len(tokens) == 0 {
+			return sqlcon.ErrNoRows
+		}
+
+		for k := range tokens {
+			// The attempt is counted before the code is compared, and only if the
+			// token has attempts left, in a single write. This locks the token so
+			// that parallel guesses can not exceed the maximum number of attempts.
+			/* #nosec G201 TableName is static */
+			counted, err := tx.RawQuery(fmt.Sprintf("UPDATE %s SET code_attempts = code_attempts + 1 WHERE id = ? AND nid = ? AND NOT used AND code_attempts < ?", tokens[k].TableName(ctx)), tokens[k].ID, nid, maxAttempts).ExecWithCount()
+			if err != nil {
+				return err
+			} else if counted == 0 {
+				continue
+			}
+
+			if p.hmacConstantCompare(ctx, code, tokens[k].Code) {
+				rt = &tokens[k]
+				break
+			}
+		}
+
+		if rt == nil {
+			remaining, err := tx.Where("selfservice_recovery_flow_id = ? AND nid = ? AND code <> '' AND NOT used AND code_attempts < ?", flowID, nid, maxAttempts).Count(new(link.RecoveryToken))
+			if err != nil {
+				return err
+			}
+
+			useErr = link.ErrCodeInvalid
+			if remaining == 0 {
+				useErr = link.ErrCodeAttemptsExceeded
+			}
+
+			// Returning nil commits the counted attempts.
+			return nil
+		}
+
+		var ra identity.RecoveryAddress
+		if err := tx.Where("id = ? AND nid = ?", rt.RecoveryAddressID, nid).First(&ra); err != nil {
+			if !errors.Is(sqlcon.HandleError(err), sqlcon.ErrNoRows) {
+				return err
+			}
+		}
+		rt.RecoveryAddress = &ra
+
+		/* #nosec G201 TableName is static */
+		return tx.RawQuery(fmt.Sprintf("UPDATE %s SET used=true, used_at=? WHERE id=? AND nid = ?", rt.TableName(ctx)), time.Now().UTC(), rt.ID, nid).Exec()
+	})); err != nil {
+		return nil, err
+	} else if useErr != nil {
+		return nil, useErr
+	}
+
+	return rt, nil
+}
+
 func (p *Persister) DeleteRecoveryToken(ctx context.Context, token string) error {
 	/* #nosec G201 TableName is static */
 	return p.GetConnection(ctx).RawQuery(fmt.Sprintf("DELETE FROM %s WHERE token=? AND nid = ?", new(link.RecoveryToken).TableName(ctx)), token, corp.ContextualizeNID(ctx, p.nid)).Exec()
@@ -196,3 +196,29 @@ func (p *Persister) RevokeSessionsIdentityExcept(ctx context.Context, iID, sID u
 	}
 	return count, nil
 }
+
+// UpdateSessionDevices stores the device list of a session. Other fields are not updated, so a session which
+// was revoked concurrently stays revoked.
+func (p *Persister) UpdateSessionDevices(ctx context.Context, sID uuid.UUID, devices session.Devices) error {
+	value, err := devices.Value()
+	if err != nil {
+		return err
+	}
+
+	// #nosec G201
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET devices = ? WHERE id = ? AND nid = ?",
+		corp.ContextualizeTableName(ctx, "sessions"),
+	),
+		value,
+		sID,
+		corp.ContextualizeNID(ctx, p.nid),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+	return nil
+}
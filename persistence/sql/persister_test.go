@@ -28,6 +28,7 @@ import (
 	"github.com/ory/kratos/internal/testhelpers"
 	"github.com/ory/kratos/persistence/sql"
 	sqltesthelpers "github.com/ory/kratos/persistence/sql/testhelpers"
+	ratelimit "github.com/ory/kratos/ratelimit/test"
 	errorx "github.com/ory/kratos/selfservice/errorx/test"
 	lf "github.com/ory/kratos/selfservice/flow/login"
 	login "github.com/ory/kratos/selfservice/flow/login/test"
@@ -37,6 +38,7 @@ import (
 	verification "github.com/ory/kratos/selfservice/flow/verification/test"
 	link "github.com/ory/kratos/selfservice/strategy/link/test"
 	session "github.com/ory/kratos/session/test"
+	webhook "github.com/ory/kratos/webhook/test"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/sqlcon"
 	"github.com/ory/x/sqlcon/dockertest"
@@ -183,6 +185,14 @@ func TestPersister(t *testing.T) {
 				upsert, insert := sqltesthelpers.DefaultNetworkWrapper(p)
 				courier.TestPersister(ctx, upsert, insert)(t)
 			})
+			t.Run("contract=webhook.TestPersister", func(t *testing.T) {
+				pop.SetLogger(pl(t))
+				webhook.TestPersister(ctx, p)(t)
+			})
+			t.Run("contract=ratelimit.TestPersister", func(t *testing.T) {
+				pop.SetLogger(pl(t))
+				ratelimit.TestPersister(ctx, p)(t)
+			})
 			t.Run("contract=verification.TestPersister", func(t *testing.T) {
 				pop.SetLogger(pl(t))
 				verification.TestFlowPersister(ctx, conf, p)(t)
@@ -43,8 +43,11 @@ func (p Persister) UpdateVerificationFlow(ctx context.Context, r *verification.F
 }
 
 func (p *Persister) CreateVerificationToken(ctx context.Context, token *link.VerificationToken) error {
-	t := token.Token
+	t, c := token.Token, token.Code
 	token.Token = p.hmacValue(ctx, t)
+	if len(c) > 0 {
+		token.Code = p.hmacValue(ctx, c)
+	}
 	token.NID = corp.ContextualizeNID(ctx, p.nid)
 
 	// This should not create the request eagerly because otherwise we might accidentally create an address that isn't
@@ -52,7 +55,7 @@ func (p *Persister) CreateVerificationToken(ctx context.Context, token *link.Ver
 	if err := p.GetConnection(ctx).Create(token); err != nil {
 		return err
 	}
-	token.Token = t
+	token.Token, token.Code = t, c
 	return nil
 }
 
@@ -90,6 +93,69 @@ func (p *Persister) UseVerificationToken(ctx context.Context, token string) (*li
 	return &rt, nil
 }
 
+func (p *Persister) UseVerificationCode(ctx context.Context, flowID uuid.UUID, code string, maxAttempts int) (*link.VerificationToken, error) {
+	var rt *link.VerificationToken
+	var useErr error
+
+	nid := corp.ContextualizeNID(ctx, p.nid)
+	if err := sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		var tokens []link.VerificationToken
+		if err := tx.Where("selfservice_verification_flow_id = ? AND nid = ? AND code <> '' AND NOT used", flowID, nid).All(&tokens); err != nil {
+			return err
+		} else if len(tokens) == 0 {
+			return sqlcon.ErrNoRows
+		}
+
+		for k := range tokens {
+			// The attempt is counted before the code is compared, and only if the
+			// token has attempts left, in a single write. This locks the token so
+			// that parallel guesses can not exceed the maximum number of attempts.
+			/* #nosec G201 TableName is static */
+			counted, err := tx.RawQuery(fmt.Sprintf("UPDATE %s SET code_attempts = code_attempts + 1 WHERE id = ? AND nid = ? AND NOT used AND code_attempts < ?", tokens[k].TableName(ctx)), tokens[k].ID, nid, maxAttempts).ExecWithCount()
+			if err != nil {
+				return err
+			} else if counted == 0 {
+				continue
+			}
+
+			if p.hmacConstantCompare(ctx, code, tokens[k].Code) {
+				rt = &tokens[k]
+				break
+			}
+		}
+
+		if rt == nil {
+			remaining, err := tx.Where("selfservice_verification_flow_id = ? AND nid = ? AND code <> '' AND NOT used AND code_attempts < ?", flowID, nid, maxAttempts).Count(new(link.VerificationToken))
+			if err != nil {
+				return err
+			}
+
+			useErr = link.ErrCodeInvalid
+			if remaining == 0 {
+				useErr = link.ErrCodeAttemptsExceeded
+			}
+
+			// Returning nil commits the counted attempts.
+			return nil
+		}
+
+		var va identity.VerifiableAddress
+		if err := tx.Where("id = ? AND nid = ?", rt.VerifiableAddressID, nid).First(&va); err != nil {
+			return sqlcon.HandleError(err)
+		}
+		rt.VerifiableAddress = &va
+
+		/* #nosec G201 TableName is static */
+		return tx.RawQuery(fmt.Sprintf("UPDATE %s SET used=true, used_at=? WHERE id=? AND nid = ?", rt.TableName(ctx)), time.Now().UTC(), rt.ID, nid).Exec()
+	})); err != nil {
+		return nil, err
+	} else if useErr != nil {
+		return nil, useErr
+	}
+
+	return rt, nil
+}
+
 func (p *Persister) DeleteVerificationToken(ctx context.Context, token string) error {
 	nid := corp.ContextualizeNID(ctx, p.nid)
 	/* #nosec G201 TableName is static */
@@ -0,0 +1,137 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/webhook"
+)
+
+var _ webhook.Persister = new(Persister)
+
+func (p *Persister) AddDelivery(ctx context.Context, d *webhook.Delivery) error {
+	d.NID = corp.ContextualizeNID(ctx, p.nid)
+	d.Status = webhook.DeliveryStatusQueued
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(d))
+}
+
+func (p *Persister) NextDeliveries(ctx context.Context, limit uint8, now, claimedUntil time.Time) (deliveries []webhook.Delivery, err error) {
+	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		var d []webhook.Delivery
+		if err := tx.
+			Where("nid = ? AND ((status = ? AND next_attempt_at <= ?) OR (status = ? AND claimed_until <= ?))",
+				corp.ContextualizeNID(ctx, p.nid),
+				webhook.DeliveryStatusQueued,
+				now.UTC(),
+				webhook.DeliveryStatusProcessing,
+				now.UTC(),
+			).
+			Order("next_attempt_at ASC").
+			Limit(int(limit)).
+			All(&d); err != nil {
+			return err
+		}
+
+		if len(d) == 0 {
+			return sql.ErrNoRows
+		}
+
+		for i := range d {
+			delivery := &d[i]
+			delivery.Status = webhook.DeliveryStatusProcessing
+			delivery.ClaimedUntil = sqlxx.NullTime(claimedUntil.UTC())
+			if err := p.update(ctx, delivery, "status", "claimed_until"); err != nil {
+				return err
+			}
+		}
+
+		deliveries = d
+		return nil
+	}); err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, errors.WithStack(webhook.ErrQueueEmpty)
+		}
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return deliveries, nil
+}
+
+func (p *Persister) SetDeliveryStatus(ctx context.Context, id uuid.UUID, status webhook.DeliveryStatus) error {
+	count, err := p.GetConnection(ctx).RawQuery(
+		// #nosec G201
+		fmt.Sprintf(
+			"UPDATE %s SET status = ?, updated_at = ? WHERE id = ? AND nid = ?",
+			corp.ContextualizeTableName(ctx, "webhook_deliveries"),
+		),
+		status,
+		time.Now().UTC(),
+		id,
+		corp.ContextualizeNID(ctx, p.nid),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (p *Persister) RecordDeliveryFailure(ctx context.Context, id uuid.UUID, status webhook.DeliveryStatus, nextAttemptAt time.Time, lastError string) error {
+	count, err := p.GetConnection(ctx).RawQuery(
+		// #nosec G201
+		fmt.Sprintf(
+			"UPDATE %s SET status = ?, attempts = attempts + 1, next_attempt_at = ?, last_error = ?, updated_at = ? WHERE id = ? AND nid = ?",
+			corp.ContextualizeTableName(ctx, "webhook_deliveries"),
+		),
+		status,
+		nextAttemptAt.UTC(),
+		lastError,
+		time.Now().UTC(),
+		id,
+		corp.ContextualizeNID(ctx, p.nid),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (p *Persister) GetDelivery(ctx context.Context, id uuid.UUID) (*webhook.Delivery, error) {
+	var d webhook.Delivery
+	if err := p.GetConnection(ctx).Where("id = ? AND nid = ?", id, corp.ContextualizeNID(ctx, p.nid)).First(&d); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &d, nil
+}
+
+func (p *Persister) PurgeDeliveries(ctx context.Context, before time.Time) error {
+	// #nosec G201
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %s WHERE nid = ? AND status IN (?, ?) AND updated_at < ?",
+		corp.ContextualizeTableName(ctx, "webhook_deliveries"),
+	),
+		corp.ContextualizeNID(ctx, p.nid),
+		webhook.DeliveryStatusDelivered,
+		webhook.DeliveryStatusAbandoned,
+		before.UTC(),
+	).Exec())
+}
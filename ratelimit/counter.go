@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/corp"
+)
+
+// Counter counts the submissions of a key, for example an identifier, within a window.
+type Counter struct {
+	ID  uuid.UUID `json:"id" faker:"-" db:"id"`
+	NID uuid.UUID `json:"-" faker:"-" db:"nid"`
+
+	// Key is the hashed key whose submissions are counted.
+	Key string `json:"key" db:"counter_key"`
+
+	// Hits is the number of submissions within the window.
+	Hits int `json:"hits" db:"hits"`
+
+	// WindowEndsAt is the time at which the number of submissions is reset.
+	WindowEndsAt time.Time `json:"window_ends_at" db:"window_ends_at"`
+
+	// LockedUntil is set if the key exceeded its rate limit and was locked.
+	LockedUntil sqlxx.NullTime `json:"locked_until" db:"locked_until"`
+
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+}
+
+func (c Counter) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "selfservice_rate_limit_counters")
+}
+
+func (c *Counter) GetID() uuid.UUID {
+	return c.ID
+}
+
+func (c *Counter) GetNID() uuid.UUID {
+	return c.NID
+}
+
+// IsLocked returns true if the key is locked at the given time.
+func (c *Counter) IsLocked(now time.Time) bool {
+	return !time.Time(c.LockedUntil).IsZero() && time.Time(c.LockedUntil).After(now)
+}
+
+// IsActive returns true if the counter's window has not ended at the given time. The window of locked keys ends
+// when the lock ends.
+func (c *Counter) IsActive(now time.Time) bool {
+	return c.WindowEndsAt.After(now)
+}
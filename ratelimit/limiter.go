@@ -0,0 +1,132 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/x"
+)
+
+// Flow is a self-service flow whose submissions are rate limited.
+type Flow string
+
+const (
+	FlowLogin        Flow = "login"
+	FlowRegistration Flow = "registration"
+	FlowRecovery     Flow = "recovery"
+	FlowVerification Flow = "verification"
+)
+
+// scope is what the submissions of a flow are counted by.
+type scope string
+
+const (
+	scopeIdentifier scope = "per_identifier"
+	scopeIP         scope = "per_ip"
+	scopeFlow       scope = "per_flow"
+)
+
+type (
+	limiterDependencies interface {
+		config.Provider
+		x.LoggingProvider
+		PersistenceProvider
+	}
+	LimiterProvider interface {
+		RateLimiter() *Limiter
+	}
+	// Limiter limits the submissions of self-service flows per identifier, per client IP address and per flow.
+	Limiter struct {
+		r limiterDependencies
+	}
+)
+
+func NewLimiter(r limiterDependencies) *Limiter {
+	return &Limiter{r: r}
+}
+
+// CheckRequest counts the submission of a flow by the client IP address of the request and by the flow itself.
+// It returns a validation error if either exceeds its rate limit. Forwarded client IP addresses are only honored
+// if the request was sent by a trusted proxy.
+func (l *Limiter) CheckRequest(ctx context.Context, flow Flow, r *http.Request, flowID uuid.UUID) error {
+	if err := l.check(ctx, flow, scopeIP, x.TrustedClientIP(r, l.r.Config(ctx).PublicTrustedProxies()), false); err != nil {
+		return err
+	}
+	return l.check(ctx, flow, scopeFlow, flowID.String(), false)
+}
+
+// CheckIdentifier counts the submission of a flow by the identifier, for example the email address, and returns
+// a validation error if it exceeds its rate limit. If lockout is enabled, identifiers which exceed the rate limit
+// are locked.
+func (l *Limiter) CheckIdentifier(ctx context.Context, flow Flow, identifier string) error {
+	return l.check(ctx, flow, scopeIdentifier, normalizeIdentifier(identifier), l.r.Config(ctx).SelfServiceRateLimitLockoutEnabled())
+}
+
+// IdentifierCounterKeys returns the keys of the identifier's counters in all flows. Use it to delete the
+// counters when the identity the identifier belongs to is erased.
+func IdentifierCounterKeys(identifier string) []string {
+	identifier = normalizeIdentifier(identifier)
+	if len(identifier) == 0 {
+		return nil
+	}
+
+	flows := []Flow{FlowLogin, FlowRegistration, FlowRecovery, FlowVerification}
+	keys := make([]string, len(flows))
+	for k, flow := range flows {
+		keys[k] = counterKey(flow, scopeIdentifier, identifier)
+	}
+	return keys
+}
+
+func normalizeIdentifier(identifier string) string {
+	return strings.ToLower(strings.TrimSpace(identifier))
+}
+
+func (l *Limiter) check(ctx context.Context, flow Flow, s scope, value string, lockout bool) error {
+	limit := l.r.Config(ctx).SelfServiceRateLimit(string(flow), string(s))
+	if limit == nil || len(value) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	key := counterKey(flow, s, value)
+	c, err := l.r.RateLimitPersister().HitRateLimitCounter(ctx, key, limit.Window, now)
+	if err != nil {
+		return err
+	}
+
+	if c.IsLocked(now) {
+		return schema.NewIdentifierLockedError(time.Time(c.LockedUntil).Sub(now))
+	} else if c.Hits <= limit.Limit {
+		return nil
+	}
+
+	l.r.Logger().
+		WithField("rate_limit_flow", flow).
+		WithField("rate_limit_scope", s).
+		Info("A self-service flow submission exceeded the rate limit.")
+
+	if lockout {
+		until := now.Add(l.r.Config(ctx).SelfServiceRateLimitLockoutDuration())
+		if err := l.r.RateLimitPersister().LockRateLimitCounter(ctx, key, until); err != nil {
+			return err
+		}
+		return schema.NewIdentifierLockedError(until.Sub(now))
+	}
+
+	return schema.NewRateLimitExceededError(c.WindowEndsAt.Sub(now))
+}
+
+// counterKey hashes the counted value so that identifiers and IP addresses are not stored in plain text.
+func counterKey(flow Flow, s scope, value string) string {
+	sum := sha256.Sum256([]byte(string(flow) + ":" + string(s) + ":" + value))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,153 @@
+package ratelimit_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/ratelimit"
+	"github.com/ory/kratos/ratelimit/test"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+)
+
+func TestMemoryPersister(t *testing.T) {
+	test.TestPersister(context.Background(), ratelimit.NewMemoryPersister())(t)
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	newRequest := func(ip string) *http.Request {
+		return &http.Request{Header: http.Header{}, RemoteAddr: ip + ":1234"}
+	}
+
+	assertMessage := func(t *testing.T, err error, id text.ID) {
+		require.Error(t, err)
+		var ve *schema.ValidationError
+		require.ErrorAs(t, err, &ve)
+		require.Len(t, ve.Messages, 1)
+		assert.Equal(t, id, ve.Messages[0].ID)
+	}
+
+	for _, store := range []string{"sql", "memory"} {
+		t.Run("store="+store, func(t *testing.T) {
+			conf, reg := internal.NewFastRegistryWithMocks(t)
+			conf.MustSet(config.ViperKeyRateLimitStore, store)
+
+			t.Run("case=does not limit submissions without a configured limit", func(t *testing.T) {
+				for i := 0; i < 10; i++ {
+					require.NoError(t, reg.RateLimiter().CheckRequest(ctx, ratelimit.FlowLogin, newRequest("192.0.2.1"), x.NewUUID()))
+					require.NoError(t, reg.RateLimiter().CheckIdentifier(ctx, ratelimit.FlowLogin, "foo@ory.sh"))
+				}
+			})
+
+			t.Run("case=limits submissions per IP address", func(t *testing.T) {
+				conf.MustSet(config.ViperKeyRateLimits+".recovery.per_ip", map[string]interface{}{"limit": 2, "window": "1h"})
+				t.Cleanup(func() {
+					conf.MustSet(config.ViperKeyRateLimits+".recovery.per_ip", nil)
+				})
+
+				for i := 0; i < 2; i++ {
+					require.NoError(t, reg.RateLimiter().CheckRequest(ctx, ratelimit.FlowRecovery, newRequest("192.0.2.2"), x.NewUUID()))
+				}
+				assertMessage(t, reg.RateLimiter().CheckRequest(ctx, ratelimit.FlowRecovery, newRequest("192.0.2.2"), x.NewUUID()), text.ErrorValidationRateLimitExceeded)
+
+				// Other IP addresses and other flows are counted independently.
+				require.NoError(t, reg.RateLimiter().CheckRequest(ctx, ratelimit.FlowRecovery, newRequest("192.0.2.3"), x.NewUUID()))
+				require.NoError(t, reg.RateLimiter().CheckRequest(ctx, ratelimit.FlowVerification, newRequest("192.0.2.2"), x.NewUUID()))
+			})
+
+			t.Run("case=does not reset the IP address counter with forwarded headers", func(t *testing.T) {
+				conf.MustSet(config.ViperKeyRateLimits+".recovery.per_ip", map[string]interface{}{"limit": 2, "window": "1h"})
+				t.Cleanup(func() {
+					conf.MustSet(config.ViperKeyRateLimits+".recovery.per_ip", nil)
+				})
+
+				spoofed := func(ip, forwarded string) *http.Request {
+					r := newRequest(ip)
+					r.Header.Set("X-Forwarded-For", forwarded)
+					r.Header.Set("X-Real-IP", forwarded)
+					return r
+				}
+
+				for i := 0; i < 2; i++ {
+					require.NoError(t, reg.RateLimiter().CheckRequest(ctx, ratelimit.FlowRecovery, spoofed("192.0.2.6", fmt.Sprintf("198.51.100.%d", i)), x.NewUUID()))
+				}
+				assertMessage(t, reg.RateLimiter().CheckRequest(ctx, ratelimit.FlowRecovery, spoofed("192.0.2.6", "198.51.100.99"), x.NewUUID()), text.ErrorValidationRateLimitExceeded)
+
+				t.Run("case=honors forwarded headers of trusted proxies", func(t *testing.T) {
+					conf.MustSet(config.ViperKeyPublicTrustedProxies, []string{"192.0.2.6"})
+					t.Cleanup(func() {
+						conf.MustSet(config.ViperKeyPublicTrustedProxies, nil)
+					})
+
+					require.NoError(t, reg.RateLimiter().CheckRequest(ctx, ratelimit.FlowRecovery, spoofed("192.0.2.6", "198.51.100.100"), x.NewUUID()))
+					require.NoError(t, reg.RateLimiter().CheckRequest(ctx, ratelimit.FlowRecovery, spoofed("192.0.2.6", "198.51.100.100"), x.NewUUID()))
+					assertMessage(t, reg.RateLimiter().CheckRequest(ctx, ratelimit.FlowRecovery, spoofed("192.0.2.6", "198.51.100.100"), x.NewUUID()), text.ErrorValidationRateLimitExceeded)
+				})
+			})
+
+			t.Run("case=limits submissions per flow", func(t *testing.T) {
+				conf.MustSet(config.ViperKeyRateLimits+".login.per_flow", map[string]interface{}{"limit": 3})
+				t.Cleanup(func() {
+					conf.MustSet(config.ViperKeyRateLimits+".login.per_flow", nil)
+				})
+
+				id := x.NewUUID()
+				for i := 0; i < 3; i++ {
+					require.NoError(t, reg.RateLimiter().CheckRequest(ctx, ratelimit.FlowLogin, newRequest("192.0.2.4"), id))
+				}
+				assertMessage(t, reg.RateLimiter().CheckRequest(ctx, ratelimit.FlowLogin, newRequest("192.0.2.5"), id), text.ErrorValidationRateLimitExceeded)
+				require.NoError(t, reg.RateLimiter().CheckRequest(ctx, ratelimit.FlowLogin, newRequest("192.0.2.4"), x.NewUUID()))
+			})
+
+			t.Run("case=limits submissions per identifier", func(t *testing.T) {
+				conf.MustSet(config.ViperKeyRateLimits+".login.per_identifier", map[string]interface{}{"limit": 2, "window": "1h"})
+				t.Cleanup(func() {
+					conf.MustSet(config.ViperKeyRateLimits+".login.per_identifier", nil)
+				})
+
+				identifier := x.NewUUID().String() + "@ory.sh"
+				require.NoError(t, reg.RateLimiter().CheckIdentifier(ctx, ratelimit.FlowLogin, identifier))
+				// Identifiers are compared case-insensitively and without surrounding whitespace.
+				require.NoError(t, reg.RateLimiter().CheckIdentifier(ctx, ratelimit.FlowLogin, " "+strings.ToUpper(identifier)+" "))
+				assertMessage(t, reg.RateLimiter().CheckIdentifier(ctx, ratelimit.FlowLogin, identifier), text.ErrorValidationRateLimitExceeded)
+
+				require.NoError(t, reg.RateLimiter().CheckIdentifier(ctx, ratelimit.FlowLogin, x.NewUUID().String()+"@ory.sh"))
+
+				keys := ratelimit.IdentifierCounterKeys(strings.ToUpper(identifier))
+				require.Len(t, keys, 4)
+				c, err := reg.RateLimitPersister().HitRateLimitCounter(ctx, keys[0], time.Hour, time.Now())
+				require.NoError(t, err)
+				assert.Equal(t, 4, c.Hits, "the keys must match the keys of the counted identifier")
+			})
+
+			t.Run("case=locks identifiers which exceed the limit", func(t *testing.T) {
+				conf.MustSet(config.ViperKeyRateLimits+".login.per_identifier", map[string]interface{}{"limit": 1, "window": "1s"})
+				conf.MustSet(config.ViperKeyRateLimitLockoutEnabled, true)
+				t.Cleanup(func() {
+					conf.MustSet(config.ViperKeyRateLimits+".login.per_identifier", nil)
+					conf.MustSet(config.ViperKeyRateLimitLockoutEnabled, false)
+				})
+
+				identifier := x.NewUUID().String() + "@ory.sh"
+				require.NoError(t, reg.RateLimiter().CheckIdentifier(ctx, ratelimit.FlowLogin, identifier))
+				assertMessage(t, reg.RateLimiter().CheckIdentifier(ctx, ratelimit.FlowLogin, identifier), text.ErrorValidationIdentifierLocked)
+
+				// The identifier stays locked for the lockout duration even though the window ended.
+				time.Sleep(1100 * time.Millisecond)
+				assertMessage(t, reg.RateLimiter().CheckIdentifier(ctx, ratelimit.FlowLogin, identifier), text.ErrorValidationIdentifierLocked)
+			})
+		})
+	}
+}
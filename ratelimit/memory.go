@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/x"
+)
+
+var _ Persister = new(MemoryPersister)
+
+// MemoryPersister stores rate limit counters in memory. The counters are not shared between instances, which is
+// why it must only be used if a single instance is running.
+type MemoryPersister struct {
+	sync.Mutex
+	counters  map[string]*Counter
+	lastSweep time.Time
+}
+
+// memorySweepInterval is how often expired counters are removed from memory.
+const memorySweepInterval = time.Minute
+
+func NewMemoryPersister() *MemoryPersister {
+	return &MemoryPersister{counters: map[string]*Counter{}}
+}
+
+func (p *MemoryPersister) HitRateLimitCounter(_ context.Context, key string, window time.Duration, now time.Time) (*Counter, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	now = now.UTC()
+	c, ok := p.counters[key]
+	if ok && c.IsActive(now) {
+		c.Hits++
+		c.UpdatedAt = now
+		counter := *c
+		return &counter, nil
+	}
+
+	// Expired counters are removed periodically so that the memory usage stays bounded.
+	if now.Sub(p.lastSweep) > memorySweepInterval {
+		p.deleteExpired(now)
+		p.lastSweep = now
+	}
+
+	c = &Counter{
+		ID:           x.NewUUID(),
+		Key:          key,
+		Hits:         1,
+		WindowEndsAt: now.Add(window),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	p.counters[key] = c
+
+	counter := *c
+	return &counter, nil
+}
+
+func (p *MemoryPersister) LockRateLimitCounter(_ context.Context, key string, until time.Time) error {
+	p.Lock()
+	defer p.Unlock()
+
+	c, ok := p.counters[key]
+	if !ok {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	c.LockedUntil = sqlxx.NullTime(until.UTC())
+	c.WindowEndsAt = until.UTC()
+	return nil
+}
+
+func (p *MemoryPersister) DeleteExpiredRateLimitCounters(_ context.Context, before time.Time) error {
+	p.Lock()
+	defer p.Unlock()
+
+	p.deleteExpired(before.UTC())
+	return nil
+}
+
+func (p *MemoryPersister) deleteExpired(now time.Time) {
+	for k, c := range p.counters {
+		if !c.IsActive(now) {
+			delete(p.counters, k)
+		}
+	}
+}
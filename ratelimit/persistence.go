@@ -0,0 +1,24 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	Persister interface {
+		// HitRateLimitCounter counts a submission of the key and returns the counter. If the counter's window
+		// ended and the key is not locked, a new window is started.
+		HitRateLimitCounter(ctx context.Context, key string, window time.Duration, now time.Time) (*Counter, error)
+
+		// LockRateLimitCounter locks the key until the given time. The counter's window ends when the lock ends,
+		// which is why a new window starts once the key is unlocked.
+		LockRateLimitCounter(ctx context.Context, key string, until time.Time) error
+
+		// DeleteExpiredRateLimitCounters deletes the counters whose window ended before the given time.
+		DeleteExpiredRateLimitCounters(ctx context.Context, before time.Time) error
+	}
+	PersistenceProvider interface {
+		RateLimitPersister() Persister
+	}
+)
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/ratelimit"
+	"github.com/ory/kratos/x"
+)
+
+func TestPersister(ctx context.Context, p ratelimit.Persister) func(t *testing.T) {
+	return func(t *testing.T) {
+		now := time.Now().UTC().Truncate(time.Second)
+
+		t.Run("case=counts hits within the window", func(t *testing.T) {
+			key := x.NewUUID().String()
+			for i := 1; i <= 3; i++ {
+				c, err := p.HitRateLimitCounter(ctx, key, time.Minute, now.Add(time.Duration(i)*time.Second))
+				require.NoError(t, err)
+				assert.Equal(t, i, c.Hits)
+				assert.Equal(t, key, c.Key)
+				assert.WithinDuration(t, now.Add(time.Minute+time.Second), c.WindowEndsAt, time.Second)
+				assert.False(t, c.IsLocked(now))
+			}
+		})
+
+		t.Run("case=starts a new window once the window ended", func(t *testing.T) {
+			key := x.NewUUID().String()
+			_, err := p.HitRateLimitCounter(ctx, key, time.Minute, now)
+			require.NoError(t, err)
+			_, err = p.HitRateLimitCounter(ctx, key, time.Minute, now)
+			require.NoError(t, err)
+
+			c, err := p.HitRateLimitCounter(ctx, key, time.Minute, now.Add(2*time.Minute))
+			require.NoError(t, err)
+			assert.Equal(t, 1, c.Hits)
+			assert.WithinDuration(t, now.Add(3*time.Minute), c.WindowEndsAt, time.Second)
+		})
+
+		t.Run("case=counts keys independently", func(t *testing.T) {
+			a, b := x.NewUUID().String(), x.NewUUID().String()
+			_, err := p.HitRateLimitCounter(ctx, a, time.Minute, now)
+			require.NoError(t, err)
+
+			c, err := p.HitRateLimitCounter(ctx, b, time.Minute, now)
+			require.NoError(t, err)
+			assert.Equal(t, 1, c.Hits)
+
+			c, err = p.HitRateLimitCounter(ctx, a, time.Minute, now)
+			require.NoError(t, err)
+			assert.Equal(t, 2, c.Hits)
+		})
+
+		t.Run("case=locks keys", func(t *testing.T) {
+			key := x.NewUUID().String()
+			_, err := p.HitRateLimitCounter(ctx, key, time.Minute, now)
+			require.NoError(t, err)
+
+			require.NoError(t, p.LockRateLimitCounter(ctx, key, now.Add(time.Hour)))
+
+			// The key stays locked after the original window ended.
+			c, err := p.HitRateLimitCounter(ctx, key, time.Minute, now.Add(30*time.Minute))
+			require.NoError(t, err)
+			assert.Equal(t, 2, c.Hits)
+			assert.True(t, c.IsLocked(now.Add(30*time.Minute)))
+
+			// A new window starts once the lock ended.
+			c, err = p.HitRateLimitCounter(ctx, key, time.Minute, now.Add(2*time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, 1, c.Hits)
+			assert.False(t, c.IsLocked(now.Add(2*time.Hour)))
+
+			require.ErrorIs(t, p.LockRateLimitCounter(ctx, x.NewUUID().String(), now.Add(time.Hour)), sqlcon.ErrNoRows)
+		})
+
+		t.Run("case=deletes expired counters", func(t *testing.T) {
+			expired, active := x.NewUUID().String(), x.NewUUID().String()
+			_, err := p.HitRateLimitCounter(ctx, expired, time.Minute, now)
+			require.NoError(t, err)
+			_, err = p.HitRateLimitCounter(ctx, active, time.Hour, now)
+			require.NoError(t, err)
+
+			require.NoError(t, p.DeleteExpiredRateLimitCounters(ctx, now.Add(2*time.Minute)))
+
+			require.ErrorIs(t, p.LockRateLimitCounter(ctx, expired, now.Add(time.Hour)), sqlcon.ErrNoRows)
+			require.NoError(t, p.LockRateLimitCounter(ctx, active, now.Add(time.Hour)))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+type (
+	AuthStrategy interface {
+		apply(req *retryablehttp.Request) error
+	}
+
+	// clientAuthStrategy is implemented by auth strategies which authenticate
+	// on the transport level, for example using client certificates.
+	clientAuthStrategy interface {
+		client(c *retryablehttp.Client) *retryablehttp.Client
+	}
+
+	authStrategyFactory func(c json.RawMessage, cache *AuthCache) (AuthStrategy, error)
+)
+
+var strategyFactories = map[string]authStrategyFactory{
+	"":                          newNoopAuthStrategy,
+	"api_key":                   newApiKeyStrategy,
+	"basic_auth":                newBasicAuthStrategy,
+	"bearer":                    newBearerStrategy,
+	"oauth2_client_credentials": newOAuth2ClientCredentialsStrategy,
+	"mtls":                      newMTLSStrategy,
+}
+
+func authStrategy(name string, c json.RawMessage, cache *AuthCache) (AuthStrategy, error) {
+	if f, ok := strategyFactories[name]; ok {
+		return f(c, cache)
+	}
+	return nil, fmt.Errorf("unsupported auth type: %s", name)
+}
+
+// parseAuth creates the auth strategy from the `auth` object of a request
+// configuration.
+func parseAuth(raw json.RawMessage, cache *AuthCache) (AuthStrategy, error) {
+	var auth struct {
+		Type   string
+		Config json.RawMessage
+	}
+
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &auth); err != nil {
+			return nil, err
+		}
+	}
+
+	return authStrategy(auth.Type, auth.Config, cache)
+}
+
+// clientFor returns the client which must be used to send requests
+// authenticated by the given strategy.
+func clientFor(as AuthStrategy, c *retryablehttp.Client) *retryablehttp.Client {
+	if cas, ok := as.(clientAuthStrategy); ok {
+		return cas.client(c)
+	}
+	return c
+}
+
+// Authenticate applies the `auth` object of a request configuration to a
+// request which was built without authentication and returns the client
+// which must be used to send it.
+func Authenticate(raw json.RawMessage, req *retryablehttp.Request, c *retryablehttp.Client, cache *AuthCache) (*retryablehttp.Client, error) {
+	as, err := parseAuth(raw, cache)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create web hook auth strategy: %w", err)
+	}
+
+	if err := as.apply(req); err != nil {
+		return nil, err
+	}
+
+	return clientFor(as, c), nil
+}
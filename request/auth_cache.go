@@ -0,0 +1,93 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"golang.org/x/oauth2"
+)
+
+type (
+	// AuthCache caches the OAuth2 token sources and mTLS transports of auth
+	// strategies by their configuration, so that access tokens and connections
+	// are reused across requests. Discard it when the configuration changes.
+	//
+	// mTLS transports are additionally versioned by the modification time and
+	// size of the certificate and key files, so that rotated files are loaded
+	// without a configuration change.
+	AuthCache struct {
+		client *http.Client
+
+		mu           sync.Mutex
+		tokenSources map[string]oauth2.TokenSource
+		transports   map[string]cachedTransport
+	}
+
+	cachedTransport struct {
+		version   string
+		transport *http.Transport
+	}
+
+	AuthCacheProvider interface {
+		RequestAuthCache(ctx context.Context) *AuthCache
+	}
+)
+
+// NewAuthCache returns an empty cache. Access tokens are fetched using the
+// given client.
+func NewAuthCache(c *retryablehttp.Client) *AuthCache {
+	return &AuthCache{
+		client:       c.StandardClient(),
+		tokenSources: map[string]oauth2.TokenSource{},
+		transports:   map[string]cachedTransport{},
+	}
+}
+
+func (c *AuthCache) tokenSource(key string, create func(client *http.Client) oauth2.TokenSource) oauth2.TokenSource {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ts, ok := c.tokenSources[key]; ok {
+		return ts
+	}
+
+	ts := create(c.client)
+	c.tokenSources[key] = ts
+	return ts
+}
+
+// transport returns the cached transport for the key if it was created for
+// the given version. Otherwise, a new transport is created and the idle
+// connections of the outdated one are closed.
+func (c *AuthCache) transport(key, version string, create func() (*http.Transport, error)) (*http.Transport, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.transports[key]
+	if ok && cached.version == version {
+		return cached.transport, nil
+	}
+
+	t, err := create()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		cached.transport.CloseIdleConnections()
+	}
+	c.transports[key] = cachedTransport{version: version, transport: t}
+	return t, nil
+}
+
+// Close closes the idle connections of the cached transports. Call it when
+// the cache is discarded.
+func (c *AuthCache) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, t := range c.transports {
+		t.transport.CloseIdleConnections()
+	}
+}
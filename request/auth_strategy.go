@@ -0,0 +1,259 @@
+package request
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/tlsx"
+)
+
+type (
+	NoopAuthStrategy struct{}
+
+	BasicAuthStrategy struct {
+		user     string
+		password string
+	}
+
+	ApiKeyStrategy struct {
+		name  string
+		value string
+		in    string
+	}
+
+	BearerStrategy struct {
+		token string
+	}
+
+	OAuth2ClientCredentialsStrategy struct {
+		tokens oauth2.TokenSource
+	}
+
+	MTLSStrategy struct {
+		transport *http.Transport
+	}
+)
+
+func newNoopAuthStrategy(_ json.RawMessage, _ *AuthCache) (AuthStrategy, error) {
+	return &NoopAuthStrategy{}, nil
+}
+
+func (c *NoopAuthStrategy) apply(_ *retryablehttp.Request) error {
+	return nil
+}
+
+func newBasicAuthStrategy(raw json.RawMessage, _ *AuthCache) (AuthStrategy, error) {
+	type config struct {
+		User     string
+		Password string
+	}
+
+	var c config
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, err
+	}
+
+	return &BasicAuthStrategy{
+		user:     c.User,
+		password: c.Password,
+	}, nil
+}
+
+func (c *BasicAuthStrategy) apply(req *retryablehttp.Request) error {
+	req.SetBasicAuth(c.user, c.password)
+	return nil
+}
+
+func newApiKeyStrategy(raw json.RawMessage, _ *AuthCache) (AuthStrategy, error) {
+	type config struct {
+		In    string
+		Name  string
+		Value string
+	}
+
+	var c config
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, err
+	}
+
+	return &ApiKeyStrategy{
+		in:    c.In,
+		name:  c.Name,
+		value: c.Value,
+	}, nil
+}
+
+func (c *ApiKeyStrategy) apply(req *retryablehttp.Request) error {
+	switch c.in {
+	case "cookie":
+		req.AddCookie(&http.Cookie{Name: c.name, Value: c.value})
+	default:
+		req.Header.Set(c.name, c.value)
+	}
+	return nil
+}
+
+func newBearerStrategy(raw json.RawMessage, _ *AuthCache) (AuthStrategy, error) {
+	type config struct {
+		Token string
+	}
+
+	var c config
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, err
+	}
+
+	return &BearerStrategy{
+		token: c.Token,
+	}, nil
+}
+
+func (c *BearerStrategy) apply(req *retryablehttp.Request) error {
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	return nil
+}
+
+func newOAuth2ClientCredentialsStrategy(raw json.RawMessage, cache *AuthCache) (AuthStrategy, error) {
+	type config struct {
+		ClientID     string   `json:"client_id"`
+		ClientSecret string   `json:"client_secret"`
+		TokenURL     string   `json:"token_url"`
+		Scopes       []string `json:"scopes"`
+	}
+
+	var c config
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, err
+	}
+
+	key, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := cache.tokenSource(string(key), func(client *http.Client) oauth2.TokenSource {
+		// The token source keeps the context to fetch new tokens once the
+		// cached token expires, which is why it must not be bound to a request.
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+		return (&clientcredentials.Config{
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			TokenURL:     c.TokenURL,
+			Scopes:       c.Scopes,
+		}).TokenSource(ctx)
+	})
+	return &OAuth2ClientCredentialsStrategy{tokens: ts}, nil
+}
+
+func (c *OAuth2ClientCredentialsStrategy) apply(req *retryablehttp.Request) error {
+	token, err := c.tokens.Token()
+	if err != nil {
+		return fmt.Errorf("failed to fetch OAuth2 access token: %w", err)
+	}
+
+	token.SetAuthHeader(req.Request)
+	return nil
+}
+
+func newMTLSStrategy(raw json.RawMessage, cache *AuthCache) (AuthStrategy, error) {
+	type source struct {
+		Path   string
+		Base64 string
+	}
+	type config struct {
+		Cert source
+		Key  source
+	}
+
+	var c config
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, err
+	}
+
+	key, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := fileVersion(c.Cert.Path, c.Key.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load client certificate")
+	}
+
+	t, err := cache.transport(string(key), version, func() (*http.Transport, error) {
+		certs, err := tlsx.Certificate(c.Cert.Base64, c.Key.Base64, c.Cert.Path, c.Key.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load client certificate")
+		}
+
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		t.TLSClientConfig.Certificates = certs
+		return t, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &MTLSStrategy{transport: t}, nil
+}
+
+// fileVersion returns the modification times and sizes of the given files, so
+// that a change of any file changes the version. Empty paths are skipped.
+func fileVersion(paths ...string) (string, error) {
+	var version strings.Builder
+	for _, path := range paths {
+		if len(path) == 0 {
+			continue
+		}
+
+		fi, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		_, _ = fmt.Fprintf(&version, "%s:%d:%d;", path, fi.ModTime().UnixNano(), fi.Size())
+	}
+	return version.String(), nil
+}
+
+func (c *MTLSStrategy) apply(_ *retryablehttp.Request) error {
+	return nil
+}
+
+// client returns a copy of the client which presents the client certificate.
+// The transport of the given client is replaced, but requests to internal IP
+// addresses stay disallowed if the given client disallows them.
+func (c *MTLSStrategy) client(rc *retryablehttp.Client) *retryablehttp.Client {
+	var transport http.RoundTripper = c.transport
+	if _, ok := rc.HTTPClient.Transport.(*httpx.NoInternalIPRoundTripper); ok {
+		transport = &httpx.NoInternalIPRoundTripper{RoundTripper: transport}
+	}
+
+	hc := *rc.HTTPClient
+	hc.Transport = transport
+
+	return &retryablehttp.Client{
+		HTTPClient:      &hc,
+		Logger:          rc.Logger,
+		RetryWaitMin:    rc.RetryWaitMin,
+		RetryWaitMax:    rc.RetryWaitMax,
+		RetryMax:        rc.RetryMax,
+		RequestLogHook:  rc.RequestLogHook,
+		ResponseLogHook: rc.ResponseLogHook,
+		CheckRetry:      rc.CheckRetry,
+		Backoff:         rc.Backoff,
+		ErrorHandler:    rc.ErrorHandler,
+	}
+}
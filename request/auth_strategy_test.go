@@ -0,0 +1,250 @@
+package request
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/tlsx"
+)
+
+func TestNoopAuthStrategy(t *testing.T) {
+	req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
+	auth := NoopAuthStrategy{}
+
+	require.NoError(t, auth.apply(&req))
+
+	assert.Empty(t, req.Header, "Empty auth strategy shall not modify any request headers")
+}
+
+func TestBasicAuthStrategy(t *testing.T) {
+	req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
+	auth := BasicAuthStrategy{
+		user:     "test-user",
+		password: "test-pass",
+	}
+
+	require.NoError(t, auth.apply(&req))
+
+	assert.Len(t, req.Header, 1)
+
+	user, pass, _ := req.BasicAuth()
+	assert.Equal(t, "test-user", user)
+	assert.Equal(t, "test-pass", pass)
+}
+
+func TestApiKeyInHeaderStrategy(t *testing.T) {
+	req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
+	auth := ApiKeyStrategy{
+		in:    "header",
+		name:  "my-api-key-name",
+		value: "my-api-key-value",
+	}
+
+	require.NoError(t, auth.apply(&req))
+
+	require.Len(t, req.Header, 1)
+
+	actualValue := req.Header.Get("my-api-key-name")
+	assert.Equal(t, "my-api-key-value", actualValue)
+}
+
+func TestApiKeyInCookieStrategy(t *testing.T) {
+	req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
+	auth := ApiKeyStrategy{
+		in:    "cookie",
+		name:  "my-api-key-name",
+		value: "my-api-key-value",
+	}
+
+	require.NoError(t, auth.apply(&req))
+
+	cookies := req.Cookies()
+	assert.Len(t, cookies, 1)
+
+	assert.Equal(t, "my-api-key-name", cookies[0].Name)
+	assert.Equal(t, "my-api-key-value", cookies[0].Value)
+}
+
+func TestBearerStrategy(t *testing.T) {
+	req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
+	auth := BearerStrategy{
+		token: "my-token",
+	}
+
+	require.NoError(t, auth.apply(&req))
+
+	require.Len(t, req.Header, 1)
+	assert.Equal(t, "Bearer my-token", req.Header.Get("Authorization"))
+}
+
+func TestOAuth2ClientCredentialsStrategy(t *testing.T) {
+	var tokenRequests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenRequests, 1)
+		require.NoError(t, r.ParseForm())
+
+		user, pass, _ := r.BasicAuth()
+		if user != "my-client" || pass != "my-secret" || r.PostForm.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":3600,"scope":%q}`, atomic.LoadInt32(&tokenRequests), r.PostForm.Get("scope"))
+	}))
+	t.Cleanup(ts.Close)
+
+	cache := NewAuthCache(httpx.NewResilientClient(httpx.ResilientClientWithMaxRetry(0)))
+	newStrategy := func(t *testing.T, secret string, cache *AuthCache) AuthStrategy {
+		auth, err := authStrategy("oauth2_client_credentials", json.RawMessage(fmt.Sprintf(
+			`{"client_id":"my-client","client_secret":%q,"token_url":%q,"scopes":["a","b"]}`, secret, ts.URL)), cache)
+		require.NoError(t, err)
+		return auth
+	}
+
+	t.Run("case=fetches and caches the access token", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
+			require.NoError(t, newStrategy(t, "my-secret", cache).apply(&req))
+			assert.Equal(t, "Bearer token-1", req.Header.Get("Authorization"))
+		}
+		assert.EqualValues(t, 1, atomic.LoadInt32(&tokenRequests))
+	})
+
+	t.Run("case=fetches a new access token with a new cache", func(t *testing.T) {
+		req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
+		require.NoError(t, newStrategy(t, "my-secret", NewAuthCache(httpx.NewResilientClient())).apply(&req))
+		assert.Equal(t, "Bearer token-2", req.Header.Get("Authorization"))
+	})
+
+	t.Run("case=fetches the access token using the given client", func(t *testing.T) {
+		req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
+		cache := NewAuthCache(httpx.NewResilientClient(httpx.ResilientClientDisallowInternalIPs(), httpx.ResilientClientWithMaxRetry(0)))
+		require.Error(t, newStrategy(t, "my-secret", cache).apply(&req), "the token URL is an internal IP address")
+		assert.Empty(t, req.Header.Get("Authorization"))
+	})
+
+	t.Run("case=fails if the token can not be fetched", func(t *testing.T) {
+		req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
+		require.Error(t, newStrategy(t, "wrong-secret", cache).apply(&req))
+		assert.Empty(t, req.Header.Get("Authorization"))
+	})
+}
+
+func TestMTLSStrategy(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	cert, err := tlsx.CreateSelfSignedCertificate(key)
+	require.NoError(t, err)
+	keyBlock, err := tlsx.PEMBlockForKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	keyPEM := pem.EncodeToMemory(keyBlock)
+
+	dir := t.TempDir()
+	certPath, keyPath := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certPath, certPEM, 0600))
+	require.NoError(t, os.WriteFile(keyPath, keyPEM, 0600))
+
+	cache := NewAuthCache(httpx.NewResilientClient())
+	for _, tc := range []struct {
+		name   string
+		config string
+	}{
+		{
+			name: "base64",
+			config: fmt.Sprintf(`{"cert":{"base64":%q},"key":{"base64":%q}}`,
+				base64.StdEncoding.EncodeToString(certPEM), base64.StdEncoding.EncodeToString(keyPEM)),
+		},
+		{
+			name:   "path",
+			config: fmt.Sprintf(`{"cert":{"path":%q},"key":{"path":%q}}`, certPath, keyPath),
+		},
+	} {
+		t.Run("source="+tc.name, func(t *testing.T) {
+			auth, err := authStrategy("mtls", json.RawMessage(tc.config), cache)
+			require.NoError(t, err)
+
+			req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
+			require.NoError(t, auth.apply(&req))
+			assert.Empty(t, req.Header)
+
+			base := httpx.NewResilientClient()
+			c := clientFor(auth, base)
+			require.NotSame(t, base, c)
+			assert.Nil(t, base.HTTPClient.Transport, "the given client must not be modified")
+
+			transport, ok := c.HTTPClient.Transport.(*http.Transport)
+			require.True(t, ok)
+			require.Len(t, transport.TLSClientConfig.Certificates, 1)
+			assert.Equal(t, cert.Raw, transport.TLSClientConfig.Certificates[0].Certificate[0])
+			assert.Equal(t, base.RetryMax, c.RetryMax)
+
+			c = clientFor(auth, httpx.NewResilientClient(httpx.ResilientClientDisallowInternalIPs()))
+			wrapped, ok := c.HTTPClient.Transport.(*httpx.NoInternalIPRoundTripper)
+			require.True(t, ok, "requests to internal IP addresses must stay disallowed")
+			assert.Same(t, transport, wrapped.RoundTripper)
+		})
+	}
+
+	t.Run("case=reloads rotated certificate files", func(t *testing.T) {
+		dir := t.TempDir()
+		certPath, keyPath := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+		require.NoError(t, os.WriteFile(certPath, certPEM, 0600))
+		require.NoError(t, os.WriteFile(keyPath, keyPEM, 0600))
+		config := json.RawMessage(fmt.Sprintf(`{"cert":{"path":%q},"key":{"path":%q}}`, certPath, keyPath))
+
+		transportFor := func(t *testing.T) *http.Transport {
+			auth, err := authStrategy("mtls", config, cache)
+			require.NoError(t, err)
+			transport, ok := clientFor(auth, httpx.NewResilientClient()).HTTPClient.Transport.(*http.Transport)
+			require.True(t, ok)
+			return transport
+		}
+
+		first := transportFor(t)
+		assert.Same(t, first, transportFor(t), "the transport must be reused while the files are unchanged")
+
+		rotatedKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		rotatedCert, err := tlsx.CreateSelfSignedCertificate(rotatedKey)
+		require.NoError(t, err)
+		rotatedKeyBlock, err := tlsx.PEMBlockForKey(rotatedKey)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rotatedCert.Raw}), 0600))
+		require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(rotatedKeyBlock), 0600))
+		// Make sure the modification time changes even on file systems with a coarse resolution.
+		later := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(certPath, later, later))
+		require.NoError(t, os.Chtimes(keyPath, later, later))
+
+		rotated := transportFor(t)
+		require.NotSame(t, first, rotated)
+		require.Len(t, rotated.TLSClientConfig.Certificates, 1)
+		assert.Equal(t, rotatedCert.Raw, rotated.TLSClientConfig.Certificates[0].Certificate[0])
+		assert.Same(t, rotated, transportFor(t))
+	})
+
+	t.Run("case=fails if the certificate can not be loaded", func(t *testing.T) {
+		_, err := authStrategy("mtls", json.RawMessage(`{"cert":{"path":"/does/not/exist"},"key":{"path":"/does/not/exist"}}`), cache)
+		require.Error(t, err)
+	})
+}
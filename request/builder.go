@@ -0,0 +1,129 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/google/go-jsonnet"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/fetcher"
+	"github.com/ory/x/logrusx"
+)
+
+// Builder creates outgoing HTTP requests from a request configuration
+// containing the URL, method, headers, auth strategy and an optional Jsonnet
+// body template.
+type Builder struct {
+	Config *Config
+	l      *logrusx.Logger
+}
+
+// NewBuilder parses the request configuration. Auth strategies which fetch
+// access tokens or load client certificates keep them in the given cache.
+func NewBuilder(config json.RawMessage, l *logrusx.Logger, cache *AuthCache) (*Builder, error) {
+	c, err := parseConfig(config, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Builder{Config: c, l: l}, nil
+}
+
+// BuildRequest renders the body template with the given context (available
+// as the `ctx` top level argument in Jsonnet) and returns the authenticated
+// request. Send it using the client returned by Client.
+func (b *Builder) BuildRequest(ctx interface{}) (*retryablehttp.Request, error) {
+	req, err := b.BuildUnauthenticatedRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.Config.Auth.apply(req); err != nil {
+		return nil, errors.Wrap(err, "failed to authenticate request")
+	}
+
+	return req, nil
+}
+
+// BuildUnauthenticatedRequest works like BuildRequest but does not apply the
+// auth strategy. Use it for requests which are authenticated when they are
+// sent, see Authenticate.
+func (b *Builder) BuildUnauthenticatedRequest(ctx interface{}) (*retryablehttp.Request, error) {
+	var body io.Reader
+	if b.Config.Method != "TRACE" {
+		// According to the HTTP spec any request method, but TRACE is allowed to
+		// have a body. Even this is a really bad practice for some of them, like for
+		// GET
+		var err error
+		body, err = b.createBody(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create request body")
+		}
+	}
+
+	if body == nil {
+		body = bytes.NewReader(make([]byte, 0))
+	}
+
+	req, err := retryablehttp.NewRequest(b.Config.Method, b.Config.URL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range b.Config.Header {
+		req.Header.Set(k, v)
+	}
+
+	return req, nil
+}
+
+// Client returns the client which must be used to send requests created by
+// BuildRequest. It is the given client unless the auth strategy authenticates
+// on the transport level, for example using a client certificate.
+func (b *Builder) Client(c *retryablehttp.Client) *retryablehttp.Client {
+	return clientFor(b.Config.Auth, c)
+}
+
+func (b *Builder) createBody(ctx interface{}) (*bytes.Reader, error) {
+	templateURI := b.Config.TemplateURI
+	if len(templateURI) == 0 {
+		return bytes.NewReader(make([]byte, 0)), nil
+	}
+
+	f := fetcher.NewFetcher()
+
+	template, err := f.Fetch(templateURI)
+	if errors.Is(err, fetcher.ErrUnknownScheme) {
+		// legacy filepath
+		templateURI = "file://" + templateURI
+		b.l.WithError(err).Warnf("support for filepaths without a 'file://' scheme will be dropped in the next release, please use %s instead in your config", templateURI)
+		template, err = f.Fetch(templateURI)
+	}
+	// this handles the first error if it is a known scheme error, or the second fetch error
+	if err != nil {
+		return nil, err
+	}
+
+	vm := jsonnet.MakeVM()
+
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "")
+
+	if err := enc.Encode(ctx); err != nil {
+		return nil, err
+	}
+	vm.TLACode("ctx", buf.String())
+
+	res, err := vm.EvaluateAnonymousSnippet(templateURI, template.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader([]byte(res)), nil
+}
@@ -1,4 +1,4 @@
-package hook
+package request
 
 import (
 	_ "embed"
@@ -8,96 +8,49 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/go-retryablehttp"
-
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 
+	"github.com/ory/x/httpx"
 	"github.com/ory/x/logrusx"
 
-	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/x"
-
-	"github.com/stretchr/testify/require"
-
-	"github.com/ory/kratos/selfservice/flow/login"
-
-	"github.com/stretchr/testify/assert"
 )
 
-func TestNoopAuthStrategy(t *testing.T) {
-	req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
-	auth := NoopAuthStrategy{}
-
-	auth.apply(&req)
-
-	assert.Empty(t, req.Header, "Empty auth strategy shall not modify any request headers")
-}
+//go:embed stub/test_body.jsonnet
+var testBodyJSONNet []byte
 
-func TestBasicAuthStrategy(t *testing.T) {
-	req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
-	auth := BasicAuthStrategy{
-		user:     "test-user",
-		password: "test-pass",
+type (
+	testFlow struct {
+		ID string `json:"id"`
 	}
-
-	auth.apply(&req)
-
-	assert.Len(t, req.Header, 1)
-
-	user, pass, _ := req.BasicAuth()
-	assert.Equal(t, "test-user", user)
-	assert.Equal(t, "test-pass", pass)
-}
-
-func TestApiKeyInHeaderStrategy(t *testing.T) {
-	req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
-	auth := ApiKeyStrategy{
-		in:    "header",
-		name:  "my-api-key-name",
-		value: "my-api-key-value",
+	testIdentity struct {
+		ID string `json:"id"`
 	}
-
-	auth.apply(&req)
-
-	require.Len(t, req.Header, 1)
-
-	actualValue := req.Header.Get("my-api-key-name")
-	assert.Equal(t, "my-api-key-value", actualValue)
-}
-
-func TestApiKeyInCookieStrategy(t *testing.T) {
-	req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
-	auth := ApiKeyStrategy{
-		in:    "cookie",
-		name:  "my-api-key-name",
-		value: "my-api-key-value",
+	testContext struct {
+		Flow           *testFlow     `json:"flow"`
+		RequestHeaders http.Header   `json:"request_headers"`
+		RequestMethod  string        `json:"request_method"`
+		RequestUrl     string        `json:"request_url"`
+		Identity       *testIdentity `json:"identity"`
 	}
-
-	auth.apply(&req)
-
-	cookies := req.Cookies()
-	assert.Len(t, cookies, 1)
-
-	assert.Equal(t, "my-api-key-name", cookies[0].Name)
-	assert.Equal(t, "my-api-key-value", cookies[0].Value)
-}
-
-//go:embed stub/test_body.jsonnet
-var testBodyJSONNet []byte
+)
 
 func TestJsonNetSupport(t *testing.T) {
-	f := &login.Flow{ID: x.NewUUID()}
-	i := identity.NewIdentity("")
+	f := &testFlow{ID: x.NewUUID().String()}
+	i := &testIdentity{ID: x.NewUUID().String()}
 	l := logrusx.New("kratos", "test")
 
 	for _, tc := range []struct {
 		desc, template string
-		data           *templateContext
+		data           *testContext
 	}{
 		{
 			desc:     "simple file URI",
 			template: "file://./stub/test_body.jsonnet",
-			data: &templateContext{
+			data: &testContext{
 				Flow: f,
 				RequestHeaders: http.Header{
 					"Cookie":      []string{"c1=v1", "c2=v2"},
@@ -111,7 +64,7 @@ func TestJsonNetSupport(t *testing.T) {
 		{
 			desc:     "legacy filepath without scheme",
 			template: "./stub/test_body.jsonnet",
-			data: &templateContext{
+			data: &testContext{
 				Flow: f,
 				RequestHeaders: http.Header{
 					"Cookie":      []string{"c1=v1", "c2=v2"},
@@ -125,7 +78,7 @@ func TestJsonNetSupport(t *testing.T) {
 		{
 			desc:     "base64 encoded template URI",
 			template: "base64://" + base64.StdEncoding.EncodeToString(testBodyJSONNet),
-			data: &templateContext{
+			data: &testContext{
 				Flow: f,
 				RequestHeaders: http.Header{
 					"Cookie":           []string{"foo=bar"},
@@ -138,13 +91,14 @@ func TestJsonNetSupport(t *testing.T) {
 		},
 	} {
 		t.Run("case="+tc.desc, func(t *testing.T) {
-			b, err := createBody(l, tc.template, tc.data)
+			b := &Builder{Config: &Config{TemplateURI: tc.template}, l: l}
+			r, err := b.createBody(tc.data)
 			require.NoError(t, err)
-			body, err := io.ReadAll(b)
+			body, err := io.ReadAll(r)
 			require.NoError(t, err)
 
 			expected, err := json.Marshal(map[string]interface{}{
-				"flow_id":     tc.data.Flow.GetID(),
+				"flow_id":     tc.data.Flow.ID,
 				"identity_id": tc.data.Identity.ID,
 				"headers":     tc.data.RequestHeaders,
 				"method":      tc.data.RequestMethod,
@@ -160,20 +114,57 @@ func TestJsonNetSupport(t *testing.T) {
 		hook := test.Hook{}
 		l := logrusx.New("kratos", "test", logrusx.WithHook(&hook))
 
-		_, _ = createBody(l, "./foo", nil)
+		b := &Builder{Config: &Config{TemplateURI: "./foo"}, l: l}
+		_, _ = b.createBody(nil)
 
 		require.Len(t, hook.Entries, 1)
 		assert.Contains(t, hook.LastEntry().Message, "support for filepaths without a 'file://' scheme will be dropped")
 	})
 
 	t.Run("case=return non nil body reader on empty templateURI", func(t *testing.T) {
-		body, err := createBody(l, "", nil)
+		b := &Builder{Config: &Config{}, l: l}
+		body, err := b.createBody(nil)
 		assert.NotNil(t, body)
 		assert.Nil(t, err)
 	})
 }
 
-func TestWebHookConfig(t *testing.T) {
+func TestBuildRequest(t *testing.T) {
+	l := logrusx.New("kratos", "test")
+
+	b, err := NewBuilder([]byte(`{
+		"url": "https://test.kratos.ory.sh/my_hook",
+		"method": "POST",
+		"body": "file://./stub/test_body.jsonnet",
+		"header": {
+			"X-Custom-Header": "custom-value"
+		},
+		"auth": {
+			"type": "api_key",
+			"config": {
+				"in": "header",
+				"name": "my-api-key",
+				"value": "secret"
+			}
+		}
+	}`), l, NewAuthCache(httpx.NewResilientClient()))
+	require.NoError(t, err)
+
+	req, err := b.BuildRequest(&testContext{Flow: &testFlow{ID: "flow-id"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "POST", req.Method)
+	assert.Equal(t, "https://test.kratos.ory.sh/my_hook", req.URL.String())
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, "custom-value", req.Header.Get("X-Custom-Header"))
+	assert.Equal(t, "secret", req.Header.Get("my-api-key"))
+
+	body, err := req.BodyBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "flow-id", gjson.GetBytes(body, "flow_id").String())
+}
+
+func TestConfig(t *testing.T) {
 	for _, tc := range []struct {
 		strategy     string
 		method       string
@@ -255,7 +246,7 @@ func TestWebHookConfig(t *testing.T) {
 		},
 	} {
 		t.Run("auth-strategy="+tc.strategy, func(t *testing.T) {
-			conf, err := newWebHookConfig([]byte(tc.rawConfig))
+			conf, err := parseConfig([]byte(tc.rawConfig), NewAuthCache(httpx.NewResilientClient()))
 			assert.Nil(t, err)
 
 			assert.Equal(t, tc.url, conf.URL)
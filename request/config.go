@@ -0,0 +1,47 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Config struct {
+	Method      string
+	URL         string
+	TemplateURI string
+	Header      map[string]string
+	Auth        AuthStrategy
+
+	// RawAuth is the `auth` object of the configuration. It is kept so that
+	// requests which are sent later can be authenticated when they are sent.
+	RawAuth json.RawMessage
+}
+
+func parseConfig(r json.RawMessage, cache *AuthCache) (*Config, error) {
+	type rawConfig struct {
+		Method string
+		Url    string
+		Body   string
+		Header map[string]string
+		Auth   json.RawMessage
+	}
+
+	var rc rawConfig
+	if err := json.Unmarshal(r, &rc); err != nil {
+		return nil, err
+	}
+
+	as, err := parseAuth(rc.Auth, cache)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create web hook auth strategy: %w", err)
+	}
+
+	return &Config{
+		Method:      rc.Method,
+		URL:         rc.Url,
+		TemplateURI: rc.Body,
+		Header:      rc.Header,
+		Auth:        as,
+		RawAuth:     rc.Auth,
+	}, nil
+}
@@ -2,6 +2,8 @@ package schema
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -92,6 +94,61 @@ func NewErrorValidationLookupInvalid() error {
 	})
 }
 
+func NewCodeInvalidError() error {
+	t := text.NewErrorValidationCodeInvalid()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: "#/code",
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewCodeAttemptsExceededError(maxAttempts int) error {
+	t := text.NewErrorValidationCodeAttemptsExceeded(maxAttempts)
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: "#/code",
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewRateLimitExceededError(retryAfter time.Duration) error {
+	t := text.NewErrorValidationRateLimitExceeded(retryAfter)
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewIdentifierLockedError(retryAfter time.Duration) error {
+	t := text.NewErrorValidationIdentifierLocked(retryAfter)
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewMagicLinkInvalidError() error {
+	t := text.NewErrorValidationLoginMagicLinkInvalidOrAlreadyUsed()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
 type ValidationErrorContextPasswordPolicyViolation struct {
 	Reason string
 }
@@ -246,3 +303,31 @@ func NewNoWebAuthnRegistered() error {
 		Messages: new(text.Messages).Add(text.NewErrorValidationNoWebAuthnDevice()),
 	})
 }
+
+// ValidationListError collects several validation errors, for example all errors returned by a web hook.
+type ValidationListError struct {
+	Validations []*ValidationError
+}
+
+func (e *ValidationListError) Error() string {
+	messages := make([]string, len(e.Validations))
+	for k, v := range e.Validations {
+		messages[k] = v.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Add appends a validation error for the given instance pointer.
+func (e *ValidationListError) Add(instancePtr, message string, details text.Messages) {
+	e.Validations = append(e.Validations, &ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     message,
+			InstancePtr: instancePtr,
+		},
+		Messages: details,
+	})
+}
+
+func (e *ValidationListError) HasErrors() bool {
+	return len(e.Validations) > 0
+}
@@ -18,7 +18,8 @@ type (
 	ExtensionConfig struct {
 		Credentials struct {
 			Password struct {
-				Identifier bool `json:"identifier"`
+				Identifier     bool   `json:"identifier"`
+				IdentifierType string `json:"identifier_type"`
 			} `json:"password"`
 			TOTP struct {
 				AccountName bool `json:"account_name"`
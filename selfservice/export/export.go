@@ -0,0 +1,208 @@
+package export
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/strategy/webauthn"
+	"github.com/ory/kratos/session"
+)
+
+// A Data Export
+//
+// Contains all data stored about an identity. Secrets such as password hashes, tokens, or keys are never included.
+//
+// swagger:model selfServiceDataExport
+type DataExport struct {
+	// ExportedAt is the time at which the export was created.
+	//
+	// required: true
+	ExportedAt time.Time `json:"exported_at"`
+
+	// Identity contains the identity's traits, addresses, and public metadata.
+	//
+	// required: true
+	Identity *identity.Identity `json:"identity"`
+
+	// Credentials contains the metadata of the identity's credentials.
+	//
+	// required: true
+	Credentials []Credentials `json:"credentials"`
+
+	// Sessions contains the identity's active sessions.
+	//
+	// required: true
+	Sessions []session.Session `json:"sessions"`
+
+	// Messages contains the messages which were sent to the identity's verified addresses since they were
+	// verified, newest first.
+	//
+	// required: true
+	Messages []Message `json:"messages"`
+}
+
+// Credentials Metadata
+//
+// Contains the metadata of a credential. Secrets are never included.
+//
+// swagger:model selfServiceDataExportCredentials
+type Credentials struct {
+	// Type is the credential's type, for example `password` or `oidc`.
+	//
+	// required: true
+	Type identity.CredentialsType `json:"type"`
+
+	// Identifiers are the identifiers used for signing in with this credential, for example an email address.
+	//
+	// required: true
+	Identifiers []string `json:"identifiers"`
+
+	// Providers lists the social sign in providers linked to the identity.
+	Providers []CredentialsProvider `json:"providers,omitempty"`
+
+	// Keys lists the security keys registered for the identity.
+	Keys []CredentialsKey `json:"keys,omitempty"`
+
+	// CreatedAt is the time at which the credential was added.
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt is the time at which the credential was last updated.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// A Social Sign In Provider
+//
+// swagger:model selfServiceDataExportCredentialsProvider
+type CredentialsProvider struct {
+	// Provider is the ID of the social sign in provider.
+	//
+	// required: true
+	Provider string `json:"provider"`
+
+	// Subject is the identity's ID at the provider.
+	//
+	// required: true
+	Subject string `json:"subject"`
+}
+
+// A Security Key
+//
+// swagger:model selfServiceDataExportCredentialsKey
+type CredentialsKey struct {
+	// DisplayName is the name given to the key when registering it.
+	//
+	// required: true
+	DisplayName string `json:"display_name"`
+
+	// AddedAt is the time at which the key was registered.
+	AddedAt time.Time `json:"added_at"`
+
+	// IsPasswordless is true if the key can be used for signing in without a password.
+	IsPasswordless bool `json:"is_passwordless"`
+}
+
+// A Sent Message
+//
+// Contains the metadata of a message which was sent to one of the identity's verified addresses. The body is
+// never included because it may contain codes or links which grant access to the account.
+//
+// swagger:model selfServiceDataExportMessage
+type Message struct {
+	// ID is the message's ID.
+	//
+	// required: true
+	ID uuid.UUID `json:"id"`
+
+	// Type is the message's type, for example `email`.
+	//
+	// required: true
+	Type courier.MessageType `json:"type"`
+
+	// Status is the message's delivery status.
+	//
+	// required: true
+	Status courier.MessageStatus `json:"status"`
+
+	// Recipient is the address the message was sent to.
+	//
+	// required: true
+	Recipient string `json:"recipient"`
+
+	// Subject is the message's subject.
+	//
+	// required: true
+	Subject string `json:"subject"`
+
+	// TemplateType is the type of the template the message was rendered from, for example `recovery_valid`.
+	//
+	// required: true
+	TemplateType courier.TemplateType `json:"template_type"`
+
+	// CreatedAt is the time at which the message was queued.
+	//
+	// required: true
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewMessage returns the metadata of the message.
+func NewMessage(m *courier.Message) Message {
+	return Message{
+		ID:           m.ID,
+		Type:         m.Type,
+		Status:       m.Status,
+		Recipient:    m.Recipient,
+		Subject:      m.Subject,
+		TemplateType: m.TemplateType,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// NewCredentials returns the metadata of the identity's credentials, sorted by type.
+func NewCredentials(i *identity.Identity) ([]Credentials, error) {
+	result := make([]Credentials, 0, len(i.Credentials))
+	for t, c := range i.Credentials {
+		ec := Credentials{
+			Type:        t,
+			Identifiers: c.Identifiers,
+			CreatedAt:   c.CreatedAt,
+			UpdatedAt:   c.UpdatedAt,
+		}
+		if ec.Identifiers == nil {
+			ec.Identifiers = []string{}
+		}
+
+		switch t {
+		case identity.CredentialsTypeOIDC:
+			var conf identity.CredentialsOIDC
+			if err := json.Unmarshal(c.Config, &conf); err != nil {
+				return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode the identity's %s credentials: %s", t, err))
+			}
+			for _, p := range conf.Providers {
+				ec.Providers = append(ec.Providers, CredentialsProvider{Provider: p.Provider, Subject: p.Subject})
+			}
+		case identity.CredentialsTypeWebAuthn:
+			var conf webauthn.CredentialsConfig
+			if err := json.Unmarshal(c.Config, &conf); err != nil {
+				return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode the identity's %s credentials: %s", t, err))
+			}
+			for _, k := range conf.Credentials {
+				ec.Keys = append(ec.Keys, CredentialsKey{DisplayName: k.DisplayName, AddedAt: k.AddedAt, IsPasswordless: k.IsPasswordless})
+			}
+		}
+
+		result = append(result, ec)
+	}
+
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].Type < result[b].Type
+	})
+	return result, nil
+}
@@ -0,0 +1,223 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/pointerx"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+const RouteGet = "/self-service/data-export"
+
+// exportPageSize is the number of sessions and messages loaded at once.
+const exportPageSize = 500
+
+type (
+	handlerDependencies interface {
+		identity.PrivilegedPoolProvider
+		session.ManagementProvider
+		session.PersistenceProvider
+		courier.PersistenceProvider
+		x.WriterProvider
+		x.LoggingProvider
+		x.CSRFProvider
+		config.Provider
+	}
+	HandlerProvider interface {
+		SelfServiceDataExportHandler() *Handler
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
+	h.r.CSRFHandler().IgnorePath(RouteGet)
+	public.GET(RouteGet, h.get)
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
+	admin.GET(RouteGet, x.RedirectToPublicRoute(h.r))
+}
+
+// nolint:deadcode,unused
+// swagger:parameters getSelfServiceDataExport
+type getSelfServiceDataExport struct {
+	// Set the Session Token when calling from non-browser clients. A session token has a format of `MP2YWEMeM8MxjkGKpH4dqOQ4Q4DlSPaj`.
+	//
+	// in: header
+	SessionToken string `json:"X-Session-Token"`
+
+	// Set the Cookie Header. This is especially useful when calling this endpoint from a server-side application. In that
+	// scenario you must include the HTTP Cookie Header which originally was included in the request to your server.
+	// An example of a session in the HTTP Cookie Header is: `ory_kratos_session=a19iOVAbdzdgl70Rq1QZmrKmcjDtdsviCTZx7m9a9yHIUS8Wa9T7hvqyGTsLHi6Qifn2WUfpAKx9DWp0SJGleIn9vh2YF4A16id93kXFTgIgmwIOvbVAScyrx7yVl6bPZnCx27ec4WQDtaTewC1CpgudeDV2jQQnSaCP6ny3xa8qLH-QUgYqdQuoA_LF1phxgRCUfIrCLQOkolX5nv3ze_f==`.
+	//
+	// It is ok if more than one cookie are included here as all other cookies will be ignored.
+	//
+	// in: header
+	Cookie string `json:"Cookie"`
+}
+
+// swagger:route GET /self-service/data-export v0alpha2 getSelfServiceDataExport
+//
+// Export the Data of the Current Identity
+//
+// Uses the HTTP Headers in the GET request to determine (e.g. by using checking the cookies) who is authenticated
+// and returns all data stored about the identity as a JSON document:
+//
+// - the identity's traits, verifiable and recovery addresses, and public metadata;
+// - the metadata of the identity's credentials, such as identifiers, linked social sign in providers, and the names
+//   of registered security keys;
+// - the identity's active sessions;
+// - the messages which were sent to the identity's addresses.
+//
+// Secrets such as password hashes, tokens, or keys are never included. The session must satisfy the same
+// authenticator assurance level as required by `/sessions/whoami`.
+//
+// Use this endpoint to let users download their data, for example to satisfy GDPR subject access requests.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: selfServiceDataExport
+//       401: jsonError
+//       403: jsonError
+//       500: jsonError
+func (h *Handler) get(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.r.SessionManager().FetchFromRequest(r.Context(), r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized.WithWrap(err).WithReasonf("No valid session cookie found."))
+		return
+	}
+
+	var aalErr *session.ErrAALNotSatisfied
+	if err := h.r.SessionManager().DoesSessionSatisfy(r, s, h.r.Config(r.Context()).SessionWhoAmIAAL()); errors.As(err, &aalErr) {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	} else if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized.WithWrap(err).WithReasonf("Unable to determine AAL."))
+		return
+	}
+
+	export, err := h.export(r.Context(), s.IdentityID)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Audit().WithRequest(r).WithField("identity_id", s.IdentityID).Info("Identity exported its data.")
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="data-export-%s.json"`, s.IdentityID))
+	h.r.Writer().Write(w, r, export)
+}
+
+func (h *Handler) export(ctx context.Context, id uuid.UUID) (*DataExport, error) {
+	i, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := NewCredentials(i)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := h.activeSessions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := h.messages(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DataExport{
+		ExportedAt:  time.Now().UTC(),
+		Identity:    i.CopyWithoutCredentials(),
+		Credentials: credentials,
+		Sessions:    sessions,
+		Messages:    messages,
+	}, nil
+}
+
+func (h *Handler) activeSessions(ctx context.Context, id uuid.UUID) ([]session.Session, error) {
+	result := make([]session.Session, 0)
+	for page := 1; ; page++ {
+		ss, err := h.r.SessionPersister().ListSessionsByIdentity(ctx, id, pointerx.Bool(true), page, exportPageSize, uuid.Nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range ss {
+			if !s.IsActive() {
+				continue
+			}
+			result = append(result, *s)
+		}
+
+		if len(ss) < exportPageSize {
+			return result, nil
+		}
+	}
+}
+
+// messages returns the messages which were sent to the identity's verified addresses. Messages which were sent
+// before an address was verified are left out because the address may have belonged to someone else back then.
+func (h *Handler) messages(ctx context.Context, i *identity.Identity) ([]Message, error) {
+	verifiedAt := map[string]time.Time{}
+	for _, a := range i.VerifiableAddresses {
+		if !a.Verified || a.VerifiedAt == nil || time.Time(*a.VerifiedAt).IsZero() {
+			continue
+		}
+		if at, ok := verifiedAt[a.Value]; !ok || time.Time(*a.VerifiedAt).Before(at) {
+			verifiedAt[a.Value] = time.Time(*a.VerifiedAt)
+		}
+	}
+
+	result := make([]Message, 0)
+	for recipient, since := range verifiedAt {
+		for page := 1; ; page++ {
+			ms, _, err := h.r.CourierPersister().ListMessages(ctx, courier.ListMessagesFilter{Recipient: recipient}, page, exportPageSize)
+			if err != nil {
+				return nil, err
+			}
+
+			for k := range ms {
+				if ms[k].CreatedAt.Before(since) {
+					continue
+				}
+				result = append(result, NewMessage(&ms[k]))
+			}
+			if len(ms) < exportPageSize {
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(a, b int) bool {
+		return result[a].CreatedAt.After(result[b].CreatedAt)
+	})
+	return result, nil
+}
@@ -0,0 +1,171 @@
+package export_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/export"
+	"github.com/ory/kratos/session"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(t, conf, "file://./stub/identity.schema.json")
+	publicTS, adminTS := testhelpers.NewKratosServer(t, reg)
+
+	const email = "export@ory.sh"
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	i.Traits = identity.Traits(`{"email":"` + email + `"}`)
+	i.MetadataPublic = sqlxx.NullJSONRawMessage(`{"plan":"pro"}`)
+	i.MetadataAdmin = sqlxx.NullJSONRawMessage(`{"risk":"low"}`)
+	verifiedAt := time.Now().UTC().Add(-time.Hour).Round(time.Second)
+	i.VerifiableAddresses = []identity.VerifiableAddress{*identity.NewVerifiableEmailAddress(email, i.ID)}
+	i.VerifiableAddresses[0].Verified = true
+	i.VerifiableAddresses[0].Status = identity.VerifiableAddressStatusCompleted
+	vat := sqlxx.NullTime(verifiedAt)
+	i.VerifiableAddresses[0].VerifiedAt = &vat
+	i.RecoveryAddresses = []identity.RecoveryAddress{*identity.NewRecoveryEmailAddress(email, i.ID)}
+	i.Credentials = map[identity.CredentialsType]identity.Credentials{
+		identity.CredentialsTypePassword: {
+			Type:        identity.CredentialsTypePassword,
+			Identifiers: []string{email},
+			Config:      sqlxx.JSONRawMessage(`{"hashed_password":"$2a$08$secret-hash"}`),
+		},
+		identity.CredentialsTypeOIDC: {
+			Type:        identity.CredentialsTypeOIDC,
+			Identifiers: []string{identity.OIDCUniqueID("google", "1234")},
+			Config:      sqlxx.JSONRawMessage(`{"providers":[{"provider":"google","subject":"1234","initial_access_token":"secret-access-token"}]}`),
+		},
+		identity.CredentialsTypeWebAuthn: {
+			Type:        identity.CredentialsTypeWebAuthn,
+			Identifiers: []string{i.ID.String()},
+			Config:      sqlxx.JSONRawMessage(`{"credentials":[{"id":"Zm9vZm9v","public_key":"c2VjcmV0LWtleQ==","display_name":"my key","is_passwordless":true}]}`),
+		},
+	}
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+	inactive, err := session.NewActiveSession(i, conf, time.Now(), identity.CredentialsTypePassword)
+	require.NoError(t, err)
+	inactive.Active = false
+	require.NoError(t, reg.SessionPersister().UpsertSession(ctx, inactive))
+
+	for _, m := range []struct {
+		recipient string
+		createdAt time.Time
+	}{
+		{recipient: email, createdAt: verifiedAt.Add(-time.Minute)},
+		{recipient: email, createdAt: verifiedAt.Add(time.Minute)},
+		{recipient: "someone-else@ory.sh", createdAt: verifiedAt.Add(time.Minute)},
+	} {
+		require.NoError(t, reg.CourierPersister().AddMessage(ctx, &courier.Message{
+			Type:         courier.MessageTypeEmail,
+			Recipient:    m.recipient,
+			Subject:      "Recover access to your account",
+			Body:         "Hi " + m.recipient,
+			TemplateType: courier.TypeRecoveryValid,
+			CreatedAt:    m.createdAt,
+		}))
+	}
+
+	var get = func(t *testing.T, c *http.Client, expectCode int) (gjson.Result, *http.Response) {
+		res, err := c.Get(publicTS.URL + export.RouteGet)
+		require.NoError(t, err)
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+
+		require.EqualValues(t, expectCode, res.StatusCode, "%s", body)
+		return gjson.ParseBytes(body), res
+	}
+
+	t.Run("case=should fail without a session", func(t *testing.T) {
+		get(t, new(http.Client), http.StatusUnauthorized)
+	})
+
+	t.Run("case=should redirect from the admin endpoint", func(t *testing.T) {
+		c := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+		res, err := c.Get(adminTS.URL + export.RouteGet)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.EqualValues(t, http.StatusTemporaryRedirect, res.StatusCode)
+		assert.Equal(t, publicTS.URL+export.RouteGet, res.Header.Get("Location"))
+	})
+
+	t.Run("case=should require the whoami authenticator assurance level", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySessionWhoAmIAAL, config.HighestAvailableAAL)
+		body, _ := get(t, testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, i), http.StatusForbidden)
+		assert.Equal(t, "session_aal2_required", body.Get("error.id").String(), "%s", body.Raw)
+	})
+
+	conf.MustSet(config.ViperKeySessionWhoAmIAAL, "aal1")
+	for name, c := range map[string]*http.Client{
+		"cookie": testhelpers.NewHTTPClientWithIdentitySessionCookie(t, reg, i),
+		"token":  testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, i),
+	} {
+		t.Run("session="+name, func(t *testing.T) {
+			body, res := get(t, c, http.StatusOK)
+			assert.Contains(t, res.Header.Get("Content-Disposition"), "attachment")
+
+			t.Run("case=should export the identity", func(t *testing.T) {
+				assert.Equal(t, i.ID.String(), body.Get("identity.id").String(), "%s", body.Raw)
+				assert.Equal(t, email, body.Get("identity.traits.email").String(), "%s", body.Raw)
+				assert.Equal(t, email, body.Get("identity.verifiable_addresses.0.value").String(), "%s", body.Raw)
+				assert.Equal(t, email, body.Get("identity.recovery_addresses.0.value").String(), "%s", body.Raw)
+				assert.JSONEq(t, `{"plan":"pro"}`, body.Get("identity.metadata_public").Raw, "%s", body.Raw)
+				assert.False(t, body.Get("identity.metadata_admin").Exists(), "%s", body.Raw)
+				assert.False(t, body.Get("identity.credentials").Exists(), "%s", body.Raw)
+			})
+
+			t.Run("case=should export credential metadata without secrets", func(t *testing.T) {
+				assert.Equal(t, []interface{}{"oidc", "password", "webauthn"}, body.Get("credentials.#.type").Value(), "%s", body.Raw)
+				assert.Equal(t, email, body.Get(`credentials.#(type=="password").identifiers.0`).String(), "%s", body.Raw)
+				assert.JSONEq(t, `[{"provider":"google","subject":"1234"}]`, body.Get(`credentials.#(type=="oidc").providers`).Raw, "%s", body.Raw)
+				assert.Equal(t, "my key", body.Get(`credentials.#(type=="webauthn").keys.0.display_name`).String(), "%s", body.Raw)
+				assert.True(t, body.Get(`credentials.#(type=="webauthn").keys.0.is_passwordless`).Bool(), "%s", body.Raw)
+
+				for _, secret := range []string{"secret-hash", "secret-access-token", "c2VjcmV0LWtleQ==", "config"} {
+					assert.NotContains(t, body.Get("credentials").Raw, secret)
+				}
+			})
+
+			t.Run("case=should export active sessions", func(t *testing.T) {
+				ids := body.Get("sessions.#.id").Array()
+				require.NotEmpty(t, ids, "%s", body.Raw)
+				for _, id := range ids {
+					assert.NotEqual(t, inactive.ID.String(), id.String())
+				}
+			})
+
+			t.Run("case=should export messages sent to the identity's verified addresses since their verification", func(t *testing.T) {
+				assert.Equal(t, []interface{}{email}, body.Get("messages.#.recipient").Value(), "%s", body.Raw)
+				assert.Equal(t, "Recover access to your account", body.Get("messages.0.subject").String(), "%s", body.Raw)
+				assert.False(t, body.Get("messages.0.body").Exists(), "%s", body.Raw)
+				assert.NotContains(t, body.Get("messages").Raw, "Hi "+email)
+			})
+		})
+	}
+
+	t.Run("case=should only export the session's identity", func(t *testing.T) {
+		other := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		body, _ := get(t, testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, other), http.StatusOK)
+		assert.Equal(t, other.ID.String(), body.Get("identity.id").String(), "%s", body.Raw)
+		assert.Empty(t, body.Get("credentials").Array(), "%s", body.Raw)
+		assert.Empty(t, body.Get("messages").Array(), "%s", body.Raw)
+		assert.Len(t, body.Get("sessions").Array(), 1, "%s", body.Raw)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/ory/nosurf"
 
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/ratelimit"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/ui/node"
 	"github.com/ory/x/decoderx"
@@ -51,6 +52,7 @@ type (
 		x.CSRFProvider
 		config.Provider
 		ErrorHandlerProvider
+		ratelimit.LimiterProvider
 	}
 	HandlerProvider interface {
 		LoginHandler() *Handler
@@ -570,6 +572,11 @@ continueLogin:
 		return
 	}
 
+	if err := h.d.RateLimiter().CheckRequest(r.Context(), ratelimit.FlowLogin, r, f.ID); err != nil {
+		h.d.LoginFlowErrorHandler().WriteFlowError(w, r, f, node.DefaultGroup, err)
+		return
+	}
+
 	var i *identity.Identity
 	for _, ss := range h.d.AllLoginStrategies() {
 		interim, err := ss.Login(w, r, f, sess)
@@ -588,7 +595,11 @@ continueLogin:
 			sess = session.NewInactiveSession()
 		}
 
-		sess.CompletedLoginFor(ss.ID())
+		if reporter, ok := ss.(AssuranceLevelReporter); ok {
+			sess.CompletedLoginForWithAAL(ss.ID(), reporter.CompletedLoginAAL(f))
+		} else {
+			sess.CompletedLoginFor(ss.ID())
+		}
 		i = interim
 		break
 	}
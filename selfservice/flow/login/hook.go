@@ -12,6 +12,7 @@ import (
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/webhook"
 	"github.com/ory/kratos/x"
 )
 
@@ -37,6 +38,7 @@ type (
 		session.PersistenceProvider
 		x.WriterProvider
 		x.LoggingProvider
+		webhook.EmitterProvider
 
 		HooksProvider
 	}
@@ -117,9 +119,12 @@ func (e *HookExecutor) PostLoginHook(w http.ResponseWriter, r *http.Request, a *
 	}
 
 	if a.Type == flow.TypeAPI {
+		e.d.SessionManager().TrackDevice(r.Context(), r, s)
+		event := s.UpsertEvent()
 		if err := e.d.SessionPersister().UpsertSession(r.Context(), s); err != nil {
 			return errors.WithStack(err)
 		}
+		e.d.WebHookEmitter().Emit(r.Context(), event, s.EventData())
 		e.d.Audit().
 			WithRequest(r).
 			WithField("session_id", s.ID).
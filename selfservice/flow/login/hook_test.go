@@ -140,6 +140,7 @@ func TestLoginExecutor(t *testing.T) {
 					res, body := makeRequestPost(t, newServer(t, flow.TypeAPI, nil), true, url.Values{})
 					assert.EqualValues(t, http.StatusOK, res.StatusCode)
 					assert.NotEmpty(t, gjson.Get(body, "session.identity.id").String())
+					assert.EqualValues(t, "127.0.0.1", gjson.Get(body, "session.devices.0.ip_address").String(), "%s", body)
 				})
 
 				t.Run("case=pass without hooks for browser flow with application/json", func(t *testing.T) {
@@ -148,6 +149,7 @@ func TestLoginExecutor(t *testing.T) {
 					res, body := makeRequestPost(t, newServer(t, flow.TypeBrowser, nil), true, url.Values{})
 					assert.EqualValues(t, http.StatusOK, res.StatusCode)
 					assert.NotEmpty(t, gjson.Get(body, "session.identity.id").String())
+					assert.EqualValues(t, "127.0.0.1", gjson.Get(body, "session.devices.0.ip_address").String(), "%s", body)
 					assert.Empty(t, gjson.Get(body, "session.token").String())
 					assert.Empty(t, gjson.Get(body, "session_token").String())
 				})
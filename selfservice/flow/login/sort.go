@@ -8,6 +8,7 @@ func sortNodes(n node.Nodes) error {
 			node.DefaultGroup,
 			node.OpenIDConnectGroup,
 			node.PasswordGroup,
+			node.MagicLinkGroup,
 			node.WebAuthnGroup,
 			node.TOTPGroup,
 			node.LookupGroup,
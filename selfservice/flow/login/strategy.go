@@ -21,6 +21,12 @@ type Strategy interface {
 	Login(w http.ResponseWriter, r *http.Request, f *Flow, ss *session.Session) (i *identity.Identity, err error)
 }
 
+// AssuranceLevelReporter is implemented by strategies which act as first and second factor and
+// therefore only know the assurance level of a login once it was completed.
+type AssuranceLevelReporter interface {
+	CompletedLoginAAL(f *Flow) identity.AuthenticatorAssuranceLevel
+}
+
 type Strategies []Strategy
 
 func (s Strategies) Strategy(id identity.CredentialsType) (Strategy, error) {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ory/nosurf"
 
+	"github.com/ory/kratos/ratelimit"
 	"github.com/ory/kratos/schema"
 
 	"github.com/ory/x/sqlcon"
@@ -51,6 +52,7 @@ type (
 		x.CSRFProvider
 		config.Provider
 		ErrorHandlerProvider
+		ratelimit.LimiterProvider
 	}
 	Handler struct {
 		d handlerDependencies
@@ -366,6 +368,11 @@ func (h *Handler) submitFlow(w http.ResponseWriter, r *http.Request, ps httprout
 		return
 	}
 
+	if err := h.d.RateLimiter().CheckRequest(r.Context(), ratelimit.FlowRecovery, r, f.ID); err != nil {
+		h.d.RecoveryFlowErrorHandler().WriteFlowError(w, r, f, node.DefaultGroup, err)
+		return
+	}
+
 	var g node.Group
 	var found bool
 	for _, ss := range h.d.AllRecoveryStrategies() {
@@ -0,0 +1,39 @@
+package recovery
+
+import (
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+)
+
+// The Response for Recovery Flows via API
+//
+// swagger:model successfulSelfServiceRecoveryWithoutBrowser
+type APIFlowResponse struct {
+	// The Session Token
+	//
+	// A session token is equivalent to a session cookie, but it can be sent in the HTTP Authorization
+	// Header:
+	//
+	// 		Authorization: bearer ${session-token}
+	//
+	// The session token is only issued for API flows, not for Browser flows!
+	//
+	// required: true
+	Token string `json:"session_token"`
+
+	// The Session
+	//
+	// The session contains information about the user, the session device, and so on.
+	// This is only available for API flows, not for Browser flows!
+	//
+	// required: true
+	Session *session.Session `json:"session"`
+
+	// The Settings Flow
+	//
+	// The settings flow which must be used to set a new password or add another
+	// login method within the privileged session lifespan.
+	//
+	// required: true
+	SettingsFlow *settings.Flow `json:"settings_flow"`
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ory/nosurf"
 
+	"github.com/ory/kratos/ratelimit"
 	"github.com/ory/kratos/schema"
 
 	"github.com/ory/kratos/identity"
@@ -47,6 +48,7 @@ type (
 		HookExecutorProvider
 		FlowPersistenceProvider
 		ErrorHandlerProvider
+		ratelimit.LimiterProvider
 	}
 	HandlerProvider interface {
 		RegistrationHandler() *Handler
@@ -436,6 +438,11 @@ func (h *Handler) submitFlow(w http.ResponseWriter, r *http.Request, _ httproute
 		return
 	}
 
+	if err := h.d.RateLimiter().CheckRequest(r.Context(), ratelimit.FlowRegistration, r, f.ID); err != nil {
+		h.d.RegistrationFlowErrorHandler().WriteFlowError(w, r, f, node.DefaultGroup, err)
+		return
+	}
+
 	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
 	var s Strategy
 	for _, ss := range h.d.AllRegistrationStrategies() {
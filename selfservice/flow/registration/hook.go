@@ -12,6 +12,7 @@ import (
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/ratelimit"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/session"
@@ -67,8 +68,10 @@ type (
 		session.PersistenceProvider
 		session.ManagementProvider
 		HooksProvider
+		StrategyProvider
 		x.LoggingProvider
 		x.WriterProvider
+		ratelimit.LimiterProvider
 	}
 	HookExecutor struct {
 		d executorDependencies
@@ -116,9 +119,27 @@ func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Reque
 	// We need to make sure that the identity has a valid schema before passing it down to the identity pool.
 	if err := e.d.IdentityValidator().Validate(r.Context(), i); err != nil {
 		return err
-		// We're now creating the identity because any of the hooks could trigger a "redirect" or a "session" which
-		// would imply that the identity has to exist already.
-	} else if err := e.d.IdentityManager().Create(r.Context(), i); err != nil {
+	}
+
+	// The identifiers of the credentials are known once the identity was validated. Credentials of different
+	// types often share identifiers, which is why each identifier is counted once.
+	checked := map[string]bool{}
+	for _, c := range i.Credentials {
+		for _, identifier := range c.Identifiers {
+			if checked[identifier] {
+				continue
+			}
+			checked[identifier] = true
+
+			if err := e.d.RateLimiter().CheckIdentifier(r.Context(), ratelimit.FlowRegistration, identifier); err != nil {
+				return err
+			}
+		}
+	}
+
+	// We're now creating the identity because any of the hooks could trigger a "redirect" or a "session" which
+	// would imply that the identity has to exist already.
+	if err := e.d.IdentityManager().Create(r.Context(), i); err != nil {
 		if errors.Is(err, sqlcon.ErrUniqueViolation) {
 			return schema.NewDuplicateCredentialsError()
 		}
@@ -142,8 +163,17 @@ func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Reque
 		WithField("identity_id", i.ID).
 		Info("A new identity has registered using self-service registration.")
 
-	s, err := session.NewActiveSession(i, e.d.Config(r.Context()), time.Now().UTC(), ct)
-	if err != nil {
+	var aal identity.AuthenticatorAssuranceLevel
+	if strategy, err := e.d.AllRegistrationStrategies().Strategy(ct); err == nil {
+		if reporter, ok := strategy.(AssuranceLevelReporter); ok {
+			aal = reporter.CompletedRegistrationAAL(a)
+		}
+	}
+
+	s := session.NewInactiveSession()
+	s.CompletedLoginForWithAAL(ct, aal)
+
+	if err := s.Activate(i, e.d.Config(r.Context()), time.Now().UTC()); err != nil {
 		return err
 	}
 
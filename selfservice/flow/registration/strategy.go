@@ -20,6 +20,12 @@ type Strategy interface {
 	Register(w http.ResponseWriter, r *http.Request, f *Flow, i *identity.Identity) (err error)
 }
 
+// AssuranceLevelReporter is implemented by strategies which act as first and second factor and
+// therefore only know the assurance level of a registration once it was completed.
+type AssuranceLevelReporter interface {
+	CompletedRegistrationAAL(f *Flow) identity.AuthenticatorAssuranceLevel
+}
+
 type Strategies []Strategy
 
 func (s Strategies) Strategy(id identity.CredentialsType) (Strategy, error) {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ory/nosurf"
 
+	"github.com/ory/kratos/ratelimit"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/ui/node"
 	"github.com/ory/x/sqlcon"
@@ -49,6 +50,7 @@ type (
 		FlowPersistenceProvider
 		ErrorHandlerProvider
 		StrategyProvider
+		ratelimit.LimiterProvider
 	}
 	Handler struct {
 		d handlerDependencies
@@ -348,6 +350,11 @@ func (h *Handler) submitFlow(w http.ResponseWriter, r *http.Request, ps httprout
 		return
 	}
 
+	if err := h.d.RateLimiter().CheckRequest(r.Context(), ratelimit.FlowVerification, r, f.ID); err != nil {
+		h.d.VerificationFlowErrorHandler().WriteFlowError(w, r, f, node.DefaultGroup, err)
+		return
+	}
+
 	var g node.Group
 	var found bool
 	for _, ss := range h.d.AllVerificationStrategies() {
@@ -9,6 +9,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/registration"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/webhook"
 	"github.com/ory/kratos/x"
 )
 
@@ -21,6 +22,7 @@ type (
 		session.ManagementProvider
 		session.PersistenceProvider
 		x.WriterProvider
+		webhook.EmitterProvider
 	}
 	SessionIssuerProvider interface {
 		HookSessionIssuer() *SessionIssuer
@@ -36,9 +38,12 @@ func NewSessionIssuer(r sessionIssuerDependencies) *SessionIssuer {
 
 func (e *SessionIssuer) ExecutePostRegistrationPostPersistHook(w http.ResponseWriter, r *http.Request, a *registration.Flow, s *session.Session) error {
 	s.AuthenticatedAt = time.Now().UTC()
+	e.r.SessionManager().TrackDevice(r.Context(), r, s)
+	event := s.UpsertEvent()
 	if err := e.r.SessionPersister().UpsertSession(r.Context(), s); err != nil {
 		return err
 	}
+	e.r.WebHookEmitter().Emit(r.Context(), event, s.EventData())
 
 	if a.Type == flow.TypeAPI {
 		e.r.Writer().Write(w, r, &registration.APIFlowResponse{
@@ -1,22 +1,25 @@
 package hook
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/hashicorp/go-retryablehttp"
-
-	"github.com/ory/x/fetcher"
-	"github.com/ory/x/logrusx"
-
-	"github.com/google/go-jsonnet"
 	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
 
+	"github.com/ory/herodot"
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/request"
+	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/recovery"
@@ -24,158 +27,58 @@ import (
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/webhook"
 	"github.com/ory/kratos/x"
 )
 
+var _ registration.PostHookPrePersistExecutor = new(WebHook)
 var _ registration.PostHookPostPersistExecutor = new(WebHook)
+var _ settings.PostHookPrePersistExecutor = new(WebHook)
+var _ settings.PostHookPostPersistExecutor = new(WebHook)
 var _ verification.PostHookExecutor = new(WebHook)
 var _ recovery.PostHookExecutor = new(WebHook)
 
 type (
-	AuthStrategy interface {
-		apply(req *retryablehttp.Request)
-	}
-
-	authStrategyFactory func(c json.RawMessage) (AuthStrategy, error)
-
-	NoopAuthStrategy struct{}
-
-	BasicAuthStrategy struct {
-		user     string
-		password string
-	}
-
-	ApiKeyStrategy struct {
-		name  string
-		value string
-		in    string
-	}
-
-	WebHookConfig struct {
-		Method      string
-		URL         string
-		TemplateURI string
-		Auth        AuthStrategy
-	}
-
 	webHookDependencies interface {
+		config.Provider
 		x.LoggingProvider
 		x.HTTPClientProvider
+		request.AuthCacheProvider
+		webhook.DispatcherProvider
 	}
 
 	templateContext struct {
-		Flow           flow.Flow          `json:"flow"`
-		RequestHeaders http.Header        `json:"request_headers"`
-		RequestMethod  string             `json:"request_method"`
-		RequestUrl     string             `json:"request_url"`
-		Identity       *identity.Identity `json:"identity"`
-	}
-
-	WebHook struct {
-		r webHookDependencies
-		c json.RawMessage
-	}
-)
-
-var strategyFactories = map[string]authStrategyFactory{
-	"":           newNoopAuthStrategy,
-	"api_key":    newApiKeyStrategy,
-	"basic_auth": newBasicAuthStrategy,
-}
-
-func newAuthStrategy(name string, c json.RawMessage) (as AuthStrategy, err error) {
-	if f, ok := strategyFactories[name]; ok {
-		as, err = f(c)
-	} else {
-		err = fmt.Errorf("unsupported auth type: %s", name)
-	}
-	return
-}
-
-func newNoopAuthStrategy(_ json.RawMessage) (AuthStrategy, error) {
-	return &NoopAuthStrategy{}, nil
-}
-
-func (c *NoopAuthStrategy) apply(_ *retryablehttp.Request) {}
-
-func newBasicAuthStrategy(raw json.RawMessage) (AuthStrategy, error) {
-	type config struct {
-		User     string
-		Password string
-	}
-
-	var c config
-	if err := json.Unmarshal(raw, &c); err != nil {
-		return nil, err
-	}
-
-	return &BasicAuthStrategy{
-		user:     c.User,
-		password: c.Password,
-	}, nil
-}
-
-func (c *BasicAuthStrategy) apply(req *retryablehttp.Request) {
-	req.SetBasicAuth(c.user, c.password)
-}
-
-func newApiKeyStrategy(raw json.RawMessage) (AuthStrategy, error) {
-	type config struct {
-		In    string
-		Name  string
-		Value string
+		Flow           flow.Flow                         `json:"flow"`
+		RequestHeaders http.Header                       `json:"request_headers"`
+		RequestMethod  string                            `json:"request_method"`
+		RequestUrl     string                            `json:"request_url"`
+		Identity       *identity.WithAdminMetadataInJSON `json:"identity"`
 	}
 
-	var c config
-	if err := json.Unmarshal(raw, &c); err != nil {
-		return nil, err
-	}
+	// webHookResponse is the reply of a web hook which has response parsing enabled.
+	webHookResponse struct {
+		// Messages are shown in the flow. If any are returned, the flow is not completed.
+		Messages []webHookResponseMessages `json:"messages"`
 
-	return &ApiKeyStrategy{
-		in:    c.In,
-		name:  c.Name,
-		value: c.Value,
-	}, nil
-}
-
-func (c *ApiKeyStrategy) apply(req *retryablehttp.Request) {
-	switch c.in {
-	case "cookie":
-		req.AddCookie(&http.Cookie{Name: c.name, Value: c.value})
-	default:
-		req.Header.Set(c.name, c.value)
+		// Identity replaces the traits and metadata of the identity before it is persisted.
+		Identity *webHookResponseIdentity `json:"identity"`
 	}
-}
-
-func newWebHookConfig(r json.RawMessage) (*WebHookConfig, error) {
-	type rawWebHookConfig struct {
-		Method string
-		Url    string
-		Body   string
-		Auth   struct {
-			Type   string
-			Config json.RawMessage
-		}
+	webHookResponseMessages struct {
+		InstancePtr string        `json:"instance_ptr"`
+		Messages    text.Messages `json:"messages"`
 	}
-
-	var rc rawWebHookConfig
-	err := json.Unmarshal(r, &rc)
-	if err != nil {
-		return nil, err
+	webHookResponseIdentity struct {
+		Traits         identity.Traits          `json:"traits"`
+		MetadataPublic sqlxx.NullJSONRawMessage `json:"metadata_public"`
+		MetadataAdmin  sqlxx.NullJSONRawMessage `json:"metadata_admin"`
 	}
 
-	as, err := newAuthStrategy(rc.Auth.Type, rc.Auth.Config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create web hook auth strategy: %w", err)
+	WebHook struct {
+		r webHookDependencies
+		c json.RawMessage
 	}
-
-	return &WebHookConfig{
-		Method:      rc.Method,
-		URL:         rc.Url,
-		TemplateURI: rc.Body,
-		Auth:        as,
-	}, nil
-}
+)
 
 func NewWebHook(r webHookDependencies, c json.RawMessage) *WebHook {
 	return &WebHook{r: r, c: c}
@@ -196,17 +99,17 @@ func (e *WebHook) ExecuteLoginPostHook(_ http.ResponseWriter, req *http.Request,
 		RequestHeaders: req.Header,
 		RequestMethod:  req.Method,
 		RequestUrl:     req.RequestURI,
-		Identity:       session.Identity,
+		Identity:       (*identity.WithAdminMetadataInJSON)(session.Identity),
 	})
 }
 
-func (e *WebHook) ExecutePostVerificationHook(_ http.ResponseWriter, req *http.Request, flow *verification.Flow, identity *identity.Identity) error {
+func (e *WebHook) ExecutePostVerificationHook(_ http.ResponseWriter, req *http.Request, flow *verification.Flow, i *identity.Identity) error {
 	return e.execute(req.Context(), &templateContext{
 		Flow:           flow,
 		RequestHeaders: req.Header,
 		RequestMethod:  req.Method,
 		RequestUrl:     req.RequestURI,
-		Identity:       identity,
+		Identity:       (*identity.WithAdminMetadataInJSON)(i),
 	})
 }
 
@@ -216,7 +119,7 @@ func (e *WebHook) ExecutePostRecoveryHook(_ http.ResponseWriter, req *http.Reque
 		RequestHeaders: req.Header,
 		RequestMethod:  req.Method,
 		RequestUrl:     req.RequestURI,
-		Identity:       session.Identity,
+		Identity:       (*identity.WithAdminMetadataInJSON)(session.Identity),
 	})
 }
 
@@ -229,103 +132,210 @@ func (e *WebHook) ExecuteRegistrationPreHook(_ http.ResponseWriter, req *http.Re
 	})
 }
 
+func (e *WebHook) ExecutePostRegistrationPrePersistHook(_ http.ResponseWriter, req *http.Request, flow *registration.Flow, i *identity.Identity) error {
+	if !e.parsesResponse() {
+		return nil
+	}
+
+	return e.executeAndParse(req.Context(), &templateContext{
+		Flow:           flow,
+		RequestHeaders: req.Header,
+		RequestMethod:  req.Method,
+		RequestUrl:     req.RequestURI,
+		Identity:       (*identity.WithAdminMetadataInJSON)(i),
+	}, i)
+}
+
 func (e *WebHook) ExecutePostRegistrationPostPersistHook(_ http.ResponseWriter, req *http.Request, flow *registration.Flow, session *session.Session) error {
+	if e.parsesResponse() {
+		// The hook was already called before the identity was persisted.
+		return nil
+	}
+
 	return e.execute(req.Context(), &templateContext{
 		Flow:           flow,
 		RequestHeaders: req.Header,
 		RequestMethod:  req.Method,
 		RequestUrl:     req.RequestURI,
-		Identity:       session.Identity,
+		Identity:       (*identity.WithAdminMetadataInJSON)(session.Identity),
 	})
 }
 
-func (e *WebHook) ExecuteSettingsPostPersistHook(_ http.ResponseWriter, req *http.Request, flow *settings.Flow, identity *identity.Identity) error {
+func (e *WebHook) ExecuteSettingsPrePersistHook(_ http.ResponseWriter, req *http.Request, flow *settings.Flow, i *identity.Identity) error {
+	if !e.parsesResponse() {
+		return nil
+	}
+
+	return e.executeAndParse(req.Context(), &templateContext{
+		Flow:           flow,
+		RequestHeaders: req.Header,
+		RequestMethod:  req.Method,
+		RequestUrl:     req.RequestURI,
+		Identity:       (*identity.WithAdminMetadataInJSON)(i),
+	}, i)
+}
+
+func (e *WebHook) ExecuteSettingsPostPersistHook(_ http.ResponseWriter, req *http.Request, flow *settings.Flow, i *identity.Identity) error {
+	if e.parsesResponse() {
+		// The hook was already called before the identity was persisted.
+		return nil
+	}
+
 	return e.execute(req.Context(), &templateContext{
 		Flow:           flow,
 		RequestHeaders: req.Header,
 		RequestMethod:  req.Method,
 		RequestUrl:     req.RequestURI,
-		Identity:       identity,
+		Identity:       (*identity.WithAdminMetadataInJSON)(i),
 	})
 }
 
+// parsesResponse returns true if the web hook is configured to parse the response. Such web hooks are called
+// before the identity is persisted so that they can reject the flow or change the identity. The response of
+// asynchronous web hooks is never parsed.
+func (e *WebHook) parsesResponse() bool {
+	return gjson.GetBytes(e.c, "response.parse").Bool() && !e.isAsync()
+}
+
+// isAsync returns true if the web hook's requests are queued and delivered by the web hook worker.
+func (e *WebHook) isAsync() bool {
+	return gjson.GetBytes(e.c, "async").Bool()
+}
+
 func (e *WebHook) execute(ctx context.Context, data *templateContext) error {
-	// TODO: reminder for the future: move parsing of config to the web hook initialization
-	conf, err := newWebHookConfig(e.c)
+	builder, err := request.NewBuilder(e.c, e.r.Logger(), e.r.RequestAuthCache(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to parse web hook config: %w", err)
 	}
 
-	var body io.Reader
-	if conf.Method != "TRACE" {
-		// According to the HTTP spec any request method, but TRACE is allowed to
-		// have a body. Even this is a really bad practice for some of them, like for
-		// GET
-		body, err = createBody(e.r.Logger(), conf.TemplateURI, data)
+	if e.isAsync() {
+		// Queued requests are authenticated and signed by the web hook worker when they are delivered.
+		req, err := builder.BuildUnauthenticatedRequest(data)
 		if err != nil {
-			return fmt.Errorf("failed to create web hook body: %w", err)
+			return fmt.Errorf("failed to create web hook request: %w", err)
 		}
-	}
 
-	if body == nil {
-		body = bytes.NewReader(make([]byte, 0))
+		var identityID uuid.UUID
+		if data.Identity != nil {
+			identityID = data.Identity.ID
+		}
+		if _, err := e.r.WebHookDispatcher().Enqueue(ctx, req, builder.Config.RawAuth, identityID); err != nil {
+			return fmt.Errorf("failed to queue web hook %w", err)
+		}
+		return nil
 	}
 
-	httpClient := e.r.HTTPClient(ctx)
+	req, err := e.buildRequest(ctx, builder, data)
+	if err != nil {
+		return err
+	}
 
-	if err = doHttpCall(conf.Method, conf.URL, conf.Auth, body, httpClient); err != nil {
+	if err = doHttpCall(req, builder.Client(e.r.HTTPClient(ctx))); err != nil {
 		return fmt.Errorf("failed to call web hook %w", err)
 	}
 	return nil
 }
 
-func createBody(l *logrusx.Logger, templateURI string, data *templateContext) (*bytes.Reader, error) {
-	if len(templateURI) == 0 {
-		return bytes.NewReader(make([]byte, 0)), nil
+// executeAndParse calls the web hook and applies its response to the identity. If the response contains
+// messages, a validation error is returned which renders the messages in the flow.
+func (e *WebHook) executeAndParse(ctx context.Context, data *templateContext, i *identity.Identity) error {
+	builder, err := request.NewBuilder(e.c, e.r.Logger(), e.r.RequestAuthCache(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to parse web hook config: %w", err)
+	}
+
+	req, err := e.buildRequest(ctx, builder, data)
+	if err != nil {
+		return err
 	}
 
-	f := fetcher.NewFetcher()
+	resp, err := builder.Client(e.r.HTTPClient(ctx)).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call web hook %w", err)
+	}
+	defer resp.Body.Close()
 
-	template, err := f.Fetch(templateURI)
-	if errors.Is(err, fetcher.ErrUnknownScheme) {
-		// legacy filepath
-		templateURI = "file://" + templateURI
-		l.WithError(err).Warnf("support for filepaths without a 'file://' scheme will be dropped in the next release, please use %s instead in your config", templateURI)
-		template, err = f.Fetch(templateURI)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, webHookResponseMaxSize))
+	if err != nil {
+		return fmt.Errorf("failed to read web hook response %w", err)
 	}
-	// this handles the first error if it is a known scheme error, or the second fetch error
+
+	return parseWebHookResponse(resp.StatusCode, body, i)
+}
+
+// buildRequest renders, authenticates and signs a web hook request which is sent immediately.
+func (e *WebHook) buildRequest(ctx context.Context, builder *request.Builder, data *templateContext) (*retryablehttp.Request, error) {
+	req, err := builder.BuildRequest(data)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to create web hook request: %w", err)
 	}
 
-	vm := jsonnet.MakeVM()
+	if err := webhook.SignRequest(req, e.r.Config(ctx).SecretsDefault()[0], time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to create web hook request: %w", err)
+	}
+	return req, nil
+}
+
+// webHookResponseMaxSize limits how much of a web hook response is read.
+const webHookResponseMaxSize = 1 << 20
+
+func parseWebHookResponse(statusCode int, body []byte, i *identity.Identity) error {
+	var parsed webHookResponse
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			if statusCode >= 400 {
+				return fmt.Errorf("web hook failed with status code %v", statusCode)
+			}
+			return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The web hook returned an invalid response: %s", err))
+		}
+	}
 
-	buf := new(bytes.Buffer)
-	enc := json.NewEncoder(buf)
-	enc.SetEscapeHTML(false)
-	enc.SetIndent("", "")
+	var validations schema.ValidationListError
+	for _, m := range parsed.Messages {
+		details := make(text.Messages, len(m.Messages))
+		for k, detail := range m.Messages {
+			if detail.ID == 0 {
+				detail.ID = text.ErrorValidationGeneric
+			}
+			if detail.Type == "" {
+				detail.Type = text.Error
+			}
+			details[k] = detail
+		}
+		if len(details) == 0 {
+			continue
+		}
 
-	if err := enc.Encode(data); err != nil {
-		return nil, err
+		instancePtr := m.InstancePtr
+		if instancePtr == "" {
+			instancePtr = "#/"
+		}
+		validations.Add(instancePtr, details[0].Text, details)
+	}
+	if validations.HasErrors() {
+		return errors.WithStack(&validations)
 	}
-	vm.TLACode("ctx", buf.String())
 
-	if res, err := vm.EvaluateAnonymousSnippet(templateURI, template.String()); err != nil {
-		return nil, err
-	} else {
-		return bytes.NewReader([]byte(res)), nil
+	if statusCode >= 400 {
+		return fmt.Errorf("web hook failed with status code %v", statusCode)
 	}
-}
 
-func doHttpCall(method string, url string, as AuthStrategy, body io.Reader, hc *retryablehttp.Client) error {
-	req, err := retryablehttp.NewRequest(method, url, body)
-	if err != nil {
-		return err
+	if parsed.Identity != nil {
+		if len(parsed.Identity.Traits) > 0 {
+			i.Traits = parsed.Identity.Traits
+		}
+		if len(parsed.Identity.MetadataPublic) > 0 {
+			i.MetadataPublic = parsed.Identity.MetadataPublic
+		}
+		if len(parsed.Identity.MetadataAdmin) > 0 {
+			i.MetadataAdmin = parsed.Identity.MetadataAdmin
+		}
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	as.apply(req)
+	return nil
+}
 
+func doHttpCall(req *retryablehttp.Request, hc *retryablehttp.Client) error {
 	resp, err := hc.Do(req)
 
 	if err != nil {
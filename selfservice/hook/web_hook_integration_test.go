@@ -1,6 +1,7 @@
 package hook_test
 
 import (
+	"context"
 	_ "embed"
 	"encoding/base64"
 	"encoding/json"
@@ -10,12 +11,18 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ory/x/sqlxx"
+
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/hook"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/webhook"
 
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/flow/registration"
@@ -293,6 +300,222 @@ func TestWebHooks(t *testing.T) {
 		})
 	}
 
+	t.Run("Must pass the identity's metadata to the template", func(t *testing.T) {
+		whr := &WebHookRequest{}
+		ts := newServer(webHookEndPoint(whr))
+		req := &http.Request{
+			Header:     map[string][]string{"Some-Header": {"Some-Value"}},
+			RequestURI: "https://www.ory.sh/some_end_point",
+			Method:     http.MethodPost,
+		}
+		s := &session.Session{ID: x.NewUUID(), Identity: &identity.Identity{
+			ID:             x.NewUUID(),
+			MetadataPublic: sqlxx.NullJSONRawMessage(`{"plan":"pro"}`),
+			MetadataAdmin:  sqlxx.NullJSONRawMessage(`{"risk":"low"}`),
+			Credentials:    map[identity.CredentialsType]identity.Credentials{identity.CredentialsTypePassword: {}},
+		}}
+		f := &login.Flow{ID: x.NewUUID()}
+		conf := json.RawMessage(fmt.Sprintf(`{
+					"url": "%s",
+					"method": "%s",
+					"body": "%s"
+				}`, ts.URL+path, "POST", "./stub/metadata_body.jsonnet"))
+		wh := hook.NewWebHook(reg, conf)
+
+		require.NoError(t, wh.ExecuteLoginPostHook(nil, req, f, s))
+		assert.JSONEq(t, fmt.Sprintf(`{
+					"identity_id": "%s",
+					"plan": "pro",
+					"risk": "low",
+					"has_credentials": false
+				}`, s.Identity.ID), whr.Body)
+	})
+
+	t.Run("Must parse the response if configured", func(t *testing.T) {
+		webHookResponseEndPoint := func(code int, body string, called *int) httprouter.Handle {
+			return func(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+				*called++
+				w.WriteHeader(code)
+				_, _ = w.Write([]byte(body))
+			}
+		}
+
+		req := &http.Request{
+			Header:     map[string][]string{"Some-Header": {"Some-Value"}},
+			RequestURI: "https://www.ory.sh/some_end_point",
+			Method:     http.MethodPost,
+		}
+		newHook := func(t *testing.T, code int, body string, parse bool) (*hook.WebHook, *int) {
+			var called int
+			ts := newServer(webHookResponseEndPoint(code, body, &called))
+			return hook.NewWebHook(reg, json.RawMessage(fmt.Sprintf(`{
+					"url": "%s",
+					"method": "POST",
+					"body": "./stub/test_body.jsonnet",
+					"response": {"parse": %t}
+				}`, ts.URL+path, parse))), &called
+		}
+
+		for _, tc := range []struct {
+			uc          string
+			prePersist  func(wh *hook.WebHook, i *identity.Identity) error
+			postPersist func(wh *hook.WebHook, i *identity.Identity) error
+		}{
+			{
+				uc: "registration",
+				prePersist: func(wh *hook.WebHook, i *identity.Identity) error {
+					return wh.ExecutePostRegistrationPrePersistHook(nil, req, &registration.Flow{ID: x.NewUUID()}, i)
+				},
+				postPersist: func(wh *hook.WebHook, i *identity.Identity) error {
+					return wh.ExecutePostRegistrationPostPersistHook(nil, req, &registration.Flow{ID: x.NewUUID()}, &session.Session{ID: x.NewUUID(), Identity: i})
+				},
+			},
+			{
+				uc: "settings",
+				prePersist: func(wh *hook.WebHook, i *identity.Identity) error {
+					return wh.ExecuteSettingsPrePersistHook(nil, req, &settings.Flow{ID: x.NewUUID()}, i)
+				},
+				postPersist: func(wh *hook.WebHook, i *identity.Identity) error {
+					return wh.ExecuteSettingsPostPersistHook(nil, req, &settings.Flow{ID: x.NewUUID()}, i)
+				},
+			},
+		} {
+			t.Run("flow="+tc.uc, func(t *testing.T) {
+				t.Run("case=is only called after persisting without response parsing", func(t *testing.T) {
+					wh, called := newHook(t, http.StatusOK, `{"identity":{"traits":{"foo":"bar"}}}`, false)
+					i := &identity.Identity{ID: x.NewUUID(), Traits: identity.Traits(`{}`)}
+
+					require.NoError(t, tc.prePersist(wh, i))
+					assert.Equal(t, 0, *called)
+					require.NoError(t, tc.postPersist(wh, i))
+					assert.Equal(t, 1, *called)
+					assert.JSONEq(t, `{}`, string(i.Traits))
+				})
+
+				t.Run("case=is only called before persisting with response parsing", func(t *testing.T) {
+					wh, called := newHook(t, http.StatusOK, ``, true)
+					i := &identity.Identity{ID: x.NewUUID(), Traits: identity.Traits(`{}`)}
+
+					require.NoError(t, tc.prePersist(wh, i))
+					assert.Equal(t, 1, *called)
+					require.NoError(t, tc.postPersist(wh, i))
+					assert.Equal(t, 1, *called)
+					assert.JSONEq(t, `{}`, string(i.Traits))
+				})
+
+				t.Run("case=patches the identity", func(t *testing.T) {
+					wh, _ := newHook(t, http.StatusOK, `{"identity":{"traits":{"foo":"bar"},"metadata_admin":{"risk":"low"}}}`, true)
+					i := &identity.Identity{
+						ID:             x.NewUUID(),
+						Traits:         identity.Traits(`{}`),
+						MetadataPublic: sqlxx.NullJSONRawMessage(`{"plan":"pro"}`),
+					}
+
+					require.NoError(t, tc.prePersist(wh, i))
+					assert.JSONEq(t, `{"foo":"bar"}`, string(i.Traits))
+					assert.JSONEq(t, `{"plan":"pro"}`, string(i.MetadataPublic))
+					assert.JSONEq(t, `{"risk":"low"}`, string(i.MetadataAdmin))
+				})
+
+				t.Run("case=returns validation messages", func(t *testing.T) {
+					wh, _ := newHook(t, http.StatusUnprocessableEntity, `{
+						"messages": [
+							{"instance_ptr": "#/traits/email", "messages": [{"id": 1234, "text": "email is blocked", "type": "error", "context": {"reason": "blocked"}}]},
+							{"messages": [{"text": "please try again later"}]}
+						],
+						"identity": {"traits": {"foo":"bar"}}
+					}`, true)
+					i := &identity.Identity{ID: x.NewUUID(), Traits: identity.Traits(`{}`)}
+
+					err := tc.prePersist(wh, i)
+					var validations *schema.ValidationListError
+					require.ErrorAs(t, err, &validations)
+					require.Len(t, validations.Validations, 2)
+
+					assert.Equal(t, "#/traits/email", validations.Validations[0].InstancePtr)
+					assert.Equal(t, text.Messages{{ID: 1234, Text: "email is blocked", Type: text.Error, Context: json.RawMessage(`{"reason": "blocked"}`)}}, validations.Validations[0].Messages)
+					assert.Equal(t, "#/", validations.Validations[1].InstancePtr)
+					assert.Equal(t, text.Messages{*text.NewValidationErrorGeneric("please try again later")}, validations.Validations[1].Messages)
+
+					assert.JSONEq(t, `{}`, string(i.Traits), "the identity is not patched if the hook returned messages")
+				})
+
+				t.Run("case=fails on error status codes", func(t *testing.T) {
+					for _, body := range []string{``, `not json`, `{}`} {
+						wh, _ := newHook(t, http.StatusForbidden, body, true)
+						err := tc.prePersist(wh, &identity.Identity{ID: x.NewUUID()})
+						require.Error(t, err)
+						assert.Contains(t, err.Error(), "web hook failed with status code 403")
+					}
+				})
+
+				t.Run("case=fails on invalid responses", func(t *testing.T) {
+					wh, _ := newHook(t, http.StatusOK, `not json`, true)
+					require.Error(t, tc.prePersist(wh, &identity.Identity{ID: x.NewUUID()}))
+				})
+			})
+		}
+	})
+
+	t.Run("Must sign the request", func(t *testing.T) {
+		whr := &WebHookRequest{}
+		ts := newServer(webHookEndPoint(whr))
+		req := &http.Request{
+			Header:     map[string][]string{"Some-Header": {"Some-Value"}},
+			RequestURI: "https://www.ory.sh/some_end_point",
+			Method:     http.MethodPost,
+		}
+		f := &login.Flow{ID: x.NewUUID()}
+		wh := hook.NewWebHook(reg, json.RawMessage(fmt.Sprintf(`{
+					"url": "%s",
+					"method": "POST",
+					"body": "./stub/test_body.jsonnet"
+				}`, ts.URL+path)))
+
+		require.NoError(t, wh.ExecuteLoginPreHook(nil, req, f))
+		require.NoError(t, webhook.VerifySignature(reg.Config(context.Background()).SecretsDefault(), whr.Headers.Get(webhook.SignatureHeader), []byte(whr.Body), time.Minute, time.Now()))
+	})
+
+	t.Run("Must queue the request if asynchronous", func(t *testing.T) {
+		ctx := context.Background()
+		whr := &WebHookRequest{}
+		ts := newServer(webHookEndPoint(whr))
+		req := &http.Request{
+			Header:     map[string][]string{"Some-Header": {"Some-Value"}},
+			RequestURI: "https://www.ory.sh/some_end_point",
+			Method:     http.MethodPost,
+		}
+		s := &session.Session{ID: x.NewUUID(), Identity: &identity.Identity{ID: x.NewUUID()}}
+		f := &registration.Flow{ID: x.NewUUID()}
+		wh := hook.NewWebHook(reg, json.RawMessage(fmt.Sprintf(`{
+					"url": "%s",
+					"method": "POST",
+					"body": "./stub/test_body.jsonnet",
+					"async": true,
+					"response": {"parse": true}
+				}`, ts.URL+path)))
+
+		i := &identity.Identity{ID: x.NewUUID()}
+		require.NoError(t, wh.ExecutePostRegistrationPrePersistHook(nil, req, f, i), "asynchronous web hooks never parse the response")
+		_, err := reg.WebHookPersister().NextDeliveries(ctx, 10, time.Now(), time.Now().Add(time.Minute))
+		require.ErrorIs(t, err, webhook.ErrQueueEmpty)
+
+		require.NoError(t, wh.ExecutePostRegistrationPostPersistHook(nil, req, f, s))
+		assert.Empty(t, whr.Method, "the web hook is not called immediately")
+
+		deliveries, err := reg.WebHookPersister().NextDeliveries(ctx, 10, time.Now(), time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, ts.URL+path, deliveries[0].URL)
+		assert.Equal(t, "POST", deliveries[0].Method)
+		assert.Equal(t, "application/json", http.Header(deliveries[0].Headers).Get("Content-Type"))
+		assert.JSONEq(t, bodyWithFlowAndIdentity(req, f, s), deliveries[0].Body)
+
+		require.NoError(t, reg.WebHookDispatcher().Deliver(ctx, deliveries[0]))
+		assert.JSONEq(t, bodyWithFlowAndIdentity(req, f, s), whr.Body)
+		require.NoError(t, webhook.VerifySignature(reg.Config(ctx).SecretsDefault(), whr.Headers.Get(webhook.SignatureHeader), []byte(whr.Body), time.Minute, time.Now()))
+	})
+
 	t.Run("Must error when config is erroneous", func(t *testing.T) {
 		req := &http.Request{
 			Header:     map[string][]string{"Some-Header": {"Some-Value"}},
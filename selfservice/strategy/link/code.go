@@ -0,0 +1,22 @@
+package link
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/randx"
+)
+
+// CodeLength is the number of digits of a one-time code.
+const CodeLength = 6
+
+var (
+	// ErrCodeInvalid is returned if a submitted one-time code does not match any active code of the flow.
+	ErrCodeInvalid = errors.New("the submitted code is invalid")
+
+	// ErrCodeAttemptsExceeded is returned if too many wrong codes were submitted for a flow.
+	ErrCodeAttemptsExceeded = errors.New("too many wrong codes were submitted")
+)
+
+func newCode() string {
+	return randx.MustString(CodeLength, randx.Numeric)
+}
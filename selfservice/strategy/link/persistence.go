@@ -2,12 +2,15 @@ package link
 
 import (
 	"context"
+
+	"github.com/gofrs/uuid"
 )
 
 type (
 	RecoveryTokenPersister interface {
 		CreateRecoveryToken(ctx context.Context, token *RecoveryToken) error
 		UseRecoveryToken(ctx context.Context, token string) (*RecoveryToken, error)
+		UseRecoveryCode(ctx context.Context, flowID uuid.UUID, code string, maxAttempts int) (*RecoveryToken, error)
 		DeleteRecoveryToken(ctx context.Context, token string) error
 	}
 
@@ -18,10 +21,22 @@ type (
 	VerificationTokenPersister interface {
 		CreateVerificationToken(ctx context.Context, token *VerificationToken) error
 		UseVerificationToken(ctx context.Context, token string) (*VerificationToken, error)
+		UseVerificationCode(ctx context.Context, flowID uuid.UUID, code string, maxAttempts int) (*VerificationToken, error)
 		DeleteVerificationToken(ctx context.Context, token string) error
 	}
 
 	VerificationTokenPersistenceProvider interface {
 		VerificationTokenPersister() VerificationTokenPersister
 	}
+
+	LoginTokenPersister interface {
+		CreateLoginToken(ctx context.Context, token *LoginToken) error
+		UseLoginToken(ctx context.Context, token string) (*LoginToken, error)
+		UseLoginCode(ctx context.Context, flowID uuid.UUID, code string, maxAttempts int) (*LoginToken, error)
+		DeleteLoginToken(ctx context.Context, token string) error
+	}
+
+	LoginTokenPersistenceProvider interface {
+		LoginTokenPersister() LoginTokenPersister
+	}
 )
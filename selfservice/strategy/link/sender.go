@@ -6,6 +6,7 @@ import (
 	"net/url"
 
 	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
 
 	"github.com/ory/x/errorsx"
 	"github.com/ory/x/sqlcon"
@@ -15,6 +16,7 @@ import (
 	templates "github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/x"
@@ -31,6 +33,7 @@ type (
 
 		VerificationTokenPersistenceProvider
 		RecoveryTokenPersistenceProvider
+		LoginTokenPersistenceProvider
 	}
 
 	SenderProvider interface {
@@ -57,9 +60,15 @@ func (s *Sender) SendRecoveryLink(ctx context.Context, r *http.Request, f *recov
 		WithSensitiveField("address", to).
 		Debug("Preparing verification code.")
 
-	address, err := s.r.IdentityPool().FindRecoveryAddressByValue(ctx, identity.RecoveryAddressTypeEmail, to)
+	if via == identity.VerifiableAddressTypePhone {
+		if phone, err := identity.NormalizePhoneNumber(to, s.r.Config(ctx).IdentityPhoneDefaultRegion()); err == nil {
+			to = phone
+		}
+	}
+
+	address, err := s.r.IdentityPool().FindRecoveryAddressByValue(ctx, identity.RecoveryAddressType(via), to)
 	if err != nil {
-		if err := s.send(ctx, string(via), templates.NewRecoveryInvalid(s.r.Config(ctx), &templates.RecoveryInvalidModel{To: to})); err != nil {
+		if err := s.send(ctx, string(via), templates.NewRecoveryInvalid(s.r.Config(ctx), &templates.RecoveryInvalidModel{To: to, Locale: s.locale(ctx, r, nil)})); err != nil {
 			return err
 		}
 		return errors.Cause(ErrUnknownAddress)
@@ -72,11 +81,14 @@ func (s *Sender) SendRecoveryLink(ctx context.Context, r *http.Request, f *recov
 	}
 
 	token := NewSelfServiceRecoveryToken(address, f, s.r.Config(r.Context()).SelfServiceLinkMethodLifespan())
+	if s.r.Config(ctx).SelfServiceLinkMethodCodeEnabled() {
+		token.Code = newCode()
+	}
 	if err := s.r.RecoveryTokenPersister().CreateRecoveryToken(ctx, token); err != nil {
 		return err
 	}
 
-	if err := s.SendRecoveryTokenTo(ctx, f, i, address, token); err != nil {
+	if err := s.SendRecoveryTokenTo(ctx, r, f, i, address, token); err != nil {
 		return err
 	}
 
@@ -86,12 +98,18 @@ func (s *Sender) SendRecoveryLink(ctx context.Context, r *http.Request, f *recov
 // SendVerificationLink sends a verification link to the specified address. If the address does not exist in the store, an email is
 // still being sent to prevent account enumeration attacks. In that case, this function returns the ErrUnknownAddress
 // error.
-func (s *Sender) SendVerificationLink(ctx context.Context, f *verification.Flow, via identity.VerifiableAddressType, to string) error {
+func (s *Sender) SendVerificationLink(ctx context.Context, r *http.Request, f *verification.Flow, via identity.VerifiableAddressType, to string) error {
 	s.r.Logger().
 		WithField("via", via).
 		WithSensitiveField("address", to).
 		Debug("Preparing verification code.")
 
+	if via == identity.VerifiableAddressTypePhone {
+		if phone, err := identity.NormalizePhoneNumber(to, s.r.Config(ctx).IdentityPhoneDefaultRegion()); err == nil {
+			to = phone
+		}
+	}
+
 	address, err := s.r.IdentityPool().FindVerifiableAddressByValue(ctx, via, to)
 	if err != nil {
 		if errorsx.Cause(err) == sqlcon.ErrNoRows {
@@ -99,7 +117,7 @@ func (s *Sender) SendVerificationLink(ctx context.Context, f *verification.Flow,
 				WithField("via", via).
 				WithSensitiveField("email_address", address).
 				Info("Sending out invalid verification email because address is unknown.")
-			if err := s.send(ctx, string(via), templates.NewVerificationInvalid(s.r.Config(ctx), &templates.VerificationInvalidModel{To: to})); err != nil {
+			if err := s.send(ctx, string(via), templates.NewVerificationInvalid(s.r.Config(ctx), &templates.VerificationInvalidModel{To: to, Locale: s.locale(ctx, r, nil)})); err != nil {
 				return err
 			}
 			return errors.Cause(ErrUnknownAddress)
@@ -114,17 +132,79 @@ func (s *Sender) SendVerificationLink(ctx context.Context, f *verification.Flow,
 	}
 
 	token := NewSelfServiceVerificationToken(address, f, s.r.Config(ctx).SelfServiceLinkMethodLifespan())
+	if s.r.Config(ctx).SelfServiceLinkMethodCodeEnabled() {
+		token.Code = newCode()
+	}
 	if err := s.r.VerificationTokenPersister().CreateVerificationToken(ctx, token); err != nil {
 		return err
 	}
 
-	if err := s.SendVerificationTokenTo(ctx, f, i, address, token); err != nil {
+	if err := s.SendVerificationTokenTo(ctx, r, f, i, address, token); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *Sender) SendRecoveryTokenTo(ctx context.Context, f *recovery.Flow, i *identity.Identity, address *identity.RecoveryAddress, token *RecoveryToken) error {
+// SendLoginLink sends a sign-in link and code to the specified email address. If the address is unknown or has not been
+// verified, an email is still being sent to prevent account enumeration attacks. In that case, this function returns the
+// ErrUnknownAddress error.
+func (s *Sender) SendLoginLink(ctx context.Context, r *http.Request, f *login.Flow, to string) error {
+	s.r.Logger().
+		WithField("via", identity.VerifiableAddressTypeEmail).
+		WithSensitiveField("address", to).
+		Debug("Preparing sign-in link.")
+
+	address, err := s.r.IdentityPool().FindVerifiableAddressByValue(ctx, identity.VerifiableAddressTypeEmail, to)
+	if err != nil && errorsx.Cause(err) != sqlcon.ErrNoRows {
+		return err
+	} else if err != nil || !address.Verified {
+		s.r.Audit().
+			WithField("via", identity.VerifiableAddressTypeEmail).
+			WithSensitiveField("email_address", to).
+			Info("Sending out invalid sign-in email because address is unknown or not verified.")
+		if err := s.send(ctx, identity.AddressTypeEmail, templates.NewLoginInvalid(s.r.Config(ctx), &templates.LoginInvalidModel{To: to, Locale: s.locale(ctx, r, nil)})); err != nil {
+			return err
+		}
+		return errors.Cause(ErrUnknownAddress)
+	}
+
+	i, err := s.r.IdentityPool().GetIdentity(ctx, address.IdentityID)
+	if err != nil {
+		return err
+	}
+
+	token := NewLoginToken(address, f, s.r.Config(ctx).SelfServiceMagicLinkMethodLifespan())
+	if err := s.r.LoginTokenPersister().CreateLoginToken(ctx, token); err != nil {
+		return err
+	}
+
+	return s.SendLoginTokenTo(ctx, r, f, i, address, token)
+}
+
+func (s *Sender) SendLoginTokenTo(ctx context.Context, r *http.Request, f *login.Flow, i *identity.Identity, address *identity.VerifiableAddress, token *LoginToken) error {
+	s.r.Audit().
+		WithField("via", address.Via).
+		WithField("identity_id", address.IdentityID).
+		WithField("login_link_id", token.ID).
+		WithSensitiveField("email_address", address.Value).
+		WithSensitiveField("login_link_token", token.Token).
+		Info("Sending out sign-in email with sign-in link.")
+
+	model, err := x.StructToMap(i)
+	if err != nil {
+		return err
+	}
+
+	return s.send(ctx, string(address.Via), templates.NewLoginValid(s.r.Config(ctx),
+		&templates.LoginValidModel{To: address.Value, LoginURL: urlx.CopyWithQuery(
+			urlx.AppendPaths(s.r.Config(ctx).SelfServiceLinkMethodBaseURL(), login.RouteSubmitFlow),
+			url.Values{
+				"flow":  {f.ID.String()},
+				"token": {token.Token},
+			}).String(), LoginCode: token.Code, Identity: model, Locale: s.locale(ctx, r, i)}))
+}
+
+func (s *Sender) SendRecoveryTokenTo(ctx context.Context, r *http.Request, f *recovery.Flow, i *identity.Identity, address *identity.RecoveryAddress, token *RecoveryToken) error {
 	s.r.Audit().
 		WithField("via", address.Via).
 		WithField("identity_id", address.IdentityID).
@@ -138,16 +218,21 @@ func (s *Sender) SendRecoveryTokenTo(ctx context.Context, f *recovery.Flow, i *i
 		return err
 	}
 
+	if len(token.Code) > 0 {
+		return s.send(ctx, string(address.Via), templates.NewRecoveryCodeValid(s.r.Config(ctx),
+			&templates.RecoveryCodeValidModel{To: address.Value, RecoveryCode: token.Code, Identity: model, Locale: s.locale(ctx, r, i)}))
+	}
+
 	return s.send(ctx, string(address.Via), templates.NewRecoveryValid(s.r.Config(ctx),
 		&templates.RecoveryValidModel{To: address.Value, RecoveryURL: urlx.CopyWithQuery(
 			urlx.AppendPaths(s.r.Config(ctx).SelfServiceLinkMethodBaseURL(), recovery.RouteSubmitFlow),
 			url.Values{
 				"token": {token.Token},
 				"flow":  {f.ID.String()},
-			}).String(), Identity: model}))
+			}).String(), Identity: model, Locale: s.locale(ctx, r, i)}))
 }
 
-func (s *Sender) SendVerificationTokenTo(ctx context.Context, f *verification.Flow, i *identity.Identity, address *identity.VerifiableAddress, token *VerificationToken) error {
+func (s *Sender) SendVerificationTokenTo(ctx context.Context, r *http.Request, f *verification.Flow, i *identity.Identity, address *identity.VerifiableAddress, token *VerificationToken) error {
 	s.r.Audit().
 		WithField("via", address.Via).
 		WithField("identity_id", address.IdentityID).
@@ -161,13 +246,19 @@ func (s *Sender) SendVerificationTokenTo(ctx context.Context, f *verification.Fl
 		return err
 	}
 
-	if err := s.send(ctx, string(address.Via), templates.NewVerificationValid(s.r.Config(ctx),
+	var t interface{} = templates.NewVerificationValid(s.r.Config(ctx),
 		&templates.VerificationValidModel{To: address.Value, VerificationURL: urlx.CopyWithQuery(
 			urlx.AppendPaths(s.r.Config(ctx).SelfServiceLinkMethodBaseURL(), verification.RouteSubmitFlow),
 			url.Values{
 				"flow":  {f.ID.String()},
 				"token": {token.Token},
-			}).String(), Identity: model})); err != nil {
+			}).String(), Identity: model, Locale: s.locale(ctx, r, i)})
+	if len(token.Code) > 0 {
+		t = templates.NewVerificationCodeValid(s.r.Config(ctx),
+			&templates.VerificationCodeValidModel{To: address.Value, VerificationCode: token.Code, Identity: model, Locale: s.locale(ctx, r, i)})
+	}
+
+	if err := s.send(ctx, string(address.Via), t); err != nil {
 		return err
 	}
 	address.Status = identity.VerifiableAddressStatusSent
@@ -177,10 +268,35 @@ func (s *Sender) SendVerificationTokenTo(ctx context.Context, f *verification.Fl
 	return nil
 }
 
-func (s *Sender) send(ctx context.Context, via string, t courier.EmailTemplate) error {
+// locale resolves the locale of emails sent to the given identity. The identity trait configured in
+// `courier.locale_trait` takes precedence over the `Accept-Language` header of the request which started the flow.
+func (s *Sender) locale(ctx context.Context, r *http.Request, i *identity.Identity) string {
+	if i != nil {
+		if trait := s.r.Config(ctx).CourierLocaleTrait(); len(trait) > 0 {
+			if locale := gjson.GetBytes(i.Traits, trait).String(); len(locale) > 0 {
+				return locale
+			}
+		}
+	}
+	return x.PreferredLocale(r)
+}
+
+// send queues the template as an email or a text message, depending on the type of the address it is sent to.
+func (s *Sender) send(ctx context.Context, via string, t interface{}) error {
 	switch via {
 	case identity.AddressTypeEmail:
-		_, err := s.r.Courier(ctx).QueueEmail(ctx, t)
+		et, ok := t.(courier.EmailTemplate)
+		if !ok {
+			return errors.Errorf("template %T can not be sent via %s", t, via)
+		}
+		_, err := s.r.Courier(ctx).QueueEmail(ctx, et)
+		return err
+	case identity.AddressTypePhone:
+		st, ok := t.(courier.SMSTemplate)
+		if !ok {
+			return errors.Errorf("template %T can not be sent via %s", t, via)
+		}
+		_, err := s.r.Courier(ctx).QueueSMS(ctx, st)
 		return err
 	default:
 		return errors.Errorf("received unexpected via type: %s", via)
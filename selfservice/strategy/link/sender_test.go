@@ -67,8 +67,8 @@ func TestManager(t *testing.T) {
 
 		require.NoError(t, reg.VerificationFlowPersister().CreateVerificationFlow(context.Background(), f))
 
-		require.NoError(t, reg.LinkSender().SendVerificationLink(context.Background(), f, "email", "tracked@ory.sh"))
-		require.EqualError(t, reg.LinkSender().SendVerificationLink(context.Background(), f, "email", "not-tracked@ory.sh"), link.ErrUnknownAddress.Error())
+		require.NoError(t, reg.LinkSender().SendVerificationLink(context.Background(), hr, f, "email", "tracked@ory.sh"))
+		require.EqualError(t, reg.LinkSender().SendVerificationLink(context.Background(), hr, f, "email", "not-tracked@ory.sh"), link.ErrUnknownAddress.Error())
 		messages, err := reg.CourierPersister().NextMessages(context.Background(), 12)
 		require.NoError(t, err)
 		require.Len(t, messages, 2)
@@ -86,4 +86,30 @@ func TestManager(t *testing.T) {
 		require.NoError(t, err)
 		assert.EqualValues(t, identity.VerifiableAddressStatusSent, address.Status)
 	})
+	t.Run("method=localized", func(t *testing.T) {
+		li := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		li.Traits = identity.Traits(`{"email": "localized@ory.sh", "locale": "de_AT"}`)
+		require.NoError(t, reg.IdentityManager().Create(context.Background(), li))
+
+		f, err := recovery.NewFlow(conf, time.Hour, "", u, reg.RecoveryStrategies(context.Background()), flow.TypeBrowser)
+		require.NoError(t, err)
+		require.NoError(t, reg.RecoveryFlowPersister().CreateRecoveryFlow(context.Background(), f))
+
+		lr := httptest.NewRequest("GET", "https://www.ory.sh", nil)
+		lr.Header.Set("Accept-Language", "fr-CH,fr;q=0.9")
+
+		require.NoError(t, reg.LinkSender().SendRecoveryLink(context.Background(), lr, f, "email", "localized@ory.sh"))
+		require.EqualError(t, reg.LinkSender().SendRecoveryLink(context.Background(), lr, f, "email", "not-tracked@ory.sh"), link.ErrUnknownAddress.Error())
+
+		messages, err := reg.CourierPersister().NextMessages(context.Background(), 12)
+		require.NoError(t, err)
+		require.Len(t, messages, 2)
+
+		assert.EqualValues(t, "localized@ory.sh", messages[0].Recipient)
+		assert.EqualValues(t, "de_AT", messages[0].Locale, "the identity's locale trait takes precedence")
+		assert.Contains(t, messages[0].Subject, "Recover access to your account", "falls back to the default template")
+
+		assert.EqualValues(t, "not-tracked@ory.sh", messages[1].Recipient)
+		assert.EqualValues(t, "fr_CH", messages[1].Locale, "uses the Accept-Language header for unknown addresses")
+	})
 }
@@ -4,14 +4,17 @@ import (
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/ratelimit"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/ui/container"
 	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/webhook"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/decoderx"
 )
@@ -40,6 +43,7 @@ type (
 
 		session.HandlerProvider
 		session.ManagementProvider
+		session.PersistenceProvider
 		settings.HandlerProvider
 		settings.FlowPersistenceProvider
 
@@ -62,11 +66,18 @@ type (
 		verification.StrategyProvider
 		verification.HookExecutorProvider
 
+		login.FlowPersistenceProvider
+
 		RecoveryTokenPersistenceProvider
 		VerificationTokenPersistenceProvider
+		LoginTokenPersistenceProvider
 		SenderProvider
 
 		schema.IdentityTraitsProvider
+
+		webhook.EmitterProvider
+
+		ratelimit.LimiterProvider
 	}
 
 	Strategy struct {
@@ -86,3 +97,16 @@ func (s *Strategy) RecoveryNodeGroup() node.Group {
 func (s *Strategy) VerificationNodeGroup() node.Group {
 	return node.VerificationLinkGroup
 }
+
+// upsertAddressNode shows the address a recovery or verification message was sent to. A phone number replaces
+// the email input.
+func upsertAddressNode(nodes *node.Nodes, group node.Group, via identity.VerifiableAddressType, value string) {
+	if via == identity.VerifiableAddressTypePhone {
+		nodes.Remove("email")
+		nodes.Upsert(node.NewInputField("phone", value, group, node.InputAttributeTypeText, node.WithRequiredInputAttribute))
+		return
+	}
+
+	// v0.5: form.Field{Name: "email", Type: "email", Required: true, Value: body.Body.Email}
+	nodes.Upsert(node.NewInputField("email", value, group, node.InputAttributeTypeEmail, node.WithRequiredInputAttribute))
+}
@@ -0,0 +1,245 @@
+package link
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/ratelimit"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+)
+
+var _ login.Strategy = new(Strategy)
+
+// submitSelfServiceLoginFlowWithMagicLinkMethodBody is used to decode the login form payload
+// when using the magic link method.
+//
+// swagger:model submitSelfServiceLoginFlowWithMagicLinkMethodBody
+type submitSelfServiceLoginFlowWithMagicLinkMethodBody struct {
+	// Method should be set to "magic_link" when requesting a sign-in link or submitting a sign-in code.
+	//
+	// required: true
+	Method string `json:"method" form:"method"`
+
+	// Email is the address the sign-in link and code should be sent to.
+	Email string `json:"email" form:"email"`
+
+	// Code is the one-time code which was sent to the email address.
+	Code string `json:"code" form:"code"`
+
+	// Token is the token of the sign-in link. It is set when the link is followed and
+	// should not be set when submitting the form.
+	Token string `json:"token" form:"token"`
+
+	// Sending the anti-csrf token is only required for browser login flows.
+	CSRFToken string `json:"csrf_token" form:"csrf_token"`
+}
+
+func (s *Strategy) ID() identity.CredentialsType {
+	return identity.CredentialsTypeMagicLink
+}
+
+func (s *Strategy) NodeGroup() node.Group {
+	return node.MagicLinkGroup
+}
+
+func (s *Strategy) RegisterLoginRoutes(_ *x.RouterPublic) {
+}
+
+func (s *Strategy) PopulateLoginMethod(r *http.Request, requestedAAL identity.AuthenticatorAssuranceLevel, f *login.Flow) error {
+	// This strategy can only solve AAL1
+	if requestedAAL > identity.AuthenticatorAssuranceLevel1 {
+		return nil
+	}
+
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	f.UI.SetNode(node.NewInputField("email", nil, node.MagicLinkGroup, node.InputAttributeTypeEmail).WithMetaLabel(text.NewInfoNodeInputEmail()))
+	f.UI.GetNodes().Append(node.NewInputField("method", s.ID(), node.MagicLinkGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoSelfServiceLoginMagicLink()))
+	return nil
+}
+
+func (s *Strategy) handleLoginError(r *http.Request, f *login.Flow, body *submitSelfServiceLoginFlowWithMagicLinkMethodBody, err error) error {
+	if f != nil {
+		email := ""
+		if body != nil {
+			email = body.Email
+		}
+
+		f.UI.Nodes.SetValueAttribute("email", email)
+		f.UI.Nodes.ResetNodes("code")
+		if f.Type == flow.TypeBrowser {
+			f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+		}
+	}
+
+	return err
+}
+
+func (s *Strategy) decodeLogin(r *http.Request) (*submitSelfServiceLoginFlowWithMagicLinkMethodBody, error) {
+	var body submitSelfServiceLoginFlowWithMagicLinkMethodBody
+
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(loginMethodSchema)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := s.dx.Decode(r, &body, compiler,
+		decoderx.HTTPDecoderUseQueryAndBody(),
+		decoderx.HTTPKeepRequestBody(true),
+		decoderx.HTTPDecoderAllowedMethods("POST", "GET"),
+		decoderx.HTTPDecoderSetValidatePayloads(false),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &body, nil
+}
+
+// Login handles all three steps of the magic link method:
+//
+// - If the flow is submitted with an email address, a sign-in link and code are sent to that address.
+// - If the flow is submitted with a code, the code is checked and the login is completed.
+// - If the sign-in link is followed, the token is checked and the login is completed.
+func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow, _ *session.Session) (*identity.Identity, error) {
+	if err := login.CheckAAL(f, identity.AuthenticatorAssuranceLevel1); err != nil {
+		return nil, err
+	}
+
+	body, err := s.decodeLogin(r)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, body, err)
+	}
+
+	// Sign-in links do not carry the method, which is why the token alone makes this strategy responsible.
+	if len(body.Token) > 0 {
+		body.Method = s.ID().String()
+	}
+
+	if err := flow.MethodEnabledAndAllowed(r.Context(), s.ID().String(), body.Method, s.d); err != nil {
+		return nil, s.handleLoginError(r, f, body, err)
+	}
+
+	if len(body.Token) > 0 {
+		return s.loginUseToken(r, f, body)
+	}
+
+	if err := flow.EnsureCSRF(s.d, r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, body.CSRFToken); err != nil {
+		return nil, s.handleLoginError(r, f, body, err)
+	}
+
+	if len(body.Code) > 0 {
+		return s.loginUseCode(r, f, body)
+	}
+
+	return nil, s.loginSendLink(w, r, f, body)
+}
+
+func (s *Strategy) loginSendLink(w http.ResponseWriter, r *http.Request, f *login.Flow, body *submitSelfServiceLoginFlowWithMagicLinkMethodBody) error {
+	if len(body.Email) == 0 {
+		return s.handleLoginError(r, f, body, schema.NewRequiredError("#/email", "email"))
+	}
+
+	if err := s.d.RateLimiter().CheckIdentifier(r.Context(), ratelimit.FlowLogin, body.Email); err != nil {
+		return s.handleLoginError(r, f, body, err)
+	}
+
+	if err := s.d.LinkSender().SendLoginLink(r.Context(), r, f, body.Email); err != nil {
+		if !errors.Is(err, ErrUnknownAddress) {
+			return s.handleLoginError(r, f, body, err)
+		}
+		// Continue execution to prevent account enumeration.
+	}
+
+	f.Active = s.ID()
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	f.UI.Nodes.SetValueAttribute("email", body.Email)
+
+	// The code input is placed in front of the submit button.
+	f.UI.GetNodes().Remove("method")
+	f.UI.GetNodes().Upsert(
+		node.NewInputField("code", nil, node.MagicLinkGroup, node.InputAttributeTypeText).WithMetaLabel(text.NewInfoNodeLabelCode()),
+	)
+	f.UI.GetNodes().Append(node.NewInputField("method", s.ID(), node.MagicLinkGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoNodeLabelSubmit()))
+	f.UI.Messages.Set(text.NewInfoSelfServiceLoginMagicLinkSent())
+
+	if err := s.d.LoginFlowPersister().UpdateLoginFlow(r.Context(), f); err != nil {
+		return s.handleLoginError(r, f, body, err)
+	}
+
+	if f.Type == flow.TypeBrowser && !x.IsJSONRequest(r) {
+		http.Redirect(w, r, f.AppendTo(s.d.Config(r.Context()).SelfServiceFlowLoginUI()).String(), http.StatusSeeOther)
+		return errors.WithStack(flow.ErrCompletedByStrategy)
+	}
+
+	s.d.Writer().Write(w, r, f)
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+func (s *Strategy) loginUseToken(r *http.Request, f *login.Flow, body *submitSelfServiceLoginFlowWithMagicLinkMethodBody) (*identity.Identity, error) {
+	// Sign-in links can only be followed in a browser.
+	if f.Type != flow.TypeBrowser {
+		return nil, s.handleLoginError(r, f, body, errors.WithStack(herodot.ErrBadRequest.WithReason("Sign-in links can only be used in browser login flows.")))
+	}
+
+	token, err := s.d.LoginTokenPersister().UseLoginToken(r.Context(), body.Token)
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		return nil, s.handleLoginError(r, f, body, schema.NewMagicLinkInvalidError())
+	} else if err != nil {
+		return nil, s.handleLoginError(r, f, body, err)
+	}
+
+	if token.FlowID != f.ID {
+		return nil, s.handleLoginError(r, f, body, schema.NewMagicLinkInvalidError())
+	}
+
+	return s.loginCompleteWithToken(r, f, body, token)
+}
+
+func (s *Strategy) loginUseCode(r *http.Request, f *login.Flow, body *submitSelfServiceLoginFlowWithMagicLinkMethodBody) (*identity.Identity, error) {
+	maxAttempts := s.d.Config(r.Context()).SelfServiceMagicLinkMethodMaxAttempts()
+	token, err := s.d.LoginTokenPersister().UseLoginCode(r.Context(), f.ID, body.Code, maxAttempts)
+	if errors.Is(err, ErrCodeAttemptsExceeded) {
+		return nil, s.handleLoginError(r, f, body, schema.NewCodeAttemptsExceededError(maxAttempts))
+	} else if errors.Is(err, ErrCodeInvalid) || errors.Is(err, sqlcon.ErrNoRows) {
+		return nil, s.handleLoginError(r, f, body, schema.NewCodeInvalidError())
+	} else if err != nil {
+		return nil, s.handleLoginError(r, f, body, err)
+	}
+
+	return s.loginCompleteWithToken(r, f, body, token)
+}
+
+func (s *Strategy) loginCompleteWithToken(r *http.Request, f *login.Flow, body *submitSelfServiceLoginFlowWithMagicLinkMethodBody, token *LoginToken) (*identity.Identity, error) {
+	if err := token.Valid(); err != nil {
+		return nil, s.handleLoginError(r, f, body, err)
+	}
+
+	// The address might have been changed or unverified since the link was sent.
+	if token.VerifiableAddress == nil || !token.VerifiableAddress.Verified {
+		return nil, s.handleLoginError(r, f, body, schema.NewMagicLinkInvalidError())
+	}
+
+	i, err := s.d.IdentityPool().GetIdentity(r.Context(), token.IdentityID)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, body, err)
+	}
+
+	f.Active = s.ID()
+	if err := s.d.LoginFlowPersister().UpdateLoginFlow(r.Context(), f); err != nil {
+		return nil, s.handleLoginError(r, f, body, errors.WithStack(herodot.ErrInternalServerError.WithReason("Could not update flow").WithDebug(err.Error())))
+	}
+
+	return i, nil
+}
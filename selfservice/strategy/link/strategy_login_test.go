@@ -0,0 +1,225 @@
+package link_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	kratos "github.com/ory/kratos-client-go"
+	"github.com/ory/x/assertx"
+	"github.com/ory/x/ioutilx"
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+)
+
+func TestLogin(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	initViper(t, conf)
+	conf.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+identity.CredentialsTypeMagicLink.String()+".enabled", true)
+	conf.MustSet(config.ViperKeyMagicLinkMaxAttempts, 2)
+
+	_ = testhelpers.NewLoginUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	redirTS := testhelpers.NewRedirSessionEchoTS(t, reg)
+
+	public, _ := testhelpers.NewKratosServerWithCSRF(t, reg)
+
+	var createIdentity = func(t *testing.T, email string, verified bool) *identity.Identity {
+		i := &identity.Identity{
+			ID:       x.NewUUID(),
+			Traits:   identity.Traits(`{"email":"` + email + `"}`),
+			SchemaID: config.DefaultIdentityTraitsSchemaID,
+			Credentials: map[identity.CredentialsType]identity.Credentials{
+				"password": {Type: "password", Identifiers: []string{email}, Config: sqlxx.JSONRawMessage(`{"hashed_password":"foo"}`)}},
+		}
+		require.NoError(t, reg.IdentityManager().Create(context.Background(), i, identity.ManagerAllowWriteProtectedTraits))
+		require.Len(t, i.VerifiableAddresses, 1)
+
+		if verified {
+			address := i.VerifiableAddresses[0]
+			address.Verified = true
+			address.Status = identity.VerifiableAddressStatusCompleted
+			require.NoError(t, reg.PrivilegedIdentityPool().UpdateVerifiableAddress(context.Background(), &address))
+		}
+		return i
+	}
+
+	var initFlow = func(t *testing.T, isAPI bool) (*http.Client, *kratos.SelfServiceLoginFlow) {
+		if isAPI {
+			hc := testhelpers.NewDebugClient(t)
+			return hc, testhelpers.InitializeLoginFlowViaAPI(t, hc, public, false)
+		}
+		hc := testhelpers.NewClientWithCookies(t)
+		return hc, testhelpers.InitializeLoginFlowViaBrowser(t, hc, public, false, false)
+	}
+
+	var requestLink = func(t *testing.T, isAPI bool, email string) (*http.Client, *kratos.SelfServiceLoginFlow, url.Values) {
+		hc, f := initFlow(t, isAPI)
+
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		values.Set("method", identity.CredentialsTypeMagicLink.String())
+		values.Set("email", email)
+		body, res := testhelpers.LoginMakeRequest(t, isAPI, false, f, hc, testhelpers.EncodeFormAsJSON(t, isAPI, values))
+		require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+		if !isAPI {
+			assert.Contains(t, res.Request.URL.String(), conf.SelfServiceFlowLoginUI().String())
+		}
+		assert.EqualValues(t, identity.CredentialsTypeMagicLink, gjson.Get(body, "active").String(), "%s", body)
+		assert.True(t, gjson.Get(body, "ui.nodes.#(attributes.name==code)").Exists(), "%s", body)
+		assertx.EqualAsJSON(t, text.NewInfoSelfServiceLoginMagicLinkSent(), json.RawMessage(gjson.Get(body, "ui.messages.0").Raw))
+
+		return hc, f, values
+	}
+
+	var wrongCode = func(code string) string {
+		if code == "000000" {
+			return "111111"
+		}
+		return "000000"
+	}
+
+	t.Run("description=should populate the login method", func(t *testing.T) {
+		_, f := initFlow(t, false)
+
+		body, err := json.Marshal(f)
+		require.NoError(t, err)
+		assert.EqualValues(t, node.InputAttributeTypeEmail, gjson.GetBytes(body, "ui.nodes.#(attributes.name==email).attributes.type").String(), "%s", body)
+		assert.EqualValues(t, node.MagicLinkGroup, gjson.GetBytes(body, "ui.nodes.#(attributes.name==email).group").String(), "%s", body)
+		assert.EqualValues(t, text.InfoSelfServiceLoginMagicLink, gjson.GetBytes(body, "ui.nodes.#(attributes.value==magic_link).meta.label.id").Int(), "%s", body)
+	})
+
+	t.Run("description=should send an invalid email to unknown and unverified addresses", func(t *testing.T) {
+		_, _, _ = requestLink(t, true, "unknown-magic-link@ory.sh")
+		message := testhelpers.CourierExpectMessage(t, reg, "unknown-magic-link@ory.sh", "Account access attempted")
+		assert.NotContains(t, message.Body, "token=")
+
+		_ = createIdentity(t, "unverified-magic-link@ory.sh", false)
+		_, _, _ = requestLink(t, true, "unverified-magic-link@ory.sh")
+		message = testhelpers.CourierExpectMessage(t, reg, "unverified-magic-link@ory.sh", "Account access attempted")
+		assert.NotContains(t, message.Body, "token=")
+	})
+
+	t.Run("description=should require an email address", func(t *testing.T) {
+		hc, f := initFlow(t, true)
+
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		values.Set("method", identity.CredentialsTypeMagicLink.String())
+		body, res := testhelpers.LoginMakeRequest(t, true, false, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+		require.EqualValues(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+		assert.EqualValues(t, "Property email is missing.", gjson.Get(body, "ui.nodes.#(attributes.name==email).messages.0.text").String(), "%s", body)
+	})
+
+	t.Run("description=should sign in by following the link", func(t *testing.T) {
+		email := "browser-magic-link@ory.sh"
+		i := createIdentity(t, email, true)
+		hc, _, _ := requestLink(t, false, email)
+
+		message := testhelpers.CourierExpectMessage(t, reg, email, "Sign in to your account")
+		loginLink := testhelpers.CourierExpectLinkInMessage(t, message, 1)
+		assert.Contains(t, loginLink, public.URL+login.RouteSubmitFlow)
+
+		hc.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+		res, err := hc.Get(loginLink)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.EqualValues(t, http.StatusSeeOther, res.StatusCode)
+		assert.Contains(t, res.Header.Get("Location"), redirTS.URL)
+
+		res, err = hc.Get(public.URL + session.RouteWhoami)
+		require.NoError(t, err)
+		body := ioutilx.MustReadAll(res.Body)
+		require.NoError(t, res.Body.Close())
+
+		assert.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.EqualValues(t, i.ID.String(), gjson.GetBytes(body, "identity.id").String(), "%s", body)
+		assert.EqualValues(t, identity.CredentialsTypeMagicLink, gjson.GetBytes(body, "authentication_methods.0.method").String(), "%s", body)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel1, gjson.GetBytes(body, "authenticator_assurance_level").String(), "%s", body)
+
+		t.Run("case=should not work twice", func(t *testing.T) {
+			hc, _ := initFlow(t, false)
+			res, err := hc.Get(loginLink)
+			require.NoError(t, err)
+			body := ioutilx.MustReadAll(res.Body)
+			require.NoError(t, res.Body.Close())
+
+			assert.Contains(t, res.Request.URL.String(), conf.SelfServiceFlowLoginUI().String())
+			assert.EqualValues(t, text.ErrorValidationLoginMagicLinkInvalidOrAlreadyUsed, gjson.GetBytes(body, "ui.messages.0.id").Int(), "%s", body)
+		})
+	})
+
+	t.Run("description=should sign in with the code", func(t *testing.T) {
+		email := "api-magic-link@ory.sh"
+		i := createIdentity(t, email, true)
+		hc, f, values := requestLink(t, true, email)
+
+		message := testhelpers.CourierExpectMessage(t, reg, email, "Sign in to your account")
+		code := testhelpers.CourierExpectCodeInMessage(t, message)
+
+		values.Set("code", wrongCode(code))
+		body, res := testhelpers.LoginMakeRequest(t, true, false, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+		require.EqualValues(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+		assert.EqualValues(t, text.ErrorValidationCodeInvalid, gjson.Get(body, "ui.nodes.#(attributes.name==code).messages.0.id").Int(), "%s", body)
+
+		values.Set("code", code)
+		body, res = testhelpers.LoginMakeRequest(t, true, false, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+		require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.NotEmpty(t, gjson.Get(body, "session_token").String(), "%s", body)
+		assert.EqualValues(t, i.ID.String(), gjson.Get(body, "session.identity.id").String(), "%s", body)
+		assert.EqualValues(t, identity.CredentialsTypeMagicLink, gjson.Get(body, "session.authentication_methods.0.method").String(), "%s", body)
+	})
+
+	t.Run("description=should lock the code after too many wrong attempts", func(t *testing.T) {
+		email := "locked-magic-link@ory.sh"
+		_ = createIdentity(t, email, true)
+		hc, f, values := requestLink(t, true, email)
+
+		code := testhelpers.CourierExpectCodeInMessage(t, testhelpers.CourierExpectMessage(t, reg, email, "Sign in to your account"))
+
+		values.Set("code", wrongCode(code))
+		body, res := testhelpers.LoginMakeRequest(t, true, false, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+		require.EqualValues(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+		assert.EqualValues(t, text.ErrorValidationCodeInvalid, gjson.Get(body, "ui.nodes.#(attributes.name==code).messages.0.id").Int(), "%s", body)
+
+		body, res = testhelpers.LoginMakeRequest(t, true, false, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+		require.EqualValues(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+		assert.EqualValues(t, text.ErrorValidationCodeAttemptsExceeded, gjson.Get(body, "ui.nodes.#(attributes.name==code).messages.0.id").Int(), "%s", body)
+
+		values.Set("code", code)
+		body, res = testhelpers.LoginMakeRequest(t, true, false, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+		require.EqualValues(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+		assert.EqualValues(t, text.ErrorValidationCodeAttemptsExceeded, gjson.Get(body, "ui.nodes.#(attributes.name==code).messages.0.id").Int(), "%s", body)
+	})
+
+	t.Run("description=should not be responsible if the method is disabled", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+identity.CredentialsTypeMagicLink.String()+".enabled", false)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+identity.CredentialsTypeMagicLink.String()+".enabled", true)
+		})
+
+		hc, f := initFlow(t, true)
+		assert.False(t, gjson.Get(testhelpers.EncodeFormAsJSON(t, true, testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)), "email").Exists())
+
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		values.Set("method", identity.CredentialsTypeMagicLink.String())
+		values.Set("email", "api-magic-link@ory.sh")
+		body, res := testhelpers.LoginMakeRequest(t, true, false, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+		assert.EqualValues(t, http.StatusNotFound, res.StatusCode, "%s", body)
+	})
+}
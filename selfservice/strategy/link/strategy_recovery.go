@@ -16,6 +16,7 @@ import (
 	"github.com/ory/x/urlx"
 
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/ratelimit"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/recovery"
@@ -23,6 +24,7 @@ import (
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/webhook"
 	"github.com/ory/kratos/x"
 )
 
@@ -190,14 +192,21 @@ func (s *Strategy) createRecoveryLink(w http.ResponseWriter, r *http.Request, _
 type submitSelfServiceRecoveryFlowWithLinkMethodBody struct {
 	// Email to Recover
 	//
-	// Needs to be set when initiating the flow. If the email is a registered
-	// recovery email, a recovery link will be sent. If the email is not known,
-	// a email with details on what happened will be sent instead.
+	// Needs to be set when initiating the flow unless a phone number is set. If
+	// the email is a registered recovery email, a recovery link will be sent. If
+	// the email is not known, a email with details on what happened will be sent
+	// instead.
 	//
 	// format: email
-	// required: true
 	Email string `json:"email" form:"email"`
 
+	// Phone Number to Recover
+	//
+	// Can be set instead of the email when initiating the flow. If the phone
+	// number is a registered recovery phone number, a recovery link or code
+	// will be sent by text message.
+	Phone string `json:"phone" form:"phone"`
+
 	// Sending the anti-csrf token is only required for browser login flows.
 	CSRFToken string `form:"csrf_token" json:"csrf_token"`
 
@@ -205,6 +214,12 @@ type submitSelfServiceRecoveryFlowWithLinkMethodBody struct {
 	//
 	// required: true
 	Method string `json:"method"`
+
+	// Recovery Code
+	//
+	// The one-time code which was sent to the recovery address. Only used if the
+	// code mode of the link method is enabled.
+	Code string `json:"code" form:"code"`
 }
 
 func (s *Strategy) Recover(w http.ResponseWriter, r *http.Request, f *recovery.Flow) (err error) {
@@ -236,8 +251,12 @@ func (s *Strategy) Recover(w http.ResponseWriter, r *http.Request, f *recovery.F
 
 	switch req.State {
 	case recovery.StateChooseMethod:
-		fallthrough
+		return s.recoveryHandleFormSubmission(w, r, req)
 	case recovery.StateEmailSent:
+		if len(body.Code) > 0 {
+			// The flow passed by the handler is used because it is the one which is written on errors.
+			return s.recoveryUseCode(w, r, f, body)
+		}
 		return s.recoveryHandleFormSubmission(w, r, req)
 	case recovery.StatePassedChallenge:
 		// was already handled, do not allow retry
@@ -300,6 +319,84 @@ func (s *Strategy) recoveryIssueSession(w http.ResponseWriter, r *http.Request,
 	return errors.WithStack(flow.ErrCompletedByStrategy)
 }
 
+func (s *Strategy) recoveryIssueAPISession(w http.ResponseWriter, r *http.Request, f *recovery.Flow, id *identity.Identity) error {
+	f.UI.Messages.Clear()
+	f.State = recovery.StatePassedChallenge
+	f.RecoveredIdentityID = uuid.NullUUID{
+		UUID:  id.ID,
+		Valid: true,
+	}
+	if err := s.d.RecoveryFlowPersister().UpdateRecoveryFlow(r.Context(), f); err != nil {
+		return s.HandleRecoveryError(w, r, f, nil, err)
+	}
+
+	sess, err := session.NewActiveSession(id, s.d.Config(r.Context()), time.Now().UTC(), identity.CredentialsTypeRecoveryLink)
+	if err != nil {
+		return s.HandleRecoveryError(w, r, f, nil, err)
+	}
+
+	s.d.SessionManager().TrackDevice(r.Context(), r, sess)
+	if err := s.d.SessionPersister().UpsertSession(r.Context(), sess); err != nil {
+		return s.HandleRecoveryError(w, r, f, nil, err)
+	}
+	s.d.WebHookEmitter().Emit(r.Context(), webhook.EventSessionIssued, sess.EventData())
+
+	sf, err := s.d.SettingsHandler().NewFlow(w, r, sess.Identity, flow.TypeAPI)
+	if err != nil {
+		return s.HandleRecoveryError(w, r, f, nil, err)
+	}
+
+	if err := s.d.RecoveryExecutor().PostRecoveryHook(w, r, f, sess); err != nil {
+		return s.HandleRecoveryError(w, r, f, nil, err)
+	}
+
+	sf.UI.Messages.Set(text.NewRecoverySuccessful(time.Now().Add(s.d.Config(r.Context()).SelfServiceFlowSettingsPrivilegedSessionMaxAge())))
+	if err := s.d.SettingsFlowPersister().UpdateSettingsFlow(r.Context(), sf); err != nil {
+		return s.HandleRecoveryError(w, r, f, nil, err)
+	}
+
+	s.d.Writer().Write(w, r, &recovery.APIFlowResponse{Token: sess.Token, Session: sess, SettingsFlow: sf})
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+func (s *Strategy) recoveryUseCode(w http.ResponseWriter, r *http.Request, f *recovery.Flow, body *recoverySubmitPayload) error {
+	// Clear the results of previous code submissions.
+	f.UI.GetNodes().ResetNodes("code")
+
+	if err := flow.EnsureCSRF(s.d, r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, body.CSRFToken); err != nil {
+		return s.HandleRecoveryError(w, r, f, body, err)
+	}
+
+	maxAttempts := s.d.Config(r.Context()).SelfServiceLinkMethodCodeMaxAttempts()
+	token, err := s.d.RecoveryTokenPersister().UseRecoveryCode(r.Context(), f.ID, body.Code, maxAttempts)
+	if errors.Is(err, ErrCodeAttemptsExceeded) {
+		return s.HandleRecoveryError(w, r, f, body, schema.NewCodeAttemptsExceededError(maxAttempts))
+	} else if errors.Is(err, ErrCodeInvalid) || errors.Is(err, sqlcon.ErrNoRows) {
+		return s.HandleRecoveryError(w, r, f, body, schema.NewCodeInvalidError())
+	} else if err != nil {
+		return s.HandleRecoveryError(w, r, f, body, err)
+	}
+
+	if err := token.Valid(); err != nil {
+		return s.HandleRecoveryError(w, r, f, body, err)
+	}
+
+	recovered, err := s.d.IdentityPool().GetIdentity(r.Context(), token.IdentityID)
+	if err != nil {
+		return s.HandleRecoveryError(w, r, f, body, err)
+	}
+
+	if err := s.markRecoveryAddressVerified(w, r, f, recovered, token.RecoveryAddress); err != nil {
+		return s.HandleRecoveryError(w, r, f, body, err)
+	}
+
+	if f.Type == flow.TypeAPI {
+		return s.recoveryIssueAPISession(w, r, f, recovered)
+	}
+
+	return s.recoveryIssueSession(w, r, f, recovered)
+}
+
 func (s *Strategy) recoveryUseToken(w http.ResponseWriter, r *http.Request, body *recoverySubmitPayload) error {
 	token, err := s.d.RecoveryTokenPersister().UseRecoveryToken(r.Context(), body.Token)
 	if err != nil {
@@ -406,7 +503,8 @@ func (s *Strategy) recoveryHandleFormSubmission(w http.ResponseWriter, r *http.R
 		return s.HandleRecoveryError(w, r, f, body, err)
 	}
 
-	if len(body.Email) == 0 {
+	via, to := body.address()
+	if len(to) == 0 {
 		return s.HandleRecoveryError(w, r, f, body, schema.NewRequiredError("#/email", "email"))
 	}
 
@@ -414,7 +512,11 @@ func (s *Strategy) recoveryHandleFormSubmission(w http.ResponseWriter, r *http.R
 		return s.HandleRecoveryError(w, r, f, body, err)
 	}
 
-	if err := s.d.LinkSender().SendRecoveryLink(r.Context(), r, f, identity.VerifiableAddressTypeEmail, body.Email); err != nil {
+	if err := s.d.RateLimiter().CheckIdentifier(r.Context(), ratelimit.FlowRecovery, to); err != nil {
+		return s.HandleRecoveryError(w, r, f, body, err)
+	}
+
+	if err := s.d.LinkSender().SendRecoveryLink(r.Context(), r, f, via, to); err != nil {
 		if !errors.Is(err, ErrUnknownAddress) {
 			return s.HandleRecoveryError(w, r, f, body, err)
 		}
@@ -422,14 +524,26 @@ func (s *Strategy) recoveryHandleFormSubmission(w http.ResponseWriter, r *http.R
 	}
 
 	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
-	f.UI.GetNodes().Upsert(
-		// v0.5: form.Field{Name: "email", Type: "email", Required: true, Value: body.Body.Email}
-		node.NewInputField("email", body.Email, node.RecoveryLinkGroup, node.InputAttributeTypeEmail, node.WithRequiredInputAttribute),
-	)
+	upsertAddressNode(f.UI.GetNodes(), node.RecoveryLinkGroup, via, to)
 
 	f.Active = sqlxx.NullString(s.RecoveryNodeGroup())
 	f.State = recovery.StateEmailSent
 	f.UI.Messages.Set(text.NewRecoveryEmailSent())
+	if via == identity.VerifiableAddressTypePhone {
+		f.UI.Messages.Set(text.NewRecoveryPhoneSent())
+	}
+	if s.d.Config(r.Context()).SelfServiceLinkMethodCodeEnabled() {
+		// The code input is placed in front of the submit button.
+		f.UI.GetNodes().Remove("method")
+		f.UI.GetNodes().Upsert(
+			node.NewInputField("code", nil, node.RecoveryLinkGroup, node.InputAttributeTypeText).WithMetaLabel(text.NewInfoNodeLabelCode()),
+		)
+		f.UI.GetNodes().Append(node.NewInputField("method", s.RecoveryStrategyID(), node.RecoveryLinkGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoNodeLabelSubmit()))
+		f.UI.Messages.Set(text.NewRecoveryCodeSent())
+		if via == identity.VerifiableAddressTypePhone {
+			f.UI.Messages.Set(text.NewRecoveryPhoneCodeSent())
+		}
+	}
 	if err := s.d.RecoveryFlowPersister().UpdateRecoveryFlow(r.Context(), f); err != nil {
 		return s.HandleRecoveryError(w, r, f, body, err)
 	}
@@ -462,16 +576,13 @@ func (s *Strategy) markRecoveryAddressVerified(w http.ResponseWriter, r *http.Re
 
 func (s *Strategy) HandleRecoveryError(w http.ResponseWriter, r *http.Request, req *recovery.Flow, body *recoverySubmitPayload, err error) error {
 	if req != nil {
-		email := ""
+		via, to := identity.VerifiableAddressTypeEmail, ""
 		if body != nil {
-			email = body.Email
+			via, to = body.address()
 		}
 
 		req.UI.SetCSRF(s.d.GenerateCSRFToken(r))
-		req.UI.GetNodes().Upsert(
-			// v0.5: form.Field{Name: "email", Type: "email", Required: true, Value: body.Body.Email}
-			node.NewInputField("email", email, node.RecoveryLinkGroup, node.InputAttributeTypeEmail, node.WithRequiredInputAttribute),
-		)
+		upsertAddressNode(req.UI.GetNodes(), node.RecoveryLinkGroup, via, to)
 	}
 
 	return err
@@ -483,6 +594,17 @@ type recoverySubmitPayload struct {
 	CSRFToken string `json:"csrf_token" form:"csrf_token"`
 	Flow      string `json:"flow" form:"flow"`
 	Email     string `json:"email" form:"email"`
+	Phone     string `json:"phone" form:"phone"`
+	Code      string `json:"code" form:"code"`
+}
+
+// address returns the type and value of the address the recovery message is sent to. The email
+// address takes precedence over the phone number.
+func (p *recoverySubmitPayload) address() (identity.VerifiableAddressType, string) {
+	if len(p.Email) == 0 && len(p.Phone) > 0 {
+		return identity.VerifiableAddressTypePhone, p.Phone
+	}
+	return identity.VerifiableAddressTypeEmail, p.Email
 }
 
 func (s *Strategy) decodeRecovery(r *http.Request) (*recoverySubmitPayload, error) {
@@ -42,6 +42,7 @@ import (
 
 	"github.com/ory/x/pointerx"
 
+	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
@@ -330,6 +331,24 @@ func TestRecovery(t *testing.T) {
 		})
 	})
 
+	t.Run("description=should limit the recovery emails per address", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyRateLimits+".recovery.per_identifier", map[string]interface{}{"limit": 1, "window": "1h"})
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyRateLimits+".recovery.per_identifier", nil)
+		})
+
+		email := x.NewUUID().String() + "@ory.sh"
+		var values = func(v url.Values) {
+			v.Set("email", email)
+		}
+
+		expectSuccess(t, nil, true, false, values)
+		testhelpers.CourierExpectMessage(t, reg, email, "Account access attempted")
+
+		actual := expectValidationError(t, nil, true, false, values)
+		assert.EqualValues(t, text.ErrorValidationRateLimitExceeded, gjson.Get(actual, "ui.messages.0.id").Int(), "%s", actual)
+	})
+
 	t.Run("description=should not be able to recover an inactive account", func(t *testing.T) {
 		var check = func(t *testing.T, recoverySubmissionResponse, recoveryEmail string, isAPI bool) {
 			addr, err := reg.IdentityPool().FindVerifiableAddressByValue(context.Background(), identity.VerifiableAddressTypeEmail, recoveryEmail)
@@ -477,6 +496,125 @@ func TestRecovery(t *testing.T) {
 		})
 	})
 
+	t.Run("description=should recover an account with a one-time code", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyLinkCodeEnabled, true)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyLinkCodeEnabled, false)
+		})
+
+		var requestCode = func(t *testing.T, hc *http.Client, isAPI bool, email string) (*kratos.SelfServiceRecoveryFlow, url.Values, string) {
+			createIdentityToRecover(email)
+
+			var f *kratos.SelfServiceRecoveryFlow
+			if isAPI {
+				f = testhelpers.InitializeRecoveryFlowViaAPI(t, hc, public)
+			} else {
+				f = testhelpers.InitializeRecoveryFlowViaBrowser(t, hc, true, public, nil)
+			}
+
+			values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+			values.Set("email", email)
+			body, res := testhelpers.RecoveryMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+			require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+			assert.True(t, gjson.Get(body, "ui.nodes.#(attributes.name==code)").Exists(), "%s", body)
+			assertx.EqualAsJSON(t, text.NewRecoveryCodeSent(), json.RawMessage(gjson.Get(body, "ui.messages.0").Raw))
+
+			message := testhelpers.CourierExpectMessage(t, reg, email, "Recover access to your account")
+			assert.Contains(t, message.Body, "please recover access to your account by entering the following code")
+
+			code := testhelpers.CourierExpectCodeInMessage(t, message)
+			wrong := "000000"
+			if code == wrong {
+				wrong = "111111"
+			}
+
+			values.Set("code", wrong)
+			body, res = testhelpers.RecoveryMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+			require.EqualValues(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+			assert.EqualValues(t, text.ErrorValidationCodeInvalid, gjson.Get(body, "ui.nodes.#(attributes.name==code).messages.0.id").Int(), "%s", body)
+
+			values.Set("code", code)
+			return f, values, code
+		}
+
+		var checkAddressVerified = func(t *testing.T, email string) {
+			addr, err := reg.IdentityPool().FindVerifiableAddressByValue(context.Background(), identity.VerifiableAddressTypeEmail, email)
+			require.NoError(t, err)
+			assert.True(t, addr.Verified)
+			assert.Equal(t, identity.VerifiableAddressStatusCompleted, addr.Status)
+		}
+
+		t.Run("type=api", func(t *testing.T) {
+			email := "recover-code-api@ory.sh"
+			hc := testhelpers.NewDebugClient(t)
+			f, values, _ := requestCode(t, hc, true, email)
+
+			body, res := testhelpers.RecoveryMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+			require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+			assert.NotEmpty(t, gjson.Get(body, "session_token").String(), "%s", body)
+			assert.Equal(t, "link_recovery", gjson.Get(body, "session.authentication_methods.0.method").String(), "%s", body)
+			assert.Equal(t, "api", gjson.Get(body, "settings_flow.type").String(), "%s", body)
+			assert.Equal(t, text.NewRecoverySuccessful(time.Now().Add(time.Hour)).Text, gjson.Get(body, "settings_flow.ui.messages.0.text").String(), "%s", body)
+			checkAddressVerified(t, email)
+
+			body, _ = testhelpers.RecoveryMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+			assert.EqualValues(t, text.ErrorValidationRecoveryRetrySuccess, gjson.Get(body, "ui.messages.0.id").Int(), "%s", body)
+		})
+
+		t.Run("type=spa", func(t *testing.T) {
+			email := "recover-code-spa@ory.sh"
+			hc := testhelpers.NewClientWithCookies(t)
+			f, values, _ := requestCode(t, hc, false, email)
+
+			body, res := testhelpers.RecoveryMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+			require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+			assert.Contains(t, res.Request.URL.String(), conf.SelfServiceFlowSettingsUI().String())
+			checkAddressVerified(t, email)
+
+			res, err := hc.Get(public.URL + session.RouteWhoami)
+			require.NoError(t, err)
+			whoami := x.MustReadAll(res.Body)
+			require.NoError(t, res.Body.Close())
+			assert.Equal(t, "link_recovery", gjson.GetBytes(whoami, "authentication_methods.0.method").String(), "%s", whoami)
+		})
+	})
+
+	t.Run("description=should recover an account with a one-time code sent by text message", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyLinkCodeEnabled, true)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyLinkCodeEnabled, false)
+		})
+
+		phone := "+491701234567"
+		require.NoError(t, reg.IdentityManager().Create(context.Background(), &identity.Identity{
+			Traits:   identity.Traits(fmt.Sprintf(`{"email":"recover-sms@ory.sh","phone":"%s"}`, phone)),
+			SchemaID: config.DefaultIdentityTraitsSchemaID,
+		}, identity.ManagerAllowWriteProtectedTraits))
+
+		hc := testhelpers.NewDebugClient(t)
+		f := testhelpers.InitializeRecoveryFlowViaAPI(t, hc, public)
+
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		values.Del("email")
+		values.Set("phone", phone)
+		body, res := testhelpers.RecoveryMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+		require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.EqualValues(t, phone, gjson.Get(body, "ui.nodes.#(attributes.name==phone).attributes.value").String(), "%s", body)
+		assert.False(t, gjson.Get(body, "ui.nodes.#(attributes.name==email)").Exists(), "%s", body)
+		assertx.EqualAsJSON(t, text.NewRecoveryPhoneCodeSent(), json.RawMessage(gjson.Get(body, "ui.messages.0").Raw))
+
+		message, err := reg.CourierPersister().LatestQueuedMessage(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, courier.MessageTypeSMS, message.Type)
+		assert.Equal(t, phone, message.Recipient)
+		assert.Contains(t, message.Body, "Your recovery code is")
+
+		values.Set("code", testhelpers.CourierExpectCodeInMessage(t, message))
+		body, res = testhelpers.RecoveryMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+		require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.NotEmpty(t, gjson.Get(body, "session_token").String(), "%s", body)
+	})
+
 	t.Run("description=should recover an account and set the csrf cookies", func(t *testing.T) {
 		recoveryEmail := "recoverme1@ory.sh"
 
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/ratelimit"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/verification"
@@ -46,6 +47,17 @@ type verificationSubmitPayload struct {
 	CSRFToken string `json:"csrf_token" form:"csrf_token"`
 	Flow      string `json:"flow" form:"flow"`
 	Email     string `json:"email" form:"email"`
+	Phone     string `json:"phone" form:"phone"`
+	Code      string `json:"code" form:"code"`
+}
+
+// address returns the type and value of the address the verification message is sent to. The email
+// address takes precedence over the phone number.
+func (p *verificationSubmitPayload) address() (identity.VerifiableAddressType, string) {
+	if len(p.Email) == 0 && len(p.Phone) > 0 {
+		return identity.VerifiableAddressTypePhone, p.Phone
+	}
+	return identity.VerifiableAddressTypeEmail, p.Email
 }
 
 func (s *Strategy) decodeVerification(r *http.Request) (*verificationSubmitPayload, error) {
@@ -72,11 +84,13 @@ func (s *Strategy) decodeVerification(r *http.Request) (*verificationSubmitPaylo
 // handleVerificationError is a convenience function for handling all types of errors that may occur (e.g. validation error).
 func (s *Strategy) handleVerificationError(w http.ResponseWriter, r *http.Request, f *verification.Flow, body *verificationSubmitPayload, err error) error {
 	if f != nil {
+		via, to := identity.VerifiableAddressTypeEmail, ""
+		if body != nil {
+			via, to = body.address()
+		}
+
 		f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
-		f.UI.GetNodes().Upsert(
-			// v0.5: form.Field{Name: "email", Type: "email", Required: true, Value: body.Body.Email}
-			node.NewInputField("email", body.Email, node.VerificationLinkGroup, node.InputAttributeTypeEmail, node.WithRequiredInputAttribute),
-		)
+		upsertAddressNode(f.UI.GetNodes(), node.VerificationLinkGroup, via, to)
 	}
 
 	return err
@@ -87,14 +101,21 @@ func (s *Strategy) handleVerificationError(w http.ResponseWriter, r *http.Reques
 type submitSelfServiceVerificationFlowWithLinkMethodBody struct {
 	// Email to Verify
 	//
-	// Needs to be set when initiating the flow. If the email is a registered
-	// verification email, a verification link will be sent. If the email is not known,
-	// a email with details on what happened will be sent instead.
+	// Needs to be set when initiating the flow unless a phone number is set. If
+	// the email is a registered verification email, a verification link will be
+	// sent. If the email is not known, a email with details on what happened will
+	// be sent instead.
 	//
 	// format: email
-	// required: true
 	Email string `json:"email"`
 
+	// Phone Number to Verify
+	//
+	// Can be set instead of the email when initiating the flow. If the phone
+	// number is a registered verification phone number, a verification link or
+	// code will be sent by text message.
+	Phone string `json:"phone"`
+
 	// Sending the anti-csrf token is only required for browser login flows.
 	CSRFToken string `form:"csrf_token" json:"csrf_token"`
 
@@ -104,6 +125,12 @@ type submitSelfServiceVerificationFlowWithLinkMethodBody struct {
 	// - link
 	// required: true
 	Method string `json:"method"`
+
+	// Verification Code
+	//
+	// The one-time code which was sent to the verifiable address. Only used if the
+	// code mode of the link method is enabled.
+	Code string `json:"code" form:"code"`
 }
 
 func (s *Strategy) Verify(w http.ResponseWriter, r *http.Request, f *verification.Flow) (err error) {
@@ -130,9 +157,11 @@ func (s *Strategy) Verify(w http.ResponseWriter, r *http.Request, f *verificatio
 
 	switch f.State {
 	case verification.StateChooseMethod:
-		fallthrough
+		return s.verificationHandleFormSubmission(w, r, f)
 	case verification.StateEmailSent:
-		// Do nothing (continue with execution after this switch statement)
+		if len(body.Code) > 0 {
+			return s.verificationUseCode(w, r, f, body)
+		}
 		return s.verificationHandleFormSubmission(w, r, f)
 	case verification.StatePassedChallenge:
 		return s.retryVerificationFlowWithMessage(w, r, f.Type, text.NewErrorValidationVerificationRetrySuccess())
@@ -148,7 +177,8 @@ func (s *Strategy) verificationHandleFormSubmission(w http.ResponseWriter, r *ht
 		return s.handleVerificationError(w, r, f, body, err)
 	}
 
-	if len(body.Email) == 0 {
+	via, to := body.address()
+	if len(to) == 0 {
 		return s.handleVerificationError(w, r, f, body, schema.NewRequiredError("#/email", "email"))
 	}
 
@@ -156,7 +186,11 @@ func (s *Strategy) verificationHandleFormSubmission(w http.ResponseWriter, r *ht
 		return s.handleVerificationError(w, r, f, body, err)
 	}
 
-	if err := s.d.LinkSender().SendVerificationLink(r.Context(), f, identity.VerifiableAddressTypeEmail, body.Email); err != nil {
+	if err := s.d.RateLimiter().CheckIdentifier(r.Context(), ratelimit.FlowVerification, to); err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	if err := s.d.LinkSender().SendVerificationLink(r.Context(), r, f, via, to); err != nil {
 		if !errors.Is(err, ErrUnknownAddress) {
 			return s.handleVerificationError(w, r, f, body, err)
 		}
@@ -164,14 +198,26 @@ func (s *Strategy) verificationHandleFormSubmission(w http.ResponseWriter, r *ht
 	}
 
 	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
-	f.UI.GetNodes().Upsert(
-		// v0.5: form.Field{Name: "email", Type: "email", Required: true, Value: body.Body.Email}
-		node.NewInputField("email", body.Email, node.VerificationLinkGroup, node.InputAttributeTypeEmail, node.WithRequiredInputAttribute),
-	)
+	upsertAddressNode(f.UI.GetNodes(), node.VerificationLinkGroup, via, to)
 
 	f.Active = sqlxx.NullString(s.VerificationNodeGroup())
 	f.State = verification.StateEmailSent
 	f.UI.Messages.Set(text.NewVerificationEmailSent())
+	if via == identity.VerifiableAddressTypePhone {
+		f.UI.Messages.Set(text.NewVerificationPhoneSent())
+	}
+	if s.d.Config(r.Context()).SelfServiceLinkMethodCodeEnabled() {
+		// The code input is placed in front of the submit button.
+		f.UI.GetNodes().Remove("method")
+		f.UI.GetNodes().Upsert(
+			node.NewInputField("code", nil, node.VerificationLinkGroup, node.InputAttributeTypeText).WithMetaLabel(text.NewInfoNodeLabelCode()),
+		)
+		f.UI.GetNodes().Append(node.NewInputField("method", s.VerificationStrategyID(), node.VerificationLinkGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoNodeLabelSubmit()))
+		f.UI.Messages.Set(text.NewVerificationCodeSent())
+		if via == identity.VerifiableAddressTypePhone {
+			f.UI.Messages.Set(text.NewVerificationPhoneCodeSent())
+		}
+	}
 	if err := s.d.VerificationFlowPersister().UpdateVerificationFlow(r.Context(), f); err != nil {
 		return s.handleVerificationError(w, r, f, body, err)
 	}
@@ -269,6 +315,58 @@ func (s *Strategy) verificationUseToken(w http.ResponseWriter, r *http.Request,
 	return errors.WithStack(flow.ErrCompletedByStrategy)
 }
 
+func (s *Strategy) verificationUseCode(w http.ResponseWriter, r *http.Request, f *verification.Flow, body *verificationSubmitPayload) error {
+	// Clear the results of previous code submissions.
+	f.UI.GetNodes().ResetNodes("code")
+
+	if err := flow.EnsureCSRF(s.d, r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, body.CSRFToken); err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	maxAttempts := s.d.Config(r.Context()).SelfServiceLinkMethodCodeMaxAttempts()
+	token, err := s.d.VerificationTokenPersister().UseVerificationCode(r.Context(), f.ID, body.Code, maxAttempts)
+	if errors.Is(err, ErrCodeAttemptsExceeded) {
+		return s.handleVerificationError(w, r, f, body, schema.NewCodeAttemptsExceededError(maxAttempts))
+	} else if errors.Is(err, ErrCodeInvalid) || errors.Is(err, sqlcon.ErrNoRows) {
+		return s.handleVerificationError(w, r, f, body, schema.NewCodeInvalidError())
+	} else if err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	if err := token.Valid(); err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	i, err := s.d.IdentityPool().GetIdentity(r.Context(), token.VerifiableAddress.IdentityID)
+	if err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	f.UI.Messages.Clear()
+	f.UI.GetNodes().Remove("code")
+	f.State = verification.StatePassedChallenge
+	f.SetCSRFToken(flow.GetCSRFToken(s.d, w, r, f.Type))
+	f.UI.Messages.Set(text.NewInfoSelfServiceVerificationSuccessful())
+	if err := s.d.VerificationFlowPersister().UpdateVerificationFlow(r.Context(), f); err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	if err := s.d.VerificationExecutor().PostVerificationHook(w, r, f, i); err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	address := token.VerifiableAddress
+	address.Verified = true
+	verifiedAt := sqlxx.NullTime(time.Now().UTC())
+	address.VerifiedAt = &verifiedAt
+	address.Status = identity.VerifiableAddressStatusCompleted
+	if err := s.d.PrivilegedIdentityPool().UpdateVerifiableAddress(r.Context(), address); err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	return nil
+}
+
 func (s *Strategy) retryVerificationFlowWithMessage(w http.ResponseWriter, r *http.Request, ft flow.Type, message *text.Message) error {
 	s.d.Logger().WithRequest(r).WithField("message", message).Debug("A verification flow is being retried because a validation error occurred.")
 
@@ -14,6 +14,8 @@ import (
 
 	"github.com/ory/x/urlx"
 
+	kratos "github.com/ory/kratos-client-go"
+
 	"github.com/ory/kratos/selfservice/strategy/link"
 	"github.com/ory/kratos/ui/node"
 
@@ -21,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 
+	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
@@ -383,4 +386,125 @@ func TestVerification(t *testing.T) {
 		assert.Equal(t, returnToURL, redirectURL.String())
 
 	})
+
+	t.Run("description=should verify an email address with a one-time code", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyLinkCodeEnabled, true)
+		conf.MustSet(config.ViperKeyLinkCodeMaxAttempts, 2)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyLinkCodeEnabled, false)
+			conf.MustSet(config.ViperKeyLinkCodeMaxAttempts, 5)
+		})
+
+		var requestCode = func(t *testing.T, isAPI bool) (*http.Client, *kratos.SelfServiceVerificationFlow, url.Values, string) {
+			hc := testhelpers.NewDebugClient(t)
+			var f *kratos.SelfServiceVerificationFlow
+			if isAPI {
+				f = testhelpers.InitializeVerificationFlowViaAPI(t, hc, public)
+			} else {
+				hc = testhelpers.NewClientWithCookies(t)
+				f = testhelpers.InitializeVerificationFlowViaBrowser(t, hc, true, public)
+			}
+
+			values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+			values.Set("email", verificationEmail)
+			body, res := testhelpers.VerificationMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+			require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+			assert.EqualValues(t, "sent_email", gjson.Get(body, "state").String(), "%s", body)
+			assert.True(t, gjson.Get(body, "ui.nodes.#(attributes.name==code)").Exists(), "%s", body)
+			assertx.EqualAsJSON(t, text.NewVerificationCodeSent(), json.RawMessage(gjson.Get(body, "ui.messages.0").Raw))
+
+			message := testhelpers.CourierExpectMessage(t, reg, verificationEmail, "Please verify your email address")
+			assert.Contains(t, message.Body, "please verify your account by entering the following code")
+			assert.NotContains(t, message.Body, "token=")
+
+			return hc, f, values, testhelpers.CourierExpectCodeInMessage(t, message)
+		}
+
+		var wrongCode = func(code string) string {
+			if code == "000000" {
+				return "111111"
+			}
+			return "000000"
+		}
+
+		for _, isAPI := range []bool{true, false} {
+			t.Run(fmt.Sprintf("api=%v", isAPI), func(t *testing.T) {
+				hc, f, values, code := requestCode(t, isAPI)
+
+				values.Set("code", wrongCode(code))
+				body, res := testhelpers.VerificationMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+				require.EqualValues(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+				assert.EqualValues(t, text.ErrorValidationCodeInvalid, gjson.Get(body, "ui.nodes.#(attributes.name==code).messages.0.id").Int(), "%s", body)
+
+				values.Set("code", code)
+				body, res = testhelpers.VerificationMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+				require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+				assert.EqualValues(t, "passed_challenge", gjson.Get(body, "state").String(), "%s", body)
+				assert.EqualValues(t, text.NewInfoSelfServiceVerificationSuccessful().Text, gjson.Get(body, "ui.messages.0.text").String(), "%s", body)
+
+				id, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), identityToVerify.ID)
+				require.NoError(t, err)
+				require.Len(t, id.VerifiableAddresses, 1)
+				assert.True(t, id.VerifiableAddresses[0].Verified)
+			})
+		}
+
+		t.Run("case=should lock the code after too many wrong attempts", func(t *testing.T) {
+			hc, f, values, code := requestCode(t, true)
+
+			values.Set("code", wrongCode(code))
+			body, res := testhelpers.VerificationMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+			require.EqualValues(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+			assert.EqualValues(t, text.ErrorValidationCodeInvalid, gjson.Get(body, "ui.nodes.#(attributes.name==code).messages.0.id").Int(), "%s", body)
+
+			body, res = testhelpers.VerificationMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+			require.EqualValues(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+			assert.EqualValues(t, text.ErrorValidationCodeAttemptsExceeded, gjson.Get(body, "ui.nodes.#(attributes.name==code).messages.0.id").Int(), "%s", body)
+
+			values.Set("code", code)
+			body, res = testhelpers.VerificationMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+			require.EqualValues(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+			assert.EqualValues(t, text.ErrorValidationCodeAttemptsExceeded, gjson.Get(body, "ui.nodes.#(attributes.name==code).messages.0.id").Int(), "%s", body)
+			assert.EqualValues(t, "sent_email", gjson.Get(body, "state").String(), "%s", body)
+		})
+	})
+	t.Run("description=should verify a phone number with a one-time code sent by text message", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyLinkCodeEnabled, true)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyLinkCodeEnabled, false)
+		})
+
+		phone := "+491701234567"
+		id := &identity.Identity{
+			Traits:   identity.Traits(fmt.Sprintf(`{"email":"verify-sms@ory.sh","phone":"%s"}`, phone)),
+			SchemaID: config.DefaultIdentityTraitsSchemaID,
+		}
+		require.NoError(t, reg.IdentityManager().Create(context.Background(), id, identity.ManagerAllowWriteProtectedTraits))
+
+		hc := testhelpers.NewDebugClient(t)
+		f := testhelpers.InitializeVerificationFlowViaAPI(t, hc, public)
+
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		values.Del("email")
+		values.Set("phone", phone)
+		body, res := testhelpers.VerificationMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+		require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.EqualValues(t, phone, gjson.Get(body, "ui.nodes.#(attributes.name==phone).attributes.value").String(), "%s", body)
+		assertx.EqualAsJSON(t, text.NewVerificationPhoneCodeSent(), json.RawMessage(gjson.Get(body, "ui.messages.0").Raw))
+
+		message, err := reg.CourierPersister().LatestQueuedMessage(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, courier.MessageTypeSMS, message.Type)
+		assert.Equal(t, phone, message.Recipient)
+		assert.Contains(t, message.Body, "Your verification code is")
+
+		values.Set("code", testhelpers.CourierExpectCodeInMessage(t, message))
+		body, res = testhelpers.VerificationMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+		require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.EqualValues(t, "passed_challenge", gjson.Get(body, "state").String(), "%s", body)
+
+		address, err := reg.IdentityPool().FindVerifiableAddressByValue(context.Background(), identity.VerifiableAddressTypePhone, phone)
+		require.NoError(t, err)
+		assert.True(t, address.Verified)
+	})
 }
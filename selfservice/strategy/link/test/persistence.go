@@ -19,6 +19,7 @@ import (
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/x"
@@ -91,6 +92,42 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 				require.Error(t, err)
 			})
 
+			t.Run("case=should use a recovery code and lock it after too many wrong attempts", func(t *testing.T) {
+				expected := newRecoveryToken(t, "code-user@ory.sh")
+				expected.Code = "123456"
+				require.NoError(t, p.CreateRecoveryToken(ctx, expected))
+				assert.Equal(t, "123456", expected.Code)
+
+				t.Run("not work on another network", func(t *testing.T) {
+					_, p := testhelpers.NewNetwork(t, ctx, p)
+					_, err := p.UseRecoveryCode(ctx, expected.FlowID.UUID, expected.Code, 2)
+					require.ErrorIs(t, err, sqlcon.ErrNoRows)
+				})
+
+				_, err := p.UseRecoveryCode(ctx, expected.FlowID.UUID, "000000", 2)
+				require.ErrorIs(t, err, link.ErrCodeInvalid)
+
+				actual, err := p.UseRecoveryCode(ctx, expected.FlowID.UUID, expected.Code, 2)
+				require.NoError(t, err)
+				assert.Equal(t, nid, actual.NID)
+				assert.Equal(t, 1, actual.CodeAttempts)
+				assert.Equal(t, expected.IdentityID, actual.IdentityID)
+
+				_, err = p.UseRecoveryCode(ctx, expected.FlowID.UUID, expected.Code, 2)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+				locked := newRecoveryToken(t, "locked-code-user@ory.sh")
+				locked.Code = "654321"
+				require.NoError(t, p.CreateRecoveryToken(ctx, locked))
+
+				_, err = p.UseRecoveryCode(ctx, locked.FlowID.UUID, "000000", 2)
+				require.ErrorIs(t, err, link.ErrCodeInvalid)
+				_, err = p.UseRecoveryCode(ctx, locked.FlowID.UUID, "000000", 2)
+				require.ErrorIs(t, err, link.ErrCodeAttemptsExceeded)
+				_, err = p.UseRecoveryCode(ctx, locked.FlowID.UUID, locked.Code, 2)
+				require.ErrorIs(t, err, link.ErrCodeAttemptsExceeded)
+			})
+
 		})
 
 		t.Run("token=verification", func(t *testing.T) {
@@ -151,6 +188,125 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 				_, err = p.UseVerificationToken(ctx, expected.Token)
 				require.Error(t, err)
 			})
+
+			t.Run("case=should use a verification code and lock it after too many wrong attempts", func(t *testing.T) {
+				expected := newVerificationToken(t, "code-user@ory.sh")
+				expected.Code = "123456"
+				require.NoError(t, p.CreateVerificationToken(ctx, expected))
+				assert.Equal(t, "123456", expected.Code)
+
+				t.Run("not work on another network", func(t *testing.T) {
+					_, p := testhelpers.NewNetwork(t, ctx, p)
+					_, err := p.UseVerificationCode(ctx, expected.FlowID.UUID, expected.Code, 2)
+					require.ErrorIs(t, err, sqlcon.ErrNoRows)
+				})
+
+				_, err := p.UseVerificationCode(ctx, expected.FlowID.UUID, "000000", 2)
+				require.ErrorIs(t, err, link.ErrCodeInvalid)
+
+				actual, err := p.UseVerificationCode(ctx, expected.FlowID.UUID, expected.Code, 2)
+				require.NoError(t, err)
+				assert.Equal(t, nid, actual.NID)
+				assert.Equal(t, 1, actual.CodeAttempts)
+				assert.Equal(t, expected.VerifiableAddress.IdentityID, actual.VerifiableAddress.IdentityID)
+
+				_, err = p.UseVerificationCode(ctx, expected.FlowID.UUID, expected.Code, 2)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+				locked := newVerificationToken(t, "locked-code-user@ory.sh")
+				locked.Code = "654321"
+				require.NoError(t, p.CreateVerificationToken(ctx, locked))
+
+				_, err = p.UseVerificationCode(ctx, locked.FlowID.UUID, "000000", 2)
+				require.ErrorIs(t, err, link.ErrCodeInvalid)
+				_, err = p.UseVerificationCode(ctx, locked.FlowID.UUID, "000000", 2)
+				require.ErrorIs(t, err, link.ErrCodeAttemptsExceeded)
+				_, err = p.UseVerificationCode(ctx, locked.FlowID.UUID, locked.Code, 2)
+				require.ErrorIs(t, err, link.ErrCodeAttemptsExceeded)
+			})
+		})
+
+		t.Run("token=login", func(t *testing.T) {
+			newLoginToken := func(t *testing.T, email string) *link.LoginToken {
+				var req login.Flow
+				require.NoError(t, faker.FakeData(&req))
+				require.NoError(t, p.CreateLoginFlow(ctx, &req))
+
+				var i identity.Identity
+				require.NoError(t, faker.FakeData(&i))
+
+				address := &identity.VerifiableAddress{Value: email, Via: identity.VerifiableAddressTypeEmail, Verified: true}
+				i.VerifiableAddresses = append(i.VerifiableAddresses, *address)
+
+				require.NoError(t, p.CreateIdentity(ctx, &i))
+				return link.NewLoginToken(&i.VerifiableAddresses[0], &req, time.Hour)
+			}
+
+			t.Run("case=should error when the login token does not exist", func(t *testing.T) {
+				_, err := p.UseLoginToken(ctx, "i-do-not-exist")
+				require.Error(t, err)
+			})
+
+			t.Run("case=should create a login token and use it", func(t *testing.T) {
+				expected := newLoginToken(t, "login-user@ory.sh")
+				token, code := expected.Token, expected.Code
+				require.NoError(t, p.CreateLoginToken(ctx, expected))
+				assert.Equal(t, token, expected.Token)
+				assert.Equal(t, code, expected.Code)
+
+				t.Run("not work on another network", func(t *testing.T) {
+					_, p := testhelpers.NewNetwork(t, ctx, p)
+					_, err := p.UseLoginToken(ctx, expected.Token)
+					require.ErrorIs(t, err, sqlcon.ErrNoRows)
+				})
+
+				actual, err := p.UseLoginToken(ctx, expected.Token)
+				require.NoError(t, err)
+				assertx.EqualAsJSONExcept(t, expected.VerifiableAddress, actual.VerifiableAddress, []string{"created_at", "updated_at"})
+				assert.Equal(t, nid, actual.NID)
+				assert.Equal(t, expected.IdentityID, actual.IdentityID)
+				assert.NotEqual(t, expected.Token, actual.Token)
+				assert.EqualValues(t, expected.FlowID, actual.FlowID)
+
+				_, err = p.UseLoginToken(ctx, expected.Token)
+				require.Error(t, err)
+
+				_, err = p.UseLoginCode(ctx, expected.FlowID, expected.Code, 2)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+			})
+
+			t.Run("case=should use a login code and lock it after too many wrong attempts", func(t *testing.T) {
+				expected := newLoginToken(t, "login-code-user@ory.sh")
+				require.NoError(t, p.CreateLoginToken(ctx, expected))
+
+				t.Run("not work on another network", func(t *testing.T) {
+					_, p := testhelpers.NewNetwork(t, ctx, p)
+					_, err := p.UseLoginCode(ctx, expected.FlowID, expected.Code, 2)
+					require.ErrorIs(t, err, sqlcon.ErrNoRows)
+				})
+
+				_, err := p.UseLoginCode(ctx, expected.FlowID, "wrong", 2)
+				require.ErrorIs(t, err, link.ErrCodeInvalid)
+
+				actual, err := p.UseLoginCode(ctx, expected.FlowID, expected.Code, 2)
+				require.NoError(t, err)
+				assert.Equal(t, nid, actual.NID)
+				assert.Equal(t, 1, actual.CodeAttempts)
+				assert.Equal(t, expected.IdentityID, actual.VerifiableAddress.IdentityID)
+
+				_, err = p.UseLoginToken(ctx, expected.Token)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+				locked := newLoginToken(t, "locked-login-code-user@ory.sh")
+				require.NoError(t, p.CreateLoginToken(ctx, locked))
+
+				_, err = p.UseLoginCode(ctx, locked.FlowID, "wrong", 2)
+				require.ErrorIs(t, err, link.ErrCodeInvalid)
+				_, err = p.UseLoginCode(ctx, locked.FlowID, "wrong", 2)
+				require.ErrorIs(t, err, link.ErrCodeAttemptsExceeded)
+				_, err = p.UseLoginCode(ctx, locked.FlowID, locked.Code, 2)
+				require.ErrorIs(t, err, link.ErrCodeAttemptsExceeded)
+			})
 		})
 	}
 }
@@ -0,0 +1,84 @@
+package link
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/randx"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/x"
+)
+
+type LoginToken struct {
+	// ID represents the tokens's unique ID.
+	//
+	// required: true
+	// type: string
+	// format: uuid
+	ID uuid.UUID `json:"id" db:"id" faker:"-"`
+
+	// Token represents the sign-in token which is part of the sign-in link. It can not be longer than 64 chars!
+	Token string `json:"-" db:"token"`
+
+	// Code is the one-time code which can be entered into the login flow instead of following the link.
+	Code string `json:"-" db:"code"`
+
+	// CodeAttempts counts how often a wrong code was submitted for this token's flow.
+	CodeAttempts int `json:"-" faker:"-" db:"code_attempts"`
+
+	// VerifiableAddress links this token to a verified address.
+	// required: true
+	VerifiableAddress *identity.VerifiableAddress `json:"verifiable_address" belongs_to:"identity_verifiable_addresses" fk_id:"VerifiableAddressID"`
+
+	// ExpiresAt is the time (UTC) when the token expires.
+	// required: true
+	ExpiresAt time.Time `json:"expires_at" faker:"time_type" db:"expires_at"`
+
+	// IssuedAt is the time (UTC) when the token was issued.
+	// required: true
+	IssuedAt time.Time `json:"issued_at" faker:"time_type" db:"issued_at"`
+
+	// CreatedAt is a helper struct field for gobuffalo.pop.
+	CreatedAt time.Time `json:"-" faker:"-" db:"created_at"`
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+	// VerifiableAddressID is a helper struct field for gobuffalo.pop.
+	VerifiableAddressID uuid.UUID `json:"-" faker:"-" db:"identity_verifiable_address_id"`
+	// FlowID is a helper struct field for gobuffalo.pop.
+	FlowID     uuid.UUID `json:"-" faker:"-" db:"selfservice_login_flow_id"`
+	NID        uuid.UUID `json:"-"  faker:"-" db:"nid"`
+	IdentityID uuid.UUID `json:"identity_id"  faker:"-" db:"identity_id"`
+}
+
+func (LoginToken) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "identity_login_tokens")
+}
+
+func NewLoginToken(address *identity.VerifiableAddress, f *login.Flow, expiresIn time.Duration) *LoginToken {
+	now := time.Now().UTC()
+	return &LoginToken{
+		ID:                  x.NewUUID(),
+		Token:               randx.MustString(32, randx.AlphaNum),
+		Code:                newCode(),
+		VerifiableAddress:   address,
+		ExpiresAt:           now.Add(expiresIn),
+		IssuedAt:            now,
+		IdentityID:          address.IdentityID,
+		FlowID:              f.ID,
+		VerifiableAddressID: address.ID,
+	}
+}
+
+func (f *LoginToken) Valid() error {
+	if f.ExpiresAt.Before(time.Now()) {
+		return errors.WithStack(flow.NewFlowExpiredError(f.ExpiresAt))
+	}
+	return nil
+}
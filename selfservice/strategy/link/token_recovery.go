@@ -29,6 +29,13 @@ type RecoveryToken struct {
 	// Token represents the recovery token. It can not be longer than 64 chars!
 	Token string `json:"-" db:"token"`
 
+	// Code is the one-time code sent instead of the token if the code mode is enabled.
+	// It is empty for tokens which are delivered as links.
+	Code string `json:"-" db:"code"`
+
+	// CodeAttempts counts how often a wrong code was submitted for this token's flow.
+	CodeAttempts int `json:"-" faker:"-" db:"code_attempts"`
+
 	// RecoveryAddress links this token to a recovery address.
 	// required: true
 	RecoveryAddress *identity.RecoveryAddress `json:"recovery_address" belongs_to:"identity_recovery_addresses" fk_id:"RecoveryAddressID"`
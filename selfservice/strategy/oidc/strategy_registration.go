@@ -206,6 +206,14 @@ func (s *Strategy) processRegistration(w http.ResponseWriter, r *http.Request, a
 		i.Traits = []byte(traits.Raw)
 	}
 
+	// The mapper may also set the identity's metadata, for example to store the plan tier of the upstream account.
+	if metadata := gjson.Get(evaluated, "identity.metadata_public"); metadata.IsObject() {
+		i.MetadataPublic = []byte(metadata.Raw)
+	}
+	if metadata := gjson.Get(evaluated, "identity.metadata_admin"); metadata.IsObject() {
+		i.MetadataAdmin = []byte(metadata.Raw)
+	}
+
 	s.d.Logger().
 		WithRequest(r).
 		WithField("oidc_provider", provider.Config().ID).
@@ -407,6 +407,8 @@ func TestStrategy(t *testing.T) {
 			ai(t, res, body)
 			assert.Equal(t, "https://www.ory.sh/kratos", gjson.GetBytes(body, "identity.traits.website").String(), "%s", body)
 			assert.Equal(t, "valid-name", gjson.GetBytes(body, "identity.traits.name").String(), "%s", body)
+			assert.Equal(t, subject, gjson.GetBytes(body, "identity.metadata_public.sub").String(), "%s", body)
+			assert.False(t, gjson.GetBytes(body, "identity.metadata_admin").Exists(), "%s", body)
 		})
 	})
 
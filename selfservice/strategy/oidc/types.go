@@ -19,9 +19,7 @@ import (
 	"github.com/ory/kratos/x"
 )
 
-type CredentialsConfig struct {
-	Providers []ProviderCredentialsConfig `json:"providers"`
-}
+type CredentialsConfig = identity.CredentialsOIDC
 
 func NewCredentials(idToken, accessToken, refreshToken, provider, subject string) (*identity.Credentials, error) {
 	var b bytes.Buffer
@@ -46,13 +44,7 @@ func NewCredentials(idToken, accessToken, refreshToken, provider, subject string
 	}, nil
 }
 
-type ProviderCredentialsConfig struct {
-	Subject             string `json:"subject"`
-	Provider            string `json:"provider"`
-	InitialIDToken      string `json:"initial_id_token"`
-	InitialAccessToken  string `json:"initial_access_token"`
-	InitialRefreshToken string `json:"initial_refresh_token"`
-}
+type ProviderCredentialsConfig = identity.CredentialsOIDCProvider
 
 type FlowMethod struct {
 	*container.Container
@@ -15,9 +15,11 @@ import (
 
 	"github.com/ory/herodot"
 	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
 
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/ratelimit"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/login"
@@ -62,7 +64,11 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, s.handleLoginError(w, r, f, &p, err)
 	}
 
-	i, c, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(r.Context(), s.ID(), p.Identifier)
+	if err := s.d.RateLimiter().CheckIdentifier(r.Context(), ratelimit.FlowLogin, p.Identifier); err != nil {
+		return nil, s.handleLoginError(w, r, f, &p, err)
+	}
+
+	i, c, err := s.findByIdentifier(r.Context(), p.Identifier)
 	if err != nil {
 		time.Sleep(x.RandomDelay(s.d.Config(r.Context()).HasherArgon2().ExpectedDuration, s.d.Config(r.Context()).HasherArgon2().ExpectedDeviation))
 		return nil, s.handleLoginError(w, r, f, &p, errors.WithStack(schema.NewInvalidCredentialsError()))
@@ -79,9 +85,8 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 	}
 
 	if !s.d.Hasher().Understands([]byte(o.HashedPassword)) {
-		// Migrate password hash if not configured hasher.
-		// But Kratos doesn't have ability to import credentials now.
-		// see https://github.com/ory/kratos/issues/605
+		// The password was hashed by another hasher or imported from a legacy system (e.g. salted SHA-1, scrypt or
+		// PHPass). Now that we know the password, we replace the hash with one of the configured hasher.
 		if err := s.migratePasswordHash(r.Context(), i.ID, []byte(p.Password)); err != nil {
 			return nil, s.handleLoginError(w, r, f, &p, err)
 		}
@@ -96,6 +101,22 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 	return i, nil
 }
 
+// findByIdentifier looks up the identity by the identifier as entered. If no identity matches
+// and the identifier is a phone number, the lookup is repeated using its E.164 representation.
+func (s *Strategy) findByIdentifier(ctx context.Context, identifier string) (*identity.Identity, *identity.Credentials, error) {
+	i, c, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, s.ID(), identifier)
+	if err == nil || !errors.Is(err, sqlcon.ErrNoRows) {
+		return i, c, err
+	}
+
+	phone, perr := identity.NormalizePhoneNumber(identifier, s.d.Config(ctx).IdentityPhoneDefaultRegion())
+	if perr != nil || phone == identifier {
+		return nil, nil, err
+	}
+
+	return s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, s.ID(), phone)
+}
+
 func (s *Strategy) migratePasswordHash(ctx context.Context, identifier uuid.UUID, password []byte) error {
 	hpw, err := s.d.Hasher().Generate(ctx, password)
 	if err != nil {
@@ -689,6 +689,26 @@ func TestCompleteLogin(t *testing.T) {
 		assert.Equal(t, identifier, gjson.Get(body, "identity.traits.subject").String(), "%s", body)
 	})
 
+	t.Run("should login same identity regardless of phone number notation", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyIdentityPhoneDefaultRegion, "DE")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyIdentityPhoneDefaultRegion, "")
+		})
+
+		identifier, pwd := "+491701234568", "password"
+		createIdentity(identifier, pwd)
+
+		browserClient := testhelpers.NewClientWithCookies(t)
+		f := testhelpers.InitializeLoginFlowViaBrowser(t, browserClient, publicTS, false, false)
+
+		values := url.Values{"method": {"password"}, "password_identifier": {"0170 1234568"}, "password": {pwd}, "csrf_token": {x.FakeCSRFToken}}.Encode()
+
+		body, res := testhelpers.LoginMakeRequest(t, false, false, f, browserClient, values)
+
+		assert.EqualValues(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, identifier, gjson.Get(body, "identity.traits.subject").String(), "%s", body)
+	})
+
 	t.Run("should fail as email is not yet verified", func(t *testing.T) {
 		conf.MustSet(config.ViperKeySelfServiceLoginAfter+".password.hooks", []map[string]interface{}{
 			{"hook": "require_verified_address"},
@@ -782,4 +802,60 @@ func TestCompleteLogin(t *testing.T) {
 			false, true, http.StatusOK, redirTS.URL)
 		assert.Equal(t, identifier, gjson.Get(body, "identity.traits.subject").String(), "%s", body)
 	})
+
+	t.Run("should upgrade legacy password hashes", func(t *testing.T) {
+		for k, tc := range []struct {
+			name, password, hash string
+		}{
+			{name: "md5", password: "test", hash: "$md5$a28wbkdMOHQ=$ah3/7dvGaxRf+lHW5k/eWg=="},
+			{name: "sha1", password: "test", hash: "$sha1$pf=e1BBU1NXT1JEfXtTQUxUfQ==$a28wbkdMOHQ=$dPr5nKHcqicYrnwrASWFCZk3eTc="},
+			{name: "sha256", password: "test", hash: "$sha256$a28wbkdMOHQ=$OHBXAzDERsN4HYbczn0EWVlgCNCE6w2MzKUG0HT8E20="},
+			{name: "sha512", password: "test", hash: "$sha512$a28wbkdMOHQ=$K/WHbPlotitO+kr+3a02OShi/yA8cE1BTpmuTBlQa7MCWk1ZPGFxhJuA7dTHV+9nLLQPPbMy7aMLXmE0LDktsQ=="},
+			{name: "scrypt", password: "test", hash: "$scrypt$ln=10,r=8,p=1$c2FsdHNhbHQ$46HvdxUujcDSAj4AkgFODg9HHywm4h9tYKUgs5xgOzI"},
+			{name: "firescrypt", password: "user1password", hash: "$firescrypt$ln=14,r=8,p=1$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ==$Bw==$jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA=="},
+			{name: "phpass", password: "test12345", hash: "$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0"},
+		} {
+			t.Run(fmt.Sprintf("case=%d/hash=%s", k, tc.name), func(t *testing.T) {
+				identifier := x.NewUUID().String()
+				iId := x.NewUUID()
+				require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), &identity.Identity{
+					ID:     iId,
+					Traits: identity.Traits(fmt.Sprintf(`{"subject":"%s"}`, identifier)),
+					Credentials: map[identity.CredentialsType]identity.Credentials{
+						identity.CredentialsTypePassword: {
+							Type:        identity.CredentialsTypePassword,
+							Identifiers: []string{identifier},
+							Config:      sqlxx.JSONRawMessage(`{"hashed_password":"` + tc.hash + `"}`),
+						},
+					},
+					VerifiableAddresses: []identity.VerifiableAddress{
+						{
+							ID:         x.NewUUID(),
+							Value:      identifier,
+							Verified:   true,
+							CreatedAt:  time.Now(),
+							IdentityID: iId,
+						},
+					},
+				}))
+
+				var values = func(v url.Values) {
+					v.Set("password_identifier", identifier)
+					v.Set("password", tc.password)
+				}
+
+				browserClient := testhelpers.NewClientWithCookies(t)
+				body := testhelpers.SubmitLoginForm(t, false, browserClient, publicTS, values,
+					false, false, http.StatusOK, redirTS.URL)
+				assert.Equal(t, identifier, gjson.Get(body, "identity.traits.subject").String(), "%s", body)
+
+				_, c, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(context.Background(), identity.CredentialsTypePassword, identifier)
+				require.NoError(t, err)
+				var o password.CredentialsConfig
+				require.NoError(t, json.NewDecoder(bytes.NewBuffer(c.Config)).Decode(&o))
+				assert.True(t, hash.IsBcryptHash([]byte(o.HashedPassword)), "%s", o.HashedPassword)
+				assert.Nil(t, hash.Compare(context.Background(), []byte(tc.password), []byte(o.HashedPassword)))
+			})
+		}
+	})
 }
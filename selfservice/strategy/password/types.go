@@ -1,14 +1,12 @@
 package password
 
 import (
+	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/ui/container"
 )
 
 // CredentialsConfig is the struct that is being used as part of the identity credentials.
-type CredentialsConfig struct {
-	// HashedPassword is a hash-representation of the password.
-	HashedPassword string `json:"hashed_password"`
-}
+type CredentialsConfig = identity.CredentialsPassword
 
 // submitSelfServiceLoginFlowWithPasswordMethodBody is used to decode the login form payload.
 //
@@ -27,6 +27,17 @@ func CredentialFromWebAuthn(credential *webauthn.Credential) *Credential {
 	}
 }
 
+// PasswordlessOnly returns the credentials which may be used for passwordless login.
+func (c Credentials) PasswordlessOnly() Credentials {
+	result := make(Credentials, 0, len(c))
+	for k := range c {
+		if c[k].IsPasswordless {
+			result = append(result, c[k])
+		}
+	}
+	return result
+}
+
 func (c Credentials) ToWebAuthn() []webauthn.Credential {
 	result := make([]webauthn.Credential, len(c))
 	for k := range c {
@@ -55,6 +66,10 @@ type Credential struct {
 	Authenticator   Authenticator `json:"authenticator"`
 	DisplayName     string        `json:"display_name"`
 	AddedAt         time.Time     `json:"added_at"`
+
+	// IsPasswordless is true if the credential is a discoverable credential (passkey)
+	// which was registered for signing in without a password.
+	IsPasswordless bool `json:"is_passwordless"`
 }
 
 type Authenticator struct {
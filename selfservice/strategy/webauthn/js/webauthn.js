@@ -30,12 +30,16 @@
     }
 
     opt.publicKey.challenge = __oryWebAuthnBufferDecode(opt.publicKey.challenge);
-    opt.publicKey.allowCredentials = opt.publicKey.allowCredentials.map(function (value) {
-      return {
-        ...value,
-        id: __oryWebAuthnBufferDecode(value.id)
-      }
-    });
+    // The allowList is empty for passwordless logins, in which case the browser offers all
+    // discoverable credentials (passkeys) of this relying party.
+    if (opt.publicKey.allowCredentials) {
+      opt.publicKey.allowCredentials = opt.publicKey.allowCredentials.map(function (value) {
+        return {
+          ...value,
+          id: __oryWebAuthnBufferDecode(value.id)
+        }
+      });
+    }
 
     navigator.credentials.get(opt).then(function (credential) {
       document.querySelector(resultQuerySelector).value = JSON.stringify({
@@ -1,6 +1,7 @@
 package webauthn
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"strings"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/duo-labs/webauthn/protocol"
 	"github.com/duo-labs/webauthn/webauthn"
+	"github.com/gofrs/uuid"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 
@@ -24,8 +26,16 @@ import (
 )
 
 func (s *Strategy) PopulateLoginMethod(r *http.Request, requestedAAL identity.AuthenticatorAssuranceLevel, sr *login.Flow) error {
+	if sr.Type != flow.TypeBrowser {
+		return nil
+	}
+
+	if requestedAAL == identity.AuthenticatorAssuranceLevel1 && s.d.Config(r.Context()).WebAuthnForPasswordless() {
+		return s.populateLoginMethodForPasswordless(r, sr)
+	}
+
 	// AAL is configurable for webauth
-	if requestedAAL != identity.AuthenticatorAssuranceLevel2 || sr.Type != flow.TypeBrowser {
+	if requestedAAL != identity.AuthenticatorAssuranceLevel2 {
 		return nil
 	}
 
@@ -66,6 +76,37 @@ func (s *Strategy) PopulateLoginMethod(r *http.Request, requestedAAL identity.Au
 		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to initiate WebAuth login.").WithDebug(err.Error()))
 	}
 
+	return s.populateLoginMethodNodes(r, sr, options, sessionData)
+}
+
+// populateLoginMethodForPasswordless asks the browser for any discoverable credential (passkey) of this
+// relying party. The allowList is empty because we do not know who is signing in yet.
+func (s *Strategy) populateLoginMethodForPasswordless(r *http.Request, sr *login.Flow) error {
+	web, err := s.newWebAuthn(r.Context())
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to initiate WebAuth.").WithDebug(err.Error()))
+	}
+
+	challenge, err := protocol.CreateChallenge()
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to initiate WebAuth login.").WithDebug(err.Error()))
+	}
+
+	options := &protocol.CredentialAssertion{Response: protocol.PublicKeyCredentialRequestOptions{
+		Challenge:        challenge,
+		Timeout:          web.Config.Timeout,
+		RelyingPartyID:   web.Config.RPID,
+		UserVerification: protocol.VerificationPreferred,
+	}}
+	sessionData := &webauthn.SessionData{
+		Challenge:        base64.RawURLEncoding.EncodeToString(challenge),
+		UserVerification: protocol.VerificationPreferred,
+	}
+
+	return s.populateLoginMethodNodes(r, sr, options, sessionData)
+}
+
+func (s *Strategy) populateLoginMethodNodes(r *http.Request, sr *login.Flow, options *protocol.CredentialAssertion, sessionData *webauthn.SessionData) (err error) {
 	// Remove the WebAuthn URL from the internal context now that it is set!
 	sr.InternalContext, err = sjson.SetBytes(sr.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeySessionData), sessionData)
 	if err != nil {
@@ -119,8 +160,11 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, flow.ErrStrategyNotResponsible
 	}
 
-	if err := login.CheckAAL(f, identity.AuthenticatorAssuranceLevel2); err != nil {
-		return nil, err
+	passwordless := f.RequestedAAL == identity.AuthenticatorAssuranceLevel1 && s.d.Config(r.Context()).WebAuthnForPasswordless()
+	if !passwordless {
+		if err := login.CheckAAL(f, identity.AuthenticatorAssuranceLevel2); err != nil {
+			return nil, err
+		}
 	}
 
 	var p submitSelfServiceLoginFlowWithWebAuthnMethodBody
@@ -145,7 +189,27 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, s.handleLoginError(r, f, err)
 	}
 
-	i, c, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(r.Context(), s.ID(), ss.IdentityID.String())
+	webAuthnResponse, err := protocol.ParseCredentialRequestResponseBody(strings.NewReader(p.Login))
+	if err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to parse WebAuthn response.").WithDebug(err.Error())))
+	}
+
+	var webAuthnSess webauthn.SessionData
+	if err := json.Unmarshal([]byte(gjson.GetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeySessionData)).Raw), &webAuthnSess); err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Expected WebAuthN in internal context to be an object but got: %s", err)))
+	}
+
+	identifier := ss.IdentityID
+	if passwordless {
+		// The user handle of a passkey is the ID of the identity it belongs to.
+		identifier, err = uuid.FromBytes(webAuthnResponse.Response.UserHandle)
+		if err != nil {
+			return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewNoWebAuthnRegistered()))
+		}
+		webAuthnSess.UserID = webAuthnResponse.Response.UserHandle
+	}
+
+	i, c, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(r.Context(), s.ID(), identifier.String())
 	if err != nil {
 		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewNoWebAuthnRegistered()))
 	}
@@ -155,22 +219,17 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("The WebAuthn credentials could not be decoded properly").WithDebug(err.Error()).WithWrap(err)))
 	}
 
-	web, err := s.newWebAuthn(r.Context())
-	if err != nil {
-		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to get webAuthn config.").WithDebug(err.Error())))
+	credentials := o.Credentials
+	if passwordless {
+		credentials = credentials.PasswordlessOnly()
 	}
 
-	webAuthnResponse, err := protocol.ParseCredentialRequestResponseBody(strings.NewReader(p.Login))
+	web, err := s.newWebAuthn(r.Context())
 	if err != nil {
-		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to parse WebAuthn response.").WithDebug(err.Error())))
-	}
-
-	var webAuthnSess webauthn.SessionData
-	if err := json.Unmarshal([]byte(gjson.GetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeySessionData)).Raw), &webAuthnSess); err != nil {
-		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Expected WebAuthN in internal context to be an object but got: %s", err)))
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to get webAuthn config.").WithDebug(err.Error())))
 	}
 
-	if _, err := web.ValidateLogin(&wrappedUser{id: i.ID, c: o.Credentials.ToWebAuthn()}, webAuthnSess, webAuthnResponse); err != nil {
+	if _, err := web.ValidateLogin(&wrappedUser{id: i.ID, c: credentials.ToWebAuthn()}, webAuthnSess, webAuthnResponse); err != nil {
 		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewWebAuthnVerifierWrongError("#/")))
 	}
 
@@ -180,6 +239,14 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, s.handleLoginError(r, f, errors.WithStack(err))
 	}
 
+	if passwordless {
+		f.InternalContext, err = sjson.SetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeyAAL),
+			passwordlessAAL(webAuthnResponse.Response.AuthenticatorData.Flags))
+		if err != nil {
+			return nil, s.handleLoginError(r, f, errors.WithStack(err))
+		}
+	}
+
 	f.Active = s.ID()
 	if err = s.d.LoginFlowPersister().UpdateLoginFlow(r.Context(), f); err != nil {
 		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("Could not update flow").WithDebug(err.Error())))
@@ -187,3 +254,9 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 
 	return i, nil
 }
+
+// CompletedLoginAAL returns the assurance level of a passwordless login. Logins using WebAuthn
+// as a second factor do not assert an assurance level on their own.
+func (s *Strategy) CompletedLoginAAL(f *login.Flow) identity.AuthenticatorAssuranceLevel {
+	return identity.AuthenticatorAssuranceLevel(gjson.GetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeyAAL)).String())
+}
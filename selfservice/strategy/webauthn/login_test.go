@@ -3,6 +3,7 @@ package webauthn_test
 import (
 	"context"
 	_ "embed"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"net/url"
@@ -15,6 +16,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
@@ -203,4 +205,94 @@ func TestCompleteLogin(t *testing.T) {
 			run(t, true)
 		})
 	})
+
+	t.Run("case=passwordless", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyWebAuthnPasswordless, true)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyWebAuthnPasswordless, false)
+		})
+
+		// The replayed assertion does not carry the user verification flag.
+		passwordlessInternalContext := []byte(`{"webauthn_session_data":{"challenge":"WzZCWULmaq5xTAlA0YYHlqoubqAhe1AWdLRZCIBAMcM","userVerification":"preferred"}}`)
+
+		createPasswordlessIdentity := func(t *testing.T, isPasswordless bool) *identity.Identity {
+			var id identity.Identity
+			require.NoError(t, json.Unmarshal(loginFixtureSuccessIdentity, &id))
+			credentials, err := sjson.SetBytes(loginFixtureSuccessCredentials, "credentials.0.is_passwordless", isPasswordless)
+			require.NoError(t, err)
+			id.Credentials = map[identity.CredentialsType]identity.Credentials{
+				identity.CredentialsTypeWebAuthn: {
+					Identifiers: []string{id.ID.String()},
+					Config:      credentials,
+					Type:        identity.CredentialsTypeWebAuthn,
+				}}
+			_ = reg.PrivilegedIdentityPool().DeleteIdentity(context.Background(), id.ID)
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), &id))
+			return &id
+		}
+
+		submit := func(t *testing.T, spa bool, id *identity.Identity) (string, *http.Response) {
+			browserClient := testhelpers.NewClientWithCookies(t)
+			f := testhelpers.InitializeLoginFlowViaBrowser(t, browserClient, publicTS, false, spa)
+
+			// We inject the session to replay
+			interim, err := reg.LoginFlowPersister().GetLoginFlow(context.Background(), uuid.FromStringOrNil(f.Id))
+			require.NoError(t, err)
+			interim.InternalContext = passwordlessInternalContext
+			require.NoError(t, reg.LoginFlowPersister().UpdateLoginFlow(context.Background(), interim))
+
+			response, err := sjson.SetBytes(loginFixtureSuccessResponse, "response.userHandle", base64.RawURLEncoding.EncodeToString(id.ID.Bytes()))
+			require.NoError(t, err)
+
+			values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+			values.Set(node.WebAuthnLogin, string(response))
+			return testhelpers.LoginMakeRequest(t, false, spa, f, browserClient, values.Encode())
+		}
+
+		t.Run("case=login trigger has an empty allow list", func(t *testing.T) {
+			f := testhelpers.InitializeLoginFlowViaBrowser(t, testhelpers.NewClientWithCookies(t), publicTS, false, true)
+			actual, err := json.Marshal(f.Ui.Nodes)
+			require.NoError(t, err)
+			onclick := gjson.GetBytes(actual, "#(attributes.name==webauthn_login_trigger).attributes.onclick").String()
+			assert.Contains(t, onclick, "__oryWebAuthnLogin")
+			assert.NotContains(t, onclick, "allowCredentials")
+		})
+
+		t.Run("case=login with a passkey", func(t *testing.T) {
+			run := func(t *testing.T, spa bool) {
+				id := createPasswordlessIdentity(t, true)
+				body, res := submit(t, spa, id)
+
+				prefix := ""
+				if spa {
+					assert.Contains(t, res.Request.URL.String(), publicTS.URL+login.RouteSubmitFlow)
+					prefix = "session."
+				} else {
+					assert.Contains(t, res.Request.URL.String(), redirTS.URL)
+				}
+
+				assert.True(t, gjson.Get(body, prefix+"active").Bool(), "%s", body)
+				assert.EqualValues(t, identity.AuthenticatorAssuranceLevel1, gjson.Get(body, prefix+"authenticator_assurance_level").String(), "%s", body)
+				assert.EqualValues(t, identity.AuthenticatorAssuranceLevel1, gjson.Get(body, prefix+"authentication_methods.#(method==webauthn).aal").String(), "%s", body)
+				assert.EqualValues(t, id.ID.String(), gjson.Get(body, prefix+"identity.id").String(), "%s", body)
+			}
+
+			t.Run("type=browser", func(t *testing.T) {
+				run(t, false)
+			})
+
+			t.Run("type=spa", func(t *testing.T) {
+				run(t, true)
+			})
+		})
+
+		t.Run("case=fails if the credential is not a passkey", func(t *testing.T) {
+			id := createPasswordlessIdentity(t, false)
+			body, res := submit(t, true, id)
+
+			assert.Contains(t, res.Request.URL.String(), publicTS.URL+login.RouteSubmitFlow)
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+			assert.Empty(t, gjson.Get(body, "session").String(), "%s", body)
+		})
+	})
 }
@@ -0,0 +1,258 @@
+package webauthn
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/duo-labs/webauthn/protocol"
+	"github.com/duo-labs/webauthn/webauthn"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/container"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+)
+
+// InternalContextKeyAAL stores the assurance level asserted by the passkey used in a
+// passwordless registration or login.
+const InternalContextKeyAAL = "aal"
+
+// submitSelfServiceRegistrationFlowWithWebAuthnMethodBody is used to decode the registration form payload
+// when using the WebAuthn method.
+//
+// swagger:model submitSelfServiceRegistrationFlowWithWebAuthnMethodBody
+type submitSelfServiceRegistrationFlowWithWebAuthnMethodBody struct {
+	// Register a WebAuthn Security Key
+	//
+	// It is expected that the JSON returned by the WebAuthn registration process
+	// is included here.
+	Register string `json:"webauthn_register"`
+
+	// Name of the WebAuthn Security Key to be Added
+	//
+	// A human-readable name for the security key which will be added.
+	RegisterDisplayName string `json:"webauthn_register_displayname"`
+
+	// The identity's traits
+	//
+	// required: true
+	Traits json.RawMessage `json:"traits"`
+
+	// CSRFToken is the anti-CSRF token
+	CSRFToken string `json:"csrf_token"`
+
+	// Method
+	//
+	// Should be set to "webauthn" when trying to register with a WebAuthn security key.
+	//
+	// required: true
+	Method string `json:"method"`
+}
+
+func (s *Strategy) RegisterRegistrationRoutes(_ *x.RouterPublic) {
+}
+
+func (s *Strategy) handleRegistrationError(_ http.ResponseWriter, r *http.Request, f *registration.Flow, p *submitSelfServiceRegistrationFlowWithWebAuthnMethodBody, err error) error {
+	if f != nil {
+		if p != nil {
+			for _, n := range container.NewFromJSON("", node.WebAuthnGroup, p.Traits, "traits").Nodes {
+				// we only set the value and not the whole field because we want to keep types from the initial form generation
+				f.UI.Nodes.SetValueAttribute(n.ID(), n.Attributes.GetValue())
+			}
+		}
+
+		f.UI.Nodes.ResetNodes(node.WebAuthnRegister)
+		if f.Type == flow.TypeBrowser {
+			f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+		}
+	}
+
+	return err
+}
+
+func (s *Strategy) decodeRegistration(r *http.Request, p *submitSelfServiceRegistrationFlowWithWebAuthnMethodBody) error {
+	raw, err := sjson.SetBytes(registrationSchema,
+		"properties.traits.$ref", s.d.Config(r.Context()).DefaultIdentityTraitsSchemaURL().String()+"#/properties/traits")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(raw)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return s.hd.Decode(r, p, compiler,
+		decoderx.HTTPKeepRequestBody(true),
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.HTTPDecoderJSONFollowsFormFormat())
+}
+
+func (s *Strategy) Register(w http.ResponseWriter, r *http.Request, f *registration.Flow, i *identity.Identity) (err error) {
+	if f.Type != flow.TypeBrowser || !s.d.Config(r.Context()).WebAuthnForPasswordless() {
+		return flow.ErrStrategyNotResponsible
+	}
+
+	var p submitSelfServiceRegistrationFlowWithWebAuthnMethodBody
+	if err := s.decodeRegistration(r, &p); err != nil {
+		return s.handleRegistrationError(w, r, f, &p, err)
+	}
+
+	if len(p.Register) == 0 {
+		return flow.ErrStrategyNotResponsible
+	}
+
+	// This method has only one submit button
+	p.Method = s.ID().String()
+	if err := flow.MethodEnabledAndAllowed(r.Context(), s.ID().String(), p.Method, s.d); err != nil {
+		return s.handleRegistrationError(w, r, f, &p, err)
+	}
+
+	if err := flow.EnsureCSRF(s.d, r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return s.handleRegistrationError(w, r, f, &p, err)
+	}
+
+	if len(p.Traits) == 0 {
+		p.Traits = json.RawMessage("{}")
+	}
+	i.Traits = identity.Traits(p.Traits)
+
+	webAuthnSession := gjson.GetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeySessionData))
+	if !webAuthnSession.IsObject() {
+		return s.handleRegistrationError(w, r, f, &p, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Expected WebAuthN in internal context to be an object.")))
+	}
+
+	var webAuthnSess webauthn.SessionData
+	if err := json.Unmarshal([]byte(webAuthnSession.Raw), &webAuthnSess); err != nil {
+		return s.handleRegistrationError(w, r, f, &p, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Expected WebAuthN in internal context to be an object but got: %s", err)))
+	}
+
+	// The user handle of the passkey is the ID of the identity which is about to be created.
+	userID, err := uuid.FromBytes(webAuthnSess.UserID)
+	if err != nil {
+		return s.handleRegistrationError(w, r, f, &p, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Expected WebAuthN user handle to be a UUID.").WithDebug(err.Error())))
+	}
+
+	webAuthnResponse, err := protocol.ParseCredentialCreationResponseBody(strings.NewReader(p.Register))
+	if err != nil {
+		return s.handleRegistrationError(w, r, f, &p, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to parse WebAuthn response: %s", err)))
+	}
+
+	web, err := s.newWebAuthn(r.Context())
+	if err != nil {
+		return s.handleRegistrationError(w, r, f, &p, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to get webAuthn config.").WithDebug(err.Error())))
+	}
+
+	credential, err := web.CreateCredential(&wrappedUser{id: userID}, webAuthnSess, webAuthnResponse)
+	if err != nil {
+		return s.handleRegistrationError(w, r, f, &p, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to create WebAuthn credential: %s", err)))
+	}
+
+	wc := CredentialFromWebAuthn(credential)
+	wc.AddedAt = time.Now().UTC().Round(time.Second)
+	wc.DisplayName = p.RegisterDisplayName
+	wc.IsPasswordless = true
+
+	co, err := json.Marshal(&CredentialsConfig{Credentials: Credentials{*wc}})
+	if err != nil {
+		return s.handleRegistrationError(w, r, f, &p, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode identity credentials.").WithDebug(err.Error())))
+	}
+
+	i.ID = userID
+	i.SetCredentials(s.ID(), identity.Credentials{Type: s.ID(), Identifiers: []string{userID.String()}, IdentityID: userID, Config: co})
+
+	if err := s.d.IdentityValidator().Validate(r.Context(), i); err != nil {
+		return s.handleRegistrationError(w, r, f, &p, err)
+	}
+
+	// Remove the WebAuthn session data from the internal context now that the credential was created.
+	f.InternalContext, err = sjson.DeleteBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeySessionData))
+	if err != nil {
+		return s.handleRegistrationError(w, r, f, &p, errors.WithStack(err))
+	}
+
+	f.InternalContext, err = sjson.SetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeyAAL),
+		passwordlessAAL(webAuthnResponse.Response.AttestationObject.AuthData.Flags))
+	if err != nil {
+		return s.handleRegistrationError(w, r, f, &p, errors.WithStack(err))
+	}
+
+	return nil
+}
+
+func (s *Strategy) CompletedRegistrationAAL(f *registration.Flow) identity.AuthenticatorAssuranceLevel {
+	return identity.AuthenticatorAssuranceLevel(gjson.GetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeyAAL)).String())
+}
+
+func (s *Strategy) PopulateRegistrationMethod(r *http.Request, f *registration.Flow) error {
+	if f.Type != flow.TypeBrowser || !s.d.Config(r.Context()).WebAuthnForPasswordless() {
+		return nil
+	}
+
+	nodes, err := container.NodesFromJSONSchema(node.WebAuthnGroup, s.d.Config(r.Context()).DefaultIdentityTraitsSchemaURL().String(), "", nil)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range nodes {
+		// Traits might already have been added by another strategy, e.g. password.
+		if f.UI.Nodes.Find(n.ID()) == nil {
+			f.UI.SetNode(n)
+		}
+	}
+
+	web, err := s.newWebAuthn(r.Context())
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to initiate WebAuth.").WithDebug(err.Error()))
+	}
+
+	// Passkeys must be discoverable so that they can be used without an identifier.
+	requireResidentKey := true
+	option, sessionData, err := web.BeginRegistration(&wrappedUser{id: x.NewUUID()},
+		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+			RequireResidentKey: &requireResidentKey,
+			UserVerification:   protocol.VerificationPreferred,
+		}))
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to initiate WebAuth registration.").WithDebug(err.Error()))
+	}
+
+	f.InternalContext, err = sjson.SetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeySessionData), sessionData)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	injectWebAuthnOptions, err := json.Marshal(option)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	f.UI.Nodes.Upsert(NewWebAuthnScript(urlx.AppendPaths(s.d.Config(r.Context()).SelfPublicURL(), webAuthnRoute).String(), jsOnLoad))
+	f.UI.Nodes.Upsert(NewWebAuthnConnectionTrigger(string(injectWebAuthnOptions)).
+		WithMetaLabel(text.NewInfoSelfServiceRegistrationRegisterWebAuthn()))
+	f.UI.Nodes.Upsert(NewWebAuthnConnectionInput())
+	return nil
+}
+
+// passwordlessAAL returns the assurance level a passkey asserted. A passkey which verified the
+// user (e.g. with a PIN or biometrics) is a multi-factor authenticator.
+func passwordlessAAL(flags protocol.AuthenticatorFlags) identity.AuthenticatorAssuranceLevel {
+	if flags.UserVerified() {
+		return identity.AuthenticatorAssuranceLevel2
+	}
+	return identity.AuthenticatorAssuranceLevel1
+}
@@ -0,0 +1,127 @@
+package webauthn_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/strategy/webauthn"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+)
+
+func TestRegistration(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypePassword)+".enabled", false)
+	enableWebAuthn(conf)
+	conf.MustSet(config.ViperKeyWebAuthnPasswordless, true)
+
+	router := x.NewRouterPublic()
+	publicTS, _ := testhelpers.NewKratosServerWithRouters(t, reg, router, x.NewRouterAdmin())
+
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	_ = testhelpers.NewRegistrationUIFlowEchoServer(t, reg)
+	redirTS := testhelpers.NewRedirSessionEchoTS(t, reg)
+
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/login.schema.json")
+	conf.MustSet(config.ViperKeySecretsDefault, []string{"not-a-secure-session-key"})
+	conf.MustSet(config.HookStrategyKey(config.ViperKeySelfServiceRegistrationAfter, identity.CredentialsTypeWebAuthn.String()), []config.SelfServiceHook{{Name: "session"}})
+
+	// The user handle recorded in the settings fixture's session data.
+	expectedID := uuid.FromStringOrNil("6e11a9a7-62fd-4c88-871a-097f18f0306f")
+
+	t.Run("case=registration trigger requests a discoverable credential", func(t *testing.T) {
+		f := testhelpers.InitializeRegistrationFlowViaBrowser(t, testhelpers.NewClientWithCookies(t), publicTS, true)
+		actual, err := json.Marshal(f.Ui.Nodes)
+		require.NoError(t, err)
+		onclick := gjson.GetBytes(actual, "#(attributes.name==webauthn_register_trigger).attributes.onclick").String()
+		assert.Contains(t, onclick, `"requireResidentKey":true`)
+	})
+
+	t.Run("case=not shown if passwordless is disabled", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyWebAuthnPasswordless, false)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyWebAuthnPasswordless, true)
+		})
+
+		f := testhelpers.InitializeRegistrationFlowViaBrowser(t, testhelpers.NewClientWithCookies(t), publicTS, true)
+		actual, err := json.Marshal(f.Ui.Nodes)
+		require.NoError(t, err)
+		assert.False(t, gjson.GetBytes(actual, "#(attributes.name==webauthn_register_trigger)").Exists())
+	})
+
+	t.Run("case=register with a passkey", func(t *testing.T) {
+		run := func(t *testing.T, spa bool) {
+			_ = reg.PrivilegedIdentityPool().DeleteIdentity(context.Background(), expectedID)
+
+			browserClient := testhelpers.NewClientWithCookies(t)
+			f := testhelpers.InitializeRegistrationFlowViaBrowser(t, browserClient, publicTS, spa)
+
+			// We inject the session to replay
+			interim, err := reg.RegistrationFlowPersister().GetRegistrationFlow(context.Background(), uuid.FromStringOrNil(f.Id))
+			require.NoError(t, err)
+			interim.InternalContext = settingsFixtureSuccessInternalContext
+			require.NoError(t, reg.RegistrationFlowPersister().UpdateRegistrationFlow(context.Background(), interim))
+
+			values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+			values.Set(node.WebAuthnRegister, string(settingsFixtureSuccessResponse))
+			values.Set(node.WebAuthnRegisterDisplayName, "my passkey")
+			body, res := testhelpers.RegistrationMakeRequest(t, false, spa, f, browserClient, values.Encode())
+
+			prefix := ""
+			if spa {
+				assert.Contains(t, res.Request.URL.String(), publicTS.URL+registration.RouteSubmitFlow)
+				prefix = "session."
+			} else {
+				assert.Contains(t, res.Request.URL.String(), redirTS.URL)
+			}
+
+			assert.True(t, gjson.Get(body, prefix+"active").Bool(), "%s", body)
+			assert.EqualValues(t, expectedID.String(), gjson.Get(body, prefix+"identity.id").String(), "%s", body)
+			assert.EqualValues(t, identity.AuthenticatorAssuranceLevel1, gjson.Get(body, prefix+"authenticator_assurance_level").String(), "%s", body)
+
+			i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), expectedID)
+			require.NoError(t, err)
+			c, ok := i.GetCredentials(identity.CredentialsTypeWebAuthn)
+			require.True(t, ok)
+			assert.Equal(t, []string{expectedID.String()}, c.Identifiers)
+
+			var cc webauthn.CredentialsConfig
+			require.NoError(t, json.Unmarshal(c.Config, &cc))
+			require.Len(t, cc.Credentials, 1)
+			assert.True(t, cc.Credentials[0].IsPasswordless)
+			assert.Equal(t, "my passkey", cc.Credentials[0].DisplayName)
+		}
+
+		t.Run("type=browser", func(t *testing.T) {
+			run(t, false)
+		})
+
+		t.Run("type=spa", func(t *testing.T) {
+			run(t, true)
+		})
+	})
+
+	t.Run("case=fails if the webauthn response is invalid", func(t *testing.T) {
+		browserClient := testhelpers.NewClientWithCookies(t)
+		f := testhelpers.InitializeRegistrationFlowViaBrowser(t, browserClient, publicTS, true)
+
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		values.Set(node.WebAuthnRegister, "{}")
+		body, res := testhelpers.RegistrationMakeRequest(t, false, true, f, browserClient, values.Encode())
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+		assert.Contains(t, gjson.Get(body, "ui.messages.0.text").String(), "Unable to parse WebAuthn response", "%s", body)
+	})
+}
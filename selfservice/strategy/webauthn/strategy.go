@@ -23,6 +23,9 @@ import (
 )
 
 var _ login.Strategy = new(Strategy)
+var _ login.AssuranceLevelReporter = new(Strategy)
+var _ registration.Strategy = new(Strategy)
+var _ registration.AssuranceLevelReporter = new(Strategy)
 var _ settings.Strategy = new(Strategy)
 var _ identity.ActiveCredentialsCounter = new(Strategy)
 
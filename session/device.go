@@ -0,0 +1,134 @@
+package session
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/identity"
+)
+
+const (
+	// maxDevices is the number of devices remembered per session. If more devices are seen, the device
+	// which was seen least recently is forgotten.
+	maxDevices = 10
+
+	// deviceSeenInterval is how often the last seen time of a device is updated when it is used repeatedly.
+	deviceSeenInterval = 5 * time.Minute
+)
+
+// Device Information
+//
+// Describes a device the session was used on.
+//
+// swagger:model sessionDevice
+type Device struct {
+	// IPAddress is the IP address the device used.
+	IPAddress string `json:"ip_address"`
+
+	// UserAgent of this device
+	UserAgent string `json:"user_agent"`
+
+	// Location is the coarse location of the IP address. It is only set if a geolocation database is
+	// configured and the IP address was found in it.
+	Location *Location `json:"location,omitempty"`
+
+	// AAL is the authenticator assurance level the session had when it was last used on this device.
+	AAL identity.AuthenticatorAssuranceLevel `json:"aal"`
+
+	// FirstSeenAt is the time at which the session was first used on this device.
+	FirstSeenAt time.Time `json:"first_seen_at"`
+
+	// LastSeenAt is the time at which the session was last used on this device.
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+// Device Location
+//
+// The coarse location of an IP address.
+//
+// swagger:model sessionDeviceLocation
+type Location struct {
+	// Country is the ISO 3166-1 alpha-2 code of the country, for example `DE`.
+	Country string `json:"country,omitempty"`
+
+	// City is the name of the city, for example `Berlin`.
+	City string `json:"city,omitempty"`
+}
+
+// List of Devices
+//
+// The devices the session was used on, most recently used first.
+//
+// swagger:model sessionDevices
+type Devices []Device
+
+// SeenOn records that the session was used on the device at the given time. The device is identified by its
+// IP address and user agent. It returns true if the device list changed.
+func (s *Session) SeenOn(d Device, at time.Time) bool {
+	at = at.UTC()
+	for k := range s.Devices {
+		known := &s.Devices[k]
+		if known.IPAddress != d.IPAddress || known.UserAgent != d.UserAgent {
+			continue
+		}
+
+		if known.AAL == s.AuthenticatorAssuranceLevel && at.Sub(known.LastSeenAt) < deviceSeenInterval &&
+			(d.Location == nil || (known.Location != nil && *known.Location == *d.Location)) {
+			return false
+		}
+
+		known.AAL = s.AuthenticatorAssuranceLevel
+		known.LastSeenAt = at
+		if d.Location != nil {
+			known.Location = d.Location
+		}
+		s.Devices.sort()
+		return true
+	}
+
+	d.AAL = s.AuthenticatorAssuranceLevel
+	d.FirstSeenAt = at
+	d.LastSeenAt = at
+	s.Devices = append(s.Devices, d)
+	s.Devices.sort()
+	if len(s.Devices) > maxDevices {
+		s.Devices = s.Devices[:maxDevices]
+	}
+	return true
+}
+
+func (n Devices) sort() {
+	sort.SliceStable(n, func(i, j int) bool {
+		return n[i].LastSeenAt.After(n[j].LastSeenAt)
+	})
+}
+
+// Scan implements the Scanner interface.
+func (n *Devices) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	v := fmt.Sprintf("%s", value)
+	if len(v) == 0 {
+		return nil
+	}
+	return errors.WithStack(json.Unmarshal([]byte(v), n))
+}
+
+// Value implements the driver Valuer interface.
+func (n Devices) Value() (driver.Value, error) {
+	if n == nil {
+		n = Devices{}
+	}
+	value, err := json.Marshal(n)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return string(value), nil
+}
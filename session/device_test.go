@@ -0,0 +1,91 @@
+package session_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/session"
+)
+
+func TestSessionDevices(t *testing.T) {
+	chrome := session.Device{IPAddress: "203.0.113.1", UserAgent: "Chrome"}
+	berlin := &session.Location{Country: "DE", City: "Berlin"}
+	now := time.Now().UTC()
+
+	t.Run("case=records new devices", func(t *testing.T) {
+		s := session.NewInactiveSession()
+		s.AuthenticatorAssuranceLevel = identity.AuthenticatorAssuranceLevel1
+
+		assert.True(t, s.SeenOn(chrome, now))
+		firefox := session.Device{IPAddress: "203.0.113.2", UserAgent: "Firefox", Location: berlin}
+		assert.True(t, s.SeenOn(firefox, now.Add(time.Minute)))
+
+		require.Len(t, s.Devices, 2)
+		assert.Equal(t, "Firefox", s.Devices[0].UserAgent, "most recently used device comes first")
+		assert.Equal(t, berlin, s.Devices[0].Location)
+		assert.Equal(t, identity.AuthenticatorAssuranceLevel1, s.Devices[0].AAL)
+		assert.Equal(t, now.Add(time.Minute), s.Devices[0].FirstSeenAt)
+		assert.Equal(t, now.Add(time.Minute), s.Devices[0].LastSeenAt)
+		assert.Equal(t, "Chrome", s.Devices[1].UserAgent)
+		assert.Nil(t, s.Devices[1].Location)
+	})
+
+	t.Run("case=updates known devices", func(t *testing.T) {
+		s := session.NewInactiveSession()
+		s.AuthenticatorAssuranceLevel = identity.AuthenticatorAssuranceLevel1
+		require.True(t, s.SeenOn(chrome, now))
+
+		assert.False(t, s.SeenOn(chrome, now.Add(time.Minute)), "last seen time is only updated after a while")
+		assert.Equal(t, now, s.Devices[0].LastSeenAt)
+
+		assert.True(t, s.SeenOn(chrome, now.Add(time.Hour)))
+		require.Len(t, s.Devices, 1)
+		assert.Equal(t, now, s.Devices[0].FirstSeenAt)
+		assert.Equal(t, now.Add(time.Hour), s.Devices[0].LastSeenAt)
+
+		s.AuthenticatorAssuranceLevel = identity.AuthenticatorAssuranceLevel2
+		assert.True(t, s.SeenOn(chrome, now.Add(time.Hour+time.Second)), "reaching a higher AAL is recorded immediately")
+		assert.Equal(t, identity.AuthenticatorAssuranceLevel2, s.Devices[0].AAL)
+
+		located := chrome
+		located.Location = berlin
+		assert.True(t, s.SeenOn(located, now.Add(time.Hour+2*time.Second)))
+		assert.Equal(t, berlin, s.Devices[0].Location)
+		assert.False(t, s.SeenOn(chrome, now.Add(time.Hour+3*time.Second)))
+		assert.Equal(t, berlin, s.Devices[0].Location, "an unknown location does not replace a known one")
+	})
+
+	t.Run("case=forgets least recently used devices", func(t *testing.T) {
+		s := session.NewInactiveSession()
+		for i := 0; i < 15; i++ {
+			s.SeenOn(session.Device{IPAddress: fmt.Sprintf("203.0.113.%d", i), UserAgent: "Chrome"}, now.Add(time.Duration(i)*time.Minute))
+		}
+
+		require.Len(t, s.Devices, 10)
+		assert.Equal(t, "203.0.113.14", s.Devices[0].IPAddress)
+		assert.Equal(t, "203.0.113.5", s.Devices[9].IPAddress)
+	})
+
+	t.Run("case=sql", func(t *testing.T) {
+		var d session.Devices
+		require.NoError(t, d.Scan(""))
+		assert.Nil(t, d)
+
+		v, err := d.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "[]", v)
+
+		d = session.Devices{{IPAddress: "203.0.113.1", UserAgent: "Chrome", Location: berlin, AAL: identity.AuthenticatorAssuranceLevel1, FirstSeenAt: now, LastSeenAt: now}}
+		v, err = d.Value()
+		require.NoError(t, err)
+
+		var actual session.Devices
+		require.NoError(t, actual.Scan(v))
+		assert.Equal(t, d, actual)
+	})
+}
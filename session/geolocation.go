@@ -0,0 +1,146 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// Geolocator determines the coarse location of IP addresses.
+	Geolocator interface {
+		// Locate returns the location of the IP address or nil if the location is unknown.
+		Locate(ctx context.Context, ip net.IP) *Location
+	}
+	GeolocatorProvider interface {
+		SessionGeolocator(ctx context.Context) Geolocator
+	}
+
+	// NoopGeolocator never knows the location of an IP address. It is used when no geolocation database is
+	// configured.
+	NoopGeolocator struct{}
+
+	// FileGeolocator looks up IP addresses in a local geolocation database.
+	FileGeolocator struct {
+		networks []geoNetwork
+	}
+	geoNetwork struct {
+		first, last net.IP
+		location    Location
+	}
+)
+
+var (
+	_ Geolocator = new(NoopGeolocator)
+	_ Geolocator = new(FileGeolocator)
+)
+
+func (g *NoopGeolocator) Locate(context.Context, net.IP) *Location {
+	return nil
+}
+
+// NewFileGeolocator loads the geolocation database from the file at path. See ParseGeolocationDatabase for
+// the file format.
+func NewFileGeolocator(path string) (*FileGeolocator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer f.Close()
+
+	g, err := ParseGeolocationDatabase(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse geolocation database %s", path)
+	}
+	return g, nil
+}
+
+// ParseGeolocationDatabase parses a geolocation database in CSV format. Each line contains a network in CIDR
+// notation, the ISO 3166-1 alpha-2 code of the country, and optionally the name of the city:
+//
+//	network,country,city
+//	203.0.113.0/24,DE,Berlin
+//	2001:db8::/32,US,
+//
+// The header line is optional and lines starting with `#` are ignored. Networks must not overlap.
+func ParseGeolocationDatabase(r io.Reader) (*FileGeolocator, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var g FileGeolocator
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		if line == 1 && record[0] == "network" {
+			continue
+		}
+
+		if len(record) < 2 {
+			return nil, errors.Errorf("line %d: expected at least a network and a country but got %d fields", line, len(record))
+		}
+
+		_, network, err := net.ParseCIDR(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+
+		n := geoNetwork{first: network.IP.To16(), last: make(net.IP, net.IPv6len)}
+		mask := network.Mask
+		if len(mask) == net.IPv4len {
+			mask = append(net.CIDRMask(96, 128)[:12:12], mask...)
+		}
+		for i := range n.first {
+			n.last[i] = n.first[i] | ^mask[i]
+		}
+
+		n.location.Country = strings.ToUpper(strings.TrimSpace(record[1]))
+		if len(record) > 2 {
+			n.location.City = strings.TrimSpace(record[2])
+		}
+		g.networks = append(g.networks, n)
+	}
+
+	sort.Slice(g.networks, func(i, j int) bool {
+		return bytes.Compare(g.networks[i].first, g.networks[j].first) < 0
+	})
+	for i := 1; i < len(g.networks); i++ {
+		if bytes.Compare(g.networks[i].first, g.networks[i-1].last) <= 0 {
+			return nil, errors.Errorf("networks starting at %s and %s overlap", g.networks[i-1].first, g.networks[i].first)
+		}
+	}
+
+	return &g, nil
+}
+
+func (g *FileGeolocator) Locate(_ context.Context, ip net.IP) *Location {
+	ip = ip.To16()
+	if ip == nil {
+		return nil
+	}
+
+	// Find the first network which starts after the IP address. The network before it is the only one which
+	// can contain the IP address.
+	i := sort.Search(len(g.networks), func(i int) bool {
+		return bytes.Compare(g.networks[i].first, ip) > 0
+	})
+	if i == 0 || bytes.Compare(ip, g.networks[i-1].last) > 0 {
+		return nil
+	}
+
+	location := g.networks[i-1].location
+	return &location
+}
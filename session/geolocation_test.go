@@ -0,0 +1,81 @@
+package session_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/session"
+)
+
+func TestFileGeolocator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("case=locates addresses", func(t *testing.T) {
+		g, err := session.ParseGeolocationDatabase(strings.NewReader(`network,country,city
+# A comment
+203.0.113.0/24,de,Berlin
+198.51.100.0/25,US,
+192.0.2.128/25, FR, Paris
+2001:db8::/32,JP,Tokyo
+`))
+		require.NoError(t, err)
+
+		for _, tc := range []struct {
+			ip       string
+			expected *session.Location
+		}{
+			{ip: "203.0.113.0", expected: &session.Location{Country: "DE", City: "Berlin"}},
+			{ip: "203.0.113.255", expected: &session.Location{Country: "DE", City: "Berlin"}},
+			{ip: "203.0.114.0"},
+			{ip: "198.51.100.127", expected: &session.Location{Country: "US"}},
+			{ip: "198.51.100.128"},
+			{ip: "192.0.2.127"},
+			{ip: "192.0.2.200", expected: &session.Location{Country: "FR", City: "Paris"}},
+			{ip: "2001:db8:ffff::1", expected: &session.Location{Country: "JP", City: "Tokyo"}},
+			{ip: "2001:db9::1"},
+			{ip: "::ffff:203.0.113.7", expected: &session.Location{Country: "DE", City: "Berlin"}},
+			{ip: "127.0.0.1"},
+		} {
+			t.Run("ip="+tc.ip, func(t *testing.T) {
+				assert.Equal(t, tc.expected, g.Locate(ctx, net.ParseIP(tc.ip)))
+			})
+		}
+
+		assert.Nil(t, g.Locate(ctx, nil))
+	})
+
+	t.Run("case=rejects invalid databases", func(t *testing.T) {
+		for _, db := range []string{
+			"203.0.113.0/24",
+			"203.0.113.0,DE,Berlin",
+			"203.0.113.0/24,DE\n203.0.113.128/25,DE",
+			"203.0.113.0/24,DE,\"Berlin",
+		} {
+			_, err := session.ParseGeolocationDatabase(strings.NewReader(db))
+			assert.Error(t, err, db)
+		}
+	})
+
+	t.Run("case=loads file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "geolocation.csv")
+		require.NoError(t, os.WriteFile(path, []byte("203.0.113.0/24,DE,Berlin\n"), 0600))
+
+		g, err := session.NewFileGeolocator(path)
+		require.NoError(t, err)
+		assert.Equal(t, &session.Location{Country: "DE", City: "Berlin"}, g.Locate(ctx, net.ParseIP("203.0.113.1")))
+
+		_, err = session.NewFileGeolocator(filepath.Join(t.TempDir(), "does-not-exist.csv"))
+		require.Error(t, err)
+	})
+
+	t.Run("case=noop", func(t *testing.T) {
+		assert.Nil(t, new(session.NoopGeolocator).Locate(ctx, net.ParseIP("203.0.113.1")))
+	})
+}
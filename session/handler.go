@@ -1,6 +1,7 @@
 package session
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/ory/herodot"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/webhook"
 	"github.com/ory/kratos/x"
 )
 
@@ -26,6 +28,7 @@ type (
 		x.LoggingProvider
 		x.CSRFProvider
 		config.Provider
+		webhook.EmitterProvider
 	}
 	HandlerProvider interface {
 		SessionHandler() *Handler
@@ -49,18 +52,25 @@ const (
 	RouteCollection         = "/sessions"
 	RouteWhoami             = RouteCollection + "/whoami"
 	RouteSession            = RouteCollection + "/:id"
+	RouteSessionExtend      = RouteSession + "/extend"
 	RouteIdentity           = "/identities"
 	RouteIdentitiesSessions = RouteIdentity + "/:id/sessions"
 )
 
 func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
-	for _, m := range []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch} {
+	for _, m := range []string{http.MethodHead, http.MethodPost, http.MethodPut} {
 		// Redirect to public endpoint
 		admin.Handle(m, RouteWhoami, x.RedirectToPublicRoute(h.r))
 	}
 
+	// The router does not allow the whoami route next to the session routes for the same method, which is why
+	// the session routes redirect the whoami endpoint themselves.
+	admin.GET(RouteSession, h.redirectWhoami(h.adminGetSession))
+	admin.DELETE(RouteSession, h.redirectWhoami(h.adminRevokeSession))
+	admin.PATCH(RouteSession, h.redirectWhoami(nil))
+	admin.PATCH(RouteSessionExtend, h.adminExtendSession)
+
 	admin.DELETE(RouteCollection, x.RedirectToPublicRoute(h.r))
-	admin.DELETE(RouteSession, x.RedirectToPublicRoute(h.r))
 	admin.GET(RouteCollection, x.RedirectToPublicRoute(h.r))
 
 	admin.GET(RouteIdentitiesSessions, h.adminListIdentitySessions)
@@ -186,7 +196,13 @@ func (h *Handler) whoami(w http.ResponseWriter, r *http.Request, ps httprouter.P
 		return
 	}
 
-	// s.Devices = nil
+	if h.r.SessionManager().TrackDevice(r.Context(), r, s) {
+		// Failing to record the device must not prevent the session from being used.
+		if err := h.r.SessionPersister().UpdateSessionDevices(r.Context(), s.ID, s.Devices); err != nil {
+			h.r.Logger().WithRequest(r).WithError(err).Warn("Unable to record the device the session was used on.")
+		}
+	}
+
 	s.Identity = s.Identity.CopyWithoutCredentials()
 
 	// Set userId as the X-Kratos-Authenticated-Identity-Id header.
@@ -230,11 +246,18 @@ func (h *Handler) adminDeleteIdentitySessions(w http.ResponseWriter, r *http.Req
 		h.r.Writer().WriteError(w, r, herodot.ErrBadRequest.WithError(err.Error()).WithDebug("could not parse UUID"))
 		return
 	}
+	revoked, err := h.sessionsForRevokedEvent(r.Context(), iID, uuid.Nil)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
 	if err := h.r.SessionPersister().DeleteSessionsByIdentity(r.Context(), iID); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
+	h.emitRevoked(r.Context(), revoked...)
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -299,6 +322,141 @@ func (h *Handler) adminListIdentitySessions(w http.ResponseWriter, r *http.Reque
 	h.r.Writer().Write(w, r, sess)
 }
 
+// redirectWhoami redirects calls to the whoami endpoint to the public API and passes all other calls to the
+// handler. If the handler is nil, all other calls result in a 404 error.
+func (h *Handler) redirectWhoami(handler httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if ps.ByName("id") == "whoami" {
+			x.RedirectToPublicRoute(h.r)(w, r, ps)
+			return
+		}
+
+		if handler == nil {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrNotFound.WithReasonf("The requested resource could not be found.")))
+			return
+		}
+
+		handler(w, r, ps)
+	}
+}
+
+// swagger:parameters adminGetSession adminExtendSession
+// nolint:deadcode,unused
+type adminGetSession struct {
+	// ID is the session's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /sessions/{id} v0alpha2 adminGetSession
+//
+// Get a Session
+//
+// This endpoint returns the session with the given ID, regardless of whether it is active or not, together with
+// the identity it belongs to.
+//
+// This endpoint is useful for:
+//
+// - Inspecting a session in an administrative context, for example when investigating an incident.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: session
+//       400: jsonError
+//       404: jsonError
+//       500: jsonError
+func (h *Handler) adminGetSession(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.sessionFromPath(r, ps)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, s.Declassify())
+}
+
+// adminRevokeSession revokes any session, including sessions the caller has no access to. It is documented
+// together with the public endpoint in revokeSession.
+func (h *Handler) adminRevokeSession(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.sessionFromPath(r, ps)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.SessionPersister().RevokeSession(r.Context(), s.IdentityID, s.ID); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.emitRevoked(r.Context(), s)
+	h.r.Audit().WithRequest(r).WithField("session_id", s.ID).WithField("identity_id", s.IdentityID).Info("Revoked session using the admin API.")
+	h.r.Writer().WriteCode(w, r, http.StatusNoContent, nil)
+}
+
+// swagger:route PATCH /sessions/{id}/extend v0alpha2 adminExtendSession
+//
+// Extend a Session
+//
+// Calling this endpoint extends the session with the given ID by the configured session lifespan, starting now.
+// Expired sessions can be extended as well, while revoked sessions can not.
+//
+// This endpoint is useful for:
+//
+// - Keeping a user signed in during an incident, for example when the login is unavailable.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: session
+//       400: jsonError
+//       404: jsonError
+//       500: jsonError
+func (h *Handler) adminExtendSession(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.sessionFromPath(r, ps)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if !s.Active {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The session was revoked and can not be extended.")))
+		return
+	}
+
+	if err := h.r.SessionPersister().UpsertSession(r.Context(), s.Refresh(h.r.Config(r.Context()))); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Audit().WithRequest(r).WithField("session_id", s.ID).WithField("identity_id", s.IdentityID).Info("Extended session using the admin API.")
+	h.r.Writer().Write(w, r, s.Declassify())
+}
+
+func (h *Handler) sessionFromPath(r *http.Request, ps httprouter.Params) (*Session, error) {
+	sid, err := uuid.FromString(ps.ByName("id"))
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithError(err.Error()).WithDebug("could not parse UUID"))
+	}
+
+	return h.r.SessionPersister().GetSession(r.Context(), sid)
+}
+
 // swagger:model revokedSessions
 type revokeSessions struct {
 	// The number of sessions that were revoked.
@@ -330,12 +488,19 @@ func (h *Handler) revokeSessions(w http.ResponseWriter, r *http.Request, _ httpr
 		return
 	}
 
+	revoked, err := h.sessionsForRevokedEvent(r.Context(), s.IdentityID, s.ID)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
 	n, err := h.r.SessionPersister().RevokeSessionsIdentityExcept(r.Context(), s.IdentityID, s.ID)
 	if err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
+	h.emitRevoked(r.Context(), revoked...)
 	h.r.Writer().WriteCode(w, r, http.StatusOK, &revokeSessions{Count: n})
 }
 
@@ -354,9 +519,12 @@ type revokeSession struct {
 // Calling this endpoint invalidates the specified session. The current session cannot be revoked.
 // Session data are not deleted.
 //
+// When called on the admin API, any session can be revoked without providing a session cookie or token.
+//
 // This endpoint is useful for:
 //
 // - To forcefully logout the current user from another device or session
+// - To forcefully logout a compromised session in an administrative context
 //
 //     Schemes: http, https
 //
@@ -364,6 +532,7 @@ type revokeSession struct {
 //       204: emptyResponse
 //       400: jsonError
 //       401: jsonError
+//       404: jsonError
 //       500: jsonError
 func (h *Handler) revokeSession(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sid := ps.ByName("id")
@@ -390,14 +559,56 @@ func (h *Handler) revokeSession(w http.ResponseWriter, r *http.Request, ps httpr
 		return
 	}
 
+	var revoked *Session
+	if h.r.WebHookEmitter().HasSubscribers(r.Context(), webhook.EventSessionRevoked) {
+		revoked, err = h.r.SessionPersister().GetSession(r.Context(), sessionID)
+		if err != nil {
+			h.r.Writer().WriteError(w, r, err)
+			return
+		}
+	}
+
 	if err := h.r.SessionPersister().RevokeSession(r.Context(), s.Identity.ID, sessionID); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
+	if revoked != nil {
+		h.emitRevoked(r.Context(), revoked)
+	}
 	h.r.Writer().WriteCode(w, r, http.StatusNoContent, nil)
 }
 
+// sessionsForRevokedEvent lists the identity's active sessions before they are revoked so that they can be sent to
+// the web hooks which are subscribed to the `session.revoked` event. It returns nil if no web hook is subscribed.
+func (h *Handler) sessionsForRevokedEvent(ctx context.Context, iID, except uuid.UUID) ([]*Session, error) {
+	if !h.r.WebHookEmitter().HasSubscribers(ctx, webhook.EventSessionRevoked) {
+		return nil, nil
+	}
+
+	const perPage = 100
+	active := true
+	var sessions []*Session
+	for page := 1; ; page++ {
+		s, err := h.r.SessionPersister().ListSessionsByIdentity(ctx, iID, &active, page, perPage, except)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s...)
+		if len(s) < perPage {
+			return sessions, nil
+		}
+	}
+}
+
+// emitRevoked calls the web hooks which are subscribed to the `session.revoked` event.
+func (h *Handler) emitRevoked(ctx context.Context, sessions ...*Session) {
+	for _, s := range sessions {
+		s.Active = false
+		h.r.WebHookEmitter().Emit(ctx, webhook.EventSessionRevoked, s.EventData())
+	}
+}
+
 // swagger:parameters listSessions
 // nolint:deadcode,unused
 type listSessions struct {
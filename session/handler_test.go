@@ -2,6 +2,7 @@ package session_test
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/ory/kratos/corpx"
 	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/stretchr/testify/assert"
@@ -106,6 +108,24 @@ func TestSessionWhoAmI(t *testing.T) {
 		})
 	})
 
+	t.Run("case=exposes public but not admin metadata", func(t *testing.T) {
+		i := createAAL1Identity(t, reg)
+		i.MetadataPublic = sqlxx.NullJSONRawMessage(`{"plan":"pro"}`)
+		i.MetadataAdmin = sqlxx.NullJSONRawMessage(`{"risk":"high"}`)
+		h, _ := testhelpers.MockSessionCreateHandlerWithIdentityAndAMR(t, reg, i, []identity.CredentialsType{identity.CredentialsTypePassword})
+		r.GET("/set/metadata", h)
+
+		client := testhelpers.NewClientWithCookies(t)
+		testhelpers.MockHydrateCookieClient(t, client, ts.URL+"/set/metadata")
+
+		res, err := client.Get(ts.URL + RouteWhoami)
+		require.NoError(t, err)
+		body := x.MustReadAll(res.Body)
+		require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.JSONEq(t, `{"plan":"pro"}`, gjson.GetBytes(body, "identity.metadata_public").Raw, "%s", body)
+		assert.False(t, gjson.GetBytes(body, "identity.metadata_admin").Exists(), "%s", body)
+	})
+
 	t.Run("case=http methods", func(t *testing.T) {
 		client := testhelpers.NewClientWithCookies(t)
 
@@ -486,6 +506,147 @@ func TestHandlerAdminSessionManagement(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("suite=single session", func(t *testing.T) {
+		client := testhelpers.NewClientWithCookies(t)
+		i := identity.NewIdentity("")
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+
+		newSession := func(t *testing.T) *Session {
+			s, err := NewActiveSession(i, conf, time.Now(), identity.CredentialsTypePassword)
+			require.NoError(t, err)
+			require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+			return s
+		}
+
+		do := func(t *testing.T, method, href string, expectCode int) gjson.Result {
+			req, _ := http.NewRequest(method, ts.URL+href, nil)
+			res, err := client.Do(req)
+			require.NoError(t, err)
+			body, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			require.Equal(t, expectCode, res.StatusCode, "%s", body)
+			return gjson.ParseBytes(body)
+		}
+
+		t.Run("case=should get a session", func(t *testing.T) {
+			s := newSession(t)
+			res := do(t, "GET", "/sessions/"+s.ID.String(), http.StatusOK)
+			assert.Equal(t, s.ID.String(), res.Get("id").String(), "%s", res.Raw)
+			assert.Equal(t, i.ID.String(), res.Get("identity.id").String(), "%s", res.Raw)
+			assert.False(t, res.Get("identity.credentials").Exists(), "%s", res.Raw)
+		})
+
+		t.Run("case=should revoke a session", func(t *testing.T) {
+			s := newSession(t)
+			do(t, "DELETE", "/sessions/"+s.ID.String(), http.StatusNoContent)
+
+			actual, err := reg.SessionPersister().GetSession(ctx, s.ID)
+			require.NoError(t, err)
+			assert.False(t, actual.Active)
+			assert.False(t, do(t, "GET", "/sessions/"+s.ID.String(), http.StatusOK).Get("active").Bool())
+		})
+
+		t.Run("case=should extend a session", func(t *testing.T) {
+			lifespan := conf.SessionLifespan()
+			conf.MustSet(config.ViperKeySessionLifespan, "24h")
+			t.Cleanup(func() {
+				conf.MustSet(config.ViperKeySessionLifespan, lifespan.String())
+			})
+
+			s := newSession(t)
+			s.ExpiresAt = time.Now().Add(-time.Minute)
+			require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+
+			res := do(t, "PATCH", "/sessions/"+s.ID.String()+"/extend", http.StatusOK)
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), res.Get("expires_at").Time(), time.Minute, "%s", res.Raw)
+
+			actual, err := reg.SessionPersister().GetSession(ctx, s.ID)
+			require.NoError(t, err)
+			assert.True(t, actual.IsActive())
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), actual.ExpiresAt, time.Minute)
+		})
+
+		t.Run("case=should not extend a revoked session", func(t *testing.T) {
+			s := newSession(t)
+			do(t, "DELETE", "/sessions/"+s.ID.String(), http.StatusNoContent)
+			do(t, "PATCH", "/sessions/"+s.ID.String()+"/extend", http.StatusBadRequest)
+		})
+
+		t.Run("case=should return 404 for unknown sessions", func(t *testing.T) {
+			id := x.NewUUID().String()
+			do(t, "GET", "/sessions/"+id, http.StatusNotFound)
+			do(t, "DELETE", "/sessions/"+id, http.StatusNotFound)
+			do(t, "PATCH", "/sessions/"+id+"/extend", http.StatusNotFound)
+		})
+
+		t.Run("case=should return 400 for bad UUIDs", func(t *testing.T) {
+			do(t, "GET", "/sessions/BADUUID", http.StatusBadRequest)
+			do(t, "DELETE", "/sessions/BADUUID", http.StatusBadRequest)
+			do(t, "PATCH", "/sessions/BADUUID/extend", http.StatusBadRequest)
+		})
+
+		t.Run("case=should redirect whoami to the public API", func(t *testing.T) {
+			c := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+			for _, method := range []string{"GET", "DELETE", "PATCH", "POST"} {
+				t.Run("http_method="+method, func(t *testing.T) {
+					req, _ := http.NewRequest(method, ts.URL+RouteWhoami, nil)
+					res, err := c.Do(req)
+					require.NoError(t, err)
+					require.NoError(t, res.Body.Close())
+					assert.Equal(t, http.StatusTemporaryRedirect, res.StatusCode)
+					assert.Contains(t, res.Header.Get("Location"), RouteWhoami)
+				})
+			}
+		})
+	})
+
+	t.Run("case=should emit revoked events when invalidating all sessions", func(t *testing.T) {
+		events := make(chan gjson.Result, 10)
+		hookTS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			events <- gjson.ParseBytes(body)
+		}))
+		t.Cleanup(hookTS.Close)
+
+		conf.MustSet(config.ViperKeyWebHookSubscriptions, []map[string]interface{}{{
+			"events": []string{"session.revoked"},
+			"config": map[string]interface{}{
+				"url":    hookTS.URL,
+				"method": "POST",
+				"body":   "base64://" + base64.StdEncoding.EncodeToString([]byte(`function(ctx) { event: ctx.event, session_id: ctx.session.id, identity_id: ctx.identity.id }`)),
+			},
+		}})
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyWebHookSubscriptions, nil)
+		})
+
+		i := identity.NewIdentity("")
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+		expected := map[string]bool{}
+		for k := 0; k < 2; k++ {
+			s, err := NewActiveSession(i, conf, time.Now(), identity.CredentialsTypePassword)
+			require.NoError(t, err)
+			require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+			expected[s.ID.String()] = true
+		}
+
+		req, _ := http.NewRequest("DELETE", ts.URL+"/identities/"+i.ID.String()+"/sessions", nil)
+		res, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, res.StatusCode)
+
+		actual := map[string]bool{}
+		for k := 0; k < 2; k++ {
+			e := <-events
+			assert.Equal(t, "session.revoked", e.Get("event").String(), "%s", e.Raw)
+			assert.Equal(t, i.ID.String(), e.Get("identity_id").String(), "%s", e.Raw)
+			actual[e.Get("session_id").String()] = true
+		}
+		assert.Equal(t, expected, actual)
+		assert.Len(t, events, 0)
+	})
 }
 
 func TestHandlerSelfServiceSessionManagement(t *testing.T) {
@@ -497,6 +658,7 @@ func TestHandlerSelfServiceSessionManagement(t *testing.T) {
 	conf.MustSet(config.ViperKeyPublicBaseURL, "http://example.com")
 	testhelpers.SetDefaultIdentitySchema(t, conf, "file://./stub/identity.schema.json")
 	conf.MustSet(config.ViperKeyPublicBaseURL, ts.URL)
+	conf.MustSet(config.ViperKeySessionDevicesGeolocationDatabase, "stub/geolocation.csv")
 
 	var setup func(t *testing.T) (*http.Client, *identity.Identity, *Session)
 	{
@@ -599,4 +761,46 @@ func TestHandlerSelfServiceSessionManagement(t *testing.T) {
 			assert.Equal(t, http.StatusNoContent, resp.StatusCode, "case=%d", j)
 		}
 	})
+
+	t.Run("case=should list devices of other sessions", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyPublicTrustedProxies, []string{"127.0.0.1", "::1"})
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyPublicTrustedProxies, nil)
+		})
+		client, i, _ := setup(t)
+
+		otherSess := Session{}
+		require.NoError(t, faker.FakeData(&otherSess))
+		otherSess.Identity = i
+		otherSess.Active = true
+		otherSess.ExpiresAt = time.Now().Add(time.Hour)
+		otherSess.AMR = nil
+		otherSess.CompletedLoginFor(identity.CredentialsTypePassword)
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, &otherSess))
+
+		req, _ := http.NewRequest("GET", ts.URL+RouteWhoami, nil)
+		req.Header.Set("X-Session-Token", otherSess.Token)
+		req.Header.Set("User-Agent", "Chrome")
+		req.Header.Set("X-Forwarded-For", "203.0.113.1")
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		body := x.MustReadAll(res.Body)
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.Equal(t, "Chrome", gjson.GetBytes(body, "devices.0.user_agent").String(), "%s", body)
+
+		res, err = client.Get(ts.URL + RouteCollection)
+		require.NoError(t, err)
+		body = x.MustReadAll(res.Body)
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		require.EqualValues(t, 1, gjson.GetBytes(body, "#").Int(), "%s", body)
+
+		device := gjson.GetBytes(body, "0.devices.0")
+		assert.Equal(t, "203.0.113.1", device.Get("ip_address").String(), "%s", body)
+		assert.Equal(t, "Chrome", device.Get("user_agent").String(), "%s", body)
+		assert.Equal(t, "DE", device.Get("location.country").String(), "%s", body)
+		assert.Equal(t, "Berlin", device.Get("location.city").String(), "%s", body)
+		assert.Equal(t, "aal1", device.Get("aal").String(), "%s", body)
+		assert.NotEmpty(t, device.Get("first_seen_at").String(), "%s", body)
+		assert.Equal(t, device.Get("first_seen_at").String(), device.Get("last_seen_at").String(), "%s", body)
+	})
 }
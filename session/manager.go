@@ -76,11 +76,18 @@ type Manager interface {
 	// PurgeFromRequest removes an HTTP session.
 	PurgeFromRequest(context.Context, http.ResponseWriter, *http.Request) error
 
+	// RevokeSessionByToken revokes the session with the given token.
+	RevokeSessionByToken(ctx context.Context, token string) error
+
 	// DoesSessionSatisfy answers if a session is satisfying the AAL.
 	DoesSessionSatisfy(r *http.Request, sess *Session, requestedAAL string) error
 
 	// SessionAddAuthenticationMethod adds one or more authentication method to the session.
 	SessionAddAuthenticationMethod(ctx context.Context, sid uuid.UUID, method ...identity.CredentialsType) error
+
+	// TrackDevice records the device which sent the request in the session's device list. It does not store
+	// the session and returns true if the device list changed.
+	TrackDevice(ctx context.Context, r *http.Request, sess *Session) bool
 }
 
 type ManagementProvider interface {
@@ -2,8 +2,10 @@ package session
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/ory/x/urlx"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/ory/herodot"
 
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/webhook"
 	"github.com/ory/kratos/x"
 )
 
@@ -29,6 +32,9 @@ type (
 		x.CookieProvider
 		x.CSRFProvider
 		PersistenceProvider
+		GeolocatorProvider
+		x.LoggingProvider
+		webhook.EmitterProvider
 	}
 	ManagerHTTP struct {
 		cookieName func(ctx context.Context) string
@@ -46,6 +52,8 @@ func NewManagerHTTP(r managerHTTPDependencies) *ManagerHTTP {
 }
 
 func (s *ManagerHTTP) UpsertAndIssueCookie(ctx context.Context, w http.ResponseWriter, r *http.Request, ss *Session) error {
+	s.TrackDevice(ctx, r, ss)
+	event := ss.UpsertEvent()
 	if err := s.r.SessionPersister().UpsertSession(ctx, ss); err != nil {
 		return err
 	}
@@ -54,6 +62,7 @@ func (s *ManagerHTTP) UpsertAndIssueCookie(ctx context.Context, w http.ResponseW
 		return err
 	}
 
+	s.r.WebHookEmitter().Emit(ctx, event, ss.EventData())
 	return nil
 }
 
@@ -143,6 +152,10 @@ func (s *ManagerHTTP) FetchFromRequest(ctx context.Context, r *http.Request) (*S
 		return nil, err
 	}
 
+	if se.Active && !se.ExpiresAt.After(time.Now()) {
+		s.expire(ctx, se)
+	}
+
 	if !se.IsActive() {
 		return nil, errors.WithStack(NewErrNoActiveSessionFound())
 	}
@@ -153,7 +166,7 @@ func (s *ManagerHTTP) FetchFromRequest(ctx context.Context, r *http.Request) (*S
 
 func (s *ManagerHTTP) PurgeFromRequest(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 	if token, ok := bearerTokenFromRequest(r); ok {
-		return errors.WithStack(s.r.SessionPersister().RevokeSessionByToken(ctx, token))
+		return s.RevokeSessionByToken(ctx, token)
 	}
 
 	cookie, _ := s.r.CookieManager(r.Context()).Get(r, s.cookieName(ctx))
@@ -162,8 +175,8 @@ func (s *ManagerHTTP) PurgeFromRequest(ctx context.Context, w http.ResponseWrite
 		return nil
 	}
 
-	if err := s.r.SessionPersister().RevokeSessionByToken(ctx, token); err != nil {
-		return errors.WithStack(err)
+	if err := s.RevokeSessionByToken(ctx, token); err != nil {
+		return err
 	}
 
 	cookie.Options.MaxAge = -1
@@ -173,6 +186,41 @@ func (s *ManagerHTTP) PurgeFromRequest(ctx context.Context, w http.ResponseWrite
 	return nil
 }
 
+// RevokeSessionByToken revokes the session with the given token and calls the web hooks which are subscribed to
+// the `session.revoked` event.
+func (s *ManagerHTTP) RevokeSessionByToken(ctx context.Context, token string) error {
+	var revoked *Session
+	if s.r.WebHookEmitter().HasSubscribers(ctx, webhook.EventSessionRevoked) {
+		var err error
+		revoked, err = s.r.SessionPersister().GetSessionByToken(ctx, token)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := s.r.SessionPersister().RevokeSessionByToken(ctx, token); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if revoked != nil {
+		revoked.Active = false
+		s.r.WebHookEmitter().Emit(ctx, webhook.EventSessionRevoked, revoked.EventData())
+	}
+	return nil
+}
+
+// expire marks a session which is used after it expired as inactive and calls the web hooks which are subscribed
+// to the `session.expired` event. Because the session is inactive afterwards, the event is emitted only once.
+func (s *ManagerHTTP) expire(ctx context.Context, se *Session) {
+	if err := s.r.SessionPersister().RevokeSession(ctx, se.IdentityID, se.ID); err != nil {
+		s.r.Logger().WithError(err).WithField("session_id", se.ID).Warn("Unable to mark the expired session as inactive.")
+		return
+	}
+
+	se.Active = false
+	s.r.WebHookEmitter().Emit(ctx, webhook.EventSessionExpired, se.EventData())
+}
+
 func (s *ManagerHTTP) DoesSessionSatisfy(r *http.Request, sess *Session, requestedAAL string) error {
 	sess.SetAuthenticatorAssuranceLevel()
 	switch requestedAAL {
@@ -214,3 +262,11 @@ func (s *ManagerHTTP) SessionAddAuthenticationMethod(ctx context.Context, sid uu
 	sess.SetAuthenticatorAssuranceLevel()
 	return s.r.SessionPersister().UpsertSession(ctx, sess)
 }
+
+func (s *ManagerHTTP) TrackDevice(ctx context.Context, r *http.Request, sess *Session) bool {
+	d := Device{IPAddress: x.TrustedClientIP(r, s.r.Config(ctx).PublicTrustedProxies()), UserAgent: r.UserAgent()}
+	if ip := net.ParseIP(d.IPAddress); ip != nil {
+		d.Location = s.r.SessionGeolocator(ctx).Locate(ctx, ip)
+	}
+	return sess.SeenOn(d, time.Now())
+}
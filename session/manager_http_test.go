@@ -2,7 +2,9 @@ package session_test
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -17,6 +19,7 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
@@ -339,6 +342,87 @@ func TestManagerHTTP(t *testing.T) {
 			assert.EqualValues(t, http.StatusUnauthorized, res.StatusCode)
 		})
 
+		t.Run("case=emits web hook events", func(t *testing.T) {
+			events := make(chan gjson.Result, 10)
+			hookTS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				events <- gjson.ParseBytes(body)
+			}))
+			t.Cleanup(hookTS.Close)
+
+			conf.MustSet(config.ViperKeyWebHookSubscriptions, []map[string]interface{}{{
+				"events": []string{"session.issued", "session.refreshed", "session.revoked", "session.expired"},
+				"config": map[string]interface{}{
+					"url":    hookTS.URL,
+					"method": "POST",
+					"body": "base64://" + base64.StdEncoding.EncodeToString([]byte(`function(ctx) {
+  event: ctx.event,
+  session_id: ctx.session.id,
+  active: ctx.session.active,
+  identity_id: ctx.identity.id,
+}`)),
+				},
+			}})
+			t.Cleanup(func() {
+				conf.MustSet(config.ViperKeyWebHookSubscriptions, nil)
+				conf.MustSet(config.ViperKeySessionLifespan, "1m")
+			})
+
+			expectEvent := func(t *testing.T, event string, active bool) {
+				e := <-events
+				assert.Equal(t, event, e.Get("event").String(), "%s", e.Raw)
+				assert.Equal(t, s.ID.String(), e.Get("session_id").String(), "%s", e.Raw)
+				assert.Equal(t, active, e.Get("active").Bool(), "%s", e.Raw)
+				assert.Equal(t, s.IdentityID.String(), e.Get("identity_id").String(), "%s", e.Raw)
+			}
+
+			i := identity.Identity{Traits: []byte("{}")}
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), &i))
+
+			t.Run("event=issued, refreshed and revoked", func(t *testing.T) {
+				conf.MustSet(config.ViperKeySessionLifespan, "1m")
+				s, _ = session.NewActiveSession(&i, conf, time.Now(), identity.CredentialsTypePassword)
+
+				c := testhelpers.NewClientWithCookies(t)
+				testhelpers.MockHydrateCookieClient(t, c, pts.URL+"/session/set")
+				expectEvent(t, "session.issued", true)
+
+				// Storing the session again, for example when re-authenticating, does not issue it again.
+				res, err := c.Get(pts.URL + "/session/set")
+				require.NoError(t, err)
+				assert.EqualValues(t, http.StatusOK, res.StatusCode)
+				expectEvent(t, "session.refreshed", true)
+
+				res, err = c.Get(pts.URL + "/session/revoke")
+				require.NoError(t, err)
+				assert.EqualValues(t, http.StatusOK, res.StatusCode)
+				expectEvent(t, "session.revoked", false)
+			})
+
+			t.Run("event=expired", func(t *testing.T) {
+				conf.MustSet(config.ViperKeySessionLifespan, "1ns")
+				s, _ = session.NewActiveSession(&i, conf, time.Now(), identity.CredentialsTypePassword)
+
+				c := testhelpers.NewClientWithCookies(t)
+				testhelpers.MockHydrateCookieClient(t, c, pts.URL+"/session/set")
+				expectEvent(t, "session.issued", true)
+
+				time.Sleep(time.Nanosecond * 2)
+
+				for k := 0; k < 2; k++ {
+					res, err := c.Get(pts.URL + "/session/get")
+					require.NoError(t, err)
+					assert.EqualValues(t, http.StatusUnauthorized, res.StatusCode)
+				}
+				expectEvent(t, "session.expired", false)
+				assert.Len(t, events, 0, "the event must only be emitted once")
+
+				actual, err := reg.SessionPersister().GetSession(context.Background(), s.ID)
+				require.NoError(t, err)
+				assert.False(t, actual.Active)
+			})
+		})
+
 		t.Run("case=respects AAL config", func(t *testing.T) {
 			conf.MustSet(config.ViperKeySessionLifespan, "1m")
 
@@ -54,6 +54,9 @@ type Persister interface {
 
 	// RevokeSessionsIdentityExcept marks all except the given session of an identity inactive. It returns the number of sessions that were revoked.
 	RevokeSessionsIdentityExcept(ctx context.Context, iID, sID uuid.UUID) (int, error)
+
+	// UpdateSessionDevices stores the device list of a session without changing any other field.
+	UpdateSessionDevices(ctx context.Context, sID uuid.UUID, devices Devices) error
 }
 
 func TestPersister(ctx context.Context, conf *config.Config, p interface {
@@ -14,6 +14,7 @@ import (
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/corp"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/webhook"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/randx"
 )
@@ -68,6 +69,11 @@ type Session struct {
 	// When this session was issued at. Usually equal or close to `authenticated_at`.
 	IssuedAt time.Time `json:"issued_at" db:"issued_at" faker:"time_type"`
 
+	// Devices
+	//
+	// The devices this session was used on, most recently used first.
+	Devices Devices `json:"devices" faker:"-" db:"devices"`
+
 	// The Logout Token
 	//
 	// Use this token to log out a user.
@@ -97,17 +103,23 @@ func (s Session) TableName(ctx context.Context) string {
 }
 
 func (s *Session) CompletedLoginFor(method identity.CredentialsType) {
+	s.CompletedLoginForWithAAL(method, "")
+}
+
+// CompletedLoginForWithAAL is like CompletedLoginFor but also records the assurance level
+// the method asserted on its own, for example when a WebAuthn passkey was used without a password.
+func (s *Session) CompletedLoginForWithAAL(method identity.CredentialsType, aal identity.AuthenticatorAssuranceLevel) {
 	s.AMR = append(s.AMR,
-		AuthenticationMethod{Method: method, CompletedAt: time.Now().UTC()})
+		AuthenticationMethod{Method: method, AAL: aal, CompletedAt: time.Now().UTC()})
 }
 
 func (s *Session) SetAuthenticatorAssuranceLevel() {
-	cts := make([]identity.CredentialsType, len(s.AMR))
+	methods := make([]identity.CompletedAuthenticationMethod, len(s.AMR))
 	for k := range s.AMR {
-		cts[k] = s.AMR[k].Method
+		methods[k] = identity.CompletedAuthenticationMethod{Method: s.AMR[k].Method, AAL: s.AMR[k].AAL}
 	}
 
-	s.AuthenticatorAssuranceLevel = identity.DetermineAAL(cts)
+	s.AuthenticatorAssuranceLevel = identity.DetermineAALFromMethods(methods)
 }
 
 func NewActiveSession(i *identity.Identity, c lifespanProvider, authenticatedAt time.Time, completedLoginFor identity.CredentialsType) (*Session, error) {
@@ -126,6 +138,7 @@ func NewInactiveSession() *Session {
 		LogoutToken:                 randx.MustString(32, randx.AlphaNum),
 		Active:                      false,
 		AuthenticatorAssuranceLevel: identity.NoAuthenticatorAssuranceLevel,
+		Devices:                     Devices{},
 	}
 }
 
@@ -145,10 +158,10 @@ func (s *Session) Activate(i *identity.Identity, c lifespanProvider, authenticat
 	return nil
 }
 
-// swagger:model sessionDevice
-type Device struct {
-	// UserAgent of this device
-	UserAgent string `json:"user_agent"`
+// Refresh extends the session's expiry by the session lifespan, starting now.
+func (s *Session) Refresh(c lifespanProvider) *Session {
+	s.ExpiresAt = time.Now().Add(c.SessionLifespan()).UTC()
+	return s
 }
 
 func (s *Session) Declassify() *Session {
@@ -160,6 +173,26 @@ func (s *Session) IsActive() bool {
 	return s.Active && s.ExpiresAt.After(time.Now()) && (s.Identity == nil || s.Identity.IsActive())
 }
 
+// UpsertEvent returns the event to emit once the session is stored: sessions which are not stored yet are issued,
+// while stored sessions are refreshed, for example when re-authenticating or upgrading the AAL. It must be called
+// before the session is stored.
+func (s *Session) UpsertEvent() webhook.Event {
+	if s.CreatedAt.IsZero() {
+		return webhook.EventSessionIssued
+	}
+	return webhook.EventSessionRefreshed
+}
+
+// EventData returns the data which is sent to the web hooks subscribed to the session's events.
+func (s *Session) EventData() webhook.EventData {
+	data := webhook.EventData{IdentityID: s.IdentityID, Session: s}
+	if s.Identity != nil {
+		data.IdentityID = s.Identity.ID
+		data.Identity = identity.WithAdminMetadataInJSON(*s.Identity)
+	}
+	return data
+}
+
 // List of (Used) AuthenticationMethods
 //
 // A list of authenticators which were used to authenticate the session.
@@ -176,6 +209,11 @@ type AuthenticationMethod struct {
 	// The method used in this authenticator.
 	Method identity.CredentialsType `json:"method"`
 
+	// The assurance level this method asserted on its own.
+	//
+	// Only set for methods which can act as first and second factor, such as WebAuthn passkeys.
+	AAL identity.AuthenticatorAssuranceLevel `json:"aal,omitempty"`
+
 	// When the authentication challenge was completed.
 	CompletedAt time.Time `json:"completed_at"`
 }
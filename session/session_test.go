@@ -60,6 +60,13 @@ func TestSession(t *testing.T) {
 		assert.Empty(t, s.AuthenticatedAt)
 	})
 
+	t.Run("case=refresh", func(t *testing.T) {
+		s := &session.Session{Active: true, ExpiresAt: time.Now().Add(-time.Hour)}
+		assert.False(t, s.IsActive())
+		assert.True(t, s.Refresh(conf).IsActive())
+		assert.WithinDuration(t, time.Now().Add(conf.SessionLifespan()), s.ExpiresAt, time.Second)
+	})
+
 	t.Run("case=aal", func(t *testing.T) {
 		for _, tc := range []struct {
 			d        string
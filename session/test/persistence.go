@@ -353,6 +353,43 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 			}
 		})
 
+		t.Run("method=update session devices", func(t *testing.T) {
+			var expected session.Session
+			require.NoError(t, faker.FakeData(&expected))
+			expected.Active = true
+			require.NoError(t, p.CreateIdentity(ctx, expected.Identity))
+			require.NoError(t, p.UpsertSession(ctx, &expected))
+
+			actual, err := p.GetSession(ctx, expected.ID)
+			require.NoError(t, err)
+			assert.Empty(t, actual.Devices)
+
+			require.NoError(t, p.RevokeSession(ctx, expected.IdentityID, expected.ID))
+			expected.SeenOn(session.Device{IPAddress: "203.0.113.1", UserAgent: "Chrome", Location: &session.Location{Country: "DE", City: "Berlin"}}, time.Now())
+
+			t.Run("on another network", func(t *testing.T) {
+				_, other := testhelpers.NewNetwork(t, ctx, p)
+				require.ErrorIs(t, other.UpdateSessionDevices(ctx, expected.ID, expected.Devices), sqlcon.ErrNoRows)
+
+				actual, err := p.GetSession(ctx, expected.ID)
+				require.NoError(t, err)
+				assert.Empty(t, actual.Devices)
+			})
+
+			require.NoError(t, p.UpdateSessionDevices(ctx, expected.ID, expected.Devices))
+
+			actual, err = p.GetSession(ctx, expected.ID)
+			require.NoError(t, err)
+			require.Len(t, actual.Devices, 1)
+			assert.Equal(t, expected.Devices[0].IPAddress, actual.Devices[0].IPAddress)
+			assert.Equal(t, expected.Devices[0].UserAgent, actual.Devices[0].UserAgent)
+			assert.Equal(t, expected.Devices[0].Location, actual.Devices[0].Location)
+			assert.Equal(t, expected.Devices[0].LastSeenAt.Unix(), actual.Devices[0].LastSeenAt.Unix())
+			assert.False(t, actual.Active, "other fields must not be updated")
+
+			require.ErrorIs(t, p.UpdateSessionDevices(ctx, x.NewUUID(), expected.Devices), sqlcon.ErrNoRows)
+		})
+
 		t.Run("case=delete session for", func(t *testing.T) {
 			var expected1 session.Session
 			var expected2 session.Session
@@ -6,17 +6,19 @@ package text
 type ID int
 
 const (
-	InfoSelfServiceLoginRoot      ID = 1010000 + iota // 1010000
-	InfoSelfServiceLogin                              // 1010001
-	InfoSelfServiceLoginWith                          // 1010002
-	InfoSelfServiceLoginReAuth                        // 1010003
-	InfoSelfServiceLoginMFA                           // 1010004
-	InfoSelfServiceLoginVerify                        // 1010005
-	InfoSelfServiceLoginTOTPLabel                     // 1010006
-	InfoLoginLookupLabel                              // 1010007
-	InfoSelfServiceLoginWebAuthn                      // 1010008
-	InfoLoginTOTP                                     // 1010009
-	InfoLoginLookup                                   // 1010010
+	InfoSelfServiceLoginRoot          ID = 1010000 + iota // 1010000
+	InfoSelfServiceLogin                                  // 1010001
+	InfoSelfServiceLoginWith                              // 1010002
+	InfoSelfServiceLoginReAuth                            // 1010003
+	InfoSelfServiceLoginMFA                               // 1010004
+	InfoSelfServiceLoginVerify                            // 1010005
+	InfoSelfServiceLoginTOTPLabel                         // 1010006
+	InfoLoginLookupLabel                                  // 1010007
+	InfoSelfServiceLoginWebAuthn                          // 1010008
+	InfoLoginTOTP                                         // 1010009
+	InfoLoginLookup                                       // 1010010
+	InfoSelfServiceLoginMagicLink                         // 1010011
+	InfoSelfServiceLoginMagicLinkSent                     // 1010012
 )
 
 const (
@@ -28,10 +30,11 @@ const (
 )
 
 const (
-	InfoSelfServiceRegistrationRoot ID = 1040000 + iota // 1040000
-	InfoSelfServiceRegistration                         // 1040001
-	InfoSelfServiceRegistrationWith                     // 1040002
-	InfoRegistrationContinue                            // 1040003
+	InfoSelfServiceRegistrationRoot             ID = 1040000 + iota // 1040000
+	InfoSelfServiceRegistration                                     // 1040001
+	InfoSelfServiceRegistrationWith                                 // 1040002
+	InfoRegistrationContinue                                        // 1040003
+	InfoSelfServiceRegistrationRegisterWebAuthn                     // 1040004
 )
 
 const (
@@ -56,9 +59,12 @@ const (
 )
 
 const (
-	InfoSelfServiceRecovery           ID = 1060000 + iota // 1060000
-	InfoSelfServiceRecoverySuccessful                     // 1060001
-	InfoSelfServiceRecoveryEmailSent                      // 1060002
+	InfoSelfServiceRecovery              ID = 1060000 + iota // 1060000
+	InfoSelfServiceRecoverySuccessful                        // 1060001
+	InfoSelfServiceRecoveryEmailSent                         // 1060002
+	InfoSelfServiceRecoveryCodeSent                          // 1060003
+	InfoSelfServiceRecoveryPhoneSent                         // 1060004
+	InfoSelfServiceRecoveryPhoneCodeSent                     // 1060005
 )
 
 const (
@@ -70,12 +76,16 @@ const (
 	InfoNodeLabelSubmit                            // 1070005
 	InfoNodeLabelVerifyOTP                         // 1070006
 	InfoNodeLabelEmail                             // 1070007
+	InfoNodeLabelCode                              // 1070008
 )
 
 const (
-	InfoSelfServiceVerification           ID = 1080000 + iota // 1080000
-	InfoSelfServiceVerificationEmailSent                      // 1080001
-	InfoSelfServiceVerificationSuccessful                     // 1080002
+	InfoSelfServiceVerification              ID = 1080000 + iota // 1080000
+	InfoSelfServiceVerificationEmailSent                         // 1080001
+	InfoSelfServiceVerificationSuccessful                        // 1080002
+	InfoSelfServiceVerificationCodeSent                          // 1080003
+	InfoSelfServiceVerificationPhoneSent                         // 1080004
+	InfoSelfServiceVerificationPhoneCodeSent                     // 1080005
 )
 
 const (
@@ -94,16 +104,21 @@ const (
 	ErrorValidationLookupAlreadyUsed
 	ErrorValidationNoWebAuthnDevice
 	ErrorValidationNoLookup
+	ErrorValidationCodeInvalid
+	ErrorValidationCodeAttemptsExceeded
+	ErrorValidationRateLimitExceeded
+	ErrorValidationIdentifierLocked
 )
 
 const (
-	ErrorValidationLogin                       ID = 4010000 + iota // 4010000
-	ErrorValidationLoginFlowExpired                                // 4010001
-	ErrorValidationLoginNoStrategyFound                            // 4010002
-	ErrorValidationRegistrationNoStrategyFound                     // 4010003
-	ErrorValidationSettingsNoStrategyFound                         // 4010004
-	ErrorValidationRecoveryNoStrategyFound                         // 4010005
-	ErrorValidationVerificationNoStrategyFound                     // 4010006
+	ErrorValidationLogin                              ID = 4010000 + iota // 4010000
+	ErrorValidationLoginFlowExpired                                       // 4010001
+	ErrorValidationLoginNoStrategyFound                                   // 4010002
+	ErrorValidationRegistrationNoStrategyFound                            // 4010003
+	ErrorValidationSettingsNoStrategyFound                                // 4010004
+	ErrorValidationRecoveryNoStrategyFound                                // 4010005
+	ErrorValidationVerificationNoStrategyFound                            // 4010006
+	ErrorValidationLoginMagicLinkInvalidOrAlreadyUsed                     // 4010007
 )
 
 const (
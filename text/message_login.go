@@ -146,3 +146,27 @@ func NewInfoSelfServiceLoginWebAuthn() *Message {
 		Type: Info,
 	}
 }
+
+func NewInfoSelfServiceLoginMagicLink() *Message {
+	return &Message{
+		ID:   InfoSelfServiceLoginMagicLink,
+		Text: "Send sign-in link",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceLoginMagicLinkSent() *Message {
+	return &Message{
+		ID:   InfoSelfServiceLoginMagicLinkSent,
+		Text: "An email containing a sign-in link and code has been sent to the email address you provided.",
+		Type: Info,
+	}
+}
+
+func NewErrorValidationLoginMagicLinkInvalidOrAlreadyUsed() *Message {
+	return &Message{
+		ID:   ErrorValidationLoginMagicLinkInvalidOrAlreadyUsed,
+		Text: "The sign-in link is invalid or has already been used. Please request a new one.",
+		Type: Error,
+	}
+}
@@ -55,3 +55,11 @@ func NewInfoNodeInputEmail() *Message {
 		Type: Info,
 	}
 }
+
+func NewInfoNodeLabelCode() *Message {
+	return &Message{
+		ID:   InfoNodeLabelCode,
+		Text: "Code",
+		Type: Info,
+	}
+}
@@ -37,6 +37,33 @@ func NewRecoveryEmailSent() *Message {
 	}
 }
 
+func NewRecoveryCodeSent() *Message {
+	return &Message{
+		ID:      InfoSelfServiceRecoveryCodeSent,
+		Type:    Info,
+		Text:    "An email containing a recovery code has been sent to the email address you provided.",
+		Context: context(nil),
+	}
+}
+
+func NewRecoveryPhoneSent() *Message {
+	return &Message{
+		ID:      InfoSelfServiceRecoveryPhoneSent,
+		Type:    Info,
+		Text:    "A text message containing a recovery link has been sent to the phone number you provided.",
+		Context: context(nil),
+	}
+}
+
+func NewRecoveryPhoneCodeSent() *Message {
+	return &Message{
+		ID:      InfoSelfServiceRecoveryPhoneCodeSent,
+		Type:    Info,
+		Text:    "A text message containing a recovery code has been sent to the phone number you provided.",
+		Context: context(nil),
+	}
+}
+
 func NewErrorValidationRecoveryTokenInvalidOrAlreadyUsed() *Message {
 	return &Message{
 		ID:      ErrorValidationRecoveryTokenInvalidOrAlreadyUsed,
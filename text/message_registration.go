@@ -33,6 +33,14 @@ func NewInfoRegistrationContinue() *Message {
 	}
 }
 
+func NewInfoSelfServiceRegistrationRegisterWebAuthn() *Message {
+	return &Message{
+		ID:   InfoSelfServiceRegistrationRegisterWebAuthn,
+		Text: "Sign up with security key",
+		Type: Info,
+	}
+}
+
 func NewErrorValidationRegistrationFlowExpired(ago time.Duration) *Message {
 	return &Message{
 		ID:   ErrorValidationRegistrationFlowExpired,
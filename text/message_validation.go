@@ -2,6 +2,8 @@ package text
 
 import (
 	"fmt"
+	"math"
+	"time"
 )
 
 func NewValidationErrorGeneric(reason string) *Message {
@@ -138,6 +140,48 @@ func NewErrorValidationNoLookup() *Message {
 	}
 }
 
+func NewErrorValidationCodeInvalid() *Message {
+	return &Message{
+		ID:      ErrorValidationCodeInvalid,
+		Text:    "The code is invalid or has already been used. Please try again.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationCodeAttemptsExceeded(maxAttempts int) *Message {
+	return &Message{
+		ID:   ErrorValidationCodeAttemptsExceeded,
+		Text: fmt.Sprintf("The code was entered incorrectly %d times. Please request a new code.", maxAttempts),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"max_attempts": maxAttempts,
+		}),
+	}
+}
+
+func NewErrorValidationRateLimitExceeded(retryAfter time.Duration) *Message {
+	return &Message{
+		ID:   ErrorValidationRateLimitExceeded,
+		Text: fmt.Sprintf("Too many attempts. Please try again in %.0f minutes.", math.Ceil(retryAfter.Minutes())),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"retry_at": Now().UTC().Add(retryAfter),
+		}),
+	}
+}
+
+func NewErrorValidationIdentifierLocked(retryAfter time.Duration) *Message {
+	return &Message{
+		ID:   ErrorValidationIdentifierLocked,
+		Text: fmt.Sprintf("Too many attempts were made for this account, it is locked temporarily. Please try again in %.0f minutes.", math.Ceil(retryAfter.Minutes())),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"locked_until": Now().UTC().Add(retryAfter),
+		}),
+	}
+}
+
 func NewErrorValidationNoWebAuthnDevice() *Message {
 	return &Message{
 		ID:      ErrorValidationNoWebAuthnDevice,
@@ -33,6 +33,33 @@ func NewVerificationEmailSent() *Message {
 	}
 }
 
+func NewVerificationCodeSent() *Message {
+	return &Message{
+		ID:      InfoSelfServiceVerificationCodeSent,
+		Type:    Info,
+		Text:    "An email containing a verification code has been sent to the email address you provided.",
+		Context: context(nil),
+	}
+}
+
+func NewVerificationPhoneSent() *Message {
+	return &Message{
+		ID:      InfoSelfServiceVerificationPhoneSent,
+		Type:    Info,
+		Text:    "A text message containing a verification link has been sent to the phone number you provided.",
+		Context: context(nil),
+	}
+}
+
+func NewVerificationPhoneCodeSent() *Message {
+	return &Message{
+		ID:      InfoSelfServiceVerificationPhoneCodeSent,
+		Type:    Info,
+		Text:    "A text message containing a verification code has been sent to the phone number you provided.",
+		Context: context(nil),
+	}
+}
+
 func NewErrorValidationVerificationTokenInvalidOrAlreadyUsed() *Message {
 	return &Message{
 		ID:      ErrorValidationVerificationTokenInvalidOrAlreadyUsed,
@@ -219,6 +219,12 @@ func TestContainer(t *testing.T) {
 				&node.Node{Group: node.DefaultGroup, Type: node.Input, Attributes: &node.InputAttributes{Name: "foo.bar.baz", Type: node.InputAttributeTypeText}, Messages: text.Messages{*text.NewValidationErrorGeneric("test")}, Meta: new(node.Meta)},
 			}}},
 			{err: &jsonschema.ValidationError{Message: "test", InstancePtr: ""}, expect: Container{Nodes: node.Nodes{}, Messages: text.Messages{*text.NewValidationErrorGeneric("test")}}},
+			{err: &schema.ValidationListError{Validations: []*schema.ValidationError{
+				{ValidationError: &jsonschema.ValidationError{Message: "foo", InstancePtr: "#/foo"}, Messages: text.Messages{*text.NewValidationErrorGeneric("foo")}},
+				{ValidationError: &jsonschema.ValidationError{Message: "bar", InstancePtr: "#/"}, Messages: text.Messages{*text.NewValidationErrorGeneric("bar")}},
+			}}, expect: Container{Nodes: node.Nodes{
+				&node.Node{Group: node.DefaultGroup, Type: node.Input, Attributes: &node.InputAttributes{Name: "foo", Type: node.InputAttributeTypeText}, Messages: text.Messages{*text.NewValidationErrorGeneric("foo")}, Meta: new(node.Meta)},
+			}, Messages: text.Messages{*text.NewValidationErrorGeneric("bar")}}},
 		} {
 			t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
 				for _, in := range []error{tc.err, errors.WithStack(tc.err)} {
@@ -36,6 +36,7 @@ const (
 	TOTPGroup             Group = "totp"
 	LookupGroup           Group = "lookup_secret"
 	WebAuthnGroup         Group = "webauthn"
+	MagicLinkGroup        Group = "magic_link"
 
 	Text   Type = "text"
 	Input  Type = "input"
@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/corp"
+)
+
+// DeliveryStatus is the status of an asynchronous web hook delivery.
+type DeliveryStatus int
+
+const (
+	DeliveryStatusQueued DeliveryStatus = iota + 1
+	DeliveryStatusDelivered
+	DeliveryStatusProcessing
+	DeliveryStatusAbandoned
+)
+
+var deliveryStatusNames = map[DeliveryStatus]string{
+	DeliveryStatusQueued:     "queued",
+	DeliveryStatusDelivered:  "delivered",
+	DeliveryStatusProcessing: "processing",
+	DeliveryStatusAbandoned:  "abandoned",
+}
+
+func (ds DeliveryStatus) String() string {
+	if name, ok := deliveryStatusNames[ds]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", int(ds))
+}
+
+func (ds DeliveryStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ds.String())
+}
+
+// Delivery is a web hook request which is delivered asynchronously by the web hook worker.
+type Delivery struct {
+	ID     uuid.UUID      `json:"id" faker:"-" db:"id"`
+	NID    uuid.UUID      `json:"-" faker:"-" db:"nid"`
+	Status DeliveryStatus `json:"status" faker:"-" db:"status"`
+
+	Method  string         `json:"method" db:"method"`
+	URL     string         `json:"url" db:"url"`
+	Headers DeliveryHeader `json:"headers" faker:"-" db:"headers"`
+	Body    string         `json:"body" db:"body"`
+
+	// IdentityID is the identity whose data the request contains, if any. It is used to purge the identity's
+	// deliveries when the identity is erased.
+	IdentityID uuid.NullUUID `json:"identity_id,omitempty" faker:"-" db:"identity_id"`
+
+	// Auth is the web hook's auth configuration which is applied when the request is delivered. It contains
+	// credentials, which is why it is encrypted using the configured cipher and never exposed.
+	Auth sqlxx.NullString `json:"-" faker:"-" db:"auth"`
+
+	// Attempts is the number of failed attempts to deliver the request.
+	Attempts int `json:"attempts" faker:"-" db:"attempts"`
+
+	// LastError is the error of the last failed attempt to deliver the request.
+	LastError sqlxx.NullString `json:"last_error,omitempty" faker:"-" db:"last_error"`
+
+	// NextAttemptAt is the time after which the worker attempts to deliver the request.
+	NextAttemptAt time.Time `json:"next_attempt_at" faker:"-" db:"next_attempt_at"`
+
+	// ClaimedUntil is the time until which a worker has claimed the delivery. If the delivery is still processing
+	// afterwards, the worker is assumed to have crashed and the delivery is claimed again.
+	ClaimedUntil sqlxx.NullTime `json:"-" faker:"-" db:"claimed_until"`
+
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+}
+
+// DeliveryHeader are the HTTP headers of a delivery.
+type DeliveryHeader http.Header
+
+func (d Delivery) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "webhook_deliveries")
+}
+
+func (d *Delivery) GetID() uuid.UUID {
+	return d.ID
+}
+
+func (d *Delivery) GetNID() uuid.UUID {
+	return d.NID
+}
+
+// Scan implements the Scanner interface.
+func (h *DeliveryHeader) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	v := fmt.Sprintf("%s", value)
+	if len(v) == 0 {
+		return nil
+	}
+	return errors.WithStack(json.Unmarshal([]byte(v), h))
+}
+
+// Value implements the driver Valuer interface.
+func (h DeliveryHeader) Value() (driver.Value, error) {
+	if h == nil {
+		h = DeliveryHeader{}
+	}
+	value, err := json.Marshal(h)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return string(value), nil
+}
@@ -0,0 +1,239 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/cipher"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/request"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	dispatchBatchSize = 10
+
+	// purgeInterval is how often the worker deletes deliveries which are past their retention.
+	purgeInterval = 10 * time.Minute
+)
+
+type (
+	dispatcherDependencies interface {
+		PersistenceProvider
+		cipher.Provider
+		config.Provider
+		x.LoggingProvider
+		x.HTTPClientProvider
+		request.AuthCacheProvider
+	}
+	DispatcherProvider interface {
+		WebHookDispatcher() *Dispatcher
+	}
+	// Dispatcher queues web hook requests and delivers them asynchronously. Failed deliveries are retried
+	// with exponential backoff until the maximum number of attempts is reached.
+	Dispatcher struct {
+		r        dispatcherDependencies
+		purgedAt time.Time
+	}
+)
+
+func NewDispatcher(r dispatcherDependencies) *Dispatcher {
+	return &Dispatcher{r: r}
+}
+
+// Enqueue persists the request so that it is delivered by the web hook worker and returns the delivery's ID.
+// The request must not be authenticated yet. Instead, the web hook's auth configuration is applied when the
+// request is delivered so that short-lived credentials, for example OAuth2 access tokens, do not expire in the
+// queue. The auth configuration is stored encrypted, and credentials which were nevertheless set on the request
+// are not stored at all. The identity ID, which is uuid.Nil if the request does not contain an identity's data,
+// allows purging the delivery when the identity is erased.
+func (d *Dispatcher) Enqueue(ctx context.Context, req *retryablehttp.Request, auth json.RawMessage, identityID uuid.UUID) (uuid.UUID, error) {
+	body, err := req.BodyBytes()
+	if err != nil {
+		return uuid.Nil, errors.WithStack(err)
+	}
+
+	encryptedAuth, err := d.r.Cipher().Encrypt(ctx, auth)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	delivery := &Delivery{
+		Method:        req.Method,
+		URL:           req.URL.String(),
+		Headers:       withoutCredentials(req.Header, auth),
+		Body:          string(body),
+		IdentityID:    uuid.NullUUID{UUID: identityID, Valid: identityID != uuid.Nil},
+		Auth:          sqlxx.NullString(encryptedAuth),
+		NextAttemptAt: time.Now().UTC(),
+	}
+	if err := d.r.WebHookPersister().AddDelivery(ctx, delivery); err != nil {
+		return uuid.Nil, err
+	}
+	return delivery.ID, nil
+}
+
+// withoutCredentials returns a copy of the headers without the headers which carry credentials.
+func withoutCredentials(h http.Header, auth json.RawMessage) DeliveryHeader {
+	h = h.Clone()
+	if h == nil {
+		return DeliveryHeader{}
+	}
+
+	for _, name := range []string{"Authorization", "Proxy-Authorization", "Cookie"} {
+		h.Del(name)
+	}
+	if gjson.GetBytes(auth, "type").String() == "api_key" {
+		if name := gjson.GetBytes(auth, "config.name").String(); name != "" {
+			h.Del(name)
+		}
+	}
+	return DeliveryHeader(h)
+}
+
+// Work delivers queued web hook requests until the context is canceled. Deliveries which are past their
+// retention are deleted periodically.
+func (d *Dispatcher) Work(ctx context.Context) error {
+	for {
+		if err := backoff.Retry(func() error {
+			return d.DispatchQueue(ctx)
+		}, backoff.NewExponentialBackOff()); err != nil {
+			return err
+		}
+
+		if time.Since(d.purgedAt) > purgeInterval {
+			if err := d.PurgeDeliveries(ctx); err != nil {
+				d.r.Logger().WithError(err).Warn("Unable to delete web hook deliveries which are past their retention.")
+			} else {
+				d.purgedAt = time.Now()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// PurgeDeliveries deletes delivered and abandoned deliveries which are past their retention.
+func (d *Dispatcher) PurgeDeliveries(ctx context.Context) error {
+	return d.r.WebHookPersister().PurgeDeliveries(ctx, time.Now().Add(-d.r.Config(ctx).WebHookDeliveryRetention()))
+}
+
+// DispatchQueue delivers all queued web hook requests which are due, as well as requests whose delivery was
+// interrupted, for example because a worker crashed.
+func (d *Dispatcher) DispatchQueue(ctx context.Context) error {
+	c := d.r.Config(ctx)
+	maxAttempts := c.WebHookDeliveryMaxAttempts()
+
+	for {
+		now := time.Now()
+		deliveries, err := d.r.WebHookPersister().NextDeliveries(ctx, dispatchBatchSize, now, now.Add(c.WebHookDeliveryClaimTimeout()))
+		if errors.Is(err, ErrQueueEmpty) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		for _, delivery := range deliveries {
+			err := d.Deliver(ctx, delivery)
+			if err == nil {
+				if err := d.r.WebHookPersister().SetDeliveryStatus(ctx, delivery.ID, DeliveryStatusDelivered); err != nil {
+					return err
+				}
+				continue
+			}
+
+			attempts := delivery.Attempts + 1
+			status := DeliveryStatusQueued
+			if attempts >= maxAttempts {
+				status = DeliveryStatusAbandoned
+			}
+
+			nextAttemptAt := time.Now().UTC().Add(retryBackoff(attempts, c.WebHookDeliveryInitialBackoff(), c.WebHookDeliveryMaxBackoff()))
+			if err := d.r.WebHookPersister().RecordDeliveryFailure(ctx, delivery.ID, status, nextAttemptAt, err.Error()); err != nil {
+				return err
+			}
+
+			l := d.r.Logger().
+				WithError(err).
+				WithField("web_hook_delivery_id", delivery.ID).
+				WithField("web_hook_delivery_attempts", attempts)
+			if status == DeliveryStatusAbandoned {
+				l.Warn("Gave up on delivering the web hook request and marked it as abandoned.")
+			} else {
+				l.WithField("web_hook_delivery_next_attempt_at", nextAttemptAt).Info("Unable to deliver the web hook request, it will be retried.")
+			}
+		}
+
+		if len(deliveries) < dispatchBatchSize {
+			return nil
+		}
+	}
+}
+
+// Deliver sends the delivery's request once. The request is authenticated using the delivery's auth
+// configuration and signed with the current default secret.
+func (d *Dispatcher) Deliver(ctx context.Context, delivery Delivery) error {
+	req, err := http.NewRequestWithContext(ctx, delivery.Method, delivery.URL, bytes.NewBufferString(delivery.Body))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	req.Header = http.Header(delivery.Headers).Clone()
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	req.Header.Set(SignatureHeader, Sign(d.r.Config(ctx).SecretsDefault()[0], []byte(delivery.Body), time.Now()))
+
+	auth, err := d.r.Cipher().Decrypt(ctx, string(delivery.Auth))
+	if err != nil {
+		return err
+	}
+
+	client, err := request.Authenticate(auth, &retryablehttp.Request{Request: req}, d.r.HTTPClient(ctx), d.r.RequestAuthCache(ctx))
+	if err != nil {
+		return err
+	}
+
+	// The worker retries failed deliveries itself, which is why the retrying client is not used here.
+	resp, err := client.HTTPClient.Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return errors.Errorf("web hook failed with status code %v", resp.StatusCode)
+	}
+	return nil
+}
+
+// retryBackoff returns how long to wait before the next attempt after the given number of failed attempts.
+// The wait time doubles with every failed attempt.
+func retryBackoff(attempts int, initial, max time.Duration) time.Duration {
+	wait := initial
+	for i := 1; i < attempts && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		return max
+	}
+	return wait
+}
@@ -0,0 +1,27 @@
+package webhook
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	for k, tc := range []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 1, expected: 30 * time.Second},
+		{attempts: 2, expected: time.Minute},
+		{attempts: 3, expected: 2 * time.Minute},
+		{attempts: 4, expected: 4 * time.Minute},
+		{attempts: 5, expected: 5 * time.Minute},
+		{attempts: 1000, expected: 5 * time.Minute},
+	} {
+		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+			assert.Equal(t, tc.expected, retryBackoff(tc.attempts, 30*time.Second, 5*time.Minute))
+		})
+	}
+}
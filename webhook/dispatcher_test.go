@@ -0,0 +1,172 @@
+package webhook_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/webhook"
+)
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyWebHookDeliveryMaxAttempts, 2)
+	conf.MustSet(config.ViperKeyWebHookDeliveryInitialBackoff, "1m")
+
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	requests := make(chan received, 10)
+	status := http.StatusOK
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- received{header: r.Header, body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(ts.Close)
+
+	enqueue := func(t *testing.T, auth json.RawMessage) *webhook.Delivery {
+		req, err := retryablehttp.NewRequest("POST", ts.URL, bytes.NewBufferString(`{"foo":"bar"}`))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer token")
+		id, err := reg.WebHookDispatcher().Enqueue(ctx, req, auth, uuid.Nil)
+		require.NoError(t, err)
+
+		d, err := reg.WebHookPersister().GetDelivery(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryStatusQueued, d.Status)
+		assert.Empty(t, http.Header(d.Headers).Get("Authorization"), "credentials must not be stored")
+		return d
+	}
+
+	t.Run("case=delivers signed requests", func(t *testing.T) {
+		status = http.StatusOK
+		d := enqueue(t, nil)
+		assert.Equal(t, `{"foo":"bar"}`, d.Body)
+
+		require.NoError(t, reg.WebHookDispatcher().DispatchQueue(ctx))
+
+		r := <-requests
+		assert.Equal(t, `{"foo":"bar"}`, string(r.body))
+		assert.Empty(t, r.header.Get("Authorization"))
+		require.NoError(t, webhook.VerifySignature(conf.SecretsDefault(), r.header.Get(webhook.SignatureHeader), r.body, time.Minute, time.Now()))
+
+		actual, err := reg.WebHookPersister().GetDelivery(ctx, d.ID)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryStatusDelivered, actual.Status)
+	})
+
+	t.Run("case=authenticates requests when they are delivered", func(t *testing.T) {
+		status = http.StatusOK
+		d := enqueue(t, json.RawMessage(`{"type":"api_key","config":{"name":"X-API-Key","value":"secret","in":"header"}}`))
+		assert.NotContains(t, string(d.Auth), "secret", "credentials must be stored encrypted")
+		auth, err := reg.Cipher().Decrypt(ctx, string(d.Auth))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"type":"api_key","config":{"name":"X-API-Key","value":"secret","in":"header"}}`, string(auth))
+
+		require.NoError(t, reg.WebHookDispatcher().DispatchQueue(ctx))
+
+		r := <-requests
+		assert.Equal(t, "secret", r.header.Get("X-API-Key"))
+		require.NoError(t, webhook.VerifySignature(conf.SecretsDefault(), r.header.Get(webhook.SignatureHeader), r.body, time.Minute, time.Now()))
+	})
+
+	t.Run("case=does not store api key headers", func(t *testing.T) {
+		req, err := retryablehttp.NewRequest("POST", ts.URL, bytes.NewBufferString(`{"foo":"bar"}`))
+		require.NoError(t, err)
+		req.Header.Set("X-API-Key", "secret")
+		req.Header.Set("X-Request-Id", "foo")
+
+		id, err := reg.WebHookDispatcher().Enqueue(ctx, req, json.RawMessage(`{"type":"api_key","config":{"name":"X-API-Key","value":"secret","in":"header"}}`), uuid.Nil)
+		require.NoError(t, err)
+
+		d, err := reg.WebHookPersister().GetDelivery(ctx, id)
+		require.NoError(t, err)
+		assert.Empty(t, http.Header(d.Headers).Get("X-API-Key"))
+		assert.Equal(t, "foo", http.Header(d.Headers).Get("X-Request-Id"))
+
+		require.NoError(t, reg.WebHookPersister().SetDeliveryStatus(ctx, id, webhook.DeliveryStatusDelivered))
+	})
+
+	t.Run("case=delivers requests whose worker crashed", func(t *testing.T) {
+		status = http.StatusOK
+		d := enqueue(t, nil)
+
+		// A worker claimed the delivery and crashed before the claim expired.
+		claimed, err := reg.WebHookPersister().NextDeliveries(ctx, 10, time.Now(), time.Now().Add(-time.Second))
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, d.ID, claimed[0].ID)
+
+		require.NoError(t, reg.WebHookDispatcher().DispatchQueue(ctx))
+		<-requests
+
+		actual, err := reg.WebHookPersister().GetDelivery(ctx, d.ID)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryStatusDelivered, actual.Status)
+	})
+
+	t.Run("case=purges deliveries past their retention", func(t *testing.T) {
+		status = http.StatusOK
+		d := enqueue(t, nil)
+		require.NoError(t, reg.WebHookDispatcher().DispatchQueue(ctx))
+		<-requests
+
+		require.NoError(t, reg.WebHookDispatcher().PurgeDeliveries(ctx))
+		_, err := reg.WebHookPersister().GetDelivery(ctx, d.ID)
+		require.NoError(t, err)
+
+		conf.MustSet(config.ViperKeyWebHookDeliveryRetention, "1ns")
+		t.Cleanup(func() { conf.MustSet(config.ViperKeyWebHookDeliveryRetention, nil) })
+		time.Sleep(time.Millisecond)
+
+		require.NoError(t, reg.WebHookDispatcher().PurgeDeliveries(ctx))
+		_, err = reg.WebHookPersister().GetDelivery(ctx, d.ID)
+		require.ErrorIs(t, err, sqlcon.ErrNoRows)
+	})
+
+	t.Run("case=retries failed deliveries and gives up eventually", func(t *testing.T) {
+		status = http.StatusBadGateway
+		d := enqueue(t, nil)
+
+		require.NoError(t, reg.WebHookDispatcher().DispatchQueue(ctx))
+		<-requests
+
+		actual, err := reg.WebHookPersister().GetDelivery(ctx, d.ID)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryStatusQueued, actual.Status)
+		assert.Equal(t, 1, actual.Attempts)
+		assert.Contains(t, string(actual.LastError), "502")
+		assert.WithinDuration(t, time.Now().Add(time.Minute), actual.NextAttemptAt, 10*time.Second)
+
+		// The delivery is not retried before the backoff has passed.
+		require.NoError(t, reg.WebHookDispatcher().DispatchQueue(ctx))
+		assert.Len(t, requests, 0)
+
+		// Record another failed attempt which is due immediately, so that the next attempt exceeds the maximum.
+		require.NoError(t, reg.WebHookPersister().RecordDeliveryFailure(ctx, d.ID, webhook.DeliveryStatusQueued, time.Now().Add(-time.Second), string(actual.LastError)))
+		require.NoError(t, reg.WebHookDispatcher().DispatchQueue(ctx))
+		<-requests
+
+		actual, err = reg.WebHookPersister().GetDelivery(ctx, d.ID)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryStatusAbandoned, actual.Status)
+		assert.Equal(t, 3, actual.Attempts)
+	})
+}
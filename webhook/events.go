@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/request"
+	"github.com/ory/kratos/x"
+)
+
+// Event is the type of an identity or session event which web hooks can subscribe to.
+type Event string
+
+const (
+	EventIdentityCreated  Event = "identity.created"
+	EventIdentityUpdated  Event = "identity.updated"
+	EventIdentityDeleted  Event = "identity.deleted"
+	EventSessionIssued    Event = "session.issued"
+	EventSessionRefreshed Event = "session.refreshed"
+	EventSessionRevoked   Event = "session.revoked"
+	EventSessionExpired   Event = "session.expired"
+)
+
+type (
+	emitterDependencies interface {
+		config.Provider
+		x.LoggingProvider
+		x.HTTPClientProvider
+		request.AuthCacheProvider
+		DispatcherProvider
+	}
+	EmitterProvider interface {
+		WebHookEmitter() *Emitter
+	}
+	// Emitter calls the web hooks which are subscribed to identity and session events.
+	Emitter struct {
+		r emitterDependencies
+	}
+
+	// EventData is the subject of an event. Identity and Session are rendered into the body template as is.
+	// IdentityID is the ID of the identity the event data belongs to, if any.
+	EventData struct {
+		IdentityID uuid.UUID
+		Identity   interface{}
+		Session    interface{}
+	}
+
+	// eventTemplateContext is available as `ctx` in the body template of subscribed web hooks.
+	eventTemplateContext struct {
+		Event      Event       `json:"event"`
+		OccurredAt time.Time   `json:"occurred_at"`
+		Identity   interface{} `json:"identity,omitempty"`
+		Session    interface{} `json:"session,omitempty"`
+	}
+)
+
+func NewEmitter(r emitterDependencies) *Emitter {
+	return &Emitter{r: r}
+}
+
+// HasSubscribers returns true if at least one web hook is subscribed to the event. Use it to avoid loading
+// event data which would not be used.
+func (e *Emitter) HasSubscribers(ctx context.Context, event Event) bool {
+	return len(e.subscriptions(ctx, event)) > 0
+}
+
+// Emit calls all web hooks which are subscribed to the event. Synchronous web hooks are called immediately
+// while asynchronous web hooks are queued. The event has already happened when it is emitted, which is why
+// failing web hooks are logged instead of failing the caller.
+func (e *Emitter) Emit(ctx context.Context, event Event, data EventData) {
+	subscriptions := e.subscriptions(ctx, event)
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	tc := &eventTemplateContext{
+		Event:      event,
+		OccurredAt: time.Now().UTC(),
+		Identity:   data.Identity,
+		Session:    data.Session,
+	}
+	for _, s := range subscriptions {
+		if err := e.call(ctx, s.Config, tc, data.IdentityID); err != nil {
+			e.r.Logger().
+				WithError(err).
+				WithField("web_hook_event", event).
+				Warn("Unable to call the web hook which is subscribed to the event.")
+		}
+	}
+}
+
+func (e *Emitter) subscriptions(ctx context.Context, event Event) []config.WebHookSubscription {
+	var subscribed []config.WebHookSubscription
+	for _, s := range e.r.Config(ctx).WebHookSubscriptions() {
+		for _, name := range s.Events {
+			if Event(name) == event {
+				subscribed = append(subscribed, s)
+				break
+			}
+		}
+	}
+	return subscribed
+}
+
+func (e *Emitter) call(ctx context.Context, c json.RawMessage, tc *eventTemplateContext, identityID uuid.UUID) error {
+	builder, err := request.NewBuilder(c, e.r.Logger(), e.r.RequestAuthCache(ctx))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse web hook config")
+	}
+
+	if isAsync(c) {
+		req, err := builder.BuildUnauthenticatedRequest(tc)
+		if err != nil {
+			return errors.Wrap(err, "failed to create web hook request")
+		}
+
+		_, err = e.r.WebHookDispatcher().Enqueue(ctx, req, builder.Config.RawAuth, identityID)
+		return err
+	}
+
+	req, err := builder.BuildRequest(tc)
+	if err != nil {
+		return errors.Wrap(err, "failed to create web hook request")
+	}
+
+	if err := SignRequest(req, e.r.Config(ctx).SecretsDefault()[0], time.Now()); err != nil {
+		return err
+	}
+
+	resp, err := builder.Client(e.r.HTTPClient(ctx)).Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return errors.Errorf("web hook failed with status code %v", resp.StatusCode)
+	}
+	return nil
+}
+
+// isAsync returns true if the web hook's requests are queued and delivered by the web hook worker.
+func isAsync(c json.RawMessage) bool {
+	return gjson.GetBytes(c, "async").Bool()
+}
@@ -0,0 +1,114 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/webhook"
+)
+
+func TestEmitter(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	requests := make(chan received, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- received{header: r.Header, body: body}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(ts.Close)
+
+	body := "base64://" + base64.StdEncoding.EncodeToString([]byte(`function(ctx) {
+  event: ctx.event,
+  identity_id: if std.objectHas(ctx, "identity") then ctx.identity.id else null,
+  session_id: if std.objectHas(ctx, "session") then ctx.session.id else null,
+}`))
+	conf.MustSet(config.ViperKeyWebHookSubscriptions, []map[string]interface{}{
+		{
+			"events": []string{"identity.created", "identity.deleted"},
+			"config": map[string]interface{}{
+				"url":    ts.URL,
+				"method": "POST",
+				"body":   body,
+				"auth": map[string]interface{}{
+					"type":   "api_key",
+					"config": map[string]interface{}{"name": "X-API-Key", "value": "secret", "in": "header"},
+				},
+			},
+		},
+		{
+			"events": []string{"session.revoked"},
+			"config": map[string]interface{}{
+				"url":    ts.URL,
+				"method": "POST",
+				"body":   body,
+				"async":  true,
+				"auth": map[string]interface{}{
+					"type":   "bearer",
+					"config": map[string]interface{}{"token": "some-token"},
+				},
+			},
+		},
+	})
+
+	identity := map[string]interface{}{"id": "some-identity"}
+	session := map[string]interface{}{"id": "some-session"}
+
+	t.Run("case=reports subscribed events", func(t *testing.T) {
+		assert.True(t, reg.WebHookEmitter().HasSubscribers(ctx, webhook.EventIdentityCreated))
+		assert.True(t, reg.WebHookEmitter().HasSubscribers(ctx, webhook.EventIdentityDeleted))
+		assert.True(t, reg.WebHookEmitter().HasSubscribers(ctx, webhook.EventSessionRevoked))
+		assert.False(t, reg.WebHookEmitter().HasSubscribers(ctx, webhook.EventIdentityUpdated))
+		assert.False(t, reg.WebHookEmitter().HasSubscribers(ctx, webhook.EventSessionIssued))
+	})
+
+	t.Run("case=calls synchronous web hooks immediately", func(t *testing.T) {
+		reg.WebHookEmitter().Emit(ctx, webhook.EventIdentityCreated, webhook.EventData{Identity: identity})
+
+		r := <-requests
+		assert.Equal(t, "identity.created", gjson.GetBytes(r.body, "event").String(), "%s", r.body)
+		assert.Equal(t, "some-identity", gjson.GetBytes(r.body, "identity_id").String(), "%s", r.body)
+		assert.Equal(t, gjson.Null, gjson.GetBytes(r.body, "session_id").Type, "%s", r.body)
+		assert.Equal(t, "secret", r.header.Get("X-API-Key"))
+		require.NoError(t, webhook.VerifySignature(conf.SecretsDefault(), r.header.Get(webhook.SignatureHeader), r.body, time.Minute, time.Now()))
+	})
+
+	t.Run("case=ignores events without subscribers", func(t *testing.T) {
+		reg.WebHookEmitter().Emit(ctx, webhook.EventIdentityUpdated, webhook.EventData{Identity: identity})
+		reg.WebHookEmitter().Emit(ctx, webhook.EventIdentityDeleted, webhook.EventData{Identity: identity})
+
+		r := <-requests
+		assert.Equal(t, "identity.deleted", gjson.GetBytes(r.body, "event").String(), "%s", r.body)
+		assert.Len(t, requests, 0)
+	})
+
+	t.Run("case=queues asynchronous web hooks", func(t *testing.T) {
+		reg.WebHookEmitter().Emit(ctx, webhook.EventSessionRevoked, webhook.EventData{Identity: identity, Session: session})
+		assert.Len(t, requests, 0)
+
+		require.NoError(t, reg.WebHookDispatcher().DispatchQueue(ctx))
+
+		r := <-requests
+		assert.Equal(t, "session.revoked", gjson.GetBytes(r.body, "event").String(), "%s", r.body)
+		assert.Equal(t, "some-identity", gjson.GetBytes(r.body, "identity_id").String(), "%s", r.body)
+		assert.Equal(t, "some-session", gjson.GetBytes(r.body, "session_id").String(), "%s", r.body)
+		assert.Equal(t, "Bearer some-token", r.header.Get("Authorization"))
+		require.NoError(t, webhook.VerifySignature(conf.SecretsDefault(), r.header.Get(webhook.SignatureHeader), r.body, time.Minute, time.Now()))
+	})
+}
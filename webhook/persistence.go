@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+var ErrQueueEmpty = errors.New("queue is empty")
+
+type (
+	Persister interface {
+		AddDelivery(context.Context, *Delivery) error
+
+		// NextDeliveries returns queued deliveries whose next attempt is due at the given time, as well as
+		// processing deliveries whose claim has expired, and claims them as processing until claimedUntil.
+		NextDeliveries(ctx context.Context, limit uint8, now, claimedUntil time.Time) ([]Delivery, error)
+
+		SetDeliveryStatus(context.Context, uuid.UUID, DeliveryStatus) error
+
+		// RecordDeliveryFailure increments the delivery's attempts, stores the delivery error, and sets the new
+		// status and the time of the next attempt.
+		RecordDeliveryFailure(ctx context.Context, id uuid.UUID, status DeliveryStatus, nextAttemptAt time.Time, lastError string) error
+
+		GetDelivery(context.Context, uuid.UUID) (*Delivery, error)
+
+		// PurgeDeliveries deletes delivered and abandoned deliveries which were last updated before the given time.
+		PurgeDeliveries(ctx context.Context, before time.Time) error
+	}
+	PersistenceProvider interface {
+		WebHookPersister() Persister
+	}
+)
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SignatureHeader is the HTTP header which carries the signature of a web hook request.
+	SignatureHeader = "X-Kratos-Signature"
+
+	// signingKeyContext is mixed into the secret so that the signing key differs from the keys the
+	// secret is used for elsewhere, for example to sign cookies.
+	signingKeyContext = "ory-kratos-web-hook-signature"
+)
+
+var ErrInvalidSignature = errors.New("the web hook signature is invalid")
+
+// SigningKey derives the key web hook requests are signed with from one of the `secrets.default` secrets:
+//
+//	HMAC-SHA256(key=secret, message="ory-kratos-web-hook-signature")
+func SigningKey(secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte(signingKeyContext))
+	return mac.Sum(nil)
+}
+
+// Sign returns the value of the signature header for the body. It contains the time of signing and the
+// hex encoded HMAC-SHA256 of `<unix time>.<body>`, keyed with the signing key derived from the secret:
+//
+//	X-Kratos-Signature: t=1648483200,v1=<hex encoded signature>
+func Sign(secret, body []byte, at time.Time) string {
+	t := strconv.FormatInt(at.Unix(), 10)
+	return "t=" + t + ",v1=" + hex.EncodeToString(signature(SigningKey(secret), t, body))
+}
+
+// SignRequest sets the signature header of a request which is sent immediately. Queued requests are signed by
+// the web hook worker when they are delivered.
+func SignRequest(req *retryablehttp.Request, secret []byte, at time.Time) error {
+	body, err := req.BodyBytes()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set(SignatureHeader, Sign(secret, body, at))
+	return nil
+}
+
+// VerifySignature checks that the signature header was created for the body with any of the secrets, and
+// that it was created no longer than tolerance before now.
+func VerifySignature(secrets [][]byte, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var t string
+	var signatures [][]byte
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		switch kv[0] {
+		case "t":
+			t = kv[1]
+		case "v1":
+			if s, err := hex.DecodeString(kv[1]); err == nil {
+				signatures = append(signatures, s)
+			}
+		}
+	}
+
+	signedAt, err := strconv.ParseInt(t, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return errors.WithStack(ErrInvalidSignature)
+	}
+
+	if now.Sub(time.Unix(signedAt, 0)) > tolerance {
+		return errors.Wrap(ErrInvalidSignature, "the signature has expired")
+	}
+
+	for _, secret := range secrets {
+		expected := signature(SigningKey(secret), t, body)
+		for _, s := range signatures {
+			if hmac.Equal(expected, s) {
+				return nil
+			}
+		}
+	}
+
+	return errors.WithStack(ErrInvalidSignature)
+}
+
+func signature(key []byte, t string, body []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(t + "."))
+	_, _ = mac.Write(body)
+	return mac.Sum(nil)
+}
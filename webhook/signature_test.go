@@ -0,0 +1,52 @@
+package webhook_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/webhook"
+)
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"identity_id":"foo"}`)
+	current, previous := []byte("current-secret-that-is-long-enough"), []byte("previous-secret-that-is-long-enough")
+	now := time.Now()
+
+	t.Run("case=signs with a derived key", func(t *testing.T) {
+		assert.Len(t, webhook.SigningKey(current), 32)
+		assert.NotEqual(t, current, webhook.SigningKey(current))
+		assert.Equal(t, webhook.Sign(current, body, now), webhook.Sign(current, body, now))
+		assert.NotEqual(t, webhook.Sign(current, body, now), webhook.Sign(previous, body, now))
+	})
+
+	t.Run("case=verifies signatures", func(t *testing.T) {
+		header := webhook.Sign(current, body, now)
+		require.NoError(t, webhook.VerifySignature([][]byte{current}, header, body, time.Minute, now))
+		require.NoError(t, webhook.VerifySignature([][]byte{previous, current}, header, body, time.Minute, now), "secrets can be rotated")
+	})
+
+	t.Run("case=rejects invalid signatures", func(t *testing.T) {
+		header := webhook.Sign(current, body, now)
+		for _, tc := range []struct {
+			d       string
+			secrets [][]byte
+			header  string
+			body    []byte
+			now     time.Time
+		}{
+			{d: "wrong secret", secrets: [][]byte{previous}, header: header, body: body, now: now},
+			{d: "tampered body", secrets: [][]byte{current}, header: header, body: []byte(`{"identity_id":"bar"}`), now: now},
+			{d: "expired", secrets: [][]byte{current}, header: header, body: body, now: now.Add(time.Hour)},
+			{d: "missing time", secrets: [][]byte{current}, header: header[len("t=1234567890,"):], body: body, now: now},
+			{d: "empty header", secrets: [][]byte{current}, header: "", body: body, now: now},
+			{d: "invalid hex", secrets: [][]byte{current}, header: "t=1,v1=zz", body: body, now: now},
+		} {
+			t.Run("case="+tc.d, func(t *testing.T) {
+				assert.ErrorIs(t, webhook.VerifySignature(tc.secrets, tc.header, tc.body, time.Minute, tc.now), webhook.ErrInvalidSignature)
+			})
+		}
+	})
+}
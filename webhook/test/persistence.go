@@ -0,0 +1,155 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/webhook"
+	"github.com/ory/kratos/x"
+)
+
+func TestPersister(ctx context.Context, p webhook.Persister) func(t *testing.T) {
+	return func(t *testing.T) {
+		now := time.Now().UTC()
+
+		t.Run("case=no deliveries in queue", func(t *testing.T) {
+			d, err := p.NextDeliveries(ctx, 10, now, now.Add(time.Minute))
+			require.ErrorIs(t, err, webhook.ErrQueueEmpty)
+			assert.Len(t, d, 0)
+		})
+
+		newDelivery := func(t *testing.T, nextAttemptAt time.Time) *webhook.Delivery {
+			d := &webhook.Delivery{
+				Method:        "POST",
+				URL:           "https://www.ory.sh/" + x.NewUUID().String(),
+				Headers:       webhook.DeliveryHeader{"Content-Type": {"application/json"}},
+				Body:          `{"foo":"bar"}`,
+				NextAttemptAt: nextAttemptAt,
+			}
+			require.NoError(t, p.AddDelivery(ctx, d))
+			return d
+		}
+
+		t.Run("case=add and get delivery", func(t *testing.T) {
+			expected := newDelivery(t, now.Add(time.Hour))
+			assert.Equal(t, webhook.DeliveryStatusQueued, expected.Status)
+
+			actual, err := p.GetDelivery(ctx, expected.ID)
+			require.NoError(t, err)
+			assert.Equal(t, expected.URL, actual.URL)
+			assert.Equal(t, expected.Body, actual.Body)
+			assert.Equal(t, expected.Headers, actual.Headers)
+			assert.Equal(t, webhook.DeliveryStatusQueued, actual.Status)
+
+			_, err = p.GetDelivery(ctx, x.NewUUID())
+			require.ErrorIs(t, err, sqlcon.ErrNoRows)
+		})
+
+		t.Run("case=pull due deliveries from the queue", func(t *testing.T) {
+			later := newDelivery(t, now.Add(-time.Minute))
+			first := newDelivery(t, now.Add(-time.Hour))
+			_ = newDelivery(t, now.Add(time.Hour))
+
+			d, err := p.NextDeliveries(ctx, 10, now, now.Add(time.Minute))
+			require.NoError(t, err)
+			require.Len(t, d, 2)
+			assert.Equal(t, first.ID, d[0].ID)
+			assert.Equal(t, later.ID, d[1].ID)
+			for _, delivery := range d {
+				assert.Equal(t, webhook.DeliveryStatusProcessing, delivery.Status)
+			}
+
+			_, err = p.NextDeliveries(ctx, 10, now, now.Add(time.Minute))
+			require.ErrorIs(t, err, webhook.ErrQueueEmpty)
+
+			t.Run("case=record failure", func(t *testing.T) {
+				nextAttemptAt := now.Add(-time.Second)
+				require.NoError(t, p.RecordDeliveryFailure(ctx, first.ID, webhook.DeliveryStatusQueued, nextAttemptAt, "some error"))
+
+				actual, err := p.GetDelivery(ctx, first.ID)
+				require.NoError(t, err)
+				assert.Equal(t, webhook.DeliveryStatusQueued, actual.Status)
+				assert.Equal(t, 1, actual.Attempts)
+				assert.Equal(t, "some error", string(actual.LastError))
+				assert.WithinDuration(t, nextAttemptAt, actual.NextAttemptAt, time.Second)
+
+				d, err := p.NextDeliveries(ctx, 10, now, now.Add(time.Minute))
+				require.NoError(t, err)
+				require.Len(t, d, 1)
+				assert.Equal(t, first.ID, d[0].ID)
+
+				require.NoError(t, p.RecordDeliveryFailure(ctx, first.ID, webhook.DeliveryStatusAbandoned, now, "another error"))
+				actual, err = p.GetDelivery(ctx, first.ID)
+				require.NoError(t, err)
+				assert.Equal(t, webhook.DeliveryStatusAbandoned, actual.Status)
+				assert.Equal(t, 2, actual.Attempts)
+				assert.Equal(t, "another error", string(actual.LastError))
+
+				require.ErrorIs(t, p.RecordDeliveryFailure(ctx, x.NewUUID(), webhook.DeliveryStatusQueued, now, ""), sqlcon.ErrNoRows)
+			})
+
+			t.Run("case=set status", func(t *testing.T) {
+				require.NoError(t, p.SetDeliveryStatus(ctx, later.ID, webhook.DeliveryStatusDelivered))
+
+				actual, err := p.GetDelivery(ctx, later.ID)
+				require.NoError(t, err)
+				assert.Equal(t, webhook.DeliveryStatusDelivered, actual.Status)
+
+				require.ErrorIs(t, p.SetDeliveryStatus(ctx, uuid.Nil, webhook.DeliveryStatusDelivered), sqlcon.ErrNoRows)
+			})
+		})
+
+		t.Run("case=claim deliveries again once their claim expired", func(t *testing.T) {
+			expected := newDelivery(t, now.Add(-time.Minute))
+
+			d, err := p.NextDeliveries(ctx, 10, now, now.Add(time.Minute))
+			require.NoError(t, err)
+			require.Len(t, d, 1)
+			assert.Equal(t, expected.ID, d[0].ID)
+
+			_, err = p.NextDeliveries(ctx, 10, now.Add(30*time.Second), now.Add(time.Minute))
+			require.ErrorIs(t, err, webhook.ErrQueueEmpty, "the delivery is still claimed")
+
+			// The worker which claimed the delivery crashed.
+			d, err = p.NextDeliveries(ctx, 10, now.Add(2*time.Minute), now.Add(3*time.Minute))
+			require.NoError(t, err)
+			require.Len(t, d, 1)
+			assert.Equal(t, expected.ID, d[0].ID)
+			assert.Equal(t, webhook.DeliveryStatusProcessing, d[0].Status)
+
+			require.NoError(t, p.SetDeliveryStatus(ctx, expected.ID, webhook.DeliveryStatusDelivered))
+			_, err = p.NextDeliveries(ctx, 10, now.Add(4*time.Minute), now.Add(5*time.Minute))
+			require.ErrorIs(t, err, webhook.ErrQueueEmpty, "delivered requests are not claimed again")
+		})
+
+		t.Run("case=purge delivered and abandoned deliveries", func(t *testing.T) {
+			delivered := newDelivery(t, now.Add(-time.Minute))
+			abandoned := newDelivery(t, now.Add(-time.Minute))
+			queued := newDelivery(t, now.Add(time.Hour))
+			require.NoError(t, p.SetDeliveryStatus(ctx, delivered.ID, webhook.DeliveryStatusDelivered))
+			require.NoError(t, p.SetDeliveryStatus(ctx, abandoned.ID, webhook.DeliveryStatusAbandoned))
+
+			require.NoError(t, p.PurgeDeliveries(ctx, time.Now().Add(-time.Hour)))
+			for _, d := range []*webhook.Delivery{delivered, abandoned, queued} {
+				_, err := p.GetDelivery(ctx, d.ID)
+				require.NoError(t, err, "deliveries within their retention are kept")
+			}
+
+			require.NoError(t, p.PurgeDeliveries(ctx, time.Now().Add(time.Minute)))
+			for _, d := range []*webhook.Delivery{delivered, abandoned} {
+				_, err := p.GetDelivery(ctx, d.ID)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+			}
+
+			_, err := p.GetDelivery(ctx, queued.ID)
+			require.NoError(t, err, "queued deliveries are never purged")
+		})
+	}
+}
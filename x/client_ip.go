@@ -0,0 +1,53 @@
+package x
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// TrustedClientIP returns the IP address of the client which sent the request. The `X-Forwarded-For` and
+// `X-Real-IP` headers are only honored if the request was sent by one of the trusted proxies. `X-Forwarded-For`
+// is read from right to left, skipping trusted proxies, so that the client can not choose the returned address by
+// setting the header.
+func TrustedClientIP(r *http.Request, trustedProxies []*net.IPNet) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return ""
+	} else if !isTrustedProxy(ip, trustedProxies) {
+		return ip.String()
+	}
+
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		hops := strings.Split(strings.Join(forwarded, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := net.ParseIP(strings.TrimSpace(hops[i]))
+			if hop == nil {
+				break
+			}
+			ip = hop
+			if !isTrustedProxy(hop, trustedProxies) {
+				break
+			}
+		}
+		return ip.String()
+	}
+
+	if realIP := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); realIP != nil {
+		return realIP.String()
+	}
+	return ip.String()
+}
+
+func isTrustedProxy(ip net.IP, trustedProxies []*net.IPNet) bool {
+	for _, n := range trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package x
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustedClientIP(t *testing.T) {
+	_, proxies, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+	trusted := []*net.IPNet{proxies}
+
+	for k, tc := range []struct {
+		remote   string
+		header   http.Header
+		expected string
+	}{
+		{remote: "127.0.0.1:1234", expected: "127.0.0.1"},
+		{remote: "[::1]:1234", expected: "::1"},
+		{remote: "not-an-ip", expected: ""},
+		{remote: "203.0.113.9:1234", header: http.Header{"X-Forwarded-For": {"203.0.113.1"}}, expected: "203.0.113.9"},
+		{remote: "203.0.113.9:1234", header: http.Header{"X-Real-Ip": {"203.0.113.1"}}, expected: "203.0.113.9"},
+		{remote: "10.0.0.1:1234", header: http.Header{"X-Forwarded-For": {"203.0.113.1"}}, expected: "203.0.113.1"},
+		{remote: "10.0.0.1:1234", header: http.Header{"X-Forwarded-For": {"198.51.100.1, 203.0.113.1, 10.0.0.2"}}, expected: "203.0.113.1"},
+		{remote: "10.0.0.1:1234", header: http.Header{"X-Forwarded-For": {"198.51.100.1", "203.0.113.1"}}, expected: "203.0.113.1"},
+		{remote: "10.0.0.1:1234", header: http.Header{"X-Forwarded-For": {"10.0.0.3, 10.0.0.2"}}, expected: "10.0.0.3"},
+		{remote: "10.0.0.1:1234", header: http.Header{"X-Forwarded-For": {"garbage"}}, expected: "10.0.0.1"},
+		{remote: "10.0.0.1:1234", header: http.Header{"X-Real-Ip": {"2001:db8::1"}}, expected: "2001:db8::1"},
+	} {
+		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+			r := &http.Request{RemoteAddr: tc.remote, Header: tc.header}
+			if r.Header == nil {
+				r.Header = http.Header{}
+			}
+			assert.Equal(t, tc.expected, TrustedClientIP(r, trusted))
+		})
+	}
+}
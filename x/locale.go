@@ -0,0 +1,22 @@
+package x
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/golang/gddo/httputil/header"
+)
+
+// PreferredLocale returns the locale the client prefers the most according to the request's
+// `Accept-Language` header, normalized to the form `de_DE`. It returns an empty string if the
+// header is not set or only contains wildcards.
+func PreferredLocale(r *http.Request) string {
+	var preferred header.AcceptSpec
+	for _, spec := range header.ParseAccept(r.Header, "Accept-Language") {
+		if spec.Value == "*" || spec.Q <= preferred.Q {
+			continue
+		}
+		preferred = spec
+	}
+	return strings.ReplaceAll(preferred.Value, "-", "_")
+}
@@ -0,0 +1,31 @@
+package x
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreferredLocale(t *testing.T) {
+	for _, tc := range []struct {
+		h string
+		e string
+	}{
+		{h: "", e: ""},
+		{h: "*", e: ""},
+		{h: "de", e: "de"},
+		{h: "de-DE", e: "de_DE"},
+		{h: "en-US,en;q=0.9,de;q=0.8", e: "en_US"},
+		{h: "en;q=0.5,de-AT;q=0.8,*;q=0.9", e: "de_AT"},
+		{h: "fr;q=0, it", e: "it"},
+	} {
+		t.Run("header="+tc.h, func(t *testing.T) {
+			r := &http.Request{Header: http.Header{}}
+			if tc.h != "" {
+				r.Header.Set("Accept-Language", tc.h)
+			}
+			assert.Equal(t, tc.e, PreferredLocale(r))
+		})
+	}
+}
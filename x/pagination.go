@@ -1,8 +1,10 @@
 package x
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/ory/x/pagination/pagepagination"
 )
@@ -45,3 +47,23 @@ func ParsePagination(r *http.Request) (page, itemsPerPage int) {
 func PaginationHeader(w http.ResponseWriter, u *url.URL, total int64, page, itemsPerPage int) {
 	pagepagination.PaginationHeader(w, u, total, page, itemsPerPage)
 }
+
+// HeaderNextPageToken contains the token of the next page for endpoints supporting keyset pagination.
+const HeaderNextPageToken = "X-Next-Page-Token"
+
+// TokenPaginationHeader sets the token and link of the next page when using keyset pagination. Nothing is set if
+// there is no next page.
+func TokenPaginationHeader(w http.ResponseWriter, u *url.URL, nextPageToken string, itemsPerPage int) {
+	if nextPageToken == "" {
+		return
+	}
+
+	q := u.Query()
+	q.Del("page")
+	q.Set("page_token", nextPageToken)
+	q.Set("per_page", strconv.Itoa(itemsPerPage))
+	u.RawQuery = q.Encode()
+
+	w.Header().Set(HeaderNextPageToken, nextPageToken)
+	w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", u.String()))
+}
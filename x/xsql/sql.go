@@ -6,6 +6,7 @@ import (
 
 	"github.com/gobuffalo/pop/v6"
 
+	"github.com/ory/kratos/ratelimit"
 	"github.com/ory/kratos/selfservice/errorx"
 
 	"github.com/ory/kratos/continuity"
@@ -18,6 +19,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/strategy/link"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/webhook"
 )
 
 func CleanSQL(t *testing.T, c *pop.Connection) {
@@ -25,7 +27,11 @@ func CleanSQL(t *testing.T, c *pop.Connection) {
 	for _, table := range []string{
 		new(continuity.Container).TableName(ctx),
 		new(courier.Message).TableName(ctx),
+		new(courier.StoredTemplate).TableName(ctx),
+		new(webhook.Delivery).TableName(ctx),
+		new(ratelimit.Counter).TableName(ctx),
 
+		new(link.LoginToken).TableName(ctx),
 		new(login.Flow).TableName(ctx),
 		new(registration.Flow).TableName(ctx),
 		new(settings.Flow).TableName(ctx),